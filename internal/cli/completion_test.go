@@ -133,9 +133,11 @@ func TestCompletionHelpContainsInstallInstructions(t *testing.T) {
 func TestFormatFlagCompletion(t *testing.T) {
 	values, directive := completeFormat(nil, nil, "")
 
-	require.Len(t, values, 2)
+	require.Len(t, values, 4)
 	assert.Contains(t, values, "markdown")
 	assert.Contains(t, values, "xml")
+	assert.Contains(t, values, "plain")
+	assert.Contains(t, values, "json")
 	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
 }
 
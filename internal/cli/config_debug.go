@@ -34,6 +34,8 @@ provided each value. Useful for diagnosing unexpected configuration behavior.`,
 func init() {
 	// Register flags on configDebugCmd.
 	configDebugCmd.Flags().Bool("json", false, "output as structured JSON")
+	configDebugCmd.Flags().Bool("yaml", false, "output as structured YAML")
+	configDebugCmd.MarkFlagsMutuallyExclusive("json", "yaml")
 	configDebugCmd.Flags().String("profile", "", "profile name to debug (default: active profile)")
 
 	// Assemble hierarchy.
@@ -44,6 +46,7 @@ func init() {
 // runConfigDebug implements `harvx config debug`.
 func runConfigDebug(cmd *cobra.Command, _ []string) error {
 	asJSON, _ := cmd.Flags().GetBool("json")
+	asYAML, _ := cmd.Flags().GetBool("yaml")
 	profileName, _ := cmd.Flags().GetString("profile")
 
 	out := cmd.OutOrStdout()
@@ -63,6 +66,13 @@ func runConfigDebug(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
+	if asYAML {
+		if err := config.FormatDebugOutputYAML(result, out); err != nil {
+			return fmt.Errorf("formatting debug output as YAML: %w", err)
+		}
+		return nil
+	}
+
 	if err := config.FormatDebugOutput(result, out); err != nil {
 		return fmt.Errorf("formatting debug output: %w", err)
 	}
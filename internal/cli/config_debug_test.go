@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // newTestConfigDebug builds an isolated command tree containing only
@@ -34,6 +35,8 @@ func newTestConfigDebug() *cobra.Command {
 		RunE:  runConfigDebug,
 	}
 	dbgCmd.Flags().Bool("json", false, "output as structured JSON")
+	dbgCmd.Flags().Bool("yaml", false, "output as structured YAML")
+	dbgCmd.MarkFlagsMutuallyExclusive("json", "yaml")
 	dbgCmd.Flags().String("profile", "", "profile name to debug (default: active profile)")
 
 	cfgCmd.AddCommand(dbgCmd)
@@ -176,6 +179,42 @@ func TestConfigDebugCommand_ProfileFlag(t *testing.T) {
 
 // ── config debug: JSON output ─────────────────────────────────────────────────
 
+// TestConfigDebugCommand_YAMLOutput verifies that `harvx config debug --yaml`
+// produces valid YAML with the debug top-level keys.
+func TestConfigDebugCommand_YAMLOutput(t *testing.T) {
+	dir := t.TempDir()
+	changeDirForTest(t, dir)
+
+	root := newTestConfigDebug()
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetArgs([]string{"config", "debug", "--yaml"})
+
+	require.NoError(t, root.Execute())
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &parsed),
+		"config debug --yaml must produce valid YAML, got: %s", buf.String())
+	assert.Contains(t, parsed, "active_profile")
+	assert.Contains(t, parsed, "config")
+}
+
+// TestConfigDebugCommand_JSONAndYAMLExclusive verifies that --json and --yaml
+// cannot be combined.
+func TestConfigDebugCommand_JSONAndYAMLExclusive(t *testing.T) {
+	dir := t.TempDir()
+	changeDirForTest(t, dir)
+
+	root := newTestConfigDebug()
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetArgs([]string{"config", "debug", "--json", "--yaml"})
+
+	require.Error(t, root.Execute())
+}
+
 // TestConfigDebugCommand_JSONOutput verifies that `harvx config debug --json`
 // produces valid JSON output.
 func TestConfigDebugCommand_JSONOutput(t *testing.T) {
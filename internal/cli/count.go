@@ -0,0 +1,171 @@
+// Package cli implements the Cobra command hierarchy for the harvx CLI tool.
+// This file implements the `harvx count` subcommand which reports token counts
+// for files and directories without any selection or budget logic.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/harvx/harvx/internal/discovery"
+	"github.com/harvx/harvx/internal/pipeline"
+	"github.com/harvx/harvx/internal/tokenizer"
+)
+
+// countCmd implements `harvx count` which prints per-file and total token
+// counts for the given paths.
+var countCmd = &cobra.Command{
+	Use:   "count [path...]",
+	Short: "Count tokens in files or directories",
+	Long: `Count reads the given files, or walks the given directories, and prints the
+token count of each file and the total using the configured tokenizer.
+
+No relevance tiers, redaction, or token budget are applied: every file is
+counted as it is on disk. Directory walks still skip the built-in default
+ignores (e.g. .git, node_modules) and binary files, and empty or
+whitespace-only files unless the profile sets skip_empty = false. With no
+arguments the --dir directory is counted.
+
+Examples:
+  # Count the current directory
+  harvx count
+
+  # Count specific files
+  harvx count main.go internal/config/types.go
+
+  # Use the fast character estimator
+  harvx count --tokenizer none src/`,
+	RunE: runCount,
+}
+
+func init() {
+	rootCmd.AddCommand(countCmd)
+}
+
+// countEntry is the token count of a single counted file.
+type countEntry struct {
+	Path   string
+	Tokens int
+}
+
+// runCount executes the count subcommand.
+func runCount(cmd *cobra.Command, args []string) error {
+	fv := GlobalFlags()
+
+	paths := args
+	if len(paths) == 0 {
+		paths = []string{fv.Dir}
+	}
+
+	tok, err := tokenizer.NewTokenizer(fv.Tokenizer)
+	if err != nil {
+		return fmt.Errorf("count: %w", err)
+	}
+
+	skipEmpty := config.DefaultProfile().SkipEmpty
+	rc, err := config.Resolve(config.ResolveOptions{
+		ProfileName: fv.Profile,
+		TargetDir:   fv.Dir,
+		CLIFlags:    fv.ProfileOverrides(),
+	})
+	if err != nil {
+		slog.Warn("count: could not resolve profile, using default skip_empty",
+			"error", err,
+		)
+	} else {
+		skipEmpty = rc.Profile.SkipEmpty
+	}
+
+	entries, total, err := countPaths(cmd.Context(), paths, tok, skipEmpty)
+	if err != nil {
+		return fmt.Errorf("count: %w", err)
+	}
+
+	writeCountReport(cmd.OutOrStdout(), entries, total, tok.Name())
+	return nil
+}
+
+// countPaths counts tokens in every file named by paths, walking directories,
+// and returns the per-file counts in input order along with their total.
+// Files that cannot be read are logged and skipped, and with skipEmpty so
+// are empty or whitespace-only files found by a directory walk.
+func countPaths(ctx context.Context, paths []string, tok tokenizer.Tokenizer, skipEmpty bool) ([]countEntry, int, error) {
+	var files []*pipeline.FileDescriptor
+	for _, p := range paths {
+		found, err := collectCountFiles(ctx, p, skipEmpty)
+		if err != nil {
+			return nil, 0, err
+		}
+		files = append(files, found...)
+	}
+
+	total, err := tokenizer.NewTokenCounter(tok).CountFiles(ctx, files)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]countEntry, len(files))
+	for i, fd := range files {
+		entries[i] = countEntry{Path: fd.Path, Tokens: fd.TokenCount}
+	}
+	return entries, total, nil
+}
+
+// collectCountFiles returns descriptors with loaded content for path: the
+// file itself, or every non-ignored file beneath it when path is a directory.
+// skipEmpty is passed to the walker as WalkerConfig.SkipEmpty; a file named
+// directly is always counted. Descriptor paths are path joined with the
+// file's path inside the directory.
+func collectCountFiles(ctx context.Context, path string, skipEmpty bool) ([]*pipeline.FileDescriptor, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", path, err)
+	}
+
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		return []*pipeline.FileDescriptor{{Path: path, Content: string(data)}}, nil
+	}
+
+	result, err := discovery.NewWalker().Walk(ctx, discovery.WalkerConfig{
+		Root:           path,
+		DefaultIgnorer: discovery.NewDefaultIgnoreMatcher(),
+		SkipEmpty:      skipEmpty,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking %s: %w", path, err)
+	}
+
+	files := make([]*pipeline.FileDescriptor, 0, len(result.Files))
+	for i := range result.Files {
+		fd := &result.Files[i]
+		if fd.Error != nil {
+			slog.Warn("count: skipping unreadable file", "path", fd.Path, "error", fd.Error)
+			continue
+		}
+		fd.Path = filepath.Join(path, fd.Path)
+		files = append(files, fd)
+	}
+	return files, nil
+}
+
+// writeCountReport writes one aligned row per file followed by the total.
+func writeCountReport(w io.Writer, entries []countEntry, total int, tokenizerName string) {
+	tw := tabwriter.NewWriter(w, 0, 0, 0, ' ', tabwriter.AlignRight)
+	for _, e := range entries {
+		fmt.Fprintf(tw, "%s\t  %s\n", tokenizer.FormatInt(e.Tokens), e.Path)
+	}
+	fmt.Fprintf(tw, "%s\t  total (%d files, %s)\n", tokenizer.FormatInt(total), len(entries), tokenizerName)
+	tw.Flush()
+}
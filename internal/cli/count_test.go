@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/harvx/harvx/internal/tokenizer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountCommandRegistered(t *testing.T) {
+	found := false
+	for _, cmd := range rootCmd.Commands() {
+		if cmd.Name() == "count" {
+			found = true
+			break
+		}
+	}
+	assert.True(t, found, "count command must be registered on root")
+}
+
+func TestCountPaths_KnownContent(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "a.txt")
+	require.NoError(t, os.WriteFile(file, []byte(strings.Repeat("x", 40)), 0o644))
+
+	tok, err := tokenizer.NewTokenizer(tokenizer.NameNone)
+	require.NoError(t, err)
+
+	entries, total, err := countPaths(context.Background(), []string{file}, tok, true)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, file, entries[0].Path)
+	assert.Equal(t, 10, entries[0].Tokens, "estimator counts len/4")
+	assert.Equal(t, 10, total)
+}
+
+func TestCountPaths_TotalsAggregate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "src"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "a.go"), []byte(strings.Repeat("a", 20)), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "src", "b.go"), []byte(strings.Repeat("b", 12)), 0o644))
+	extra := filepath.Join(dir, "extra.md")
+	require.NoError(t, os.WriteFile(extra, []byte(strings.Repeat("c", 8)), 0o644))
+
+	tok, err := tokenizer.NewTokenizer(tokenizer.NameNone)
+	require.NoError(t, err)
+
+	srcDir := filepath.Join(dir, "src")
+	entries, total, err := countPaths(context.Background(), []string{srcDir, extra}, tok, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []countEntry{
+		{Path: filepath.Join(srcDir, "a.go"), Tokens: 5},
+		{Path: filepath.Join(srcDir, "b.go"), Tokens: 3},
+		{Path: extra, Tokens: 2},
+	}, entries)
+	assert.Equal(t, 10, total)
+}
+
+func TestCountPaths_SkipEmpty(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte(strings.Repeat("a", 20)), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "empty.go"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blank.txt"), []byte(" \n\t\n"), 0o644))
+
+	tok, err := tokenizer.NewTokenizer(tokenizer.NameNone)
+	require.NoError(t, err)
+
+	entries, _, err := countPaths(context.Background(), []string{dir}, tok, true)
+	require.NoError(t, err)
+	assert.Equal(t, []countEntry{{Path: filepath.Join(dir, "a.go"), Tokens: 5}}, entries)
+
+	entries, _, err = countPaths(context.Background(), []string{dir}, tok, false)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3, "skip_empty = false counts empty files too")
+
+	empty := filepath.Join(dir, "empty.go")
+	entries, _, err = countPaths(context.Background(), []string{empty}, tok, true)
+	require.NoError(t, err)
+	assert.Equal(t, []countEntry{{Path: empty, Tokens: 0}}, entries, "a file named directly is always counted")
+}
+
+func TestCountPaths_MissingPath(t *testing.T) {
+	t.Parallel()
+
+	tok, err := tokenizer.NewTokenizer(tokenizer.NameNone)
+	require.NoError(t, err)
+
+	_, _, err = countPaths(context.Background(), []string{filepath.Join(t.TempDir(), "missing")}, tok, true)
+	require.Error(t, err)
+}
+
+func TestWriteCountReport(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	writeCountReport(&buf, []countEntry{
+		{Path: "big.go", Tokens: 1500},
+		{Path: "small.go", Tokens: 7},
+	}, 1507, tokenizer.NameNone)
+
+	assert.Equal(t, ""+
+		"1,500  big.go\n"+
+		"    7  small.go\n"+
+		"1,507  total (2 files, none)\n", buf.String())
+}
@@ -1,9 +1,8 @@
 package cli
 
 import (
-	"os"
-
 	"github.com/harvx/harvx/internal/doctor"
+	"github.com/harvx/harvx/internal/pipeline"
 	"github.com/spf13/cobra"
 )
 
@@ -21,8 +20,14 @@ Checks include:
   - Large binary files not excluded (>1MB)
   - Oversized text files that may blow token budgets (>500KB)
   - Build artifact directories without .harvxignore
-  - Configuration validation (harvx.toml)
-  - Stale cache files in .harvx/state/`,
+  - Configuration validation and lint (harvx.toml)
+  - Global configuration validation
+  - HARVX_* environment variables overriding config files
+  - Tokenizer data availability
+  - Stale cache files in .harvx/state/
+
+Failing and warning checks carry a suggested fix; the fixes are listed after
+the summary, failures first.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dir := flagValues.Dir
 		if dir == "" {
@@ -44,9 +49,9 @@ Checks include:
 		doctor.FormatText(cmd.OutOrStdout(), report)
 
 		if report.HasFail {
-			// Return exit code 1 via os.Exit rather than error to avoid
-			// printing the error message twice (cobra + slog).
-			os.Exit(1)
+			// Execute maps the error to exit code 1; the failing checks are
+			// already in the report above.
+			return pipeline.NewError("doctor found failing checks", nil)
 		}
 
 		return nil
@@ -72,3 +72,25 @@ func TestDoctorCmd_JSONOutput(t *testing.T) {
 	assert.Contains(t, output, `"directory"`)
 	assert.Contains(t, output, `"checks"`)
 }
+
+func TestDoctorCmd_FailingCheckReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"),
+		[]byte("[profile.default]\nformat = \"html\"\n"), 0o644))
+
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	defer rootCmd.SetOut(nil)
+	rootCmd.SetErr(&buf)
+	defer rootCmd.SetErr(nil)
+	// --json=false resets the flag a previous test may have left set.
+	rootCmd.SetArgs([]string{"doctor", "--json=false", "--dir", dir})
+	defer rootCmd.SetArgs(nil)
+	t.Cleanup(func() { flagValues.Dir = "." })
+
+	err := rootCmd.Execute()
+	require.Error(t, err, "a failing check must be reported as an error, not os.Exit")
+	assert.Equal(t, 1, extractExitCode(err))
+	assert.Contains(t, buf.String(), "[FAIL]")
+}
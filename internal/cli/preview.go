@@ -123,11 +123,24 @@ func runPreviewJSON(cmd *cobra.Command, fv *config.FlagValues) error {
 	pipe := pipeline.NewPipeline(pipeOpts...)
 
 	// Run with preview stages only (discovery + relevance + tokenize).
-	runOpts := pipeline.RunOptions{
-		Dir:       fv.Dir,
-		MaxTokens: fv.MaxTokens,
-		Stages:    pipeline.PreviewStages(),
+	var runOpts pipeline.RunOptions
+	rc, err := config.Resolve(config.ResolveOptions{
+		ProfileName: fv.Profile,
+		TargetDir:   fv.Dir,
+		CLIFlags:    fv.ProfileOverrides(),
+	})
+	if err != nil {
+		slog.Warn("preview --json: could not resolve profile, ignoring its settings",
+			"error", err,
+		)
+	} else {
+		runOpts = pipeline.RunOptionsForProfile(rc.Profile)
 	}
+	runOpts.Dir = fv.Dir
+	runOpts.MaxTokens = fv.MaxTokens
+	runOpts.Since = fv.Since
+	runOpts.Focus = fv.Focus
+	runOpts.Stages = pipeline.PreviewStages()
 
 	result, err := pipe.Run(ctx, runOpts)
 	if err != nil {
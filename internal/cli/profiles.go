@@ -23,7 +23,8 @@ Use these subcommands to discover, create, and inspect your harvx configuration:
 
   list   Show all available profiles from all config sources
   init   Generate a starter harvx.toml in the current directory
-  show   Display the fully resolved configuration for a named profile`,
+  show   Display the fully resolved configuration for a named profile
+  diff   Compare two resolved profiles field by field`,
 	// No RunE: default Cobra behaviour will print help when no subcommand is given.
 }
 
@@ -349,6 +350,17 @@ func runProfilesShow(cmd *cobra.Command, args []string) error {
 // ResolveProfile to get the inheritance chain. Returns just [profileName] on
 // any error so that the show command can still function without it.
 func resolveChainForShow(profileName string) ([]string, error) {
+	res, err := config.ResolveProfile(profileName, loadConfigProfiles())
+	if err != nil {
+		return nil, err
+	}
+	return res.Chain, nil
+}
+
+// loadConfigProfiles returns the named profiles from the repo and global
+// config files, with repo profiles taking precedence over global ones of the
+// same name. Missing or unreadable config files are silently skipped.
+func loadConfigProfiles() map[string]*config.Profile {
 	profiles := make(map[string]*config.Profile)
 
 	// Load repo config profiles.
@@ -375,11 +387,7 @@ func resolveChainForShow(profileName string) ([]string, error) {
 		}
 	}
 
-	res, err := config.ResolveProfile(profileName, profiles)
-	if err != nil {
-		return nil, err
-	}
-	return res.Chain, nil
+	return profiles
 }
 
 // availableProfileNames returns the names of all profiles from all config
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// profilesDiffCmd compares two resolved profiles.
+var profilesDiffCmd = &cobra.Command{
+	Use:   "diff <a> <b>",
+	Short: "Compare two resolved profiles field by field",
+	Long: `Resolve two named profiles (following their inheritance chains) and print
+every field whose value differs, with the profile each value comes from.
+
+Fields marked "*" differ; the others are listed for reference with --all.
+Use --json to get the full field-level diff as JSON.`,
+	Args:              cobra.ExactArgs(2),
+	RunE:              runProfilesDiff,
+	ValidArgsFunction: completeProfileNames,
+}
+
+func init() {
+	profilesDiffCmd.Flags().Bool("json", false, "output the diff as JSON")
+	profilesDiffCmd.Flags().Bool("all", false, "also list fields that are the same in both profiles")
+	profilesCmd.AddCommand(profilesDiffCmd)
+}
+
+// runProfilesDiff implements `harvx profiles diff <a> <b>`.
+func runProfilesDiff(cmd *cobra.Command, args []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	showAll, _ := cmd.Flags().GetBool("all")
+	out := cmd.OutOrStdout()
+
+	diff, err := config.DiffProfiles(args[0], args[1], loadConfigProfiles())
+	if err != nil {
+		available, listErr := availableProfileNames()
+		if listErr == nil && len(available) > 0 {
+			return fmt.Errorf("%w\n\nAvailable profiles: %s", err, strings.Join(available, ", "))
+		}
+		return err
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("serializing profile diff to JSON: %w", err)
+		}
+		fmt.Fprintln(out, string(data))
+		return nil
+	}
+
+	return printProfileDiff(out, diff, showAll)
+}
+
+// printProfileDiff writes diff as a table with one row per field. Unchanged
+// fields are only included when showAll is set.
+func printProfileDiff(out io.Writer, diff *config.ProfileDiff, showAll bool) error {
+	fmt.Fprintf(out, "# %s: %s\n", diff.A, strings.Join(diff.ChainA, " -> "))
+	fmt.Fprintf(out, "# %s: %s\n", diff.B, strings.Join(diff.ChainB, " -> "))
+	fmt.Fprintln(out)
+
+	changed := diff.Changed()
+	if len(changed) == 0 && !showAll {
+		fmt.Fprintln(out, "Profiles are identical after inheritance.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintf(tw, "  FIELD\t%s\t%s\n", strings.ToUpper(diff.A), strings.ToUpper(diff.B))
+	for _, f := range diff.Fields {
+		if !f.Changed && !showAll {
+			continue
+		}
+		marker := " "
+		if f.Changed {
+			marker = "*"
+		}
+		fmt.Fprintf(tw, "%s %s\t%s (%s)\t%s (%s)\n",
+			marker, f.Key, formatDiffValue(f.A), f.SourceA, formatDiffValue(f.B), f.SourceB)
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flushing table: %w", err)
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%d of %d fields differ\n", len(changed), len(diff.Fields))
+	return nil
+}
+
+// formatDiffValue renders a flat field value for the diff table: strings are
+// quoted, lists are bracketed, and absent values print as "-".
+func formatDiffValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		return fmt.Sprintf("%q", val)
+	case []string:
+		quoted := make([]string, len(val))
+		for i, s := range val {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(val)
+	}
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProfilesDiff builds an isolated command tree containing only
+// `harvx profiles diff` so each test gets a fresh command state.
+func newTestProfilesDiff() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "harvx",
+		SilenceErrors: true,
+		SilenceUsage:  true,
+	}
+	pCmd := &cobra.Command{Use: "profiles"}
+	diffCmd := &cobra.Command{
+		Use:  "diff <a> <b>",
+		Args: cobra.ExactArgs(2),
+		RunE: runProfilesDiff,
+	}
+	diffCmd.Flags().Bool("json", false, "output as JSON")
+	diffCmd.Flags().Bool("all", false, "list unchanged fields")
+	pCmd.AddCommand(diffCmd)
+	root.AddCommand(pCmd)
+	return root
+}
+
+// setupInheritanceFixture copies testdata/config/inheritance.toml into a temp
+// directory as harvx.toml and changes into it.
+func setupInheritanceFixture(t *testing.T) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "testdata", "config", "inheritance.toml"))
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"), data, 0o644))
+	changeDirForTest(t, dir)
+}
+
+func runProfilesDiffForTest(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	root := newTestProfilesDiff()
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetArgs(append([]string{"profiles", "diff"}, args...))
+	err := root.Execute()
+	return buf.String(), err
+}
+
+func TestProfilesDiff_BaseChild(t *testing.T) {
+	setupInheritanceFixture(t)
+
+	output, err := runProfilesDiffForTest(t, "base", "child")
+	require.NoError(t, err)
+
+	assert.Contains(t, output, "# child: child -> base -> default")
+	assert.Regexp(t, `\* format\s+"markdown" \(default\)\s+"xml" \(child\)`, output)
+	assert.NotContains(t, output, "max_tokens ", "unchanged fields are hidden without --all")
+	assert.Contains(t, output, "1 of ")
+}
+
+func TestProfilesDiff_AllShowsInheritedUnchanged(t *testing.T) {
+	setupInheritanceFixture(t)
+
+	output, err := runProfilesDiffForTest(t, "base", "child", "--all")
+	require.NoError(t, err)
+
+	assert.Regexp(t, `(?m)^  max_tokens\s+64000 \(base\)\s+64000 \(base\)$`, output)
+}
+
+func TestProfilesDiff_JSON(t *testing.T) {
+	setupInheritanceFixture(t)
+
+	output, err := runProfilesDiffForTest(t, "base", "child", "--json")
+	require.NoError(t, err)
+
+	var diff config.ProfileDiff
+	require.NoError(t, json.Unmarshal([]byte(output), &diff))
+
+	fields := make(map[string]config.ProfileFieldDiff, len(diff.Fields))
+	for _, f := range diff.Fields {
+		fields[f.Key] = f
+	}
+
+	assert.True(t, fields["format"].Changed)
+	assert.Equal(t, "xml", fields["format"].B)
+	assert.Equal(t, "child", fields["format"].SourceB)
+
+	assert.False(t, fields["max_tokens"].Changed)
+	assert.Equal(t, "base", fields["max_tokens"].SourceB)
+}
+
+func TestProfilesDiff_UnknownProfile(t *testing.T) {
+	setupInheritanceFixture(t)
+
+	_, err := runProfilesDiffForTest(t, "base", "nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "nope" is not defined`)
+	assert.Contains(t, err.Error(), "Available profiles:")
+}
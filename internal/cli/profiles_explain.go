@@ -116,6 +116,9 @@ func printTo(w io.Writer, result config.ExplainResult) {
 				}
 			}
 			fmt.Fprintf(w, "  Matched by: %s pattern %q\n", tierLabel, result.TierPattern)
+			if !result.IsPriority {
+				fmt.Fprintf(w, "  Depth:      %s\n", formatPatternDepth(result.TierPattern))
+			}
 		}
 		fmt.Fprintf(w, "  Redaction:  %s\n", formatRedaction(result))
 		fmt.Fprintf(w, "  Compress:   %s\n", formatCompression(result.Compression))
@@ -132,6 +135,19 @@ func printTo(w io.Writer, result config.ExplainResult) {
 	}
 }
 
+// formatPatternDepth describes how deep below its literal prefix a tier
+// pattern reaches (see config.PatternDepth).
+func formatPatternDepth(pattern string) string {
+	switch depth := config.PatternDepth(pattern); depth {
+	case config.UnboundedDepth:
+		return "any (** crosses directories)"
+	case 0:
+		return "exact path (no wildcards)"
+	default:
+		return fmt.Sprintf("%d level(s) (a single * does not cross /)", depth)
+	}
+}
+
 // formatTier returns a human-readable string for the given tier number.
 func formatTier(tier int) string {
 	switch tier {
@@ -50,6 +50,8 @@ func TestProfilesExplain_IncludedFile(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, buf.String(), "INCLUDED",
 		"output must show INCLUDED for a regular source file")
+	assert.Contains(t, buf.String(), "Depth:      any (** crosses directories)",
+		"the default src/** pattern reaches any depth")
 }
 
 // TestProfilesExplain_ExcludedFile verifies that a path matching the default
@@ -229,6 +231,16 @@ func TestFormatTier_Values(t *testing.T) {
 	}
 }
 
+// TestFormatPatternDepth verifies the depth description for bounded,
+// unbounded, and literal patterns.
+func TestFormatPatternDepth(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1 level(s) (a single * does not cross /)", formatPatternDepth("src/*"))
+	assert.Equal(t, "any (** crosses directories)", formatPatternDepth("src/**"))
+	assert.Equal(t, "exact path (no wildcards)", formatPatternDepth("go.mod"))
+}
+
 // ── formatCompression ─────────────────────────────────────────────────────────
 
 // TestFormatCompression_Supported verifies that a non-empty language name
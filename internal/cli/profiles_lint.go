@@ -2,7 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
+	"os"
 
 	"github.com/harvx/harvx/internal/config"
 	"github.com/spf13/cobra"
@@ -17,12 +19,19 @@ var profilesLintCmd = &cobra.Command{
 Lint groups findings by severity (errors, warnings, info) and exits with code 1
 if any errors are found. Warnings do not cause a non-zero exit.
 
-Use --profile to restrict linting to a single named profile.`,
+Use --profile to restrict linting to a single named profile.
+
+Use --fix to remove redundant entries before linting: tier patterns already
+listed in a higher-priority tier and priority_files entries that are also
+ignored. The rewritten harvx.toml keeps its profile tables but not its
+comments or key order. Issues that need human judgment, such as invalid
+values or malformed globs, are only reported.`,
 	RunE: runProfilesLint,
 }
 
 func init() {
 	profilesLintCmd.Flags().String("profile", "", "lint only the specified profile name")
+	profilesLintCmd.Flags().Bool("fix", false, "rewrite harvx.toml with redundant entries removed")
 	profilesCmd.AddCommand(profilesLintCmd)
 }
 
@@ -31,6 +40,7 @@ func runProfilesLint(cmd *cobra.Command, _ []string) error {
 	out := cmd.OutOrStdout()
 
 	profileFlag, _ := cmd.Flags().GetString("profile")
+	fixFlag, _ := cmd.Flags().GetBool("fix")
 
 	// Discover the repo config path for display purposes.
 	repoPath, err := config.DiscoverRepoConfig(".")
@@ -52,13 +62,22 @@ func runProfilesLint(cmd *cobra.Command, _ []string) error {
 
 	// Filter to a single profile if requested.
 	if profileFlag != "" {
-		p, ok := cfg.Profile[profileFlag]
-		if !ok {
+		if _, ok := cfg.Profile[profileFlag]; !ok {
 			return fmt.Errorf("profile %q not found in configuration", profileFlag)
 		}
+	}
+
+	if fixFlag && repoPath != "" {
+		cfg, err = applyLintFixes(out, repoPath, cfg, profileFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	if profileFlag != "" {
 		cfg = &config.Config{
 			Profile: map[string]*config.Profile{
-				profileFlag: p,
+				profileFlag: cfg.Profile[profileFlag],
 			},
 		}
 	}
@@ -128,3 +147,51 @@ func runProfilesLint(cmd *cobra.Command, _ []string) error {
 	}
 	return nil
 }
+
+// applyLintFixes runs config.AutoFix over cfg, or only over the named profile
+// when profileName is non-empty, rewrites path when anything changed, prints
+// the fixes, and returns the fixed config.
+func applyLintFixes(out io.Writer, path string, cfg *config.Config, profileName string) (*config.Config, error) {
+	target := cfg
+	if profileName != "" {
+		target = &config.Config{
+			Profile: map[string]*config.Profile{profileName: cfg.Profile[profileName]},
+		}
+	}
+
+	fixed, fixes, err := config.AutoFix(target)
+	if err != nil {
+		return nil, fmt.Errorf("fixing config: %w", err)
+	}
+	if len(fixes) == 0 {
+		fmt.Fprintln(out, "No automatic fixes available.")
+		return cfg, nil
+	}
+
+	if profileName != "" {
+		merged := &config.Config{Root: cfg.Root, Profile: make(map[string]*config.Profile, len(cfg.Profile))}
+		for name, p := range cfg.Profile {
+			merged.Profile[name] = p
+		}
+		merged.Profile[profileName] = fixed.Profile[profileName]
+		fixed = merged
+	}
+
+	data, err := config.EncodeConfig(fixed)
+	if err != nil {
+		return nil, fmt.Errorf("fixing config: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("fixing config: %w", err)
+	}
+	if err := os.WriteFile(path, data, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("writing fixed config: %w", err)
+	}
+
+	fmt.Fprintf(out, "Fixed %d issue(s) in %s:\n", len(fixes), displayPath(path))
+	for _, f := range fixes {
+		fmt.Fprintf(out, "  - [%s] %s\n", f.Field, f.Message)
+	}
+	return fixed, nil
+}
@@ -25,6 +25,7 @@ func newTestLint() *cobra.Command {
 		RunE: runProfilesLint,
 	}
 	lintCmd.Flags().String("profile", "", "lint only the specified profile name")
+	lintCmd.Flags().Bool("fix", false, "rewrite harvx.toml with redundant entries removed")
 	pCmd.AddCommand(lintCmd)
 	root.AddCommand(pCmd)
 	return root
@@ -302,3 +303,46 @@ func TestProfilesLintCmd_Registered(t *testing.T) {
 	}
 	assert.True(t, found, "profiles command must have a 'lint' subcommand")
 }
+
+// TestProfilesLint_FixRewritesConfig verifies that --fix removes redundant
+// entries from harvx.toml, prints a summary, and that a second run has
+// nothing left to fix.
+func TestProfilesLint_FixRewritesConfig(t *testing.T) {
+	dir := t.TempDir()
+	content := `
+[profile.default]
+ignore = [".env"]
+priority_files = ["go.mod", ".env"]
+
+[profile.default.relevance]
+tier_0 = ["go.mod"]
+tier_1 = ["**/*.go", "go.mod"]
+`
+	path := filepath.Join(dir, "harvx.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	changeDirForTest(t, dir)
+
+	root := newTestLint()
+	var buf bytes.Buffer
+	root.SetOut(&buf)
+	root.SetErr(&buf)
+	root.SetArgs([]string{"profiles", "lint", "--fix"})
+	require.NoError(t, root.Execute())
+
+	out := buf.String()
+	assert.Contains(t, out, "Fixed 2 issue(s)")
+	assert.Contains(t, out, `removed pattern "go.mod"`)
+	assert.Contains(t, out, `removed priority file ".env"`)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `tier_1 = ["**/*.go"]`)
+	assert.Contains(t, string(data), `priority_files = ["go.mod"]`)
+
+	root = newTestLint()
+	buf.Reset()
+	root.SetOut(&buf)
+	root.SetArgs([]string{"profiles", "lint", "--fix"})
+	require.NoError(t, root.Execute())
+	assert.Contains(t, buf.String(), "No automatic fixes available.")
+}
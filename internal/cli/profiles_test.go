@@ -471,7 +471,7 @@ func TestProfilesCmd_SubcommandsRegistered(t *testing.T) {
 		subNames[sub.Use] = true
 	}
 
-	for _, want := range []string{"list", "init", "show [profile]"} {
+	for _, want := range []string{"list", "init", "show [profile]", "diff <a> <b>"} {
 		assert.True(t, subNames[want], "profiles must have subcommand %q", want)
 	}
 }
@@ -58,6 +58,7 @@ context file optimized for large language models like Claude, ChatGPT, and other
 			resolved, err := config.Resolve(config.ResolveOptions{
 				TargetDir:   flagValues.Dir,
 				ProfileName: flagValues.Profile,
+				CLIFlags:    flagValues.ProfileOverrides(),
 			})
 			if err != nil {
 				return fmt.Errorf("resolving config for TUI: %w", err)
@@ -91,7 +92,7 @@ func completeCompressEngine(_ *cobra.Command, _ []string, _ string) ([]string, c
 
 // completeFormat returns the valid values for the --format flag.
 func completeFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	return []string{"markdown", "xml"}, cobra.ShellCompDirectiveNoFileComp
+	return []string{"markdown", "xml", "plain", "json"}, cobra.ShellCompDirectiveNoFileComp
 }
 
 // completeTarget returns the valid values for the --target flag.
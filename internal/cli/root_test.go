@@ -83,6 +83,8 @@ func TestRootCommandHasBooleanFlags(t *testing.T) {
 		"stdout",
 		"line-numbers",
 		"no-redact",
+		"no-redaction",
+		"no-compression",
 		"fail-on-redaction",
 		"yes",
 		"clear-cache",
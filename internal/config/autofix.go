@@ -0,0 +1,164 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// AutoFix returns a copy of cfg with safe, behaviour-preserving lint fixes
+// applied, together with one LintResult (severity "info") per fix so callers
+// can print a summary. cfg itself is not modified.
+//
+// Only redundant entries are removed:
+//   - Tier patterns that already appear in a higher-priority tier, or earlier
+//     in the same tier (Code "unreachable-tier" when the whole tier is
+//     redundant, "overlapping-tier" otherwise). The first tier to list a
+//     pattern always wins, so dropping the later copies changes nothing.
+//   - priority_files entries that also appear verbatim in ignore (Code
+//     "priority-file-ignored"). Ignored files are never harvested, so the
+//     entries have no effect.
+//
+// A list is never emptied: an empty list inherits the parent profile's entries
+// during merging, which would change behaviour. When every entry of a list is
+// redundant the first one is kept. Anything that needs human judgment, such as
+// invalid enum values or malformed globs, is left untouched.
+func AutoFix(cfg *Config) (*Config, []LintResult, error) {
+	if cfg == nil {
+		return nil, nil, errors.New("autofix: config is nil")
+	}
+
+	fixed := &Config{Root: cfg.Root}
+	if cfg.Profile != nil {
+		fixed.Profile = make(map[string]*Profile, len(cfg.Profile))
+	}
+
+	names := make([]string, 0, len(cfg.Profile))
+	for name := range cfg.Profile {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fixes []LintResult
+	for _, name := range names {
+		p := cfg.Profile[name]
+		if p == nil {
+			fixed.Profile[name] = nil
+			continue
+		}
+		cp := *p
+		fixes = append(fixes, fixDuplicateTierPatterns(name, &cp)...)
+		fixes = append(fixes, fixIgnoredPriorityFiles(name, &cp)...)
+		fixed.Profile[name] = &cp
+	}
+
+	return fixed, fixes, nil
+}
+
+// fixDuplicateTierPatterns removes tier patterns already listed in an earlier
+// tier or earlier in the same tier, replacing the tier slices of p with new
+// slices.
+func fixDuplicateTierPatterns(profileName string, p *Profile) []LintResult {
+	tiers := []struct {
+		name     string
+		patterns *[]string
+	}{
+		{"tier_0", &p.Relevance.Tier0},
+		{"tier_1", &p.Relevance.Tier1},
+		{"tier_2", &p.Relevance.Tier2},
+		{"tier_3", &p.Relevance.Tier3},
+		{"tier_4", &p.Relevance.Tier4},
+		{"tier_5", &p.Relevance.Tier5},
+	}
+
+	// Map each pattern to the first tier it appears in.
+	seen := make(map[string]string)
+	var results []LintResult
+
+	for _, tier := range tiers {
+		patterns := *tier.patterns
+		if len(patterns) == 0 {
+			continue
+		}
+
+		unreachable := true
+		for _, pattern := range patterns {
+			if _, ok := seen[pattern]; !ok {
+				unreachable = false
+				break
+			}
+		}
+		code := "overlapping-tier"
+		if unreachable {
+			code = "unreachable-tier"
+		}
+
+		kept := make([]string, 0, len(patterns))
+		for _, pattern := range patterns {
+			firstTier, dup := seen[pattern]
+			if !dup {
+				seen[pattern] = tier.name
+			}
+			if dup && !(unreachable && len(kept) == 0) {
+				results = append(results, LintResult{
+					ValidationError: ValidationError{
+						Severity: "info",
+						Field:    fmt.Sprintf("profile.%s.relevance.%s", profileName, tier.name),
+						Message:  fmt.Sprintf("removed pattern %q, already matched by %s", pattern, firstTier),
+					},
+					Code: code,
+				})
+				continue
+			}
+			kept = append(kept, pattern)
+		}
+		if len(kept) < len(patterns) {
+			*tier.patterns = kept
+		}
+	}
+
+	return results
+}
+
+// fixIgnoredPriorityFiles removes priority_files entries that also appear
+// verbatim in ignore, replacing p.PriorityFiles with a new slice.
+func fixIgnoredPriorityFiles(profileName string, p *Profile) []LintResult {
+	if len(p.PriorityFiles) == 0 || len(p.Ignore) == 0 {
+		return nil
+	}
+
+	ignoreSet := make(map[string]bool, len(p.Ignore))
+	for _, ig := range p.Ignore {
+		ignoreSet[ig] = true
+	}
+
+	allIgnored := true
+	for _, pf := range p.PriorityFiles {
+		if !ignoreSet[pf] {
+			allIgnored = false
+			break
+		}
+	}
+
+	var results []LintResult
+	kept := make([]string, 0, len(p.PriorityFiles))
+	for _, pf := range p.PriorityFiles {
+		if !ignoreSet[pf] || (allIgnored && len(kept) == 0) {
+			kept = append(kept, pf)
+			continue
+		}
+		results = append(results, LintResult{
+			ValidationError: ValidationError{
+				Severity: "info",
+				Field:    fmt.Sprintf("profile.%s.priority_files", profileName),
+				Message:  fmt.Sprintf("removed priority file %q, which is also listed in ignore", pf),
+			},
+			Code: "priority-file-ignored",
+		})
+	}
+	if len(kept) < len(p.PriorityFiles) {
+		p.PriorityFiles = kept
+	}
+
+	return results
+}
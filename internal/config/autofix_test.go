@@ -0,0 +1,243 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAutoFix_Golden verifies the fixes and re-encoded output for the autofix
+// fixture against a golden file, and that a second pass over the rewritten
+// file is a no-op. Run with -update to regenerate the golden file.
+func TestAutoFix_Golden(t *testing.T) {
+	cfg, err := LoadFromFile("../../testdata/config/autofix.toml")
+	require.NoError(t, err)
+
+	fixed, fixes, err := AutoFix(cfg)
+	require.NoError(t, err)
+
+	codes := make(map[string]int)
+	for _, f := range fixes {
+		assert.Equal(t, "info", f.Severity)
+		codes[f.Code]++
+	}
+	assert.Equal(t, map[string]int{
+		"overlapping-tier":      2,
+		"unreachable-tier":      1,
+		"priority-file-ignored": 1,
+	}, codes)
+
+	actual, err := EncodeConfig(fixed)
+	require.NoError(t, err)
+
+	goldenPath := filepath.Join("../../testdata", "expected-output", "autofix-fixed.toml")
+	if *update {
+		require.NoError(t, os.WriteFile(goldenPath, actual, 0o644), "failed to write golden file")
+		t.Logf("golden file updated: %s", goldenPath)
+		return
+	}
+
+	expected, err := os.ReadFile(goldenPath)
+	require.NoError(t, err, "golden file missing -- run: go test -run TestAutoFix_Golden -update")
+	assert.Equal(t, string(expected), string(actual), "fixed config must match golden file")
+
+	// Idempotence: fixing the rewritten file changes nothing.
+	reloaded, err := LoadFromString(string(actual), "autofix-fixed.toml")
+	require.NoError(t, err)
+	refixed, again, err := AutoFix(reloaded)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	reencoded, err := EncodeConfig(refixed)
+	require.NoError(t, err)
+	assert.Equal(t, string(actual), string(reencoded))
+}
+
+// TestAutoFix_DoesNotModifyInput verifies that AutoFix works on a copy.
+func TestAutoFix_DoesNotModifyInput(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore:        []string{".env"},
+				PriorityFiles: []string{"go.mod", ".env"},
+				Relevance: RelevanceConfig{
+					Tier0: []string{"go.mod"},
+					Tier1: []string{"**/*.go", "go.mod"},
+				},
+			},
+		},
+	}
+
+	fixed, fixes, err := AutoFix(cfg)
+	require.NoError(t, err)
+	require.Len(t, fixes, 2)
+
+	assert.Equal(t, []string{"**/*.go"}, fixed.Profile["p"].Relevance.Tier1)
+	assert.Equal(t, []string{"go.mod"}, fixed.Profile["p"].PriorityFiles)
+	assert.Equal(t, []string{"**/*.go", "go.mod"}, cfg.Profile["p"].Relevance.Tier1)
+	assert.Equal(t, []string{"go.mod", ".env"}, cfg.Profile["p"].PriorityFiles)
+}
+
+// TestAutoFix_NeverEmptiesList verifies that a fully redundant list keeps its
+// first entry, since an empty list would inherit the parent's entries.
+func TestAutoFix_NeverEmptiesList(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore:        []string{"a.env", "b.env"},
+				PriorityFiles: []string{"a.env", "b.env"},
+				Relevance: RelevanceConfig{
+					Tier0: []string{"go.mod", "go.sum"},
+					Tier3: []string{"go.sum", "go.mod", "go.sum"},
+				},
+			},
+		},
+	}
+
+	fixed, fixes, err := AutoFix(cfg)
+	require.NoError(t, err)
+
+	p := fixed.Profile["p"]
+	assert.Equal(t, []string{"go.sum"}, p.Relevance.Tier3)
+	assert.Equal(t, []string{"a.env"}, p.PriorityFiles)
+	assert.Len(t, fixes, 3)
+}
+
+// TestAutoFix_LeavesJudgmentCallsAlone verifies that invalid enums and
+// malformed globs are neither fixed nor reported as fixes.
+func TestAutoFix_LeavesJudgmentCallsAlone(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Format:    "html",
+				Tokenizer: "bogus",
+				Ignore:    []string{"[unclosed"},
+				Relevance: RelevanceConfig{Tier1: []string{"src/[a-z.go"}},
+			},
+		},
+	}
+
+	fixed, fixes, err := AutoFix(cfg)
+	require.NoError(t, err)
+	assert.Empty(t, fixes)
+	assert.Equal(t, cfg.Profile["p"], fixed.Profile["p"])
+}
+
+// TestAutoFix_NilConfig verifies that a nil config is an error.
+func TestAutoFix_NilConfig(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := AutoFix(nil)
+	require.Error(t, err)
+}
+
+// TestEncodeConfig_RoundTrip verifies that encoding a loaded config and
+// decoding the result yields an equal Config. The encoded copy is written next
+// to the original so both loads record the same config directory.
+func TestEncodeConfig_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{"valid.toml", "finvault.toml", "inheritance.toml", "autofix.toml"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			original, err := os.ReadFile(filepath.Join("../../testdata/config", name))
+			require.NoError(t, err)
+			dir := t.TempDir()
+			writeTomlFile(t, dir, name, string(original))
+
+			cfg, err := LoadFromFile(filepath.Join(dir, name))
+			require.NoError(t, err)
+
+			data, err := EncodeConfig(cfg)
+			require.NoError(t, err)
+
+			writeTomlFile(t, dir, "encoded.toml", string(data))
+			decoded, err := LoadFromFile(filepath.Join(dir, "encoded.toml"))
+			require.NoError(t, err)
+			assert.Equal(t, cfg, decoded)
+		})
+	}
+}
+
+// TestEncodeConfig_KeepsExplicitEmptyList verifies that an explicitly empty
+// list is written while an unset one is omitted.
+func TestEncodeConfig_KeepsExplicitEmptyList(t *testing.T) {
+	t.Parallel()
+
+	tier := 0
+	cfg := &Config{
+		Root: true,
+		Profile: map[string]*Profile{
+			"p": {
+				Relevance: RelevanceConfig{Tier2: []string{}},
+				Overrides: []PathOverride{{Match: "a/**", Tier: &tier}},
+			},
+		},
+	}
+
+	data, err := EncodeConfig(cfg)
+	require.NoError(t, err)
+	out := string(data)
+
+	assert.Contains(t, out, "root = true")
+	assert.Contains(t, out, "tier_2 = []")
+	assert.NotContains(t, out, "tier_0")
+	assert.Contains(t, out, "tier = 0")
+	assert.NotContains(t, out, "format")
+}
+
+// TestEncodeConfig_KeepsExplicitFalse verifies that booleans the source file
+// set to false are written back, so they still override inherited or default
+// true values after a round trip.
+func TestEncodeConfig_KeepsExplicitFalse(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.base]
+redaction = true
+skip_empty = true
+
+[profile.base.redaction_config]
+enabled = true
+
+[profile.child]
+extends = "base"
+redaction = false
+skip_empty = false
+
+[profile.child.redaction_config]
+enabled = false
+`)
+	cfg, err := LoadFromFile(filepath.Join(dir, "harvx.toml"))
+	require.NoError(t, err)
+
+	data, err := EncodeConfig(cfg)
+	require.NoError(t, err)
+	out := string(data)
+	assert.Contains(t, out, "redaction = false")
+	assert.Contains(t, out, "skip_empty = false")
+	assert.Contains(t, out, "enabled = false")
+	assert.NotContains(t, out, "compression")
+
+	writeTomlFile(t, dir, "encoded.toml", out)
+	decoded, err := LoadFromFile(filepath.Join(dir, "encoded.toml"))
+	require.NoError(t, err)
+	assert.Equal(t, cfg, decoded)
+
+	child, err := ResolveProfile("child", decoded.Profile)
+	require.NoError(t, err)
+	assert.False(t, child.Profile.Redaction)
+	assert.False(t, child.Profile.SkipEmpty)
+	assert.False(t, child.Profile.RedactionConfig.Enabled)
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// checkBaseDir reports why baseDir is not a usable Profile.BaseDir, or returns
+// nil when it is acceptable. An empty baseDir is always acceptable. A usable
+// base_dir is a relative path that stays inside the target directory once
+// cleaned, so "pkg/api" and "./pkg/api" pass while "/abs", "../sibling" and
+// "pkg/../../x" do not.
+func checkBaseDir(baseDir string) error {
+	if baseDir == "" {
+		return nil
+	}
+	if filepath.IsAbs(baseDir) || strings.HasPrefix(baseDir, "/") {
+		return fmt.Errorf("base_dir %q must be a relative path", baseDir)
+	}
+	cleaned := path.Clean(strings.ReplaceAll(baseDir, `\`, "/"))
+	if cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return fmt.Errorf("base_dir %q escapes the repository root", baseDir)
+	}
+	return nil
+}
+
+// RebasePath converts filePath, which is relative to the target directory,
+// into a path relative to baseDir so that profile globs can be evaluated
+// against it. The boolean result is false when filePath lies outside baseDir;
+// such files match no profile pattern. An empty baseDir (or ".") returns
+// filePath unchanged.
+//
+// Paths are compared with forward slashes and a leading "./" is ignored, so
+// RebasePath("pkg/api", "./pkg/api/src/main.go") returns ("src/main.go", true).
+func RebasePath(baseDir, filePath string) (string, bool) {
+	filePath = strings.TrimPrefix(strings.ReplaceAll(filePath, `\`, "/"), "./")
+	if baseDir == "" {
+		return filePath, true
+	}
+	base := path.Clean(strings.ReplaceAll(baseDir, `\`, "/"))
+	if base == "." {
+		return filePath, true
+	}
+	rel, ok := strings.CutPrefix(filePath, base+"/")
+	if !ok || rel == "" {
+		return "", false
+	}
+	return rel, true
+}
+
+// hasBaseDir reports whether baseDir names a subdirectory, that is, whether
+// it is neither empty nor ".".
+func hasBaseDir(baseDir string) bool {
+	return baseDir != "" && path.Clean(strings.ReplaceAll(baseDir, `\`, "/")) != "."
+}
+
+// joinBaseDir is the inverse of RebasePath: it converts filePath, relative to
+// baseDir, into a path relative to the target directory. An empty baseDir (or
+// ".") returns filePath unchanged.
+func joinBaseDir(baseDir, filePath string) string {
+	if !hasBaseDir(baseDir) {
+		return filePath
+	}
+	return path.Join(strings.ReplaceAll(baseDir, `\`, "/"), strings.ReplaceAll(filePath, `\`, "/"))
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebasePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		baseDir  string
+		filePath string
+		want     string
+		wantOK   bool
+	}{
+		{name: "empty base returns path", baseDir: "", filePath: "src/main.go", want: "src/main.go", wantOK: true},
+		{name: "dot base returns path", baseDir: ".", filePath: "./src/main.go", want: "src/main.go", wantOK: true},
+		{name: "inside base", baseDir: "packages/api", filePath: "packages/api/src/main.go", want: "src/main.go", wantOK: true},
+		{name: "leading dot slash on both", baseDir: "./packages/api/", filePath: "./packages/api/go.mod", want: "go.mod", wantOK: true},
+		{name: "backslashes", baseDir: `packages\api`, filePath: `packages\api\src\main.go`, want: "src/main.go", wantOK: true},
+		{name: "outside base", baseDir: "packages/api", filePath: "packages/web/src/main.go", wantOK: false},
+		{name: "sibling with shared prefix", baseDir: "packages/api", filePath: "packages/api-v2/main.go", wantOK: false},
+		{name: "base itself", baseDir: "packages/api", filePath: "packages/api", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, ok := RebasePath(tt.baseDir, tt.filePath)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCheckBaseDir(t *testing.T) {
+	t.Parallel()
+
+	valid := []string{"", ".", "packages/api", "./packages/api", "packages/../tools"}
+	for _, dir := range valid {
+		assert.NoError(t, checkBaseDir(dir), "base_dir %q must be accepted", dir)
+	}
+
+	invalid := []string{"/abs/path", "..", "../sibling", "packages/../../escape"}
+	for _, dir := range invalid {
+		assert.Error(t, checkBaseDir(dir), "base_dir %q must be rejected", dir)
+	}
+}
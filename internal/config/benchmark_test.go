@@ -13,7 +13,8 @@ import (
 func clearHarvxEnvForBenchmark() {
 	for _, name := range []string{
 		EnvProfile, EnvMaxTokens, EnvFormat, EnvTokenizer,
-		EnvOutput, EnvTarget, EnvLogFormat, EnvCompress, EnvRedact,
+		EnvOutput, EnvTarget, EnvLogFormat, EnvCompress, EnvRedact, EnvIgnore,
+		EnvConfigHome,
 	} {
 		os.Unsetenv(name)
 	}
@@ -0,0 +1,378 @@
+package config
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// cascadeConfigFile is the name of a directory-local config file. It is the
+// same file name as the repository config so that a subdirectory checked out
+// on its own still picks it up through DiscoverRepoConfig.
+const cascadeConfigFile = "harvx.toml"
+
+// cascadeLayer is a single directory-local harvx.toml below the target
+// directory. meta is kept so boolean fields the file leaves unset can be told
+// apart from explicit false values when the layer is merged.
+type cascadeLayer struct {
+	cfg  *Config
+	meta toml.MetaData
+}
+
+// Cascade holds the directory-local harvx.toml files found below a target
+// directory. Like editorconfig, each file's effective profile is built by
+// merging the profiles of every ancestor directory config on top of the
+// repository profile, with the closest directory winning. A config that sets
+// root = true discards everything above it and starts again from the
+// built-in defaults.
+//
+// Glob patterns in a directory-local config are relative to the directory
+// that holds it, so tier_0 = ["*.txt"] in pkg/api/harvx.toml matches
+// "pkg/api/notes.txt" but not "pkg/notes.txt". A nil *Cascade is valid and
+// contributes nothing.
+type Cascade struct {
+	// layers maps a slash-separated directory path, relative to the target
+	// directory, to the config found in it.
+	layers map[string]*cascadeLayer
+}
+
+// DiscoverCascade walks rootDir and loads every harvx.toml found in a
+// subdirectory. The harvx.toml at rootDir itself is the repository config and
+// is not part of the cascade. .git directories and nested repositories
+// (directories containing .git or RootMarkerFile) are skipped, so a vendored
+// checkout's config never leaks into the parent repository.
+//
+// A config that fails to parse is an error; the returned error names the
+// offending file.
+func DiscoverCascade(rootDir string) (*Cascade, error) {
+	c := &Cascade{layers: make(map[string]*cascadeLayer)}
+
+	err := filepath.WalkDir(rootDir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if p == rootDir {
+			return nil
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || boundaryMarker(p) != "" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Name() != cascadeConfigFile || filepath.Dir(p) == rootDir {
+			return nil
+		}
+
+		rel, err := filepath.Rel(rootDir, filepath.Dir(p))
+		if err != nil {
+			return fmt.Errorf("relative path for %s: %w", p, err)
+		}
+		layer, err := loadCascadeLayer(p)
+		if err != nil {
+			return err
+		}
+		c.layers[filepath.ToSlash(rel)] = layer
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("discover directory configs in %s: %w", rootDir, err)
+	}
+
+	slog.Debug("discovered directory configs",
+		"root", rootDir,
+		"count", len(c.layers),
+	)
+	return c, nil
+}
+
+// loadCascadeLayer decodes a directory-local config the same way
+// LoadFromFile does, keeping the TOML metadata for the merge.
+func loadCascadeLayer(p string) (*cascadeLayer, error) {
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", p, err)
+	}
+
+	var cfg Config
+	meta, err := decodeConfig(string(data), &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", p, err)
+	}
+
+	warnUndecodedKeys(undecodedKeys(meta), p)
+
+	if err := applyRelevanceFiles(&cfg, filepath.Dir(p)); err != nil {
+		return nil, fmt.Errorf("load config %s: %w", p, err)
+	}
+
+	return &cascadeLayer{cfg: &cfg, meta: meta}, nil
+}
+
+// Dirs returns the directories that hold a directory-local config, relative
+// to the target directory, in sorted order.
+func (c *Cascade) Dirs() []string {
+	if c == nil {
+		return nil
+	}
+	dirs := make([]string, 0, len(c.layers))
+	for dir := range c.layers {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	return dirs
+}
+
+// GoverningDir returns the deepest directory with a directory-local config
+// that contains filePath, or "" when no directory config applies. Files with
+// the same governing directory share the same effective profile, which makes
+// it a convenient cache key.
+func (c *Cascade) GoverningDir(filePath string) string {
+	dirs := c.ancestorDirs(filePath)
+	if len(dirs) == 0 {
+		return ""
+	}
+	return dirs[len(dirs)-1]
+}
+
+// ancestorDirs returns the directories holding a config that contain
+// filePath, ordered from the shallowest to the deepest.
+func (c *Cascade) ancestorDirs(filePath string) []string {
+	if c == nil || len(c.layers) == 0 {
+		return nil
+	}
+
+	filePath = strings.TrimPrefix(filepath.ToSlash(filePath), "./")
+	var dirs []string
+	for dir := path.Dir(filePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		if _, ok := c.layers[dir]; ok {
+			dirs = append(dirs, dir)
+		}
+	}
+
+	// Collected deepest first; callers merge shallowest first.
+	for i, j := 0, len(dirs)-1; i < j; i, j = i+1, j-1 {
+		dirs[i], dirs[j] = dirs[j], dirs[i]
+	}
+	return dirs
+}
+
+// ProfileFor returns the effective profile for filePath, a path relative to
+// the target directory. base is the resolved repository profile and
+// profileName selects the profile to read from each directory config,
+// falling back to that config's "default" profile. Directory configs that
+// define neither are skipped, although root = true still takes effect.
+//
+// Layers are merged with the usual profile rules, except that a boolean the
+// directory config leaves unset keeps the inherited value instead of
+// resetting it to false; a subdirectory that only adjusts tiers therefore
+// does not switch off redaction. extends is not followed inside directory
+// configs.
+//
+// All patterns in the returned profile, including those from base, are
+// relative to the target directory and its BaseDir is empty. When no
+// directory config applies, a copy of base is returned unchanged.
+func (c *Cascade) ProfileFor(base *Profile, profileName, filePath string) *Profile {
+	if base == nil {
+		base = DefaultProfile()
+	}
+
+	dirs := c.ancestorDirs(filePath)
+	if len(dirs) == 0 {
+		cp := *base
+		return &cp
+	}
+
+	result := rebaseProfile(base, base.BaseDir)
+	for _, dir := range dirs {
+		layer := c.layers[dir]
+		if layer.cfg.Root {
+			result = DefaultProfile()
+		}
+
+		name := profileName
+		p := layer.cfg.Profile[name]
+		if p == nil {
+			name = "default"
+			p = layer.cfg.Profile[name]
+		}
+		if p == nil {
+			continue
+		}
+
+		result = mergeCascadeLayer(result, rebaseProfile(p, path.Join(dir, p.BaseDir)), layer.meta, name)
+	}
+	return result
+}
+
+// mergeCascadeLayer applies layer on top of base with mergeProfile and then
+// restores the inherited value of every boolean the layer's file does not
+// define.
+func mergeCascadeLayer(base, layer *Profile, meta toml.MetaData, name string) *Profile {
+	defined := func(key ...string) bool {
+		return meta.IsDefined(append([]string{"profile", name}, key...)...)
+	}
+
+	merged := mergeProfile(base, layer)
+	if !defined("compression") {
+		merged.Compression = base.Compression
+	}
+	if !defined("redaction") {
+		merged.Redaction = base.Redaction
+	}
+	if !defined("include_only") {
+		merged.IncludeOnly = base.IncludeOnly
+	}
+	if !defined("priority_globs") {
+		merged.PriorityGlobs = base.PriorityGlobs
+	}
+	if !defined("stub_vendored") {
+		merged.StubVendored = base.StubVendored
+	}
+	if !defined("exclude_binary") {
+		merged.ExcludeBinary = base.ExcludeBinary
+	}
+	if !defined("case_insensitive") {
+		merged.CaseInsensitive = base.CaseInsensitive
+	}
+	if !defined("skip_empty") {
+		merged.SkipEmpty = base.SkipEmpty
+	}
+
+	if !defined("redaction_config", "enabled") {
+		merged.RedactionConfig.Enabled = base.RedactionConfig.Enabled
+	}
+	if !defined("redaction_config", "override_sensitive_defaults") {
+		merged.RedactionConfig.OverrideSensitiveDefaults = base.RedactionConfig.OverrideSensitiveDefaults
+	}
+	return merged
+}
+
+// rebaseProfile returns a copy of p whose path patterns (ignore, include,
+// priority_files, assert_include, relevance tiers, redaction exclude_paths,
+// and override match globs) are prefixed with dir, and whose BaseDir and Extends are
+// cleared. A dir of "" or "." copies the patterns unchanged.
+func rebaseProfile(p *Profile, dir string) *Profile {
+	cp := *p
+	cp.BaseDir = ""
+	cp.Extends = nil
+
+	cp.Ignore = prefixPatterns(dir, p.Ignore)
+	cp.Include = prefixPatterns(dir, p.Include)
+	cp.PriorityFiles = prefixPatterns(dir, p.PriorityFiles)
+	cp.AssertInclude = prefixPatterns(dir, p.AssertInclude)
+	cp.Relevance = RelevanceConfig{
+		Tier0: prefixPatterns(dir, p.Relevance.Tier0),
+		Tier1: prefixPatterns(dir, p.Relevance.Tier1),
+		Tier2: prefixPatterns(dir, p.Relevance.Tier2),
+		Tier3: prefixPatterns(dir, p.Relevance.Tier3),
+		Tier4: prefixPatterns(dir, p.Relevance.Tier4),
+		Tier5: prefixPatterns(dir, p.Relevance.Tier5),
+
+		Labels: p.Relevance.Labels,
+	}
+	cp.RedactionConfig.ExcludePaths = prefixPatterns(dir, p.RedactionConfig.ExcludePaths)
+	if len(p.Overrides) > 0 {
+		cp.Overrides = make([]PathOverride, len(p.Overrides))
+		for i, ov := range p.Overrides {
+			ov.Match = prefixPatterns(dir, []string{ov.Match})[0]
+			cp.Overrides[i] = ov
+		}
+	}
+	return &cp
+}
+
+// prefixPatterns returns a copy of patterns with dir prepended to each one.
+// A leading "!" negation is kept in front, and a leading "./" or "/" on the
+// pattern is dropped. A nil slice stays nil so that mergeSlice and the tier
+// defaults still treat it as unset.
+func prefixPatterns(dir string, patterns []string) []string {
+	if patterns == nil {
+		return nil
+	}
+
+	dir = path.Clean(filepath.ToSlash(dir))
+	out := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		if dir == "." {
+			out[i] = pattern
+			continue
+		}
+		neg := ""
+		if strings.HasPrefix(pattern, "!") {
+			neg, pattern = "!", pattern[1:]
+		}
+		pattern = strings.TrimPrefix(strings.TrimPrefix(pattern, "./"), "/")
+		out[i] = neg + dir + "/" + pattern
+	}
+	return out
+}
+
+// EffectiveConfigFor returns the configuration that applies to filePath: the
+// result of Resolve(opts) with the directory-local configs between
+// opts.TargetDir and the file merged on top, as Cascade.ProfileFor does.
+// filePath may be relative to opts.TargetDir or absolute. When no directory
+// config governs the file, including when opts.ProfileFile is set (which
+// replaces every harvx.toml), the result is the plain Resolve result.
+//
+// Fields a directory config changes are attributed to SourceRepo. Each call
+// walks opts.TargetDir; callers resolving many files should use Resolve,
+// DiscoverCascade, and ProfileFor directly.
+func EffectiveConfigFor(filePath string, opts ResolveOptions) (*ResolvedConfig, error) {
+	rc, err := Resolve(opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.ProfileFile != "" {
+		return rc, nil
+	}
+
+	targetDir := opts.TargetDir
+	if targetDir == "" {
+		targetDir = "."
+	}
+	rel := filePath
+	if filepath.IsAbs(filePath) {
+		absTarget, err := filepath.Abs(targetDir)
+		if err != nil {
+			return nil, fmt.Errorf("effective config for %s: %w", filePath, err)
+		}
+		rel, err = filepath.Rel(absTarget, filePath)
+		if err != nil {
+			return nil, fmt.Errorf("effective config for %s: %w", filePath, err)
+		}
+	}
+
+	cascade, err := DiscoverCascade(targetDir)
+	if err != nil {
+		return nil, fmt.Errorf("effective config for %s: %w", filePath, err)
+	}
+	if cascade.GoverningDir(rel) == "" {
+		return rc, nil
+	}
+
+	profile := cascade.ProfileFor(rc.Profile, rc.ProfileName, rel)
+	sources := make(SourceMap, len(rc.Sources))
+	for k, v := range rc.Sources {
+		sources[k] = v
+	}
+	before := profileToFlatMap(rc.Profile)
+	for k, v := range profileToFlatMap(profile) {
+		if !flatValuesEqual(before[k], v) {
+			sources[k] = SourceRepo
+		}
+	}
+
+	return &ResolvedConfig{
+		Profile:     profile,
+		Sources:     sources,
+		ProfileName: rc.ProfileName,
+	}, nil
+}
@@ -0,0 +1,267 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCascadeFile writes content to rel below root, creating parent
+// directories as needed.
+func writeCascadeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	p := filepath.Join(root, filepath.FromSlash(rel))
+	require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+	require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+}
+
+// ── DiscoverCascade ───────────────────────────────────────────────────────────
+
+// TestDiscoverCascade_FindsNestedConfigs verifies that configs in
+// subdirectories are loaded while the root config, .git, and nested
+// repositories are skipped.
+func TestDiscoverCascade_FindsNestedConfigs(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "harvx.toml", "[profile.default]\n")
+	writeCascadeFile(t, root, "subdir/harvx.toml", "[profile.default]\n")
+	writeCascadeFile(t, root, "subdir/deeper/harvx.toml", "[profile.default]\n")
+	writeCascadeFile(t, root, ".git/harvx.toml", "[profile.default]\n")
+	writeCascadeFile(t, root, "vendor/lib/.git/HEAD", "ref: refs/heads/main\n")
+	writeCascadeFile(t, root, "vendor/lib/harvx.toml", "[profile.default]\n")
+
+	c, err := DiscoverCascade(root)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"subdir", "subdir/deeper"}, c.Dirs())
+}
+
+// TestDiscoverCascade_InvalidTOML verifies that a malformed directory config
+// is reported with its path.
+func TestDiscoverCascade_InvalidTOML(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "broken/harvx.toml", "[profile.default\n")
+
+	_, err := DiscoverCascade(root)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join("broken", "harvx.toml"))
+}
+
+// ── Cascade.ProfileFor ────────────────────────────────────────────────────────
+
+// TestCascade_ProfileFor_ClosestWins verifies that configs are merged from
+// the shallowest to the deepest directory and that patterns are rebased onto
+// the directory holding each config.
+func TestCascade_ProfileFor_ClosestWins(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "subdir/harvx.toml", `
+[profile.default]
+max_tokens = 50000
+format = "xml"
+
+[profile.default.relevance]
+tier_0 = ["*.txt"]
+`)
+	writeCascadeFile(t, root, "subdir/deeper/harvx.toml", `
+[profile.default]
+max_tokens = 20000
+`)
+
+	c, err := DiscoverCascade(root)
+	require.NoError(t, err)
+
+	base := &Profile{Format: "markdown", MaxTokens: 100000, Redaction: true}
+
+	deep := c.ProfileFor(base, "default", "subdir/deeper/notes.txt")
+	assert.Equal(t, 20000, deep.MaxTokens)
+	assert.Equal(t, "xml", deep.Format)
+	assert.Equal(t, []string{"subdir/*.txt"}, deep.Relevance.Tier0)
+	assert.True(t, deep.Redaction, "unset booleans must be inherited, not reset")
+
+	sibling := c.ProfileFor(base, "default", "other/notes.txt")
+	assert.Equal(t, base, sibling)
+	assert.NotSame(t, base, sibling)
+
+	assert.Equal(t, "subdir/deeper", c.GoverningDir("subdir/deeper/notes.txt"))
+	assert.Equal(t, "subdir", c.GoverningDir("./subdir/notes.txt"))
+	assert.Equal(t, "", c.GoverningDir("other/notes.txt"))
+}
+
+// TestCascade_ProfileFor_ExplicitFalseOverrides verifies that a boolean the
+// directory config sets explicitly does override the inherited value.
+func TestCascade_ProfileFor_ExplicitFalseOverrides(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "fixtures/harvx.toml", `
+[profile.default]
+redaction = false
+`)
+
+	c, err := DiscoverCascade(root)
+	require.NoError(t, err)
+
+	got := c.ProfileFor(&Profile{Redaction: true}, "default", "fixtures/data.json")
+	assert.False(t, got.Redaction)
+}
+
+// TestCascade_ProfileFor_NamedProfile verifies that the requested profile
+// name is preferred and that "default" is used as the fallback.
+func TestCascade_ProfileFor_NamedProfile(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "a/harvx.toml", `
+[profile.default]
+max_tokens = 1000
+
+[profile.ci]
+max_tokens = 2000
+`)
+	writeCascadeFile(t, root, "b/harvx.toml", `
+[profile.default]
+max_tokens = 3000
+`)
+
+	c, err := DiscoverCascade(root)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2000, c.ProfileFor(nil, "ci", "a/x.go").MaxTokens)
+	assert.Equal(t, 3000, c.ProfileFor(nil, "ci", "b/x.go").MaxTokens)
+}
+
+// TestCascade_ProfileFor_Root verifies that root = true drops settings from
+// the base profile and from shallower directory configs.
+func TestCascade_ProfileFor_Root(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "pkg/harvx.toml", `
+[profile.default]
+format = "xml"
+`)
+	writeCascadeFile(t, root, "pkg/standalone/harvx.toml", `
+root = true
+
+[profile.default]
+max_tokens = 9000
+`)
+
+	c, err := DiscoverCascade(root)
+	require.NoError(t, err)
+
+	got := c.ProfileFor(&Profile{Target: "claude"}, "default", "pkg/standalone/main.go")
+	assert.Equal(t, 9000, got.MaxTokens)
+	assert.Equal(t, DefaultProfile().Format, got.Format)
+	assert.Empty(t, got.Target)
+}
+
+// TestCascade_ProfileFor_RebasesBaseDir verifies that the base profile's
+// base_dir is folded into its patterns so they combine with directory
+// config patterns in a single profile.
+func TestCascade_ProfileFor_RebasesBaseDir(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "svc/api/harvx.toml", `
+[profile.default.relevance]
+tier_1 = ["handlers/**"]
+`)
+
+	c, err := DiscoverCascade(root)
+	require.NoError(t, err)
+
+	base := &Profile{
+		BaseDir:   "svc",
+		Ignore:    []string{"!keep.log", "./tmp/**"},
+		Relevance: RelevanceConfig{Tier0: []string{"go.mod"}},
+	}
+	got := c.ProfileFor(base, "default", "svc/api/handlers/user.go")
+
+	assert.Empty(t, got.BaseDir)
+	assert.Equal(t, []string{"!svc/keep.log", "svc/tmp/**"}, got.Ignore)
+	assert.Equal(t, []string{"svc/go.mod"}, got.Relevance.Tier0)
+	assert.Equal(t, []string{"svc/api/handlers/**"}, got.Relevance.Tier1)
+}
+
+// TestCascade_Nil verifies that a nil *Cascade contributes nothing.
+func TestCascade_Nil(t *testing.T) {
+	t.Parallel()
+
+	var c *Cascade
+	assert.Nil(t, c.Dirs())
+	assert.Equal(t, "", c.GoverningDir("a/b.go"))
+
+	base := &Profile{MaxTokens: 42}
+	assert.Equal(t, base, c.ProfileFor(base, "default", "a/b.go"))
+}
+
+// ── EffectiveConfigFor ────────────────────────────────────────────────────────
+
+// TestEffectiveConfigFor_NestedConfig verifies that a file below a directory
+// config gets the merged profile while a root file gets the repository
+// profile.
+func TestEffectiveConfigFor_NestedConfig(t *testing.T) {
+	clearHarvxEnv(t)
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "harvx.toml", `
+[profile.default]
+format = "markdown"
+max_tokens = 5000
+`)
+	writeCascadeFile(t, root, "subdir/harvx.toml", `
+[profile.default]
+format = "xml"
+
+[profile.default.relevance]
+tier_0 = ["*.txt"]
+`)
+	opts := ResolveOptions{
+		TargetDir:        root,
+		GlobalConfigPath: filepath.Join(root, "nonexistent.toml"),
+	}
+
+	rootRC, err := EffectiveConfigFor("main.go", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "markdown", rootRC.Profile.Format)
+	assert.Equal(t, 5000, rootRC.Profile.MaxTokens)
+
+	subRC, err := EffectiveConfigFor("subdir/notes.txt", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "xml", subRC.Profile.Format)
+	assert.Equal(t, 5000, subRC.Profile.MaxTokens, "unset fields are inherited from the root config")
+	assert.Equal(t, []string{"subdir/*.txt"}, subRC.Profile.Relevance.Tier0)
+	assert.Equal(t, SourceRepo, subRC.Sources["format"])
+	assert.Equal(t, "default", subRC.ProfileName)
+
+	absRC, err := EffectiveConfigFor(filepath.Join(root, "subdir", "notes.txt"), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "xml", absRC.Profile.Format, "absolute paths are made relative to TargetDir")
+}
+
+// TestEffectiveConfigFor_NoNestedConfigs verifies that without directory
+// configs the result matches Resolve.
+func TestEffectiveConfigFor_NoNestedConfigs(t *testing.T) {
+	clearHarvxEnv(t)
+
+	root := t.TempDir()
+	writeCascadeFile(t, root, "harvx.toml", "[profile.default]\nmax_tokens = 7000\n")
+	opts := ResolveOptions{
+		TargetDir:        root,
+		GlobalConfigPath: filepath.Join(root, "nonexistent.toml"),
+	}
+
+	want, err := Resolve(opts)
+	require.NoError(t, err)
+	got, err := EffectiveConfigFor("pkg/main.go", opts)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
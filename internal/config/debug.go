@@ -1,49 +1,75 @@
 package config
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
 )
 
 // ConfigFileStatus represents the found/not-found status of a single harvx
 // config file, along with a display-friendly path.
 type ConfigFileStatus struct {
-	Label string `json:"label"` // "Global" or "Repo"
-	Path  string `json:"path"`  // display path with ~ or ./
-	Found bool   `json:"found"`
+	Label string `json:"label" yaml:"label"` // "Global" or "Repo"
+	Path  string `json:"path" yaml:"path"`   // display path with ~ or ./
+	Found bool   `json:"found" yaml:"found"`
 }
 
 // EnvVarStatus tracks whether a known HARVX_* environment variable is
 // currently set and active.
 type EnvVarStatus struct {
-	Name    string `json:"name"`
-	Value   string `json:"value,omitempty"`
-	Applied bool   `json:"applied"`
+	Name    string `json:"name" yaml:"name"`
+	Value   string `json:"value,omitempty" yaml:"value,omitempty"`
+	Applied bool   `json:"applied" yaml:"applied"`
+}
+
+// FlagStatus reports one CLI flag override passed in DebugOptions.CLIFlags.
+// Name is the flat profile key the flag sets and Applied reports whether the
+// resolved value came from the flag layer.
+type FlagStatus struct {
+	Name    string `json:"name" yaml:"name"`
+	Value   string `json:"value" yaml:"value"`
+	Applied bool   `json:"applied" yaml:"applied"`
 }
 
 // ConfigEntry is one row in the resolved configuration table, pairing a flat
 // field key with its display value and the source layer that provided it.
 type ConfigEntry struct {
-	Key    string `json:"key"`
-	Value  string `json:"value"`
-	Source string `json:"source"`
+	Key    string `json:"key" yaml:"key"`
+	Value  string `json:"value" yaml:"value"`
+	Source string `json:"source" yaml:"source"`
+}
+
+// TierEntry describes one effective relevance tier: its number, the
+// human-readable label used by the relevance package, the full pattern list
+// that will be used for classification, and whether the patterns came from a
+// profile ("profile") or the built-in defaults ("default").
+type TierEntry struct {
+	Tier     int      `json:"tier" yaml:"tier"`
+	Label    string   `json:"label" yaml:"label"`
+	Patterns []string `json:"patterns" yaml:"patterns"`
+	Source   string   `json:"source" yaml:"source"`
 }
 
 // DebugOutput is the complete structured result produced by BuildDebugOutput.
 // It is consumed by FormatDebugOutput for human-readable text and by
-// FormatDebugOutputJSON for machine-readable JSON.
+// FormatDebugOutputJSON and FormatDebugOutputYAML for machine-readable output.
 type DebugOutput struct {
-	ConfigFiles   []ConfigFileStatus `json:"config_files"`
-	ActiveProfile string             `json:"active_profile"`
-	InheritChain  []string           `json:"inherit_chain,omitempty"`
-	EnvVars       []EnvVarStatus     `json:"env_vars"`
-	Config        []ConfigEntry      `json:"config"`
+	ConfigFiles   []ConfigFileStatus `json:"config_files" yaml:"config_files"`
+	ActiveProfile string             `json:"active_profile" yaml:"active_profile"`
+	InheritChain  []string           `json:"inherit_chain,omitempty" yaml:"inherit_chain,omitempty"`
+	EnvVars       []EnvVarStatus     `json:"env_vars" yaml:"env_vars"`
+	Flags         []FlagStatus       `json:"flags" yaml:"flags"`
+	Config        []ConfigEntry      `json:"config" yaml:"config"`
+	Tiers         []TierEntry        `json:"tiers" yaml:"tiers"`
 }
 
 // DebugOptions configures BuildDebugOutput. All fields are optional and fall
@@ -56,6 +82,10 @@ type DebugOptions struct {
 	// GlobalConfigPath overrides automatic global config discovery. Useful in
 	// tests to point at a fixture file instead of the real user config.
 	GlobalConfigPath string
+	// RepoConfigChain resolves with every harvx.toml up to the repository
+	// root (see ResolveOptions.RepoConfigChain) and lists each of them in
+	// ConfigFiles.
+	RepoConfigChain bool
 	// CLIFlags holds explicit CLI flag overrides (highest precedence layer).
 	// Keys are flat Profile field names: "format", "max_tokens", etc.
 	CLIFlags map[string]any
@@ -75,7 +105,7 @@ func BuildDebugOutput(opts DebugOptions) (*DebugOutput, error) {
 	}
 
 	// ── Config file statuses ─────────────────────────────────────────────────
-	configFiles, err := buildConfigFileStatuses(targetDir, opts.GlobalConfigPath)
+	configFiles, err := buildConfigFileStatuses(targetDir, opts.GlobalConfigPath, opts.RepoConfigChain)
 	if err != nil {
 		return nil, fmt.Errorf("building config file statuses: %w", err)
 	}
@@ -85,6 +115,7 @@ func BuildDebugOutput(opts DebugOptions) (*DebugOutput, error) {
 		ProfileName:      profileName,
 		TargetDir:        targetDir,
 		GlobalConfigPath: opts.GlobalConfigPath,
+		RepoConfigChain:  opts.RepoConfigChain,
 		CLIFlags:         opts.CLIFlags,
 	})
 	if err != nil {
@@ -103,15 +134,23 @@ func BuildDebugOutput(opts DebugOptions) (*DebugOutput, error) {
 	// ── Env var statuses ─────────────────────────────────────────────────────
 	envVars := buildEnvVarStatuses()
 
+	// ── CLI flag statuses ────────────────────────────────────────────────────
+	flags := buildFlagStatuses(opts.CLIFlags, resolved.Sources)
+
 	// ── Ordered config entries ───────────────────────────────────────────────
 	configEntries := buildConfigEntries(resolved.Profile, resolved.Sources)
 
+	// ── Effective tier definitions ───────────────────────────────────────────
+	tiers := buildTierEntries(resolved.Profile, resolved.Sources)
+
 	return &DebugOutput{
 		ConfigFiles:   configFiles,
 		ActiveProfile: activeProfile,
 		InheritChain:  chain,
 		EnvVars:       envVars,
+		Flags:         flags,
 		Config:        configEntries,
+		Tiers:         tiers,
 	}, nil
 }
 
@@ -133,9 +172,15 @@ func BuildDebugOutput(opts DebugOptions) (*DebugOutput, error) {
 //	  HARVX_MAX_TOKENS = 150000 (applied)
 //	  HARVX_COMPRESS   = (not set)
 //
+//	CLI Flags:
+//	  format = xml (applied)
+//
 //	Resolved Configuration:
 //	  KEY          VALUE           SOURCE
 //	  output       harvx-out.md    repo
+//
+//	Effective Tiers:
+//	  0   critical    profile   [go.mod, Dockerfile]
 func FormatDebugOutput(out *DebugOutput, w io.Writer) error {
 	// Header.
 	fmt.Fprintln(w, "Harvx Configuration Debug")
@@ -181,6 +226,26 @@ func FormatDebugOutput(out *DebugOutput, w io.Writer) error {
 	}
 	fmt.Fprintln(w)
 
+	// CLI Flags section.
+	fmt.Fprintln(w, "CLI Flags:")
+	if len(out.Flags) == 0 {
+		fmt.Fprintln(w, "  (none)")
+	} else {
+		maxLen := 0
+		for _, f := range out.Flags {
+			maxLen = max(maxLen, len(f.Name))
+		}
+		for _, f := range out.Flags {
+			padded := f.Name + strings.Repeat(" ", maxLen-len(f.Name))
+			status := "applied"
+			if !f.Applied {
+				status = "ignored"
+			}
+			fmt.Fprintf(w, "  %s = %s (%s)\n", padded, f.Value, status)
+		}
+	}
+	fmt.Fprintln(w)
+
 	// Resolved Configuration section.
 	fmt.Fprintln(w, "Resolved Configuration:")
 	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
@@ -192,6 +257,23 @@ func FormatDebugOutput(out *DebugOutput, w io.Writer) error {
 		return fmt.Errorf("flushing config table: %w", err)
 	}
 
+	// Effective Tiers section.
+	if len(out.Tiers) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Effective Tiers:")
+		tw = tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+		for _, te := range out.Tiers {
+			patterns := abbreviateSlice(te.Patterns)
+			if patterns == "" {
+				patterns = "(none)"
+			}
+			fmt.Fprintf(tw, "  %d\t%s\t%s\t%s\n", te.Tier, te.Label, te.Source, patterns)
+		}
+		if err := tw.Flush(); err != nil {
+			return fmt.Errorf("flushing tier table: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -206,11 +288,33 @@ func FormatDebugOutputJSON(out *DebugOutput, w io.Writer) error {
 	return err
 }
 
+// FormatDebugOutputYAML marshals a DebugOutput to YAML and writes it to w.
+// Keys match FormatDebugOutputJSON. inherit_chain is omitted when empty and
+// also when it holds a single profile, since a profile that extends nothing
+// has no chain worth reporting.
+func FormatDebugOutputYAML(out *DebugOutput, w io.Writer) error {
+	doc := *out
+	if len(doc.InheritChain) <= 1 {
+		doc.InheritChain = nil
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("marshal debug output to YAML: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("marshal debug output to YAML: %w", err)
+	}
+	return nil
+}
+
 // ── Internal builders ────────────────────────────────────────────────────────
 
 // buildConfigFileStatuses computes the Found/not-found status and display path
-// for the global and repo config files.
-func buildConfigFileStatuses(targetDir, globalConfigPathOverride string) ([]ConfigFileStatus, error) {
+// for the global and repo config files. With chain set, every repo config
+// layer gets its own "Repo" row, nearest first.
+func buildConfigFileStatuses(targetDir, globalConfigPathOverride string, chain bool) ([]ConfigFileStatus, error) {
 	statuses := make([]ConfigFileStatus, 0, 2)
 
 	// Global config: compute canonical expected path via globalConfigDir.
@@ -238,6 +342,20 @@ func buildConfigFileStatuses(targetDir, globalConfigPathOverride string) ([]Conf
 		Found: globalFound,
 	})
 
+	if chain {
+		layers, discErr := DiscoverRepoConfigChain(targetDir)
+		if discErr == nil && len(layers) > 0 {
+			for _, layer := range layers {
+				statuses = append(statuses, ConfigFileStatus{
+					Label: "Repo",
+					Path:  displayDotPath(layer, targetDir),
+					Found: true,
+				})
+			}
+			return statuses, nil
+		}
+	}
+
 	// Repo config: expected path is harvx.toml directly inside targetDir.
 	repoExpected := filepath.Join(targetDir, "harvx.toml")
 	repoDisplay := displayDotPath(repoExpected, targetDir)
@@ -285,6 +403,7 @@ func buildEnvVarStatuses() []EnvVarStatus {
 		EnvTarget,
 		EnvCompress,
 		EnvRedact,
+		EnvIgnore,
 		EnvLogFormat,
 	}
 
@@ -300,25 +419,92 @@ func buildEnvVarStatuses() []EnvVarStatus {
 	return statuses
 }
 
+// buildFlagStatuses returns one FlagStatus per CLI flag override, sorted by
+// name. A flag counts as applied when the resolved value for its key came
+// from the flag layer. The result is never nil so the section is always
+// present in JSON and YAML output.
+func buildFlagStatuses(cliFlags map[string]any, sources SourceMap) []FlagStatus {
+	statuses := make([]FlagStatus, 0, len(cliFlags))
+	for name, value := range cliFlags {
+		display := fmt.Sprint(value)
+		if items, ok := value.([]string); ok {
+			display = abbreviateSlice(items)
+		}
+		statuses = append(statuses, FlagStatus{
+			Name:    name,
+			Value:   display,
+			Applied: sources[name] == SourceFlag,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b FlagStatus) int {
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return statuses
+}
+
+// canonicalConfigKeyOrder is the fixed display order of ConfigEntry keys in
+// debug output. It mirrors the field order of the profile golden files so that
+// debug output diffs stay stable. Each key sits next to the field it modifies:
+// priority_globs follows priority_files, include_only follows include, and
+// relevance_file precedes the tiers.
+var canonicalConfigKeyOrder = []string{
+	"output",
+	"format",
+	"max_tokens",
+	"tokenizer",
+	"compression",
+	"redaction",
+	"target",
+	"base_dir",
+	"sort_order",
+	"ignore",
+	"priority_files",
+	"priority_globs",
+	"include",
+	"include_only",
+	"relevance_file",
+	"relevance.tier_0",
+	"relevance.tier_1",
+	"relevance.tier_2",
+	"relevance.tier_3",
+	"relevance.tier_4",
+	"relevance.tier_5",
+	"redaction_config.enabled",
+	"redaction_config.confidence_threshold",
+	"redaction_config.exclude_paths",
+}
+
 // buildConfigEntries constructs the ordered list of configuration rows from a
-// resolved Profile and its source attribution map. The display order follows
-// the canonical field sequence documented in the harvx PRD.
+// resolved Profile and its source attribution map. Rows are returned in
+// canonicalConfigKeyOrder.
 func buildConfigEntries(p *Profile, sources SourceMap) []ConfigEntry {
-	entries := make([]ConfigEntry, 0, 19)
+	entries := make([]ConfigEntry, 0, len(canonicalConfigKeyOrder))
 
 	// Scalar fields.
 	entries = append(entries, stringEntry("output", p.Output, sources))
 	entries = append(entries, stringEntry("format", p.Format, sources))
-	entries = append(entries, intEntry("max_tokens", p.MaxTokens, sources))
+	maxTokens := intEntry("max_tokens", p.MaxTokens, sources)
+	if p.MaxTokensPercent > 0 {
+		target := p.Target
+		if target == "" {
+			target = "generic"
+		}
+		maxTokens.Source += fmt.Sprintf(" (%d%% of %s context %d)", p.MaxTokensPercent, target, TargetContextWindow(p.Target))
+	}
+	entries = append(entries, maxTokens)
 	entries = append(entries, stringEntry("tokenizer", p.Tokenizer, sources))
 	entries = append(entries, boolEntry("compression", p.Compression, sources))
 	entries = append(entries, boolEntry("redaction", p.Redaction, sources))
 	entries = append(entries, stringEntry("target", p.Target, sources))
+	entries = append(entries, stringEntry("base_dir", p.BaseDir, sources))
+	entries = append(entries, stringEntry("sort_order", p.SortOrder, sources))
 
 	// Top-level slice fields.
 	entries = append(entries, sliceEntry("ignore", p.Ignore, sources))
 	entries = append(entries, sliceEntry("priority_files", p.PriorityFiles, sources))
+	entries = append(entries, boolEntry("priority_globs", p.PriorityGlobs, sources))
 	entries = append(entries, sliceEntry("include", p.Include, sources))
+	entries = append(entries, boolEntry("include_only", p.IncludeOnly, sources))
 
 	// RedactionConfig nested fields.
 	entries = append(entries, boolEntry("redaction_config.enabled", p.RedactionConfig.Enabled, sources))
@@ -326,6 +512,7 @@ func buildConfigEntries(p *Profile, sources SourceMap) []ConfigEntry {
 	entries = append(entries, sliceEntry("redaction_config.exclude_paths", p.RedactionConfig.ExcludePaths, sources))
 
 	// Relevance tier slice fields.
+	entries = append(entries, stringEntry("relevance_file", p.RelevanceFile, sources))
 	entries = append(entries, sliceEntry("relevance.tier_0", p.Relevance.Tier0, sources))
 	entries = append(entries, sliceEntry("relevance.tier_1", p.Relevance.Tier1, sources))
 	entries = append(entries, sliceEntry("relevance.tier_2", p.Relevance.Tier2, sources))
@@ -333,6 +520,71 @@ func buildConfigEntries(p *Profile, sources SourceMap) []ConfigEntry {
 	entries = append(entries, sliceEntry("relevance.tier_4", p.Relevance.Tier4, sources))
 	entries = append(entries, sliceEntry("relevance.tier_5", p.Relevance.Tier5, sources))
 
+	// With include_only the tiers are never consulted; say so on each row so
+	// the table does not suggest they influence ordering.
+	if p.IncludeOnly {
+		for i := range entries {
+			if strings.HasPrefix(entries[i].Key, "relevance.") {
+				entries[i].Source += " (inactive: include_only)"
+			}
+		}
+	}
+
+	sortConfigEntries(entries)
+	return entries
+}
+
+// sortConfigEntries orders entries by canonicalConfigKeyOrder. Keys missing
+// from the canonical list sort after all known keys, alphabetically.
+func sortConfigEntries(entries []ConfigEntry) {
+	rank := func(key string) int {
+		if i := slices.Index(canonicalConfigKeyOrder, key); i >= 0 {
+			return i
+		}
+		return len(canonicalConfigKeyOrder)
+	}
+	slices.SortStableFunc(entries, func(a, b ConfigEntry) int {
+		if c := cmp.Compare(rank(a.Key), rank(b.Key)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+// tierLabels holds the display label for each tier number. The labels match
+// relevance.Tier.String, which config cannot import without a cycle.
+var tierLabels = [...]string{"critical", "primary", "secondary", "tests", "docs", "low"}
+
+// buildTierEntries returns the effective tier definitions of the resolved
+// profile, in tier order. A tier whose patterns were supplied only by the
+// built-in default layer is attributed "default"; any other layer counts as
+// "profile".
+func buildTierEntries(p *Profile, sources SourceMap) []TierEntry {
+	tiers := [...][]string{
+		p.Relevance.Tier0,
+		p.Relevance.Tier1,
+		p.Relevance.Tier2,
+		p.Relevance.Tier3,
+		p.Relevance.Tier4,
+		p.Relevance.Tier5,
+	}
+
+	entries := make([]TierEntry, 0, len(tiers))
+	for i, patterns := range tiers {
+		if patterns == nil {
+			patterns = []string{}
+		}
+		source := "default"
+		if sources[fmt.Sprintf("relevance.tier_%d", i)] != SourceDefault {
+			source = "profile"
+		}
+		entries = append(entries, TierEntry{
+			Tier:     i,
+			Label:    tierLabels[i],
+			Patterns: patterns,
+			Source:   source,
+		})
+	}
 	return entries
 }
 
@@ -424,6 +676,7 @@ func keyToEnvVar(key string) string {
 		"target":      EnvTarget,
 		"compression": EnvCompress,
 		"redaction":   EnvRedact,
+		"ignore":      EnvIgnore,
 	}
 	return m[key]
 }
@@ -3,12 +3,14 @@ package config
 import (
 	"bytes"
 	"encoding/json"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // ── abbreviateSlice ───────────────────────────────────────────────────────────
@@ -202,6 +204,182 @@ format = "xml"
 	assert.Equal(t, "repo", formatEntry.Source)
 }
 
+// TestBuildDebugOutput_IncludeOnlyMarksTiersInactive verifies that when
+// include_only is enabled the include_only row is reported and every
+// relevance tier row is annotated as inactive.
+func TestBuildDebugOutput_IncludeOnlyMarksTiersInactive(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+include = ["internal/**/*.go"]
+include_only = true
+`)
+
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "no-global.toml"),
+	})
+	require.NoError(t, err)
+
+	includeOnly := findConfigEntry(t, out, "include_only")
+	assert.Equal(t, "true", includeOnly.Value)
+	assert.Equal(t, "repo", includeOnly.Source)
+
+	for _, ce := range out.Config {
+		if strings.HasPrefix(ce.Key, "relevance.") {
+			assert.Contains(t, ce.Source, "inactive: include_only", "tier %q must be marked inactive", ce.Key)
+		}
+	}
+}
+
+// TestBuildDebugOutput_CanonicalKeyOrder verifies that Config rows for a
+// fully-populated profile are returned in the fixed canonical key order.
+func TestBuildDebugOutput_CanonicalKeyOrder(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "tiers.toml", `tier_5 = ["**/*.lock"]`)
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+output = "out.md"
+format = "xml"
+max_tokens = 50000
+tokenizer = "o200k_base"
+compression = true
+redaction = true
+target = "claude"
+ignore = ["dist"]
+priority_files = ["go.mod"]
+include = ["**/*.go"]
+relevance_file = "tiers.toml"
+
+[profile.default.relevance]
+tier_0 = ["go.mod"]
+tier_1 = ["cmd/**"]
+tier_2 = ["internal/**"]
+tier_3 = ["**/*_test.go"]
+tier_4 = ["**/*.md"]
+
+[profile.default.redaction_config]
+enabled = true
+confidence_threshold = "medium"
+exclude_paths = ["testdata/**"]
+`)
+
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "no-global.toml"),
+	})
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(out.Config))
+	for _, ce := range out.Config {
+		keys = append(keys, ce.Key)
+	}
+	assert.Equal(t, []string{
+		"output",
+		"format",
+		"max_tokens",
+		"tokenizer",
+		"compression",
+		"redaction",
+		"target",
+		"base_dir",
+		"sort_order",
+		"ignore",
+		"priority_files",
+		"priority_globs",
+		"include",
+		"include_only",
+		"relevance_file",
+		"relevance.tier_0",
+		"relevance.tier_1",
+		"relevance.tier_2",
+		"relevance.tier_3",
+		"relevance.tier_4",
+		"relevance.tier_5",
+		"redaction_config.enabled",
+		"redaction_config.confidence_threshold",
+		"redaction_config.exclude_paths",
+	}, keys)
+}
+
+// TestSortConfigEntries_UnknownKeysLast verifies that keys outside the
+// canonical list sort after all known keys in alphabetical order.
+func TestSortConfigEntries_UnknownKeysLast(t *testing.T) {
+	t.Parallel()
+
+	entries := []ConfigEntry{
+		{Key: "zeta"},
+		{Key: "relevance.tier_0"},
+		{Key: "alpha"},
+		{Key: "output"},
+	}
+	sortConfigEntries(entries)
+
+	keys := make([]string, 0, len(entries))
+	for _, ce := range entries {
+		keys = append(keys, ce.Key)
+	}
+	assert.Equal(t, []string{"output", "relevance.tier_0", "alpha", "zeta"}, keys)
+}
+
+// TestBuildDebugOutput_TiersReportSource verifies that a profile overriding
+// tier_0 reports its patterns with source "profile" while untouched tiers
+// keep the default patterns with source "default".
+func TestBuildDebugOutput_TiersReportSource(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default.relevance]
+tier_0 = ["schema.sql", "go.mod"]
+`)
+
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "no-global.toml"),
+	})
+	require.NoError(t, err)
+	require.Len(t, out.Tiers, 6)
+
+	defaults := DefaultProfile().Relevance
+	assert.Equal(t, TierEntry{Tier: 0, Label: "critical", Patterns: []string{"schema.sql", "go.mod"}, Source: "profile"}, out.Tiers[0])
+	assert.Equal(t, TierEntry{Tier: 1, Label: "primary", Patterns: defaults.Tier1, Source: "default"}, out.Tiers[1])
+	for _, te := range out.Tiers[1:] {
+		assert.Equal(t, "default", te.Source, "tier %d must come from defaults", te.Tier)
+		assert.NotEmpty(t, te.Patterns)
+	}
+	assert.Equal(t, "low", out.Tiers[5].Label)
+}
+
+// TestFormatDebugOutput_TiersSection verifies the compact text rendering of
+// the effective tier definitions and their JSON encoding.
+func TestFormatDebugOutput_TiersSection(t *testing.T) {
+	out := sampleDebugOutput()
+	out.Tiers = []TierEntry{
+		{Tier: 0, Label: "critical", Patterns: []string{"go.mod"}, Source: "profile"},
+		{Tier: 5, Label: "low", Patterns: []string{}, Source: "default"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, FormatDebugOutput(out, &buf))
+	text := buf.String()
+	assert.Contains(t, text, "Effective Tiers:")
+	assert.Regexp(t, `0\s+critical\s+profile\s+\[go\.mod\]`, text)
+	assert.Regexp(t, `5\s+low\s+default\s+\(none\)`, text)
+
+	buf.Reset()
+	require.NoError(t, FormatDebugOutputJSON(out, &buf))
+	var parsed struct {
+		Tiers []TierEntry `json:"tiers"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.Equal(t, out.Tiers, parsed.Tiers)
+}
+
 // ── BuildDebugOutput: env var override ────────────────────────────────────────
 
 // TestBuildDebugOutput_EnvVarOverride verifies that a field set via HARVX_*
@@ -228,6 +406,26 @@ func TestBuildDebugOutput_EnvVarOverride(t *testing.T) {
 	assert.Equal(t, "200000", ev.Value)
 }
 
+// TestBuildDebugOutput_MaxTokensPercent verifies that a max_tokens derived
+// from a percentage shows the absolute value and notes the derivation.
+func TestBuildDebugOutput_MaxTokensPercent(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", "[profile.default]\ntarget = \"claude\"\nmax_tokens = \"80%\"\n")
+
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "no-global.toml"),
+	})
+	require.NoError(t, err)
+
+	entry := findConfigEntry(t, out, "max_tokens")
+	assert.Equal(t, "160000", entry.Value)
+	assert.Contains(t, entry.Source, "repo")
+	assert.Contains(t, entry.Source, "(80% of claude context 200000)")
+}
+
 // TestBuildDebugOutput_EnvVarOutput verifies the HARVX_OUTPUT env var.
 func TestBuildDebugOutput_EnvVarOutput(t *testing.T) {
 	clearHarvxEnv(t)
@@ -282,6 +480,54 @@ func TestBuildDebugOutput_CLIFlagFormat(t *testing.T) {
 	assert.Equal(t, "flag (--format)", entry.Source)
 }
 
+// TestBuildDebugOutput_FlagsReported verifies that every CLIFlags entry is
+// reported in DebugOutput.Flags, sorted by name and marked applied.
+func TestBuildDebugOutput_FlagsReported(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "no-global.toml"),
+		CLIFlags:         map[string]any{"max_tokens": 50000, "format": "xml"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []FlagStatus{
+		{Name: "format", Value: "xml", Applied: true},
+		{Name: "max_tokens", Value: "50000", Applied: true},
+	}, out.Flags)
+}
+
+// TestBuildDebugOutput_NoFlags verifies that an empty CLIFlags map yields an
+// empty but non-nil Flags slice, so the section is still present in JSON and
+// YAML output.
+func TestBuildDebugOutput_NoFlags(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "no-global.toml"),
+		CLIFlags:         map[string]any{},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, out.Flags)
+	assert.Empty(t, out.Flags)
+
+	var jsonBuf bytes.Buffer
+	require.NoError(t, FormatDebugOutputJSON(out, &jsonBuf))
+	assert.Contains(t, jsonBuf.String(), `"flags": []`)
+
+	var yamlBuf bytes.Buffer
+	require.NoError(t, FormatDebugOutputYAML(out, &yamlBuf))
+	assert.Contains(t, yamlBuf.String(), "flags: []")
+
+	var textBuf bytes.Buffer
+	require.NoError(t, FormatDebugOutput(out, &textBuf))
+	assert.Contains(t, textBuf.String(), "CLI Flags:\n  (none)\n")
+}
+
 // ── BuildDebugOutput: missing vs. present config files ───────────────────────
 
 // TestBuildDebugOutput_RepoConfigNotFound verifies that when no harvx.toml
@@ -398,6 +644,7 @@ func TestBuildDebugOutput_AllEnvVarsReported(t *testing.T) {
 		EnvTarget,
 		EnvCompress,
 		EnvRedact,
+		EnvIgnore,
 		EnvLogFormat,
 	}
 
@@ -567,6 +814,24 @@ func TestFormatDebugOutput_EnvVarApplied(t *testing.T) {
 	assert.Contains(t, text, "150000")
 }
 
+// TestFormatDebugOutput_FlagsSection verifies that CLI flags are rendered
+// under a "CLI Flags:" section with aligned names and their status.
+func TestFormatDebugOutput_FlagsSection(t *testing.T) {
+	out := sampleDebugOutput()
+	out.Flags = []FlagStatus{
+		{Name: "format", Value: "xml", Applied: true},
+		{Name: "max_tokens", Value: "50000", Applied: true},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, FormatDebugOutput(out, &buf))
+
+	text := buf.String()
+	assert.Contains(t, text, "CLI Flags:\n")
+	assert.Contains(t, text, "  format     = xml (applied)\n")
+	assert.Contains(t, text, "  max_tokens = 50000 (applied)\n")
+}
+
 // TestFormatDebugOutput_ConfigTableHeaders verifies that the resolved config
 // table contains KEY, VALUE, and SOURCE column headers.
 func TestFormatDebugOutput_ConfigTableHeaders(t *testing.T) {
@@ -635,7 +900,7 @@ func TestFormatDebugOutputJSON_ExpectedTopLevelFields(t *testing.T) {
 	var parsed map[string]any
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
 
-	for _, field := range []string{"config_files", "active_profile", "env_vars", "config"} {
+	for _, field := range []string{"config_files", "active_profile", "env_vars", "flags", "config"} {
 		assert.Contains(t, parsed, field,
 			"JSON output must contain top-level key %q", field)
 	}
@@ -849,7 +1114,7 @@ func TestBuildAndFormatJSON_Integration(t *testing.T) {
 	err = json.Unmarshal(buf.Bytes(), &parsed)
 	require.NoError(t, err, "full pipeline JSON output must be valid")
 
-	for _, field := range []string{"config_files", "active_profile", "env_vars", "config"} {
+	for _, field := range []string{"config_files", "active_profile", "env_vars", "flags", "config"} {
 		assert.Contains(t, parsed, field)
 	}
 }
@@ -857,6 +1122,58 @@ func TestBuildAndFormatJSON_Integration(t *testing.T) {
 // ── helpers ───────────────────────────────────────────────────────────────────
 
 // sampleDebugOutput returns a minimal DebugOutput suitable for format tests.
+// ── FormatDebugOutputYAML ─────────────────────────────────────────────────────
+
+// TestFormatDebugOutputYAML_TopLevelKeys verifies that the YAML output parses
+// back and carries the same top-level keys as the JSON renderer.
+func TestFormatDebugOutputYAML_TopLevelKeys(t *testing.T) {
+	out := sampleDebugOutput()
+	out.InheritChain = []string{"child", "default"}
+
+	var buf bytes.Buffer
+	require.NoError(t, FormatDebugOutputYAML(out, &buf))
+
+	var parsed map[string]any
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &parsed), "output must be valid YAML")
+
+	for _, key := range []string{"config_files", "active_profile", "inherit_chain", "env_vars", "config"} {
+		assert.Contains(t, parsed, key, "YAML output must contain top-level key %q", key)
+	}
+	assert.Equal(t, []any{"child", "default"}, parsed["inherit_chain"])
+}
+
+// TestFormatDebugOutputYAML_RoundTrip verifies that decoding the YAML output
+// back into a DebugOutput reproduces the input.
+func TestFormatDebugOutputYAML_RoundTrip(t *testing.T) {
+	out := sampleDebugOutput()
+	out.InheritChain = []string{"child", "default"}
+	out.Tiers = []TierEntry{{Tier: 0, Label: "Critical", Patterns: []string{"go.mod"}, Source: "default"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, FormatDebugOutputYAML(out, &buf))
+
+	var decoded DebugOutput
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, *out, decoded)
+}
+
+// TestFormatDebugOutputYAML_SingleElementChainOmitted verifies that
+// inherit_chain is omitted for a nil or single-element chain.
+func TestFormatDebugOutputYAML_SingleElementChainOmitted(t *testing.T) {
+	for _, chain := range [][]string{nil, {"default"}} {
+		out := sampleDebugOutput()
+		out.InheritChain = chain
+
+		var buf bytes.Buffer
+		require.NoError(t, FormatDebugOutputYAML(out, &buf))
+
+		var parsed map[string]any
+		require.NoError(t, yaml.Unmarshal(buf.Bytes(), &parsed))
+		assert.NotContains(t, parsed, "inherit_chain", "chain %v", chain)
+		assert.Equal(t, chain, out.InheritChain, "input must not be modified")
+	}
+}
+
 func sampleDebugOutput() *DebugOutput {
 	return &DebugOutput{
 		ConfigFiles: []ConfigFileStatus{
@@ -869,6 +1186,7 @@ func sampleDebugOutput() *DebugOutput {
 			{Name: "HARVX_MAX_TOKENS", Applied: false},
 			{Name: "HARVX_FORMAT", Applied: false},
 		},
+		Flags: []FlagStatus{},
 		Config: []ConfigEntry{
 			{Key: "output", Value: "harvx-output.md", Source: "default"},
 			{Key: "format", Value: "markdown", Source: "default"},
@@ -902,3 +1220,35 @@ func findEnvVarStatus(t *testing.T, out *DebugOutput, name string) EnvVarStatus
 	return EnvVarStatus{}
 }
 
+
+// TestBuildDebugOutput_RepoConfigChain verifies that every chained repo config
+// layer is listed, nearest first.
+func TestBuildDebugOutput_RepoConfigChain(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repo := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	writeTomlFile(t, repo, "harvx.toml", "[profile.default]\nformat = \"xml\"\n")
+	pkg := filepath.Join(repo, "pkg")
+	require.NoError(t, os.Mkdir(pkg, 0o755))
+	writeTomlFile(t, pkg, "harvx.toml", "[profile.default]\nmax_tokens = 9000\n")
+
+	out, err := BuildDebugOutput(DebugOptions{
+		TargetDir:        pkg,
+		GlobalConfigPath: filepath.Join(repo, "no-global.toml"),
+		RepoConfigChain:  true,
+	})
+	require.NoError(t, err)
+
+	var repoRows []ConfigFileStatus
+	for _, cf := range out.ConfigFiles {
+		if cf.Label == "Repo" {
+			repoRows = append(repoRows, cf)
+		}
+	}
+	require.Len(t, repoRows, 2)
+	assert.Equal(t, "./harvx.toml", repoRows[0].Path)
+	assert.True(t, repoRows[0].Found)
+	assert.True(t, repoRows[1].Found)
+	assert.Equal(t, "xml", findConfigEntry(t, out, "format").Value)
+}
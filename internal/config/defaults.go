@@ -15,6 +15,8 @@ func DefaultProfile() *Profile {
 		Compression: false,
 		Redaction:   true,
 		Target:      "",
+		IncludeOnly: false,
+		SkipEmpty:   true,
 		Ignore: []string{
 			"node_modules",
 			"dist",
@@ -0,0 +1,33 @@
+package config
+
+import "strings"
+
+// UnboundedDepth is the PatternDepth of a pattern containing "**", which
+// matches files at any depth.
+const UnboundedDepth = -1
+
+// PatternDepth reports how many path segments a glob pattern matches below
+// its literal directory prefix. A single "*" never crosses "/", so "src/*"
+// matches only direct children of src and has depth 1, and "src/*/main.go"
+// has depth 2. A pattern containing "**" returns UnboundedDepth, and a
+// literal path with no wildcards returns 0. A leading "!" negation is
+// ignored.
+//
+// PatternDepth is descriptive only; matching itself is done by doublestar.
+func PatternDepth(pattern string) int {
+	segments := strings.Split(NormalizePattern(strings.TrimPrefix(pattern, "!")), "/")
+
+	first := -1
+	for i, seg := range segments {
+		if strings.Contains(seg, "**") {
+			return UnboundedDepth
+		}
+		if first == -1 && strings.ContainsAny(seg, globMetaChars) {
+			first = i
+		}
+	}
+	if first == -1 {
+		return 0
+	}
+	return len(segments) - first
+}
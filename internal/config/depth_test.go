@@ -0,0 +1,35 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternDepth(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		want    int
+	}{
+		{pattern: "src/*", want: 1},
+		{pattern: "src/**", want: UnboundedDepth},
+		{pattern: "src/**/*.go", want: UnboundedDepth},
+		{pattern: "**/*.go", want: UnboundedDepth},
+		{pattern: "src/*.go", want: 1},
+		{pattern: "cmd/*/main.go", want: 2},
+		{pattern: "*.config.*", want: 1},
+		{pattern: "./src/*", want: 1},
+		{pattern: "!vendor/*", want: 1},
+		{pattern: "go.mod", want: 0},
+		{pattern: "docs/guide.md", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, PatternDepth(tt.pattern))
+		})
+	}
+}
@@ -12,11 +12,22 @@ import (
 // when looking for harvx.toml, to prevent runaway traversal.
 const maxSearchDepth = 20
 
+// RootMarkerFile is the name of an explicit stop marker for config discovery.
+// Placing it in a directory makes that directory a boundary just like a .git
+// directory, which is useful in worktrees, submodules, and non-git checkouts.
+const RootMarkerFile = ".harvx-root"
+
 // DiscoverRepoConfig walks up the directory tree from startDir, looking for a
 // harvx.toml file. It returns the absolute path of the first harvx.toml found,
 // or an empty string if no file is found. The search stops at the filesystem
-// root, at a .git directory boundary (repo root), or after maxSearchDepth
-// levels, whichever comes first.
+// root, at a boundary directory, or after maxSearchDepth levels, whichever
+// comes first.
+//
+// A boundary is a directory containing a .git entry or a RootMarkerFile.
+// harvx.toml is checked before the boundary test at each level, so a config
+// beside the marker is found. When .git and .harvx-root exist at different
+// levels, the one closest to startDir wins because the walk stops at the
+// first boundary it reaches.
 //
 // Symlinks in the directory chain are resolved before walking to prevent loops.
 func DiscoverRepoConfig(startDir string) (string, error) {
@@ -47,12 +58,13 @@ func DiscoverRepoConfig(startDir string) (string, error) {
 			return configPath, nil
 		}
 
-		// Check for .git boundary: if .git exists here, we are at the repo
-		// root. After checking for harvx.toml at this level (done above),
-		// stop the search regardless.
-		if _, statErr := os.Stat(filepath.Join(dir, ".git")); statErr == nil {
-			slog.Debug("reached .git boundary, stopping search",
+		// Check for a boundary: if .git or .harvx-root exists here, we are at
+		// the repo root. After checking for harvx.toml at this level (done
+		// above), stop the search regardless.
+		if marker := boundaryMarker(dir); marker != "" {
+			slog.Debug("reached boundary, stopping search",
 				"dir", dir,
+				"marker", marker,
 				"depth", depth,
 			)
 			return "", nil
@@ -74,11 +86,68 @@ func DiscoverRepoConfig(startDir string) (string, error) {
 	return "", nil
 }
 
+// DiscoverRepoConfigChain is like DiscoverRepoConfig but keeps walking after
+// the first match: it returns the absolute paths of every harvx.toml from
+// startDir up to and including the boundary directory, nearest first. The
+// walk stops under the same conditions as DiscoverRepoConfig. An empty slice
+// means no config was found.
+//
+// The result is meant for layering with ResolveOptions.RepoConfigChain, where
+// a config closer to startDir overrides the ones above it, so that a monorepo
+// can keep shared settings in its root harvx.toml and per-package overrides
+// beside each package.
+func DiscoverRepoConfigChain(startDir string) ([]string, error) {
+	abs, err := filepath.Abs(startDir)
+	if err != nil {
+		return nil, fmt.Errorf("abs path for %s: %w", startDir, err)
+	}
+	if resolved, evalErr := filepath.EvalSymlinks(abs); evalErr == nil {
+		abs = resolved
+	}
+
+	var chain []string
+	dir := abs
+	for depth := 0; depth < maxSearchDepth; depth++ {
+		configPath := filepath.Join(dir, "harvx.toml")
+		if _, statErr := os.Stat(configPath); statErr == nil {
+			slog.Debug("discovered repo config layer",
+				"path", configPath,
+				"depth", depth,
+			)
+			chain = append(chain, configPath)
+		}
+
+		if boundaryMarker(dir) != "" {
+			return chain, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return chain, nil
+		}
+		dir = parent
+	}
+	return chain, nil
+}
+
+// boundaryMarker returns the name of the boundary marker present in dir
+// (".git" or RootMarkerFile), or an empty string when dir is not a boundary.
+func boundaryMarker(dir string) string {
+	for _, marker := range []string{".git", RootMarkerFile} {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return marker
+		}
+	}
+	return ""
+}
+
 // DiscoverGlobalConfig returns the path to the global harvx configuration file,
 // following XDG Base Directory conventions. It returns an empty string if the
 // file does not exist. No error is returned for a missing file.
 //
 // Priority:
+//   - $HARVX_CONFIG_HOME/harvx/config.toml (if HARVX_CONFIG_HOME is set, on
+//     every platform)
 //   - $XDG_CONFIG_HOME/harvx/config.toml (if XDG_CONFIG_HOME is set)
 //   - ~/.config/harvx/config.toml (Linux/macOS)
 //   - %APPDATA%\harvx\config.toml (Windows)
@@ -103,8 +172,13 @@ func DiscoverGlobalConfig() (string, error) {
 }
 
 // globalConfigDir returns the base configuration directory for the current OS,
-// respecting XDG_CONFIG_HOME on non-Windows platforms.
+// respecting HARVX_CONFIG_HOME everywhere and XDG_CONFIG_HOME on non-Windows
+// platforms.
 func globalConfigDir() (string, error) {
+	if home := os.Getenv(EnvConfigHome); home != "" {
+		return home, nil
+	}
+
 	if runtime.GOOS == "windows" {
 		appData := os.Getenv("APPDATA")
 		if appData == "" {
@@ -129,6 +129,94 @@ func TestDiscoverRepoConfig_FoundAtGitBoundary(t *testing.T) {
 	assertSamePath(t, configPath, got)
 }
 
+// TestDiscoverRepoConfig_StopsAtRootMarker verifies that a .harvx-root marker
+// file acts as a boundary exactly like a .git directory.
+func TestDiscoverRepoConfig_StopsAtRootMarker(t *testing.T) {
+	t.Parallel()
+
+	// Layout:
+	//   grandparent/
+	//     harvx.toml       <-- should NOT be found
+	//     child/
+	//       .harvx-root    <-- boundary
+	//       grandchild/    <-- start dir
+
+	grandparent := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(grandparent, "harvx.toml"), []byte("[profile.default]\n"), 0o644))
+
+	child := filepath.Join(grandparent, "child")
+	require.NoError(t, os.Mkdir(child, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(child, RootMarkerFile), nil, 0o644))
+
+	grandchild := filepath.Join(child, "grandchild")
+	require.NoError(t, os.Mkdir(grandchild, 0o755))
+
+	got, err := DiscoverRepoConfig(grandchild)
+	require.NoError(t, err)
+	assert.Empty(t, got, "search must stop at .harvx-root and not reach grandparent config")
+}
+
+// TestDiscoverRepoConfig_FoundAtRootMarker verifies that a harvx.toml beside
+// the .harvx-root marker is returned.
+func TestDiscoverRepoConfig_FoundAtRootMarker(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, RootMarkerFile), nil, 0o644))
+	configPath := filepath.Join(root, "harvx.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte("[profile.default]\n"), 0o644))
+
+	sub := filepath.Join(root, "sub")
+	require.NoError(t, os.Mkdir(sub, 0o755))
+
+	got, err := DiscoverRepoConfig(sub)
+	require.NoError(t, err)
+	assertSamePath(t, configPath, got)
+}
+
+// TestDiscoverRepoConfig_ClosestBoundaryWins verifies that when .git and
+// .harvx-root exist at different levels, the boundary closest to the start
+// directory stops the search.
+func TestDiscoverRepoConfig_ClosestBoundaryWins(t *testing.T) {
+	t.Parallel()
+
+	t.Run("marker below .git", func(t *testing.T) {
+		t.Parallel()
+
+		// repo/.git, repo/harvx.toml, repo/module/.harvx-root, start in module/pkg.
+		repo := t.TempDir()
+		require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(repo, "harvx.toml"), []byte("[profile.default]\n"), 0o644))
+		module := filepath.Join(repo, "module")
+		require.NoError(t, os.Mkdir(module, 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(module, RootMarkerFile), nil, 0o644))
+		start := filepath.Join(module, "pkg")
+		require.NoError(t, os.Mkdir(start, 0o755))
+
+		got, err := DiscoverRepoConfig(start)
+		require.NoError(t, err)
+		assert.Empty(t, got, ".harvx-root is closer and must stop the search")
+	})
+
+	t.Run(".git below marker", func(t *testing.T) {
+		t.Parallel()
+
+		// outer/.harvx-root, outer/harvx.toml, outer/sub/.git, start in sub/pkg.
+		outer := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(outer, RootMarkerFile), nil, 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(outer, "harvx.toml"), []byte("[profile.default]\n"), 0o644))
+		sub := filepath.Join(outer, "sub")
+		require.NoError(t, os.Mkdir(sub, 0o755))
+		require.NoError(t, os.Mkdir(filepath.Join(sub, ".git"), 0o755))
+		start := filepath.Join(sub, "pkg")
+		require.NoError(t, os.Mkdir(start, 0o755))
+
+		got, err := DiscoverRepoConfig(start)
+		require.NoError(t, err)
+		assert.Empty(t, got, ".git is closer and must stop the search")
+	})
+}
+
 // TestDiscoverRepoConfig_ClosestWins verifies that when multiple harvx.toml
 // files exist in the tree, the one closest to startDir is returned.
 func TestDiscoverRepoConfig_ClosestWins(t *testing.T) {
@@ -254,6 +342,40 @@ func TestDiscoverGlobalConfig_XDGConfigHome_NoFile(t *testing.T) {
 	assert.Empty(t, got)
 }
 
+// TestDiscoverGlobalConfig_HarvxConfigHome verifies that HARVX_CONFIG_HOME is
+// joined with harvx/config.toml and takes precedence over XDG_CONFIG_HOME.
+func TestDiscoverGlobalConfig_HarvxConfigHome(t *testing.T) {
+	xdgBase := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(xdgBase, "harvx"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(xdgBase, "harvx", "config.toml"), []byte("[profile.default]\n"), 0o644))
+	t.Setenv("XDG_CONFIG_HOME", xdgBase)
+
+	home := t.TempDir()
+	t.Setenv(EnvConfigHome, home)
+	configPath := filepath.Join(home, "harvx", "config.toml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0o755))
+	require.NoError(t, os.WriteFile(configPath, []byte("[profile.default]\n"), 0o644))
+
+	got, err := DiscoverGlobalConfig()
+	require.NoError(t, err)
+	assert.Equal(t, configPath, got, "HARVX_CONFIG_HOME must take precedence over XDG_CONFIG_HOME")
+}
+
+// TestDiscoverGlobalConfig_HarvxConfigHome_NoFile verifies that an empty
+// string is returned when HARVX_CONFIG_HOME holds no harvx/config.toml, even
+// if XDG_CONFIG_HOME does.
+func TestDiscoverGlobalConfig_HarvxConfigHome_NoFile(t *testing.T) {
+	xdgBase := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(xdgBase, "harvx"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(xdgBase, "harvx", "config.toml"), []byte("[profile.default]\n"), 0o644))
+	t.Setenv("XDG_CONFIG_HOME", xdgBase)
+	t.Setenv(EnvConfigHome, t.TempDir())
+
+	got, err := DiscoverGlobalConfig()
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 // ── Resolver integration ──────────────────────────────────────────────────────
 
 // TestResolve_AutoDiscoversRepoConfig verifies that when TargetDir points to a
@@ -840,3 +962,89 @@ func TestDiscoverRepoConfig_TableDriven(t *testing.T) {
 		})
 	}
 }
+
+// ── DiscoverRepoConfigChain ───────────────────────────────────────────────────
+
+// TestDiscoverRepoConfigChain_NearestFirst verifies that every harvx.toml up to
+// and including the .git boundary is returned, nearest first, and that files
+// above the boundary are not.
+func TestDiscoverRepoConfigChain_NearestFirst(t *testing.T) {
+	t.Parallel()
+
+	// Layout:
+	//   outer/harvx.toml          <-- above the boundary, excluded
+	//   outer/repo/.git
+	//   outer/repo/harvx.toml     <-- farthest layer
+	//   outer/repo/pkg/           <-- no config
+	//   outer/repo/pkg/api/harvx.toml  <-- nearest layer
+	outer := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(outer, "harvx.toml"), []byte(""), 0o644))
+	repo := filepath.Join(outer, "repo")
+	api := filepath.Join(repo, "pkg", "api")
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0o755))
+	require.NoError(t, os.MkdirAll(api, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "harvx.toml"), []byte(""), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(api, "harvx.toml"), []byte(""), 0o644))
+
+	got, err := DiscoverRepoConfigChain(api)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assertSamePath(t, filepath.Join(api, "harvx.toml"), got[0])
+	assertSamePath(t, filepath.Join(repo, "harvx.toml"), got[1])
+
+	// DiscoverRepoConfig still returns only the nearest file.
+	nearest, err := DiscoverRepoConfig(api)
+	require.NoError(t, err)
+	assertSamePath(t, got[0], nearest)
+}
+
+func TestDiscoverRepoConfigChain_NotFound(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+
+	got, err := DiscoverRepoConfigChain(dir)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+// TestResolve_RepoConfigChain verifies that chained repo configs are layered
+// with the nearer file overriding the farther one, and that the default
+// single-file resolution ignores the farther file.
+func TestResolve_RepoConfigChain(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repo := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(repo, ".git"), 0o755))
+	writeTomlFile(t, repo, "harvx.toml", `
+[profile.default]
+format = "xml"
+max_tokens = 50000
+ignore = ["dist/**"]
+`)
+	pkg := filepath.Join(repo, "packages", "web")
+	require.NoError(t, os.MkdirAll(pkg, 0o755))
+	writeTomlFile(t, pkg, "harvx.toml", `
+[profile.default]
+max_tokens = 20000
+`)
+
+	opts := ResolveOptions{
+		TargetDir:        pkg,
+		GlobalConfigPath: filepath.Join(repo, "nonexistent.toml"),
+		RepoConfigChain:  true,
+	}
+	rc, err := Resolve(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 20000, rc.Profile.MaxTokens, "nearer file overrides")
+	assert.Equal(t, "xml", rc.Profile.Format, "unset fields inherit from the farther file")
+	assert.Equal(t, []string{"dist/**"}, rc.Profile.Ignore)
+	assert.Equal(t, SourceRepo, rc.Sources["format"])
+
+	opts.RepoConfigChain = false
+	rc, err = Resolve(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 20000, rc.Profile.MaxTokens)
+	assert.Equal(t, DefaultProfile().Format, rc.Profile.Format, "single-file mode reads only the nearest file")
+}
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// EncodeConfig renders cfg as a harvx.toml document that decodes back to an
+// equal Config. Each profile becomes a [profile.<name>] table with nested
+// relevance, render, redaction_config, and overrides tables, and only
+// settings that are set are written: empty strings, zero integers, and nil
+// lists are omitted, while an explicitly empty list is kept because it
+// differs from an unset one. A false boolean is written only when the source
+// file set it, since an explicit false overrides an inherited or default
+// true. A percentage max_tokens is written back as "N%".
+//
+// Keys are sorted within each table. Comments and formatting of the original
+// file are not preserved.
+func EncodeConfig(cfg *Config) ([]byte, error) {
+	doc := make(map[string]any)
+	if cfg != nil {
+		if cfg.Root {
+			doc["root"] = true
+		}
+		profiles := make(map[string]any, len(cfg.Profile))
+		for name, p := range cfg.Profile {
+			if p != nil {
+				profiles[name] = encodeProfile(p)
+			}
+		}
+		if len(profiles) > 0 {
+			doc["profile"] = profiles
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("encoding config TOML: %w", err)
+	}
+	return tidyTOML(buf.Bytes()), nil
+}
+
+// tidyTOML separates tables with a single blank line and drops the empty
+// [profile] header, which the encoder writes before the profile tables.
+func tidyTOML(data []byte) []byte {
+	var out bytes.Buffer
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		switch {
+		case line == "" || line == "[profile]":
+			continue
+		case strings.HasPrefix(line, "[") && out.Len() > 0:
+			out.WriteString("\n")
+		}
+		out.WriteString(line + "\n")
+	}
+	return out.Bytes()
+}
+
+// encodeProfile converts p into the table written by EncodeConfig.
+func encodeProfile(p *Profile) map[string]any {
+	m := make(map[string]any)
+	if p.Extends != nil {
+		m["extends"] = *p.Extends
+	}
+	putString(m, "output", p.Output)
+	putString(m, "format", p.Format)
+	if p.MaxTokensPercent != 0 {
+		m["max_tokens"] = fmt.Sprintf("%d%%", p.MaxTokensPercent)
+	} else {
+		putInt(m, "max_tokens", p.MaxTokens)
+	}
+	putInt(m, "brief_max_tokens", p.BriefMaxTokens)
+	putInt(m, "max_file_tokens", p.MaxFileTokens)
+	putString(m, "tokenizer", p.Tokenizer)
+	putBool(m, "compression", p.Compression, p.boolKeys["compression"])
+	putBool(m, "redaction", p.Redaction, p.boolKeys["redaction"])
+	putString(m, "target", p.Target)
+	putString(m, "base_dir", p.BaseDir)
+	putString(m, "sort_order", p.SortOrder)
+	putStrings(m, "ignore", p.Ignore)
+	putStrings(m, "priority_files", p.PriorityFiles)
+	putBool(m, "priority_globs", p.PriorityGlobs, p.boolKeys["priority_globs"])
+	putStrings(m, "include", p.Include)
+	putBool(m, "include_only", p.IncludeOnly, p.boolKeys["include_only"])
+	putStrings(m, "assert_include", p.AssertInclude)
+	putBool(m, "stub_vendored", p.StubVendored, p.boolKeys["stub_vendored"])
+	putStrings(m, "vendor_dirs", p.VendorDirs)
+	putBool(m, "exclude_binary", p.ExcludeBinary, p.boolKeys["exclude_binary"])
+	putBool(m, "case_insensitive", p.CaseInsensitive, p.boolKeys["case_insensitive"])
+	putBool(m, "skip_empty", p.SkipEmpty, p.boolKeys["skip_empty"])
+	putInt(m, "slice_max_tokens", p.SliceMaxTokens)
+	putInt(m, "slice_depth", p.SliceDepth)
+	putString(m, "relevance_file", p.RelevanceFile)
+
+	rel := make(map[string]any)
+	putStrings(rel, "tier_0", p.Relevance.Tier0)
+	putStrings(rel, "tier_1", p.Relevance.Tier1)
+	putStrings(rel, "tier_2", p.Relevance.Tier2)
+	putStrings(rel, "tier_3", p.Relevance.Tier3)
+	putStrings(rel, "tier_4", p.Relevance.Tier4)
+	putStrings(rel, "tier_5", p.Relevance.Tier5)
+	if len(p.Relevance.Labels) > 0 {
+		rel["labels"] = p.Relevance.Labels
+	}
+	if len(rel) > 0 {
+		m["relevance"] = rel
+	}
+
+	if len(p.TierFileCaps) > 0 {
+		caps := make(map[string]any, len(p.TierFileCaps))
+		for tier, n := range p.TierFileCaps {
+			caps[strconv.Itoa(tier)] = n
+		}
+		m["tier_file_caps"] = caps
+	}
+	if len(p.TierWeights) > 0 {
+		weights := make(map[string]any, len(p.TierWeights))
+		for tier, w := range p.TierWeights {
+			weights[strconv.Itoa(tier)] = w
+		}
+		m["tier_weights"] = weights
+	}
+
+	render := make(map[string]any)
+	if p.Render.IncludeTOC != nil {
+		render["include_toc"] = *p.Render.IncludeTOC
+	}
+	if p.Render.LineNumbers != nil {
+		render["line_numbers"] = *p.Render.LineNumbers
+	}
+	if len(render) > 0 {
+		m["render"] = render
+	}
+
+	rc := p.RedactionConfig
+	red := make(map[string]any)
+	putBool(red, "enabled", rc.Enabled, p.boolKeys["redaction_config.enabled"])
+	putStrings(red, "exclude_paths", rc.ExcludePaths)
+	putString(red, "confidence_threshold", rc.ConfidenceThreshold)
+	putString(red, "mode", rc.Mode)
+	putBool(red, "override_sensitive_defaults", rc.OverrideSensitiveDefaults, p.boolKeys["redaction_config.override_sensitive_defaults"])
+	putStrings(red, "sensitive_patterns", rc.SensitivePatterns)
+	if rc.CustomPatterns != nil {
+		patterns := make([]map[string]any, 0, len(rc.CustomPatterns))
+		for _, cp := range rc.CustomPatterns {
+			pm := make(map[string]any)
+			putString(pm, "id", cp.ID)
+			putString(pm, "description", cp.Description)
+			putString(pm, "regex", cp.Regex)
+			putString(pm, "secret_type", cp.SecretType)
+			putString(pm, "confidence", cp.Confidence)
+			putStrings(pm, "keywords", cp.Keywords)
+			patterns = append(patterns, pm)
+		}
+		red["custom_patterns"] = patterns
+	}
+	if len(red) > 0 {
+		m["redaction_config"] = red
+	}
+
+	if p.Overrides != nil {
+		overrides := make([]map[string]any, 0, len(p.Overrides))
+		for _, o := range p.Overrides {
+			om := make(map[string]any)
+			putString(om, "match", o.Match)
+			if o.Tier != nil {
+				om["tier"] = *o.Tier
+			}
+			if o.Redact != nil {
+				om["redact"] = *o.Redact
+			}
+			putBool(om, "priority", o.Priority, false)
+			overrides = append(overrides, om)
+		}
+		m["overrides"] = overrides
+	}
+
+	return m
+}
+
+// putString sets m[key] to v when v is non-empty.
+func putString(m map[string]any, key, v string) {
+	if v != "" {
+		m[key] = v
+	}
+}
+
+// putInt sets m[key] to v when v is non-zero.
+func putInt(m map[string]any, key string, v int) {
+	if v != 0 {
+		m[key] = v
+	}
+}
+
+// putBool sets m[key] to v when v is true or the key was set explicitly.
+func putBool(m map[string]any, key string, v, explicit bool) {
+	if v || explicit {
+		m[key] = v
+	}
+}
+
+// putStrings sets m[key] to v when v is non-nil, so that an explicitly empty
+// list survives the round trip.
+func putStrings(m map[string]any, key string, v []string) {
+	if v != nil {
+		m[key] = v
+	}
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 // Environment variable name constants for HARVX_ prefixed overrides.
@@ -25,6 +28,12 @@ const (
 	EnvCompress = "HARVX_COMPRESS"
 	// EnvRedact overrides the redaction flag.
 	EnvRedact = "HARVX_REDACT"
+	// EnvIgnore adds ignore globs (comma- or newline-separated) on top of the
+	// configured ignore list.
+	EnvIgnore = "HARVX_IGNORE"
+	// EnvConfigHome overrides the base directory DiscoverGlobalConfig looks
+	// in for harvx/config.toml (not a profile field).
+	EnvConfigHome = "HARVX_CONFIG_HOME"
 )
 
 // buildEnvMap reads HARVX_* environment variables and returns a flat map
@@ -38,7 +47,7 @@ func buildEnvMap() map[string]any {
 		m["format"] = v
 	}
 	if v := os.Getenv(EnvMaxTokens); v != "" {
-		if n, err := strconv.Atoi(v); err == nil {
+		if n, err := ParseTokenCount(v); err == nil {
 			m["max_tokens"] = n
 		}
 	}
@@ -61,6 +70,46 @@ func buildEnvMap() map[string]any {
 			m["redaction"] = b
 		}
 	}
+	if v := os.Getenv(EnvIgnore); v != "" {
+		if patterns := parseIgnoreEnv(v); len(patterns) > 0 {
+			m["ignore"] = patterns
+		}
+	}
 
 	return m
 }
+
+// parseIgnoreEnv splits a HARVX_IGNORE value on commas and newlines, trimming
+// whitespace around each entry. Empty entries and invalid glob patterns are
+// skipped, consistent with how buildEnvMap treats unparseable values.
+func parseIgnoreEnv(v string) []string {
+	fields := strings.FieldsFunc(v, func(r rune) bool {
+		return r == ',' || r == '\n' || r == '\r'
+	})
+
+	patterns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		f = strings.TrimSpace(f)
+		if f == "" || !doublestar.ValidatePattern(f) {
+			continue
+		}
+		patterns = append(patterns, f)
+	}
+	return patterns
+}
+
+// mergeIgnorePatterns appends extra to base, dropping duplicates while
+// preserving first-seen order. It is used to layer HARVX_IGNORE on top of the
+// ignore list from lower layers, since koanf replaces slices on merge.
+func mergeIgnorePatterns(base, extra []string) []string {
+	seen := make(map[string]bool, len(base)+len(extra))
+	merged := make([]string, 0, len(base)+len(extra))
+	for _, p := range append(append([]string{}, base...), extra...) {
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		merged = append(merged, p)
+	}
+	return merged
+}
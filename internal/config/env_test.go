@@ -111,6 +111,27 @@ func TestBuildEnvMap_Redact(t *testing.T) {
 	assert.Equal(t, false, m["redaction"])
 }
 
+// TestBuildEnvMap_Ignore verifies HARVX_IGNORE splits on commas and newlines,
+// trims whitespace, and skips empty entries and invalid globs.
+func TestBuildEnvMap_Ignore(t *testing.T) {
+	clearHarvxEnv(t)
+	t.Setenv(EnvIgnore, "*.log, tmp/**\nbuild/[bad\n\n dist/ ")
+
+	m := buildEnvMap()
+	assert.Equal(t, []string{"*.log", "tmp/**", "dist/"}, m["ignore"])
+}
+
+// TestBuildEnvMap_Ignore_AllInvalid verifies that HARVX_IGNORE is omitted from
+// the map when no entry is a valid glob.
+func TestBuildEnvMap_Ignore_AllInvalid(t *testing.T) {
+	clearHarvxEnv(t)
+	t.Setenv(EnvIgnore, "[bad, ,")
+
+	m := buildEnvMap()
+	_, ok := m["ignore"]
+	assert.False(t, ok)
+}
+
 // TestBuildEnvMap_LogFormat_NotInMap verifies that HARVX_LOG_FORMAT does not
 // appear in the profile map (it is not a profile field).
 func TestBuildEnvMap_LogFormat_NotInMap(t *testing.T) {
@@ -163,9 +184,9 @@ func clearHarvxEnv(t *testing.T) {
 	t.Helper()
 	for _, name := range []string{
 		EnvProfile, EnvMaxTokens, EnvFormat, EnvTokenizer,
-		EnvOutput, EnvTarget, EnvLogFormat, EnvCompress, EnvRedact,
+		EnvOutput, EnvTarget, EnvLogFormat, EnvCompress, EnvRedact, EnvIgnore,
 		"HARVX_VERBOSE", "HARVX_QUIET", "HARVX_NO_REDACT",
-		"HARVX_FAIL_ON_REDACTION", "HARVX_STDOUT", "HARVX_DIR",
+		"HARVX_FAIL_ON_REDACTION", "HARVX_STDOUT", "HARVX_DIR", EnvConfigHome,
 	} {
 		t.Setenv(name, "")
 	}
@@ -42,6 +42,8 @@ type LintResult struct {
 	ValidationError
 
 	// Code is a kebab-case identifier for the lint rule that fired.
-	// Examples: "unreachable-tier", "no-ext-match", "complexity".
+	// Examples: "unreachable-tier", "no-ext-match", "tier-pattern-ignored",
+	// "shallow-glob", "bounded-depth", "include-without-tiers",
+	// "redundant-redaction-exclude", "complexity".
 	Code string
 }
@@ -71,6 +71,13 @@ type ExplainResult struct {
 //  4. Include filter
 //  5. Priority files check
 //  6-11. Relevance tiers 0-5
+//
+// When p.IncludeOnly is set, the include filter acts as an exact allowlist and
+// the relevance tier steps are reported as skipped.
+//
+// When p.BaseDir is set, profile patterns are evaluated against filePath with
+// the base_dir prefix stripped (see RebasePath); files outside base_dir match
+// no profile pattern. Default ignore patterns always use filePath as given.
 func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 	result := ExplainResult{
 		FilePath:    filePath,
@@ -78,6 +85,14 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 		Tier:        -1,
 	}
 
+	rebased, inBase := RebasePath(p.BaseDir, filePath)
+	profileMatch := func(pattern string) bool {
+		return inBase && matchesGlob(pattern, rebased)
+	}
+	profileMatchesAny := func(patterns []string) bool {
+		return inBase && matchesAny(rebased, patterns)
+	}
+
 	// Set Extends if the profile inherits from a parent.
 	if p.Extends != nil && *p.Extends != "" {
 		result.Extends = *p.Extends
@@ -124,7 +139,7 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 		}
 		matchedPattern := ""
 		for _, pattern := range p.Ignore {
-			if matchesGlob(pattern, filePath) {
+			if profileMatch(pattern) {
 				matchedPattern = pattern
 				break
 			}
@@ -159,7 +174,7 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 			Rule:    "Include filter",
 		}
 		if len(p.Include) > 0 {
-			if !matchesAny(filePath, p.Include) {
+			if !profileMatchesAny(p.Include) {
 				step.Matched = true
 				step.Outcome = "EXCLUDED"
 				result.Trace = append(result.Trace, step)
@@ -169,6 +184,9 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 			}
 			step.Matched = false
 			step.Outcome = "include match -> continue"
+			if p.IncludeOnly {
+				step.Outcome = "allowlist match (include_only) -> continue"
+			}
 		} else {
 			step.Matched = false
 			step.Outcome = "not active -> continue"
@@ -183,7 +201,7 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 			Rule:    "Priority files",
 		}
 		for _, pf := range p.PriorityFiles {
-			if filePath == pf {
+			if inBase && rebased == pf {
 				result.IsPriority = true
 				result.Tier = 0
 				result.TierPattern = "priority_files"
@@ -218,6 +236,14 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 			Rule:    fmt.Sprintf("Relevance %s", tier.name),
 		}
 
+		if p.IncludeOnly {
+			// include_only disables tiers; files are ordered by path instead.
+			step.Matched = false
+			step.Outcome = "skipped (include_only: tiers inactive)"
+			result.Trace = append(result.Trace, step)
+			continue
+		}
+
 		if result.IsPriority {
 			// Priority files skip tier matching but still add steps.
 			step.Matched = false
@@ -228,7 +254,7 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 
 		matchedPattern := ""
 		for _, pattern := range tier.patterns {
-			if matchesGlob(pattern, filePath) {
+			if profileMatch(pattern) {
 				matchedPattern = pattern
 				break
 			}
@@ -252,7 +278,7 @@ func ExplainFile(filePath, profileName string, p *Profile) ExplainResult {
 	result.Included = true
 
 	// ── Redaction check ─────────────────────────────────────────────────────
-	result.RedactionOn = p.Redaction && !matchesAny(filePath, p.RedactionConfig.ExcludePaths)
+	result.RedactionOn = p.Redaction && !profileMatchesAny(p.RedactionConfig.ExcludePaths)
 
 	// ── Compression check ───────────────────────────────────────────────────
 	result.Compression = compressionLanguage(filePath)
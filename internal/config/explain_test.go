@@ -29,6 +29,33 @@ func TestExplainFile_FileInTier1(t *testing.T) {
 	assert.Equal(t, "myprofile", result.ProfileName)
 }
 
+// TestExplainFile_IncludeOnly verifies that include_only skips every tier
+// step and leaves the file untiered when it matches the allowlist.
+func TestExplainFile_IncludeOnly(t *testing.T) {
+	t.Parallel()
+
+	p := &Profile{
+		Include:     []string{"internal/**/*.go"},
+		IncludeOnly: true,
+		Relevance: RelevanceConfig{
+			Tier1: []string{"internal/**"},
+		},
+	}
+
+	result := ExplainFile("internal/config/types.go", "p", p)
+
+	assert.True(t, result.Included)
+	assert.Equal(t, -1, result.Tier, "tiers are inactive under include_only")
+	for _, step := range result.Trace {
+		if strings.HasPrefix(step.Rule, "Relevance") {
+			assert.Contains(t, step.Outcome, "include_only")
+		}
+	}
+
+	excluded := ExplainFile("cmd/harvx/main.go", "p", p)
+	assert.False(t, excluded.Included, "files outside the allowlist must be excluded")
+}
+
 // TestExplainFile_FileInIgnoreList verifies that a path matching a default
 // ignore pattern is excluded. The default profile includes "node_modules" which
 // matches the literal path segment "node_modules". We also test a profile with
@@ -506,3 +533,35 @@ func TestMatchesGlob(t *testing.T) {
 		})
 	}
 }
+
+// TestExplainFile_BaseDir verifies that profile patterns are evaluated
+// relative to base_dir when the file path is given relative to the repo root,
+// and that files outside base_dir match no profile pattern.
+func TestExplainFile_BaseDir(t *testing.T) {
+	t.Parallel()
+
+	p := &Profile{
+		BaseDir:       "packages/api",
+		Ignore:        []string{"tmp/**"},
+		PriorityFiles: []string{"go.mod"},
+		Relevance: RelevanceConfig{
+			Tier1: []string{"src/**"},
+		},
+	}
+
+	tiered := ExplainFile("packages/api/src/main.go", "default", p)
+	assert.True(t, tiered.Included)
+	assert.Equal(t, 1, tiered.Tier)
+	assert.Equal(t, "src/**", tiered.TierPattern)
+
+	priority := ExplainFile("packages/api/go.mod", "default", p)
+	assert.True(t, priority.IsPriority)
+
+	ignored := ExplainFile("packages/api/tmp/cache.bin", "default", p)
+	assert.False(t, ignored.Included)
+	assert.Contains(t, ignored.ExcludedBy, "tmp/**")
+
+	outside := ExplainFile("packages/web/src/main.go", "default", p)
+	assert.True(t, outside.Included)
+	assert.Equal(t, -1, outside.Tier, "files outside base_dir match no tier pattern")
+}
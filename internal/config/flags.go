@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/spf13/cobra"
 )
 
@@ -26,22 +27,27 @@ type FlagValues struct {
 	Includes        []string // include glob patterns
 	Excludes        []string // exclude glob patterns
 	Format          string
+	FormatVersion   int // XML schema version for --format xml (0 = legacy layout)
 	Target          string
 	GitTrackedOnly  bool
 	SkipLargeFiles  int64 // bytes
 	Stdout          bool
 	LineNumbers     bool
 	NoRedact        bool
+	NoRedaction     bool // force profile redaction off for this run
+	NoCompression   bool // force profile compression off for this run
 	FailOnRedaction bool
 	RedactionReport string // path for --redaction-report; empty means flag not set
 	Verbose         bool
 	Quiet           bool
 	Yes             bool
 	ClearCache      bool
+	CacheDir        string // run cache directory for --cache-dir; empty disables the run cache
 
 	// Token counting flags (T-033)
 	Tokenizer          string // Tokenizer encoding: cl100k_base, o200k_base, none
 	MaxTokens          int    // Token budget (0 = unlimited)
+	MaxFileTokens      int    // Per-file token cap (0 = no cap)
 	TruncationStrategy string // Budget overflow: truncate or skip
 	TokenCountOnly     bool   // Report token counts only, no output file generated
 	TopFiles           int    // Show N largest files by token count (0 = disabled)
@@ -60,6 +66,7 @@ type FlagValues struct {
 
 	// Diff flags (T-064)
 	DiffOnly bool   // Output only changed files since last run
+	Since    string // Git ref for --since: only files changed since the ref are processed
 	Profile  string // Profile name for config and state caching
 
 	// Preview/workflow JSON output flag (T-068)
@@ -68,6 +75,9 @@ type FlagValues struct {
 	// Assert-include patterns (T-069)
 	AssertIncludes []string // --assert-include glob patterns for coverage checks
 
+	// Focus patterns
+	Focus []string // --focus glob patterns promoted to tier 0 for this run
+
 	// Interactive TUI flag (T-079)
 	Interactive bool // Launch interactive TUI instead of headless generation
 }
@@ -85,23 +95,29 @@ func BindFlags(cmd *cobra.Command) *FlagValues {
 	pf.StringArrayVar(&fv.Includes, "include", nil, "include glob pattern (repeatable)")
 	pf.StringArrayVar(&fv.Excludes, "exclude", nil, "exclude glob pattern (repeatable)")
 	pf.StringArrayVar(&fv.AssertIncludes, "assert-include", nil, "assert glob pattern must match at least one file (repeatable)")
-	pf.StringVar(&fv.Format, "format", "markdown", "output format: markdown, xml")
+	pf.StringArrayVar(&fv.Focus, "focus", nil, "rank files matching glob pattern first, in tier 0, for this run (repeatable)")
+	pf.StringVar(&fv.Format, "format", "markdown", "output format: markdown, xml, plain, json")
+	pf.IntVar(&fv.FormatVersion, "format-version", 0, "XML output schema version: 0 (legacy layout), 1 (stable <harvx version=\"1\">)")
 	pf.StringVar(&fv.Target, "target", "generic", "LLM target: claude, chatgpt, generic")
 	pf.BoolVar(&fv.GitTrackedOnly, "git-tracked-only", false, "only include files in git index")
 	pf.StringVar(&skipLargeFilesRaw, "skip-large-files", "1MB", "skip files larger than threshold (e.g. 500KB, 2MB)")
 	pf.BoolVar(&fv.Stdout, "stdout", false, "output to stdout instead of file")
 	pf.BoolVar(&fv.LineNumbers, "line-numbers", false, "add line numbers to code blocks")
 	pf.BoolVar(&fv.NoRedact, "no-redact", false, "disable secret redaction")
+	pf.BoolVar(&fv.NoRedaction, "no-redaction", false, "force redaction off for this run, overriding the profile")
+	pf.BoolVar(&fv.NoCompression, "no-compression", false, "force compression off for this run, overriding the profile")
 	pf.BoolVar(&fv.FailOnRedaction, "fail-on-redaction", false, "exit 1 if secrets are detected")
 	pf.StringVar(&fv.RedactionReport, "redaction-report", "", "write redaction report to path (default: harvx-redaction-report.json when flag is set)")
 	pf.BoolVarP(&fv.Verbose, "verbose", "v", false, "enable debug logging")
 	pf.BoolVarP(&fv.Quiet, "quiet", "q", false, "suppress all output except errors")
 	pf.BoolVar(&fv.Yes, "yes", false, "skip confirmation prompts")
 	pf.BoolVar(&fv.ClearCache, "clear-cache", false, "clear cached state before running")
+	pf.StringVar(&fv.CacheDir, "cache-dir", "", "reuse the previous output when config and files are unchanged, caching runs in this directory")
 
 	// Token reporting flags (T-033)
 	pf.StringVar(&fv.Tokenizer, "tokenizer", "cl100k_base", "Tokenizer encoding: cl100k_base, o200k_base, none")
 	pf.IntVar(&fv.MaxTokens, "max-tokens", 0, "Token budget (0 = unlimited)")
+	pf.IntVar(&fv.MaxFileTokens, "max-file-tokens", 0, "Exclude any single file over this many tokens (0 = no cap)")
 	pf.StringVar(&fv.TruncationStrategy, "truncation-strategy", "skip", "Budget overflow: truncate or skip")
 	pf.BoolVar(&fv.TokenCountOnly, "token-count", false, "Report token counts only, no output file generated")
 	pf.IntVar(&fv.TopFiles, "top-files", 0, "Show N largest files by token count (0 = disabled)")
@@ -119,6 +135,7 @@ func BindFlags(cmd *cobra.Command) *FlagValues {
 
 	// Diff flags (T-064)
 	pf.BoolVar(&fv.DiffOnly, "diff-only", false, "Output only changed files since last run")
+	pf.StringVar(&fv.Since, "since", "", "Process only files changed since this git ref (plus untracked files)")
 	pf.StringVar(&fv.Profile, "profile", "default", "Profile name for config and state caching")
 
 	// Interactive TUI flag (T-079)
@@ -127,6 +144,31 @@ func BindFlags(cmd *cobra.Command) *FlagValues {
 	return fv
 }
 
+// ProfileOverrides returns the flat profile overrides implied by the parsed
+// flags, suitable for ResolveOptions.CLIFlags. Values land in the SourceFlag
+// layer, so they win over the resolved profile and over HARVX_REDACT and
+// HARVX_COMPRESS. --max-file-tokens sets max_file_tokens when positive.
+// Returns nil when no override flag is set.
+func (fv *FlagValues) ProfileOverrides() map[string]any {
+	var m map[string]any
+	set := func(key string, value any) {
+		if m == nil {
+			m = make(map[string]any)
+		}
+		m[key] = value
+	}
+	if fv.NoRedaction {
+		set("redaction", false)
+	}
+	if fv.NoCompression {
+		set("compression", false)
+	}
+	if fv.MaxFileTokens > 0 {
+		set("max_file_tokens", fv.MaxFileTokens)
+	}
+	return m
+}
+
 // skipLargeFilesRaw holds the raw string value for --skip-large-files before
 // parsing. This is a package-level variable because Cobra needs a string target
 // for binding, and we parse it into FlagValues.SkipLargeFiles during validation.
@@ -139,6 +181,15 @@ func ValidateFlags(fv *FlagValues, cmd *cobra.Command) error {
 	// Apply environment variable fallbacks for flags not explicitly set.
 	applyEnvOverrides(fv, cmd)
 
+	// --no-redaction and --no-compression sit above HARVX_REDACT and
+	// HARVX_COMPRESS, so they are applied after the env fallbacks.
+	if fv.NoRedaction {
+		fv.NoRedact = true
+	}
+	if fv.NoCompression {
+		fv.Compress = false
+	}
+
 	// Mutual exclusion: --verbose and --quiet
 	if fv.Verbose && fv.Quiet {
 		return fmt.Errorf("--verbose and --quiet are mutually exclusive")
@@ -166,10 +217,21 @@ func ValidateFlags(fv *FlagValues, cmd *cobra.Command) error {
 
 	// Validate --format
 	switch fv.Format {
-	case "markdown", "xml":
+	case "markdown", "xml", "plain", "json":
+		// valid
+	default:
+		return fmt.Errorf("--format: invalid value %q (allowed: markdown, xml, plain, json)", fv.Format)
+	}
+
+	// Validate --format-version (only XML output is versioned)
+	switch fv.FormatVersion {
+	case 0, 1:
 		// valid
 	default:
-		return fmt.Errorf("--format: invalid value %q (allowed: markdown, xml)", fv.Format)
+		return fmt.Errorf("--format-version: unsupported value %d (allowed: 0, 1)", fv.FormatVersion)
+	}
+	if fv.FormatVersion != 0 && fv.Format != "xml" {
+		return fmt.Errorf("--format-version: requires --format xml, got %q", fv.Format)
 	}
 
 	// Validate --target
@@ -216,6 +278,18 @@ func ValidateFlags(fv *FlagValues, cmd *cobra.Command) error {
 		return fmt.Errorf("--compress-engine: invalid value %q (allowed: ast, regex, auto, wasm)", fv.CompressEngine)
 	}
 
+	// Validate --max-file-tokens
+	if fv.MaxFileTokens < 0 {
+		return fmt.Errorf("--max-file-tokens: must be a non-negative integer, got %d", fv.MaxFileTokens)
+	}
+
+	// Validate --focus
+	for _, pattern := range fv.Focus {
+		if !doublestar.ValidatePattern(pattern) {
+			return fmt.Errorf("--focus: invalid glob pattern %q", pattern)
+		}
+	}
+
 	// Validate --split
 	if fv.Split < 0 {
 		return fmt.Errorf("--split: must be a non-negative integer, got %d", fv.Split)
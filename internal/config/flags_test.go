@@ -43,6 +43,8 @@ func TestFlagDefaults(t *testing.T) {
 	assert.False(t, fv.Quiet)
 	assert.False(t, fv.Yes)
 	assert.False(t, fv.ClearCache)
+	assert.Empty(t, fv.CacheDir)
+	assert.Empty(t, fv.Since)
 }
 
 func TestVerboseQuietMutualExclusion(t *testing.T) {
@@ -105,7 +107,7 @@ func TestFormatInvalid(t *testing.T) {
 }
 
 func TestFormatValidValues(t *testing.T) {
-	tests := []string{"markdown", "xml"}
+	tests := []string{"markdown", "xml", "plain", "json"}
 	for _, format := range tests {
 		t.Run(format, func(t *testing.T) {
 			cmd, fv := newTestCommand()
@@ -119,6 +121,50 @@ func TestFormatValidValues(t *testing.T) {
 	}
 }
 
+func TestFormatVersionDefault(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.Equal(t, 0, fv.FormatVersion)
+}
+
+func TestFormatVersionXML(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--format", "xml", "--format-version", "1"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.Equal(t, 1, fv.FormatVersion)
+}
+
+func TestFormatVersionRejected(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "unknown version", args: []string{"--format", "xml", "--format-version", "2"}, want: "unsupported value 2"},
+		{name: "markdown format", args: []string{"--format", "markdown", "--format-version", "1"}, want: "requires --format xml"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd, fv := newTestCommand()
+			cmd.SetArgs(tt.args)
+			require.NoError(t, cmd.Execute())
+
+			skipLargeFilesRaw = "1MB"
+			err := ValidateFlags(fv, cmd)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "--format-version")
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
 func TestTargetInvalid(t *testing.T) {
 	cmd, fv := newTestCommand()
 	cmd.SetArgs([]string{"--target", "xyz"})
@@ -581,6 +627,86 @@ func TestEnvNoRedactNotOverriddenByExplicitFlag(t *testing.T) {
 	assert.True(t, fv.NoRedact)
 }
 
+func TestNoRedactionNoCompressionFlags(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--compress", "--no-redaction", "--no-compression"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.True(t, fv.NoRedact, "--no-redaction must imply --no-redact")
+	assert.False(t, fv.Compress, "--no-compression must win over --compress")
+	assert.Equal(t, map[string]any{"redaction": false, "compression": false}, fv.ProfileOverrides())
+}
+
+func TestNoRedactionNoCompressionFlagsBeatEnv(t *testing.T) {
+	t.Setenv("HARVX_REDACT", "true")
+	t.Setenv("HARVX_COMPRESS", "true")
+
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--no-redaction", "--no-compression"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.True(t, fv.NoRedact)
+	assert.False(t, fv.Compress)
+}
+
+func TestProfileOverridesNilWithoutFlags(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.Nil(t, fv.ProfileOverrides())
+}
+
+func TestMaxFileTokensFlag(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--max-file-tokens", "4000"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.Equal(t, 4000, fv.MaxFileTokens)
+	assert.Equal(t, map[string]any{"max_file_tokens": 4000}, fv.ProfileOverrides())
+}
+
+func TestFocusFlag(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--focus", "**/auth/**", "--focus", "cmd/*.go"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	require.NoError(t, ValidateFlags(fv, cmd))
+	assert.Equal(t, []string{"**/auth/**", "cmd/*.go"}, fv.Focus)
+	assert.Nil(t, fv.ProfileOverrides(), "--focus does not change the profile")
+}
+
+func TestFocusFlagInvalidPattern(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--focus", "src/[a-"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	err := ValidateFlags(fv, cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--focus")
+}
+
+func TestMaxFileTokensFlagNegative(t *testing.T) {
+	cmd, fv := newTestCommand()
+	cmd.SetArgs([]string{"--max-file-tokens", "-5"})
+	require.NoError(t, cmd.Execute())
+
+	skipLargeFilesRaw = "1MB"
+	err := ValidateFlags(fv, cmd)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--max-file-tokens")
+}
+
 func TestEnvFailOnRedactionOverride(t *testing.T) {
 	t.Setenv("HARVX_FAIL_ON_REDACTION", "1")
 
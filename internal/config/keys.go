@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// suggestMaxDistance is the largest edit distance at which a candidate is
+// still offered as a "did you mean" suggestion for an unknown key or an
+// invalid enum value.
+const suggestMaxDistance = 3
+
+// UnknownKeyWarnings converts the dotted key paths returned by LoadWithReport
+// into warning-severity ValidationErrors. When a known field at the same
+// level is a close match, Suggest carries a "did you mean" hint, e.g.
+// "did you mean max_tokens?" for profile.default.max_token.
+func UnknownKeyWarnings(keys []string) []ValidationError {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	errs := make([]ValidationError, 0, len(keys))
+	for _, key := range keys {
+		ve := ValidationError{
+			Severity: "warning",
+			Field:    key,
+			Message:  "unknown key will be ignored",
+		}
+		if s := SuggestKey(key); s != "" {
+			ve.Suggest = fmt.Sprintf("did you mean %s?", s)
+		}
+		errs = append(errs, ve)
+	}
+	return errs
+}
+
+// SuggestKey returns the known field name closest to the last segment of the
+// dotted key path, or "" when the key's parent is not a known table or no
+// field is close enough. Only fields at the same nesting level are
+// considered, so "profile.default.relevance.tier0" suggests "tier_0" rather
+// than a profile-level field.
+func SuggestKey(key string) string {
+	segments := strings.Split(key, ".")
+	if len(segments) == 0 {
+		return ""
+	}
+
+	fields := knownKeys(reflect.TypeOf(Config{}), segments[:len(segments)-1])
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return closestMatch(segments[len(segments)-1], fields)
+}
+
+// ConfigKeys returns every key a profile table can set, as dotted paths
+// relative to [profile.<name>] (e.g. "format", "relevance.tier_0",
+// "redaction_config.confidence_threshold"), sorted lexicographically. The
+// list is derived from the toml tags on Profile and the tables it nests, so
+// it cannot drift from what the decoder accepts. Arrays of tables contribute
+// their fields without an index, as in "overrides.match", matching the key
+// paths the decoder reports.
+func ConfigKeys() []string {
+	keys := collectKeys(reflect.TypeOf(Profile{}), "")
+	sort.Strings(keys)
+	return keys
+}
+
+// collectKeys returns the leaf key paths of the table type t, each prefixed
+// with prefix. Fields whose type is a table (a struct, a pointer to one, or
+// an array of them) are descended into; every other field is a leaf,
+// including maps such as relevance.labels whose keys are user-defined.
+func collectKeys(t reflect.Type, prefix string) []string {
+	var keys []string
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name := tomlName(f)
+		if name == "" {
+			continue
+		}
+		key := prefix + name
+		if ft := derefTable(f.Type); ft.Kind() == reflect.Struct {
+			keys = append(keys, collectKeys(ft, key+".")...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// closestMatch returns the candidate with the smallest edit distance to
+// input, or "" when none is within suggestMaxDistance. A candidate that
+// would replace every character of a short input is never returned. Ties go
+// to the earliest candidate.
+func closestMatch(input string, candidates []string) string {
+	best, bestDist := "", suggestMaxDistance+1
+	for _, c := range candidates {
+		if d := levenshtein(input, c); d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+
+	if bestDist >= len(input) {
+		return ""
+	}
+	return best
+}
+
+// knownKeys walks t along the parent key path and returns the TOML field
+// names of the table it lands on. Map levels (such as profile names) consume
+// one segment; arrays of tables do not consume a segment because the decoder
+// omits indices from key paths.
+func knownKeys(t reflect.Type, parents []string) []string {
+	for _, seg := range parents {
+		t = derefTable(t)
+		switch t.Kind() {
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Struct:
+			f, ok := fieldByTOMLName(t, seg)
+			if !ok {
+				return nil
+			}
+			t = f.Type
+		default:
+			return nil
+		}
+	}
+
+	t = derefTable(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	names := make([]string, 0, t.NumField())
+	for i := range t.NumField() {
+		if name := tomlName(t.Field(i)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// derefTable strips pointer and slice wrappers from t so that pointers to
+// tables and arrays of tables resolve to their element type.
+func derefTable(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
+
+// fieldByTOMLName returns the struct field whose toml tag is name.
+func fieldByTOMLName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for i := range t.NumField() {
+		if f := t.Field(i); tomlName(f) == name {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// tomlName returns the key name from a field's toml tag, or "" for untagged
+// or skipped fields.
+func tomlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("toml"), ",")
+	if name == "-" {
+		return ""
+	}
+	return name
+}
+
+// levenshtein returns the edit distance between a and b, counting single-byte
+// insertions, deletions, and substitutions.
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
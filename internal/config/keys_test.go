@@ -0,0 +1,125 @@
+package config
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{key: "profile.default.max_token", want: "max_tokens"},
+		{key: "profile.work.fromat", want: "format"},
+		{key: "profile.default.relevance.tier0", want: "tier_0"},
+		{key: "profile.default.redaction_config.exclude_path", want: "exclude_paths"},
+		{key: "profile.default.redaction_config.custom_patterns.regx", want: "regex"},
+		{key: "profiles", want: "profile"},
+		{key: "profile.default.future_feature", want: ""},
+		{key: "profile.default.nosuchtable.max_token", want: ""},
+		{key: "profile.default.x", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, SuggestKey(tt.key))
+		})
+	}
+}
+
+func TestUnknownKeyWarnings(t *testing.T) {
+	t.Parallel()
+
+	errs := UnknownKeyWarnings([]string{
+		"profile.default.max_token",
+		"profile.default.future_feature",
+	})
+	require.Len(t, errs, 2)
+
+	assert.Equal(t, "warning", errs[0].Severity)
+	assert.Equal(t, "profile.default.max_token", errs[0].Field)
+	assert.Equal(t, "did you mean max_tokens?", errs[0].Suggest)
+	assert.Equal(t,
+		"[warning] profile.default.max_token: unknown key will be ignored (suggestion: did you mean max_tokens?)",
+		errs[0].Error())
+
+	assert.Equal(t, "profile.default.future_feature", errs[1].Field)
+	assert.Empty(t, errs[1].Suggest)
+}
+
+func TestConfigKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := ConfigKeys()
+	for _, want := range []string{
+		"format",
+		"extends",
+		"tier_file_caps",
+		"relevance.tier_0",
+		"relevance.labels",
+		"redaction_config.confidence_threshold",
+		"redaction_config.custom_patterns.regex",
+		"render.include_toc",
+		"overrides.match",
+	} {
+		assert.Contains(t, keys, want)
+	}
+
+	assert.NotContains(t, keys, "relevance", "tables are not leaf keys")
+	assert.True(t, sort.StringsAreSorted(keys))
+}
+
+func TestCollectKeys_FollowsStruct(t *testing.T) {
+	t.Parallel()
+
+	type nested struct {
+		Mode string `toml:"mode"`
+	}
+	type profile struct {
+		Format   string `toml:"format"`
+		Skipped  int    `toml:"-"`
+		Untagged bool
+		Nested   nested   `toml:"nested"`
+		Items    []nested `toml:"items"`
+		NewKey   *bool    `toml:"new_key"`
+	}
+
+	assert.Equal(t,
+		[]string{"format", "nested.mode", "items.mode", "new_key"},
+		collectKeys(reflect.TypeOf(profile{}), ""))
+}
+
+func TestUnknownKeyWarnings_Empty(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, UnknownKeyWarnings(nil))
+}
+
+func TestLevenshtein(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 0, levenshtein("format", "format"))
+	assert.Equal(t, 1, levenshtein("max_token", "max_tokens"))
+	assert.Equal(t, 2, levenshtein("fromat", "format"))
+	assert.Equal(t, 3, levenshtein("kitten", "sitting"))
+	assert.Equal(t, 5, levenshtein("", "tier0"))
+}
+
+func TestClosestMatch(t *testing.T) {
+	t.Parallel()
+
+	candidates := []string{"chatgpt", "claude", "generic"}
+	assert.Equal(t, "claude", closestMatch("cluade", candidates))
+	assert.Equal(t, "generic", closestMatch("generik", candidates))
+	assert.Empty(t, closestMatch("gemini-pro-max", candidates))
+	assert.Empty(t, closestMatch("x", candidates))
+	assert.Empty(t, closestMatch("claude", nil))
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -12,47 +14,105 @@ import (
 // a fully decoded *Config on success. Unknown TOML keys produce slog warnings
 // (not errors) to maintain forward compatibility with future schema additions.
 // Invalid TOML syntax causes an error that includes the file path and line
-// information from the TOML decoder.
+// information from the TOML decoder. Profiles that set relevance_file have
+// the referenced tiers loaded and merged beneath their inline tiers; a missing
+// or malformed relevance file is an error.
 func LoadFromFile(path string) (*Config, error) {
+	cfg, unknown, err := LoadWithReport(path)
+	if err != nil {
+		return nil, err
+	}
+
+	warnUndecodedKeys(unknown, path)
+
+	return cfg, nil
+}
+
+// LoadWithReport behaves like LoadFromFile but returns the unknown keys to
+// the caller instead of logging them. Each entry is the dotted key path the
+// decoder did not recognize (e.g. "profile.default.max_token"), in document
+// order. Pass the keys to UnknownKeyWarnings to turn them into validation
+// warnings with "did you mean" suggestions.
+func LoadWithReport(path string) (*Config, []string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse config %s: %w", path, err)
+	}
+
 	var cfg Config
-	meta, err := toml.DecodeFile(path, &cfg)
+	meta, err := decodeConfig(string(data), &cfg)
 	if err != nil {
-		return nil, fmt.Errorf("parse config %s: %w", path, err)
+		return nil, nil, fmt.Errorf("parse config %s: %w", path, err)
 	}
 
-	warnUndecodedKeys(meta, path)
+	if err := applyRelevanceFiles(&cfg, filepath.Dir(path)); err != nil {
+		return nil, nil, fmt.Errorf("load config %s: %w", path, err)
+	}
+
+	// Anchor "file#profile" extends references to this file's directory.
+	for _, p := range cfg.Profile {
+		if p != nil {
+			p.configDir = filepath.Dir(path)
+		}
+	}
 
-	return &cfg, nil
+	return &cfg, undecodedKeys(meta), nil
 }
 
 // LoadFromString parses TOML configuration from an in-memory string. It
 // behaves identically to LoadFromFile except the source is a string rather
 // than a file. The name parameter is used in log messages and error output.
 func LoadFromString(data, name string) (*Config, error) {
+	cfg, unknown, err := LoadFromStringWithReport(data, name)
+	if err != nil {
+		return nil, err
+	}
+
+	warnUndecodedKeys(unknown, name)
+
+	return cfg, nil
+}
+
+// LoadFromStringWithReport is the in-memory counterpart of LoadWithReport.
+func LoadFromStringWithReport(data, name string) (*Config, []string, error) {
 	var cfg Config
-	meta, err := toml.Decode(data, &cfg)
+	meta, err := decodeConfig(data, &cfg)
 	if err != nil {
-		return nil, fmt.Errorf("parse config %s: %w", name, err)
+		return nil, nil, fmt.Errorf("parse config %s: %w", name, err)
 	}
 
-	warnUndecodedKeys(meta, name)
+	// There is no file location to anchor relative paths, so relevance_file
+	// entries are resolved against the working directory.
+	if err := applyRelevanceFiles(&cfg, "."); err != nil {
+		return nil, nil, fmt.Errorf("load config %s: %w", name, err)
+	}
 
-	return &cfg, nil
+	return &cfg, undecodedKeys(meta), nil
 }
 
-// warnUndecodedKeys logs a warning for each key in the TOML document that did
-// not map to any field in the Config struct. This allows users to add new
-// fields to their config files without breaking older versions of harvx.
-func warnUndecodedKeys(meta toml.MetaData, source string) {
+// undecodedKeys returns the dotted paths of every key in the TOML document
+// that did not map to any field in the Config struct, or nil if there are
+// none.
+func undecodedKeys(meta toml.MetaData) []string {
 	undecoded := meta.Undecoded()
 	if len(undecoded) == 0 {
-		return
+		return nil
 	}
 
 	keys := make([]string, 0, len(undecoded))
 	for _, k := range undecoded {
 		keys = append(keys, k.String())
 	}
+	return keys
+}
+
+// warnUndecodedKeys logs a warning listing keys that did not map to any field
+// in the Config struct. This allows users to add new fields to their config
+// files without breaking older versions of harvx.
+func warnUndecodedKeys(keys []string, source string) {
+	if len(keys) == 0 {
+		return
+	}
 
 	slog.Warn("unknown config keys will be ignored",
 		"source", source,
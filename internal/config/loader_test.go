@@ -179,6 +179,60 @@ func TestLoadFromFile_UnknownKeys(t *testing.T) {
 	assert.Equal(t, 128000, def.MaxTokens)
 }
 
+// TestLoadWithReport_UnknownKeys verifies that LoadWithReport returns the
+// dotted paths of unknown keys while still decoding known fields.
+func TestLoadWithReport_UnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg, unknown, err := LoadWithReport(testdataPath(t, "unknown_keys.toml"))
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, []string{
+		"profile.default.future_feature",
+		"profile.default.experimental_flag",
+		"profile.default.relevance.undocumented_tier_6",
+	}, unknown)
+	assert.Equal(t, 128000, cfg.Profile["default"].MaxTokens)
+}
+
+// TestLoadWithReport_NoUnknownKeys verifies that a config with only known
+// keys reports nil.
+func TestLoadWithReport_NoUnknownKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg, unknown, err := LoadWithReport(testdataPath(t, "valid.toml"))
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+	assert.Nil(t, unknown)
+}
+
+// TestLoadWithReport_NonExistentFile verifies that a missing file returns an
+// error and no keys.
+func TestLoadWithReport_NonExistentFile(t *testing.T) {
+	t.Parallel()
+
+	cfg, unknown, err := LoadWithReport(filepath.Join(t.TempDir(), "missing.toml"))
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Nil(t, unknown)
+}
+
+// TestLoadFromStringWithReport_Typo verifies that a misspelled key is
+// reported and the intended field keeps its zero value.
+func TestLoadFromStringWithReport_Typo(t *testing.T) {
+	t.Parallel()
+
+	data := `
+[profile.default]
+max_token = 50000
+`
+	cfg, unknown, err := LoadFromStringWithReport(data, "<test-typo>")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"profile.default.max_token"}, unknown)
+	assert.Zero(t, cfg.Profile["default"].MaxTokens)
+}
+
 // TestLoadFromFile_NonExistentFile verifies that a missing file returns an
 // error.
 func TestLoadFromFile_NonExistentFile(t *testing.T) {
@@ -374,6 +428,84 @@ func TestLoadFromString_TargetField(t *testing.T) {
 	}
 }
 
+// TestLoadFromString_MaxTokensPercent verifies that a percentage max_tokens
+// decodes into MaxTokensPercent and leaves MaxTokens unset, while unknown
+// keys are still reported.
+func TestLoadFromString_MaxTokensPercent(t *testing.T) {
+	t.Parallel()
+
+	cfg, unknown, err := LoadFromStringWithReport(`
+[profile.p]
+target = "claude"
+max_tokens = "80%"
+max_token = 1
+`, "<test>")
+	require.NoError(t, err)
+
+	p := cfg.Profile["p"]
+	require.NotNil(t, p)
+	assert.Equal(t, 80, p.MaxTokensPercent)
+	assert.Zero(t, p.MaxTokens)
+	assert.Equal(t, []string{"profile.p.max_token"}, unknown)
+}
+
+// TestLoadFromString_MaxTokensInvalidString verifies that a string max_tokens
+// that is neither a count nor a percentage is a parse error naming the value.
+func TestLoadFromString_MaxTokensInvalidString(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"lots", "12x", "-5k", "1.5M"} {
+		_, err := LoadFromString("[profile.p]\nmax_tokens = \""+value+"\"\n", "<test>")
+		require.Error(t, err, value)
+		assert.Contains(t, err.Error(), "profile.p", value)
+		assert.Contains(t, err.Error(), `"`+value+`"`, value)
+	}
+}
+
+// TestLoadFromString_MaxTokensSuffix verifies that k and M suffixes decode
+// into an absolute MaxTokens and that bare integers still work.
+func TestLoadFromString_MaxTokensSuffix(t *testing.T) {
+	t.Parallel()
+
+	cfg, unknown, err := LoadFromStringWithReport(`
+[profile.k]
+max_tokens = "128k"
+
+[profile.m]
+max_tokens = "2M"
+
+[profile.bare]
+max_tokens = 64000
+`, "<test>")
+	require.NoError(t, err)
+	assert.Empty(t, unknown)
+
+	assert.Equal(t, 128000, cfg.Profile["k"].MaxTokens)
+	assert.Equal(t, 2000000, cfg.Profile["m"].MaxTokens)
+	assert.Equal(t, 64000, cfg.Profile["bare"].MaxTokens)
+	for _, p := range cfg.Profile {
+		assert.Zero(t, p.MaxTokensPercent)
+	}
+}
+
+// TestParseTokenCount verifies suffix handling and rejection of malformed
+// counts.
+func TestParseTokenCount(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string]int{"64000": 64000, "128k": 128000, "128K": 128000, "2M": 2000000, " 32 k ": 32000, "0": 0}
+	for in, want := range valid {
+		got, err := ParseTokenCount(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "k", "12x", "-1", "1.5k", "10kk"} {
+		_, err := ParseTokenCount(in)
+		assert.Error(t, err, in)
+	}
+}
+
 // TestLoadFromFile_RoundTrip loads the valid.toml fixture and writes a temp
 // file to confirm field values survive a decode. This is a simplified round-
 // trip test; full TOML encoding is tested in golden tests (T-025).
@@ -602,6 +734,7 @@ output = "prod-output.md"
 enabled = true
 exclude_paths = ["testdata/**", "docs/**/*.md"]
 confidence_threshold = "medium"
+mode = "partial"
 `
 
 	cfg, err := LoadFromString(data, "<test>")
@@ -614,6 +747,7 @@ confidence_threshold = "medium"
 	assert.True(t, rc.Enabled, "redaction_config.enabled must be true")
 	assert.Equal(t, []string{"testdata/**", "docs/**/*.md"}, rc.ExcludePaths)
 	assert.Equal(t, "medium", rc.ConfidenceThreshold)
+	assert.Equal(t, "partial", rc.Mode)
 }
 
 // TestLoadFromString_RedactionConfig_ZeroValue verifies that when
@@ -0,0 +1,205 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// tokenSuffixes maps the case-insensitive suffixes accepted by
+// ParseTokenCount to their multipliers. Suffixes are decimal: "128k" is
+// 128000, matching how context windows are usually quoted.
+var tokenSuffixes = map[byte]int{'k': 1_000, 'm': 1_000_000}
+
+// ParseTokenCount parses a token count written as a bare integer ("64000")
+// or with a k or M suffix ("128k", "2M"). The suffix is case-insensitive.
+// Negative and non-integer values are errors.
+func ParseTokenCount(s string) (int, error) {
+	num := strings.TrimSpace(s)
+	mult := 1
+	if num != "" {
+		if m, ok := tokenSuffixes[strings.ToLower(num[len(num)-1:])[0]]; ok {
+			num, mult = strings.TrimSpace(num[:len(num)-1]), m
+		}
+	}
+	n, err := strconv.Atoi(num)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid token count %q: expected an integer, optionally with a k or M suffix such as \"128k\"", s)
+	}
+	return n * mult, nil
+}
+
+// parseMaxTokensString parses a string max_tokens value: either a percentage
+// of the target's context window ("80%"), returned as pct, or a token count
+// accepted by ParseTokenCount ("128k"), returned as tokens. A percentage
+// outside 1-100 is an error, so a returned pct of 0 always means an absolute
+// count.
+func parseMaxTokensString(s string) (tokens, pct int, err error) {
+	if num, ok := strings.CutSuffix(strings.TrimSpace(s), "%"); ok {
+		pct, err := strconv.Atoi(strings.TrimSpace(num))
+		if err != nil {
+			return 0, 0, fmt.Errorf("max_tokens %q: invalid percentage", s)
+		}
+		if err := checkMaxTokensPercent(pct); err != nil {
+			return 0, 0, err
+		}
+		return 0, pct, nil
+	}
+	tokens, err = ParseTokenCount(s)
+	if err != nil {
+		return 0, 0, fmt.Errorf("max_tokens %q must be an integer, a count such as \"128k\" or \"2M\", or a percentage such as \"80%%\"", s)
+	}
+	return tokens, 0, nil
+}
+
+// maxTokensFromPercent returns pct percent of the context window of target.
+// The percentage must be between 1 and 100.
+func maxTokensFromPercent(pct int, target string) (int, error) {
+	if err := checkMaxTokensPercent(pct); err != nil {
+		return 0, err
+	}
+	return TargetContextWindow(target) * pct / 100, nil
+}
+
+// checkMaxTokensPercent reports an error unless pct is between 1 and 100.
+func checkMaxTokensPercent(pct int) error {
+	if pct < 1 || pct > 100 {
+		return fmt.Errorf("max_tokens \"%d%%\" is out of range: the percentage must be between 1 and 100", pct)
+	}
+	return nil
+}
+
+// resolveMaxTokensPercent converts a percentage max_tokens on the resolved
+// profile p into an absolute MaxTokens. When overridden is true an env var or
+// CLI flag set an absolute max_tokens, which wins and the percentage is
+// dropped. The max_tokens source becomes the layer that wrote the percentage
+// so debug output attributes the derived value correctly.
+func resolveMaxTokensPercent(p *Profile, sources SourceMap, overridden bool) error {
+	if p.MaxTokensPercent == 0 {
+		return nil
+	}
+	if overridden {
+		p.MaxTokensPercent = 0
+		return nil
+	}
+
+	n, err := maxTokensFromPercent(p.MaxTokensPercent, p.Target)
+	if err != nil {
+		return err
+	}
+	p.MaxTokens = n
+	sources["max_tokens"] = sources["max_tokens_percent"]
+	return nil
+}
+
+// mergeMaxTokensPercent merges MaxTokensPercent like mergeInt, except that an
+// absolute max_tokens in override also clears a percentage inherited from
+// base.
+func mergeMaxTokensPercent(base, override *Profile) int {
+	if override.MaxTokensPercent != 0 {
+		return override.MaxTokensPercent
+	}
+	if override.MaxTokens != 0 {
+		return 0
+	}
+	return base.MaxTokensPercent
+}
+
+// decodeConfig decodes the TOML document data into cfg. Profiles that write
+// max_tokens as a string ("128k" or "80%") cannot be decoded into the integer
+// field directly, so those values are replaced with their token count, or 0
+// for a percentage, and re-encoded before decoding. Percentages are stored in
+// Profile.MaxTokensPercent. Documents without a string max_tokens are decoded
+// as-is. Each profile records the boolean keys the document set explicitly.
+func decodeConfig(data string, cfg *Config) (toml.MetaData, error) {
+	var raw map[string]any
+	if _, err := toml.Decode(data, &raw); err != nil {
+		return toml.MetaData{}, err
+	}
+
+	percents, changed, err := normalizeMaxTokens(raw)
+	if err != nil {
+		return toml.MetaData{}, err
+	}
+	if !changed {
+		meta, err := toml.Decode(data, cfg)
+		if err == nil {
+			recordBoolKeys(cfg, meta)
+		}
+		return meta, err
+	}
+
+	var buf strings.Builder
+	if err := toml.NewEncoder(&buf).Encode(raw); err != nil {
+		return toml.MetaData{}, fmt.Errorf("re-encoding config: %w", err)
+	}
+	meta, err := toml.Decode(buf.String(), cfg)
+	if err != nil {
+		return meta, err
+	}
+	for name, pct := range percents {
+		if p := cfg.Profile[name]; p != nil {
+			p.MaxTokensPercent = pct
+		}
+	}
+	recordBoolKeys(cfg, meta)
+	return meta, nil
+}
+
+// recordBoolKeys stores on each profile in cfg the dotted boolean keys that
+// meta reports under [profile.<name>], relative to the profile table. Keys
+// inside arrays of tables such as overrides are skipped because they do not
+// name a single value.
+func recordBoolKeys(cfg *Config, meta toml.MetaData) {
+	for _, key := range meta.Keys() {
+		if len(key) < 3 || key[0] != "profile" || meta.Type(key...) != "Bool" {
+			continue
+		}
+		if meta.Type(key[:3]...) == "ArrayHash" {
+			continue
+		}
+		p := cfg.Profile[key[1]]
+		if p == nil {
+			continue
+		}
+		if p.boolKeys == nil {
+			p.boolKeys = make(map[string]bool)
+		}
+		p.boolKeys[strings.Join(key[2:], ".")] = true
+	}
+}
+
+// normalizeMaxTokens replaces every string max_tokens under
+// [profile.<name>] in raw with its token count, or 0 for a percentage. It
+// returns the parsed percentages keyed by profile name and whether raw was
+// changed.
+func normalizeMaxTokens(raw map[string]any) (map[string]int, bool, error) {
+	profiles, _ := raw["profile"].(map[string]any)
+	var percents map[string]int
+	changed := false
+	for name, v := range profiles {
+		prof, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		s, ok := prof["max_tokens"].(string)
+		if !ok {
+			continue
+		}
+		tokens, pct, err := parseMaxTokensString(s)
+		if err != nil {
+			return nil, false, fmt.Errorf("profile.%s: %w", name, err)
+		}
+		if pct != 0 {
+			if percents == nil {
+				percents = make(map[string]int)
+			}
+			percents[name] = pct
+		}
+		prof["max_tokens"] = int64(tokens)
+		changed = true
+	}
+	return percents, changed, nil
+}
@@ -1,5 +1,10 @@
 package config
 
+import (
+	"maps"
+	"slices"
+)
+
 // mergeProfile creates a new Profile by applying override on top of base.
 // The merge rules are:
 //   - String scalars: use override if non-empty; otherwise keep base.
@@ -8,8 +13,11 @@ package config
 //   - Slice fields (Ignore, PriorityFiles, Include): use override slice if
 //     it is non-nil and non-empty; otherwise keep base slice.
 //   - RelevanceConfig: each tier is replaced independently (non-nil, non-empty
-//     child tier replaces the parent tier).
+//     child tier replaces the parent tier); labels are merged per tier.
 //   - RedactionConfig: merged field-by-field with the same scalar/slice rules.
+//   - RenderConfig: each option uses override if set; otherwise keep base.
+//   - Overrides: child list replaces the parent list when non-empty.
+//   - TierFileCaps, TierWeights: merged per tier, like relevance labels.
 //
 // Neither base nor override is mutated. A fresh Profile is always returned.
 // The Extends field is always cleared on the returned profile.
@@ -20,26 +28,44 @@ func mergeProfile(base, override *Profile) *Profile {
 		Format:    mergeString(base.Format, override.Format),
 		Tokenizer: mergeString(base.Tokenizer, override.Tokenizer),
 		Target:    mergeString(base.Target, override.Target),
+		BaseDir:   mergeString(base.BaseDir, override.BaseDir),
+		SortOrder: mergeString(base.SortOrder, override.SortOrder),
+
+		RelevanceFile: mergeString(base.RelevanceFile, override.RelevanceFile),
 
 		// Scalar: int
-		MaxTokens:      mergeInt(base.MaxTokens, override.MaxTokens),
-		BriefMaxTokens: mergeInt(base.BriefMaxTokens, override.BriefMaxTokens),
-		SliceMaxTokens: mergeInt(base.SliceMaxTokens, override.SliceMaxTokens),
-		SliceDepth:     mergeInt(base.SliceDepth, override.SliceDepth),
+		MaxTokens:        mergeInt(base.MaxTokens, override.MaxTokens),
+		MaxTokensPercent: mergeMaxTokensPercent(base, override),
+		BriefMaxTokens:   mergeInt(base.BriefMaxTokens, override.BriefMaxTokens),
+		MaxFileTokens:    mergeInt(base.MaxFileTokens, override.MaxFileTokens),
+		SliceMaxTokens:   mergeInt(base.SliceMaxTokens, override.SliceMaxTokens),
+		SliceDepth:       mergeInt(base.SliceDepth, override.SliceDepth),
 
 		// Scalar: bool -- override always wins (false is meaningful)
 		Compression: override.Compression,
 		Redaction:   override.Redaction,
+		IncludeOnly: override.IncludeOnly,
+
+		PriorityGlobs:   override.PriorityGlobs,
+		StubVendored:    override.StubVendored,
+		ExcludeBinary:   override.ExcludeBinary,
+		CaseInsensitive: override.CaseInsensitive,
+		SkipEmpty:       override.SkipEmpty,
 
 		// Slices: child replaces parent entirely when non-nil and non-empty
 		Ignore:        mergeSlice(base.Ignore, override.Ignore),
 		PriorityFiles: mergeSlice(base.PriorityFiles, override.PriorityFiles),
 		Include:       mergeSlice(base.Include, override.Include),
 		AssertInclude: mergeSlice(base.AssertInclude, override.AssertInclude),
+		VendorDirs:    mergeSlice(base.VendorDirs, override.VendorDirs),
 
 		// Nested structs
 		Relevance:       mergeRelevance(base.Relevance, override.Relevance),
 		RedactionConfig: mergeRedactionConfig(base.RedactionConfig, override.RedactionConfig),
+		Render:          mergeRenderConfig(base.Render, override.Render),
+		Overrides:       mergeOverrides(base.Overrides, override.Overrides),
+		TierFileCaps:    mergeTierValues(base.TierFileCaps, override.TierFileCaps),
+		TierWeights:     mergeTierValues(base.TierWeights, override.TierWeights),
 
 		// Extends is always cleared after merge (profile is fully resolved)
 		Extends: nil,
@@ -47,6 +73,15 @@ func mergeProfile(base, override *Profile) *Profile {
 	return result
 }
 
+// mergeOverrides returns a copy of override if it is non-empty, otherwise a
+// copy of base. Override lists are ordered, so they are never concatenated.
+func mergeOverrides(base, override []PathOverride) []PathOverride {
+	if len(override) > 0 {
+		return slices.Clone(override)
+	}
+	return slices.Clone(base)
+}
+
 // mergeString returns override if non-empty, otherwise base.
 func mergeString(base, override string) string {
 	if override != "" {
@@ -81,7 +116,9 @@ func mergeSlice(base, override []string) []string {
 }
 
 // mergeRelevance merges two RelevanceConfig values. Each tier is independent:
-// if the override tier is non-empty it fully replaces the base tier.
+// if the override tier is non-empty it fully replaces the base tier. Labels
+// merge per tier, so an override that labels one tier keeps the base labels
+// of the others.
 func mergeRelevance(base, override RelevanceConfig) RelevanceConfig {
 	return RelevanceConfig{
 		Tier0: mergeSlice(base.Tier0, override.Tier0),
@@ -90,17 +127,79 @@ func mergeRelevance(base, override RelevanceConfig) RelevanceConfig {
 		Tier3: mergeSlice(base.Tier3, override.Tier3),
 		Tier4: mergeSlice(base.Tier4, override.Tier4),
 		Tier5: mergeSlice(base.Tier5, override.Tier5),
+
+		Labels: mergeLabels(base.Labels, override.Labels),
+	}
+}
+
+// mergeLabels returns base with the entries of override layered on top. It
+// returns nil when neither map has entries.
+func mergeLabels(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(map[string]string, len(override))
 	}
+	maps.Copy(merged, override)
+	return merged
+}
+
+// mergeTierValues returns base with the entries of override layered on top.
+// It returns nil when neither map has entries.
+func mergeTierValues[M ~map[int]int](base, override M) M {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := maps.Clone(base)
+	if merged == nil {
+		merged = make(M, len(override))
+	}
+	maps.Copy(merged, override)
+	return merged
 }
 
 // mergeRedactionConfig merges two RedactionConfig values field-by-field.
-// Enabled always uses override (false is a valid explicit value).
-// ConfidenceThreshold uses override if non-empty.
-// ExcludePaths uses override slice if non-nil and non-empty.
+// Enabled and OverrideSensitiveDefaults always use override (false is a valid
+// explicit value). ConfidenceThreshold and Mode use override if non-empty.
+// ExcludePaths, SensitivePatterns, and CustomPatterns use the override slice
+// if non-nil and non-empty.
 func mergeRedactionConfig(base, override RedactionConfig) RedactionConfig {
+	customPatterns := base.CustomPatterns
+	if len(override.CustomPatterns) > 0 {
+		customPatterns = override.CustomPatterns
+	}
 	return RedactionConfig{
-		Enabled:             override.Enabled,
-		ExcludePaths:        mergeSlice(base.ExcludePaths, override.ExcludePaths),
-		ConfidenceThreshold: mergeString(base.ConfidenceThreshold, override.ConfidenceThreshold),
+		Enabled:                   override.Enabled,
+		ExcludePaths:              mergeSlice(base.ExcludePaths, override.ExcludePaths),
+		ConfidenceThreshold:       mergeString(base.ConfidenceThreshold, override.ConfidenceThreshold),
+		Mode:                      mergeString(base.Mode, override.Mode),
+		OverrideSensitiveDefaults: override.OverrideSensitiveDefaults,
+		SensitivePatterns:         mergeSlice(base.SensitivePatterns, override.SensitivePatterns),
+		CustomPatterns:            slices.Clone(customPatterns),
+	}
+}
+
+// mergeRenderConfig merges two RenderConfig values option by option: a set
+// option in override wins, and an unset one keeps base's value.
+func mergeRenderConfig(base, override RenderConfig) RenderConfig {
+	return RenderConfig{
+		IncludeTOC:  mergeBoolPtr(base.IncludeTOC, override.IncludeTOC),
+		LineNumbers: mergeBoolPtr(base.LineNumbers, override.LineNumbers),
+	}
+}
+
+// mergeBoolPtr returns a copy of override if it is set, otherwise a copy of
+// base, so the merged profile shares no pointers with its inputs.
+func mergeBoolPtr(base, override *bool) *bool {
+	v := base
+	if override != nil {
+		v = override
+	}
+	if v == nil {
+		return nil
 	}
+	b := *v
+	return &b
 }
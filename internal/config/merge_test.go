@@ -129,6 +129,22 @@ func TestMergeRelevance_OverrideTierReplacesBase(t *testing.T) {
 		"unset override tier must inherit base")
 }
 
+func TestMergeRelevance_LabelsMergePerTier(t *testing.T) {
+	t.Parallel()
+	base := RelevanceConfig{Labels: map[string]string{"tier_0": "Schemas", "tier_1": "Services"}}
+	override := RelevanceConfig{Labels: map[string]string{"tier_1": "Handlers", "tier_3": "Fixtures"}}
+
+	result := mergeRelevance(base, override)
+
+	assert.Equal(t, map[string]string{
+		"tier_0": "Schemas",
+		"tier_1": "Handlers",
+		"tier_3": "Fixtures",
+	}, result.Labels)
+	assert.Equal(t, "Services", base.Labels["tier_1"], "base labels must not be modified")
+	assert.Nil(t, mergeRelevance(RelevanceConfig{}, RelevanceConfig{}).Labels)
+}
+
 func TestMergeRelevance_AllTiersOverridden(t *testing.T) {
 	t.Parallel()
 	base := RelevanceConfig{
@@ -262,6 +278,16 @@ func TestMergeRedactionConfig_ConfidenceThreshold_OverrideWins(t *testing.T) {
 	assert.Equal(t, "low", result.ConfidenceThreshold)
 }
 
+func TestMergeRedactionConfig_ConfidenceThreshold_OffOverrideWins(t *testing.T) {
+	t.Parallel()
+	base := RedactionConfig{ConfidenceThreshold: "high"}
+	override := RedactionConfig{ConfidenceThreshold: "off"}
+
+	result := mergeRedactionConfig(base, override)
+
+	assert.Equal(t, "off", result.ConfidenceThreshold)
+}
+
 func TestMergeRedactionConfig_ConfidenceThreshold_EmptyOverride_KeepsBase(t *testing.T) {
 	t.Parallel()
 	base := RedactionConfig{ConfidenceThreshold: "high"}
@@ -272,6 +298,38 @@ func TestMergeRedactionConfig_ConfidenceThreshold_EmptyOverride_KeepsBase(t *tes
 	assert.Equal(t, "high", result.ConfidenceThreshold)
 }
 
+func TestMergeRedactionConfig_SensitiveAndCustomPatterns_InheritedFromBase(t *testing.T) {
+	t.Parallel()
+	base := RedactionConfig{
+		SensitivePatterns: []string{"*.p12"},
+		CustomPatterns:    []CustomPatternDefinition{{ID: "base-rule"}},
+	}
+
+	result := mergeRedactionConfig(base, RedactionConfig{})
+
+	assert.Equal(t, []string{"*.p12"}, result.SensitivePatterns)
+	assert.Equal(t, []CustomPatternDefinition{{ID: "base-rule"}}, result.CustomPatterns)
+}
+
+func TestMergeRedactionConfig_SensitiveAndCustomPatterns_OverrideReplaces(t *testing.T) {
+	t.Parallel()
+	base := RedactionConfig{
+		SensitivePatterns:         []string{"*.p12"},
+		CustomPatterns:            []CustomPatternDefinition{{ID: "base-rule"}},
+		OverrideSensitiveDefaults: true,
+	}
+	override := RedactionConfig{
+		SensitivePatterns: []string{"*.jks"},
+		CustomPatterns:    []CustomPatternDefinition{{ID: "child-rule"}},
+	}
+
+	result := mergeRedactionConfig(base, override)
+
+	assert.Equal(t, []string{"*.jks"}, result.SensitivePatterns)
+	assert.Equal(t, []CustomPatternDefinition{{ID: "child-rule"}}, result.CustomPatterns)
+	assert.False(t, result.OverrideSensitiveDefaults, "override bool always wins")
+}
+
 // ── mergeProfile ─────────────────────────────────────────────────────────────
 
 // TestMergeProfile_StringScalars verifies that non-empty override string fields
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// MatchPathOverride returns the first override whose Match glob matches
+// filePath, which is relative to the repository root. Overrides with an
+// invalid glob never match. The second result is false when no override
+// applies.
+func MatchPathOverride(overrides []PathOverride, filePath string) (PathOverride, bool) {
+	normalized := filepath.ToSlash(filePath)
+	for _, ov := range overrides {
+		if matched, err := doublestar.Match(ov.Match, normalized); err == nil && matched {
+			return ov, true
+		}
+	}
+	return PathOverride{}, false
+}
+
+// String renders o as a TOML inline table holding only the settings it sets,
+// e.g. {match = "vendor/**", tier = 5}.
+func (o PathOverride) String() string {
+	parts := []string{fmt.Sprintf("match = %q", o.Match)}
+	if o.Tier != nil {
+		parts = append(parts, fmt.Sprintf("tier = %d", *o.Tier))
+	}
+	if o.Redact != nil {
+		parts = append(parts, fmt.Sprintf("redact = %t", *o.Redact))
+	}
+	if o.Priority {
+		parts = append(parts, "priority = true")
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
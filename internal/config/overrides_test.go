@@ -0,0 +1,117 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFromString_Overrides(t *testing.T) {
+	t.Parallel()
+
+	const data = `
+[profile.default]
+format = "markdown"
+
+[[profile.default.overrides]]
+match = "examples/**"
+tier = 0
+redact = false
+
+[[profile.default.overrides]]
+match = "migrations/**"
+priority = true
+`
+
+	cfg, err := LoadFromString(data, "<test>")
+	require.NoError(t, err)
+
+	overrides := cfg.Profile["default"].Overrides
+	require.Len(t, overrides, 2)
+
+	assert.Equal(t, "examples/**", overrides[0].Match)
+	require.NotNil(t, overrides[0].Tier)
+	assert.Equal(t, 0, *overrides[0].Tier)
+	require.NotNil(t, overrides[0].Redact)
+	assert.False(t, *overrides[0].Redact)
+	assert.False(t, overrides[0].Priority)
+
+	assert.Equal(t, "migrations/**", overrides[1].Match)
+	assert.Nil(t, overrides[1].Tier)
+	assert.Nil(t, overrides[1].Redact)
+	assert.True(t, overrides[1].Priority)
+}
+
+func TestMatchPathOverride(t *testing.T) {
+	t.Parallel()
+
+	tier0 := 0
+	overrides := []PathOverride{
+		{Match: "examples/**", Tier: &tier0},
+		{Match: "**/*.sql", Priority: true},
+		{Match: "examples/keep.go", Priority: true},
+	}
+
+	ov, ok := MatchPathOverride(overrides, "examples/keep.go")
+	require.True(t, ok)
+	assert.Equal(t, "examples/**", ov.Match, "first matching override wins")
+
+	ov, ok = MatchPathOverride(overrides, "db/migrations/001.sql")
+	require.True(t, ok)
+	assert.True(t, ov.Priority)
+
+	_, ok = MatchPathOverride(overrides, "internal/app.go")
+	assert.False(t, ok)
+
+	_, ok = MatchPathOverride(nil, "internal/app.go")
+	assert.False(t, ok)
+}
+
+func TestValidate_Overrides(t *testing.T) {
+	t.Parallel()
+
+	tier0, tier9, tierNeg := 0, 9, -1
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Overrides: []PathOverride{
+					{Match: "examples/**", Tier: &tier0},
+					{Match: "src/[abc", Priority: true},
+					{Match: "", Tier: &tier9},
+					{Match: "vendor/**", Tier: &tierNeg},
+				},
+			},
+		},
+	}
+
+	errs := errorsWithSeverity(Validate(cfg), "error")
+	assert.Empty(t, errorsWithField(errs, "profile.p.overrides[0].match"))
+	assert.Empty(t, errorsWithField(errs, "profile.p.overrides[0].tier"))
+
+	globErrs := errorsWithField(errs, "profile.p.overrides[1].match")
+	require.Len(t, globErrs, 1)
+	assert.Contains(t, globErrs[0].Message, "invalid glob pattern")
+
+	require.Len(t, errorsWithField(errs, "profile.p.overrides[2].match"), 1)
+	tierErrs := errorsWithField(errs, "profile.p.overrides[2].tier")
+	require.Len(t, tierErrs, 1)
+	assert.Contains(t, tierErrs[0].Message, "9")
+
+	negErrs := errorsWithField(errs, "profile.p.overrides[3].tier")
+	require.Len(t, negErrs, 1)
+	assert.Contains(t, negErrs[0].Message, "-1")
+}
+
+func TestMergeProfile_Overrides(t *testing.T) {
+	t.Parallel()
+
+	parent := &Profile{Overrides: []PathOverride{{Match: "examples/**"}}}
+
+	inherited := mergeProfile(parent, &Profile{})
+	assert.Equal(t, parent.Overrides, inherited.Overrides)
+
+	replaced := mergeProfile(parent, &Profile{Overrides: []PathOverride{{Match: "docs/**"}}})
+	require.Len(t, replaced.Overrides, 1)
+	assert.Equal(t, "docs/**", replaced.Overrides[0].Match)
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ExpandPriorityFiles resolves priority_files entries into concrete file paths
+// from files, the relative paths of the discovered files. Entries are
+// relative to baseDir (Profile.BaseDir) and the returned paths are relative
+// to the target directory, like files. When globs (Profile.PriorityGlobs) is
+// false the entries are exact paths and are only rebased. Otherwise each
+// entry containing glob metacharacters is replaced by the files under baseDir
+// it matches, in sorted order, while exact entries keep their place. Entry
+// order is preserved and a path listed more than once keeps its first
+// position. Patterns that match nothing, or are invalid, contribute no paths.
+func ExpandPriorityFiles(entries []string, globs bool, baseDir string, files []string) []string {
+	if !globs {
+		if !hasBaseDir(baseDir) {
+			return entries
+		}
+		expanded := make([]string, len(entries))
+		for i, entry := range entries {
+			expanded[i] = joinBaseDir(baseDir, entry)
+		}
+		return expanded
+	}
+
+	seen := make(map[string]bool)
+	var expanded []string
+	add := func(path string) {
+		if !seen[path] {
+			seen[path] = true
+			expanded = append(expanded, path)
+		}
+	}
+
+	for _, entry := range entries {
+		if !strings.ContainsAny(entry, globMetaChars) {
+			add(joinBaseDir(baseDir, entry))
+			continue
+		}
+
+		var matches []string
+		for _, f := range files {
+			if matchRebased(entry, baseDir, f) {
+				matches = append(matches, f)
+			}
+		}
+		slices.Sort(matches)
+		for _, m := range matches {
+			add(m)
+		}
+	}
+	return expanded
+}
+
+// UnmatchedPriorityFiles returns the priority_files entries that correspond
+// to none of files, the relative paths of the discovered files, in entry
+// order. Entries are relative to baseDir (Profile.BaseDir). An exact entry is
+// unmatched when no file has that path; with globs enabled, a pattern entry
+// is unmatched when it matches no file under baseDir. Such entries usually
+// point at a typo'd or stale path.
+func UnmatchedPriorityFiles(entries []string, globs bool, baseDir string, files []string) []string {
+	present := make(map[string]bool, len(files))
+	for _, f := range files {
+		present[f] = true
+	}
+
+	var unmatched []string
+	for _, entry := range entries {
+		if globs && strings.ContainsAny(entry, globMetaChars) {
+			if !slices.ContainsFunc(files, func(f string) bool {
+				return matchRebased(entry, baseDir, f)
+			}) {
+				unmatched = append(unmatched, entry)
+			}
+			continue
+		}
+		if !present[joinBaseDir(baseDir, entry)] {
+			unmatched = append(unmatched, entry)
+		}
+	}
+	return unmatched
+}
+
+// matchRebased reports whether pattern, relative to baseDir, matches file,
+// relative to the target directory. Files outside baseDir and invalid
+// patterns never match.
+func matchRebased(pattern, baseDir, file string) bool {
+	rel, ok := RebasePath(baseDir, file)
+	if !ok {
+		return false
+	}
+	matched, err := doublestar.Match(pattern, rel)
+	return err == nil && matched
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandPriorityFiles(t *testing.T) {
+	t.Parallel()
+
+	files := []string{
+		"README.md",
+		"docs/usage.md",
+		"docs/api.md",
+		"docs/guide/intro.md",
+		"docs/logo.png",
+		"go.mod",
+	}
+
+	tests := []struct {
+		name    string
+		entries []string
+		globs   bool
+		want    []string
+	}{
+		{
+			name:    "globs off returns entries unchanged",
+			entries: []string{"docs/*.md", "go.mod"},
+			globs:   false,
+			want:    []string{"docs/*.md", "go.mod"},
+		},
+		{
+			name:    "star md expands to sorted matches",
+			entries: []string{"docs/*.md"},
+			globs:   true,
+			want:    []string{"docs/api.md", "docs/usage.md"},
+		},
+		{
+			name:    "exact entries keep their position",
+			entries: []string{"go.mod", "docs/*.md", "README.md"},
+			globs:   true,
+			want:    []string{"go.mod", "docs/api.md", "docs/usage.md", "README.md"},
+		},
+		{
+			name:    "duplicates keep first position",
+			entries: []string{"docs/usage.md", "docs/**/*.md"},
+			globs:   true,
+			want:    []string{"docs/usage.md", "docs/api.md", "docs/guide/intro.md"},
+		},
+		{
+			name:    "pattern without matches contributes nothing",
+			entries: []string{"*.txt"},
+			globs:   true,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExpandPriorityFiles(tt.entries, tt.globs, "", files))
+		})
+	}
+}
+
+func TestExpandPriorityFiles_BaseDir(t *testing.T) {
+	t.Parallel()
+
+	files := []string{"main.go", "pkg/api/main.go", "pkg/api/docs/a.md", "docs/b.md"}
+
+	assert.Equal(t, []string{"pkg/api/main.go"},
+		ExpandPriorityFiles([]string{"main.go"}, false, "pkg/api", files))
+	assert.Equal(t, []string{"pkg/api/main.go", "pkg/api/docs/a.md"},
+		ExpandPriorityFiles([]string{"main.go", "docs/*.md"}, true, "pkg/api", files))
+}
+
+func TestUnmatchedPriorityFiles(t *testing.T) {
+	t.Parallel()
+
+	files := []string{"README.md", "CLAUDE.md", "docs/api.md"}
+
+	tests := []struct {
+		name    string
+		entries []string
+		globs   bool
+		want    []string
+	}{
+		{
+			name:    "existing entries are matched",
+			entries: []string{"CLAUDE.md", "README.md"},
+			want:    nil,
+		},
+		{
+			name:    "missing entry is reported",
+			entries: []string{"CLAUDE.md", "AGENTS.md"},
+			want:    []string{"AGENTS.md"},
+		},
+		{
+			name:    "globs off treats patterns as paths",
+			entries: []string{"docs/*.md"},
+			want:    []string{"docs/*.md"},
+		},
+		{
+			name:    "glob matching nothing is reported",
+			entries: []string{"docs/*.md", "specs/**/*.md"},
+			globs:   true,
+			want:    []string{"specs/**/*.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, UnmatchedPriorityFiles(tt.entries, tt.globs, "", files))
+		})
+	}
+}
+
+func TestUnmatchedPriorityFiles_BaseDir(t *testing.T) {
+	t.Parallel()
+
+	files := []string{"README.md", "pkg/api/main.go", "pkg/api/docs/a.md"}
+
+	assert.Nil(t, UnmatchedPriorityFiles([]string{"main.go", "docs/*.md"}, true, "pkg/api", files))
+	assert.Equal(t, []string{"README.md", "*.md"},
+		UnmatchedPriorityFiles([]string{"README.md", "*.md", "main.go"}, true, "pkg/api", files))
+}
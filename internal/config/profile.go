@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"log/slog"
+	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -10,6 +12,12 @@ import (
 // Chains deeper than this are still resolved; only a warning is logged.
 const maxInheritanceDepth = 3
 
+// maxExtendsFiles caps how many distinct config files one profile resolution
+// may load through "file#profile" extends references. It guards against
+// reference loops that never revisit the same file path, such as a file
+// extending a copy of itself one directory down.
+const maxExtendsFiles = 32
+
 // ProfileResolution is the result of resolving a profile with full inheritance
 // chain flattened into a single Profile value.
 type ProfileResolution struct {
@@ -19,8 +27,31 @@ type ProfileResolution struct {
 
 	// Chain is the ordered list of profile names in the inheritance chain,
 	// from the requested profile to the ultimate ancestor. For example,
-	// ["finvault", "base", "default"]. Useful for debugging.
+	// ["finvault", "base", "default"]. Useful for debugging. A parent
+	// referenced in another file appears as written in extends, e.g.
+	// "shared.toml#base".
 	Chain []string
+
+	// resolved holds the resolved profile of each Chain entry, so callers
+	// can inspect an ancestor without resolving it again.
+	resolved []*Profile
+}
+
+// CircularInheritanceError reports an extends chain that loops back on
+// itself. ResolveProfile wraps it with the path that led to the cycle; use
+// errors.As to recover the cycle alone.
+type CircularInheritanceError struct {
+	// Cycle lists the profiles that form the loop, in extends order, starting
+	// and ending with the same profile: ["a", "b", "c", "a"] for a profile a
+	// that extends b, which extends c, which extends a. A self-reference is
+	// ["a", "a"]. Profiles from other files are qualified by their absolute
+	// path, as in "/repo/shared.toml#base".
+	Cycle []string
+}
+
+// Error implements the error interface.
+func (e *CircularInheritanceError) Error() string {
+	return fmt.Sprintf("circular profile inheritance: %s", strings.Join(e.Cycle, " -> "))
 }
 
 // ResolveProfile resolves the named profile by following its inheritance chain
@@ -37,11 +68,24 @@ type ProfileResolution struct {
 //   - Slices (Ignore, PriorityFiles, Include, tier globs): child replaces
 //     parent entirely when non-nil and non-empty.
 //   - RedactionConfig: merged field-by-field with the same rules.
+//   - Overrides: child list replaces parent entirely when non-empty.
+//
+// An extends value of the form "shared.toml#base" names profile base in
+// another config file. A relative path is resolved against the directory of
+// the config file that declared the extends (the working directory for
+// profiles not loaded by LoadFromFile). Each referenced file is read once per
+// resolution, and profiles in it resolve their own plain extends against that
+// file's profiles, falling back to its own "default".
 //
 // Error conditions:
-//   - Profile not found (and is not "default"): returns descriptive error.
-//   - Circular inheritance detected: returns the full cycle path in the error.
+//   - Profile not found (and is not "default"): returns descriptive error,
+//     naming the file for a profile referenced in another file.
+//   - Circular inheritance detected: returns a *CircularInheritanceError
+//     naming the profiles of the cycle in order (e.g. "a -> b -> c -> a"),
+//     with profiles from other files qualified by their absolute path.
 //   - Self-referential extends: detected as circular.
+//   - A referenced file that cannot be loaded, or more than maxExtendsFiles
+//     files referenced in one chain.
 //
 // The returned ProfileResolution.Profile always has Extends == nil.
 func ResolveProfile(name string, profiles map[string]*Profile) (*ProfileResolution, error) {
@@ -67,25 +111,96 @@ func ResolveProfile(name string, profiles map[string]*Profile) (*ProfileResoluti
 	return resolution, nil
 }
 
-// resolveChain is the recursive helper that builds the inheritance chain and
-// merges profiles from ancestor to descendant. visited tracks the names
-// already seen in the current call path for circular dependency detection.
+// FlattenProfile resolves the named profile against all and returns it as a
+// standalone profile: every value inherited through extends (and from the
+// built-in defaults) is materialized on the returned profile and Extends is
+// nil, so it can be serialized on its own without its ancestors. It uses
+// ResolveProfile and returns the same errors for unknown profiles and
+// circular inheritance. all is not modified.
+func FlattenProfile(name string, all map[string]*Profile) (*Profile, error) {
+	resolution, err := ResolveProfile(name, all)
+	if err != nil {
+		return nil, fmt.Errorf("flatten profile %q: %w", name, err)
+	}
+	return resolution.Profile, nil
+}
+
+// resolveChain builds the inheritance chain of name within profiles and
+// merges profiles from ancestor to descendant. visited lists the chain
+// entries already seen in the current call path for circular dependency
+// detection.
 func resolveChain(name string, profiles map[string]*Profile, visited []string) (*ProfileResolution, error) {
+	r := &chainResolver{files: make(map[string]map[string]*Profile)}
+	return r.resolve(profileRef{name: name}, profiles, visited)
+}
+
+// profileRef identifies a profile during chain resolution. file is empty for
+// the profile map passed to ResolveProfile; for a profile in another config
+// file it is the file's absolute path, and label is the reference as written
+// in extends ("shared.toml#base").
+type profileRef struct {
+	name  string
+	file  string
+	label string
+}
+
+// id is the key used for cycle detection, unique across files.
+func (ref profileRef) id() string {
+	if ref.file == "" {
+		return ref.name
+	}
+	return ref.file + "#" + ref.name
+}
+
+// chainLabel is how the profile appears in ProfileResolution.Chain.
+func (ref profileRef) chainLabel() string {
+	if ref.label != "" {
+		return ref.label
+	}
+	return ref.name
+}
+
+// sibling returns the reference to another profile in the same file as ref.
+func (ref profileRef) sibling(name string) profileRef {
+	sib := profileRef{name: name, file: ref.file}
+	if ref.file != "" {
+		sib.label = filepath.Base(ref.file) + "#" + name
+	}
+	return sib
+}
+
+// chainResolver resolves inheritance chains, caching the profiles of every
+// config file loaded through a "file#profile" extends reference.
+type chainResolver struct {
+	// files maps the absolute path of each loaded file to its profiles.
+	files map[string]map[string]*Profile
+}
+
+// resolve is the recursive helper behind resolveChain. profiles is the
+// profile map ref.name is looked up in: the caller's map, or the map of the
+// file ref points into.
+func (r *chainResolver) resolve(ref profileRef, profiles map[string]*Profile, visited []string) (*ProfileResolution, error) {
 	// Detect circular inheritance before doing any work.
-	for _, v := range visited {
-		if v == name {
-			cycle := append(visited, name)
-			return nil, fmt.Errorf("circular profile inheritance: %s", strings.Join(cycle, " -> "))
+	// The cycle starts at the first visit of id; profiles visited before it
+	// lead into the cycle but are not part of it.
+	id := ref.id()
+	for i, v := range visited {
+		if v == id {
+			cycle := append(slices.Clone(visited[i:]), id)
+			return nil, &CircularInheritanceError{Cycle: cycle}
 		}
 	}
 
-	visited = append(visited, name)
+	visited = append(visited, id)
 
 	// Look up the profile. The built-in "default" profile is synthesized from
 	// DefaultProfile() if it is not explicitly defined in the map.
-	profile := lookupProfile(name, profiles)
+	profile := lookupProfile(ref.name, profiles)
 	if profile == nil {
-		return nil, fmt.Errorf("profile %q is not defined", name)
+		if ref.file != "" {
+			return nil, fmt.Errorf("profile %q is not defined in %s", ref.name, ref.file)
+		}
+		return nil, fmt.Errorf("profile %q is not defined", ref.name)
 	}
 
 	// Base case: no parent profile. Start the chain with the current profile.
@@ -95,38 +210,86 @@ func resolveChain(name string, profiles map[string]*Profile, visited []string) (
 		// sensible values. We start a fresh visited set (nil) for this
 		// implicit resolution to avoid false circular detection when "default"
 		// happens to appear elsewhere in the ancestor chain.
-		if name != "default" {
-			defaultResolution, err := resolveChain("default", profiles, nil)
+		if ref.name != "default" {
+			defaultResolution, err := r.resolve(ref.sibling("default"), profiles, nil)
 			if err != nil {
-				return nil, fmt.Errorf("resolving default base for %q: %w", name, err)
+				return nil, fmt.Errorf("resolving default base for %q: %w", ref.chainLabel(), err)
 			}
-			merged := mergeProfile(defaultResolution.Profile, profile)
-			chain := append([]string{name}, defaultResolution.Chain...)
-			return &ProfileResolution{Profile: merged, Chain: chain}, nil
+			return extendResolution(ref, mergeProfile(defaultResolution.Profile, profile), defaultResolution), nil
 		}
 
 		// This is the default profile with no parent: return a copy merged on
 		// top of the built-in DefaultProfile() to fill any gaps.
 		builtin := DefaultProfile()
 		merged := mergeProfile(builtin, profile)
-		return &ProfileResolution{Profile: merged, Chain: []string{name}}, nil
+		return &ProfileResolution{Profile: merged, Chain: []string{ref.chainLabel()}, resolved: []*Profile{merged}}, nil
 	}
 
 	parentName := *profile.Extends
+	parentRef, parentProfiles := ref.sibling(parentName), profiles
+	if file, name, ok := strings.Cut(parentName, "#"); ok {
+		if file == "" || name == "" {
+			return nil, fmt.Errorf("profile %q: extends %q must have the form \"file#profile\"", ref.chainLabel(), parentName)
+		}
+		path := extendsFilePath(file, profile.configDir)
+		loaded, err := r.load(path)
+		if err != nil {
+			return nil, fmt.Errorf("resolving parent %q for profile %q: %w", parentName, ref.chainLabel(), err)
+		}
+		parentRef = profileRef{name: name, file: path, label: parentName}
+		parentProfiles = loaded
+	}
 
 	// Resolve the parent first (depth-first recursion).
-	parentResolution, err := resolveChain(parentName, profiles, visited)
+	parentResolution, err := r.resolve(parentRef, parentProfiles, visited)
 	if err != nil {
-		return nil, fmt.Errorf("resolving parent %q for profile %q: %w", parentName, name, err)
+		return nil, fmt.Errorf("resolving parent %q for profile %q: %w", parentName, ref.chainLabel(), err)
 	}
 
 	// Merge: apply child on top of resolved parent.
-	merged := mergeProfile(parentResolution.Profile, profile)
+	return extendResolution(ref, mergeProfile(parentResolution.Profile, profile), parentResolution), nil
+}
 
+// extendResolution returns the resolution of ref, whose merged profile is
+// merged, on top of its parent's resolution.
+func extendResolution(ref profileRef, merged *Profile, parent *ProfileResolution) *ProfileResolution {
 	// Chain: current profile first, then parent chain.
-	chain := append([]string{name}, parentResolution.Chain...)
+	return &ProfileResolution{
+		Profile:  merged,
+		Chain:    append([]string{ref.chainLabel()}, parent.Chain...),
+		resolved: append([]*Profile{merged}, parent.resolved...),
+	}
+}
 
-	return &ProfileResolution{Profile: merged, Chain: chain}, nil
+// load returns the profiles of the config file at path, reading it on first
+// use only.
+func (r *chainResolver) load(path string) (map[string]*Profile, error) {
+	if profiles, ok := r.files[path]; ok {
+		return profiles, nil
+	}
+	if len(r.files) >= maxExtendsFiles {
+		return nil, fmt.Errorf("extends references more than %d config files; check for an extends loop", maxExtendsFiles)
+	}
+
+	cfg, err := LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+	r.files[path] = cfg.Profile
+	return cfg.Profile, nil
+}
+
+// extendsFilePath returns the absolute path of the config file an extends
+// reference names, resolving a relative file against configDir, the
+// directory of the config that declared the extends.
+func extendsFilePath(file, configDir string) string {
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(configDir, file)
+	}
+	if abs, err := filepath.Abs(file); err == nil {
+		return abs
+	}
+	return file
 }
 
 // lookupProfile returns the named profile from the map, or the synthesized
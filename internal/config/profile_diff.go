@@ -0,0 +1,211 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ProfileFieldDiff compares one flat field (e.g. "format" or
+// "relevance.tier_0") between two resolved profiles.
+type ProfileFieldDiff struct {
+	// Key is the flat field name, as used in SourceMap.
+	Key string `json:"key"`
+
+	// A and B are the resolved values in each profile. A value is nil when
+	// the field is absent from that profile (e.g. an unset tier label).
+	A any `json:"a"`
+	B any `json:"b"`
+
+	// SourceA and SourceB name the profile in each inheritance chain that
+	// set the value, e.g. "base" for the max_tokens that child inherits from
+	// base. Values no profile sets come from "default".
+	SourceA string `json:"source_a"`
+	SourceB string `json:"source_b"`
+
+	// Changed reports whether the resolved values differ.
+	Changed bool `json:"changed"`
+}
+
+// ProfileDiff is the field-level comparison of two resolved profiles.
+type ProfileDiff struct {
+	// A and B are the compared profile names.
+	A string `json:"a"`
+	B string `json:"b"`
+
+	// ChainA and ChainB are the inheritance chains, as in
+	// ProfileResolution.Chain.
+	ChainA []string `json:"chain_a"`
+	ChainB []string `json:"chain_b"`
+
+	// Fields holds every field of either profile, sorted by key, whether or
+	// not it changed.
+	Fields []ProfileFieldDiff `json:"fields"`
+}
+
+// Changed returns the fields whose resolved values differ.
+func (d *ProfileDiff) Changed() []ProfileFieldDiff {
+	var changed []ProfileFieldDiff
+	for _, f := range d.Fields {
+		if f.Changed {
+			changed = append(changed, f)
+		}
+	}
+	return changed
+}
+
+// DiffProfiles resolves profiles a and b against profiles, following their
+// inheritance chains as ResolveProfile does, and compares the results field
+// by field. Each field records which profile in its chain supplied the
+// value, so the diff shows not only that two profiles differ but where each
+// value was inherited from. It returns the ResolveProfile error for an
+// unknown profile or circular inheritance.
+func DiffProfiles(a, b string, profiles map[string]*Profile) (*ProfileDiff, error) {
+	flatA, sourcesA, chainA, err := flattenWithChainSources(a, profiles)
+	if err != nil {
+		return nil, fmt.Errorf("diff profiles: %w", err)
+	}
+	flatB, sourcesB, chainB, err := flattenWithChainSources(b, profiles)
+	if err != nil {
+		return nil, fmt.Errorf("diff profiles: %w", err)
+	}
+
+	return &ProfileDiff{
+		A:      a,
+		B:      b,
+		ChainA: chainA,
+		ChainB: chainB,
+		Fields: diffFlatFields(flatA, flatB, sourcesA, sourcesB),
+	}, nil
+}
+
+// diffFlatFields compares two flat field maps, as built by profileToFlatMap,
+// and returns one entry per key of either map, sorted by key. Sources are
+// looked up in sourcesA and sourcesB, which may be nil.
+func diffFlatFields(flatA, flatB map[string]any, sourcesA, sourcesB map[string]string) []ProfileFieldDiff {
+	keys := make(map[string]bool, len(flatA))
+	for k := range flatA {
+		keys[k] = true
+	}
+	for k := range flatB {
+		keys[k] = true
+	}
+
+	fields := make([]ProfileFieldDiff, 0, len(keys))
+	for k := range keys {
+		fields = append(fields, ProfileFieldDiff{
+			Key:     k,
+			A:       flatA[k],
+			B:       flatB[k],
+			SourceA: sourcesA[k],
+			SourceB: sourcesB[k],
+			Changed: !flatValuesEqual(flatA[k], flatB[k]),
+		})
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Key < fields[j].Key
+	})
+	return fields
+}
+
+// FieldDiff is one field whose value differs between two profiles, as
+// returned by CompareProfiles.
+type FieldDiff struct {
+	// Field is the flat field name, as used in SourceMap (e.g. "format" or
+	// "relevance.tier_1").
+	Field string `json:"field"`
+
+	// ValueA and ValueB are the stringified values: scalars as printed by
+	// fmt, lists as "[a, b]", and an absent field as "".
+	ValueA string `json:"value_a"`
+	ValueB string `json:"value_b"`
+}
+
+// CompareProfiles compares two already-resolved profiles field by field and
+// returns the fields whose values differ, ordered by field name. Lists are
+// compared element by element, so the same entries in a different order
+// count as a difference. Fields are compared as DiffProfiles compares them,
+// covering every profile setting including overrides and custom redaction
+// patterns. Unlike DiffProfiles it does no inheritance or source tracking;
+// use it to compare profiles obtained elsewhere, such as from two
+// environments' Resolve results. A nil profile compares as an empty one.
+func CompareProfiles(a, b *Profile) []FieldDiff {
+	if a == nil {
+		a = &Profile{}
+	}
+	if b == nil {
+		b = &Profile{}
+	}
+	var diffs []FieldDiff
+	for _, f := range diffFlatFields(profileToFlatMap(a), profileToFlatMap(b), nil, nil) {
+		if f.Changed {
+			diffs = append(diffs, FieldDiff{
+				Field:  f.Key,
+				ValueA: stringifyFlatValue(f.A),
+				ValueB: stringifyFlatValue(f.B),
+			})
+		}
+	}
+	return diffs
+}
+
+// stringifyFlatValue renders a flat field value for FieldDiff: scalars as
+// printed by fmt, lists as "[a, b]" with each element printed by fmt, and an
+// absent field as "".
+func stringifyFlatValue(v any) string {
+	if v == nil {
+		return ""
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return fmt.Sprint(v)
+	}
+	elems := make([]string, rv.Len())
+	for i := range elems {
+		elems[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+	return "[" + strings.Join(elems, ", ") + "]"
+}
+
+// flattenWithChainSources resolves name and returns its flat field map, the
+// chain profile that set each field, and the chain itself. A field's source
+// is the profile closest to name whose resolved value differs from the one
+// it inherits; fields no profile changes are attributed to the chain's root.
+func flattenWithChainSources(name string, profiles map[string]*Profile) (map[string]any, map[string]string, []string, error) {
+	resolution, err := ResolveProfile(name, profiles)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	chain := resolution.Chain
+
+	var prev map[string]any
+	sources := make(map[string]string)
+	for i := len(chain) - 1; i >= 0; i-- {
+		flat := profileToFlatMap(resolution.resolved[i])
+		for k, v := range flat {
+			if old, ok := prev[k]; !ok || !flatValuesEqual(old, v) {
+				sources[k] = chain[i]
+			}
+		}
+		prev = flat
+	}
+
+	return prev, sources, chain, nil
+}
+
+// flatValuesEqual compares two flat field values, treating nil and empty
+// lists as equal so an unset list does not differ from an explicitly empty
+// one.
+func flatValuesEqual(a, b any) bool {
+	return reflect.DeepEqual(normalizeFlatValue(a), normalizeFlatValue(b))
+}
+
+// normalizeFlatValue maps empty lists, such as an empty []string or
+// []PathOverride, to nil.
+func normalizeFlatValue(v any) any {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice && rv.Len() == 0 {
+		return nil
+	}
+	return v
+}
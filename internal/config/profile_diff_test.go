@@ -0,0 +1,131 @@
+package config
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// diffField returns the field with key from d, failing the test if absent.
+func diffField(t *testing.T, d *ProfileDiff, key string) ProfileFieldDiff {
+	t.Helper()
+	for _, f := range d.Fields {
+		if f.Key == key {
+			return f
+		}
+	}
+	require.Failf(t, "field not in diff", "key %q", key)
+	return ProfileFieldDiff{}
+}
+
+func TestDiffProfiles_FromInheritanceTOML(t *testing.T) {
+	cfg, err := LoadFromFile("../../testdata/config/inheritance.toml")
+	require.NoError(t, err)
+
+	d, err := DiffProfiles("base", "child", cfg.Profile)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"base", "default"}, d.ChainA)
+	assert.Equal(t, []string{"child", "base", "default"}, d.ChainB)
+
+	format := diffField(t, d, "format")
+	assert.True(t, format.Changed)
+	assert.Equal(t, "markdown", format.A)
+	assert.Equal(t, "xml", format.B)
+	assert.Equal(t, "default", format.SourceA)
+	assert.Equal(t, "child", format.SourceB)
+
+	maxTokens := diffField(t, d, "max_tokens")
+	assert.False(t, maxTokens.Changed)
+	assert.Equal(t, 64000, maxTokens.B)
+	assert.Equal(t, "base", maxTokens.SourceA)
+	assert.Equal(t, "base", maxTokens.SourceB)
+
+	changed := d.Changed()
+	require.Len(t, changed, 1)
+	assert.Equal(t, "format", changed[0].Key)
+}
+
+func TestFlatValuesEqual(t *testing.T) {
+	assert.True(t, flatValuesEqual([]string(nil), []string{}))
+	assert.True(t, flatValuesEqual([]string{"a"}, []string{"a"}))
+	assert.False(t, flatValuesEqual([]string{"a"}, []string{"b"}))
+	assert.False(t, flatValuesEqual(nil, "label"))
+	assert.True(t, flatValuesEqual(64000, 64000))
+}
+
+func TestDiffProfiles_UnknownProfile(t *testing.T) {
+	_, err := DiffProfiles("default", "missing", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "missing" is not defined`)
+}
+
+func TestCompareProfiles(t *testing.T) {
+	t.Parallel()
+
+	a := DefaultProfile()
+	b := DefaultProfile()
+	b.Format = "xml"
+	b.MaxTokens = 64000
+	b.Ignore = slices.Clone(a.Ignore)
+	b.Ignore[0], b.Ignore[1] = b.Ignore[1], b.Ignore[0]
+
+	diffs := CompareProfiles(a, b)
+
+	fields := make([]string, len(diffs))
+	for i, d := range diffs {
+		fields[i] = d.Field
+	}
+	assert.Equal(t, []string{"format", "ignore", "max_tokens"}, fields,
+		"only the changed fields appear, ordered by name")
+
+	assert.Equal(t, FieldDiff{Field: "format", ValueA: "markdown", ValueB: "xml"}, diffs[0])
+	assert.Equal(t, "[node_modules, dist, .git, coverage, __pycache__, .next, target, vendor]", diffs[1].ValueA)
+	assert.Equal(t, "[dist, node_modules, .git, coverage, __pycache__, .next, target, vendor]", diffs[1].ValueB)
+	assert.Equal(t, FieldDiff{Field: "max_tokens", ValueA: "128000", ValueB: "64000"}, diffs[2])
+}
+
+func TestCompareProfiles_OverridesAndCustomPatterns(t *testing.T) {
+	t.Parallel()
+
+	tier := 5
+	a := DefaultProfile()
+	b := DefaultProfile()
+	b.Overrides = []PathOverride{{Match: "vendor/**", Tier: &tier}}
+	b.RedactionConfig.CustomPatterns = []CustomPatternDefinition{{ID: "corp-token", Regex: "corp_[a-z0-9]{32}"}}
+	b.RedactionConfig.SensitivePatterns = []string{"*.vault"}
+
+	diffs := CompareProfiles(a, b)
+
+	fields := make([]string, len(diffs))
+	for i, d := range diffs {
+		fields[i] = d.Field
+	}
+	assert.Equal(t, []string{
+		"overrides",
+		"redaction_config.custom_patterns",
+		"redaction_config.sensitive_patterns",
+	}, fields)
+	assert.Equal(t, `[{match = "vendor/**", tier = 5}]`, diffs[0].ValueB)
+	assert.Equal(t, "[*.vault]", diffs[2].ValueB)
+
+	// DiffProfiles reports the same fields through the shared comparison.
+	d, err := DiffProfiles("a", "b", map[string]*Profile{"a": a, "b": b})
+	require.NoError(t, err)
+	changed := make([]string, 0, len(d.Changed()))
+	for _, f := range d.Changed() {
+		changed = append(changed, f.Key)
+	}
+	assert.Equal(t, fields, changed)
+}
+
+func TestCompareProfiles_Equal(t *testing.T) {
+	t.Parallel()
+
+	a := &Profile{Format: "xml", Ignore: []string{}}
+	b := &Profile{Format: "xml"}
+	assert.Empty(t, CompareProfiles(a, b), "an empty list equals an unset one")
+	assert.Empty(t, CompareProfiles(nil, &Profile{}))
+}
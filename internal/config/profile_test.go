@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/BurntSushi/toml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -280,6 +282,7 @@ func TestResolveProfile_SelfReferential(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "circular")
+	assert.Contains(t, err.Error(), "self-ref -> self-ref")
 }
 
 // TestResolveProfile_CircularThreeProfiles verifies circular detection in a
@@ -297,6 +300,29 @@ func TestResolveProfile_CircularThreeProfiles(t *testing.T) {
 
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "circular")
+	assert.Contains(t, err.Error(), "a -> b -> c -> a")
+
+	var cycleErr *CircularInheritanceError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []string{"a", "b", "c", "a"}, cycleErr.Cycle)
+}
+
+// TestResolveProfile_CycleExcludesLeadIn verifies that profiles leading into
+// a cycle are not reported as part of it.
+func TestResolveProfile_CycleExcludesLeadIn(t *testing.T) {
+	t.Parallel()
+
+	profiles := makeProfiles(
+		"x", &Profile{Extends: strPtr("a")},
+		"a", &Profile{Extends: strPtr("b")},
+		"b", &Profile{Extends: strPtr("a")},
+	)
+
+	_, err := ResolveProfile("x", profiles)
+
+	var cycleErr *CircularInheritanceError
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, []string{"a", "b", "a"}, cycleErr.Cycle)
 }
 
 // TestResolveProfile_ExtendsCleared verifies that the Extends field in the
@@ -869,7 +895,7 @@ func TestResolveProfile_FinvaultGolden(t *testing.T) {
 	require.NotNil(t, res)
 
 	// Render the resolved profile to a deterministic text representation.
-	actual := renderProfileForGolden(res)
+	actual := renderProfileForGolden(res, goldenRenderOptions{})
 
 	goldenPath := filepath.Join("../../testdata", "expected-output", "finvault-profile-resolved.txt")
 
@@ -887,10 +913,19 @@ func TestResolveProfile_FinvaultGolden(t *testing.T) {
 	assert.Equal(t, string(expected), actual, "resolved finvault profile must match golden file")
 }
 
+// goldenRenderOptions controls renderProfileForGolden.
+type goldenRenderOptions struct {
+	// SortPatternsInGolden lists each relevance tier's patterns in
+	// lexicographic order instead of config order, so goldens stay stable
+	// when pattern order is not significant to the comparison.
+	SortPatternsInGolden bool
+}
+
 // renderProfileForGolden produces a deterministic, human-readable text
 // representation of a ProfileResolution suitable for golden file comparison.
 // Fields are listed in a fixed order; slices are listed one item per line.
-func renderProfileForGolden(res *ProfileResolution) string {
+// Tier patterns keep their config order unless opts.SortPatternsInGolden is set.
+func renderProfileForGolden(res *ProfileResolution, opts goldenRenderOptions) string {
 	p := res.Profile
 	var sb strings.Builder
 
@@ -907,6 +942,12 @@ func renderProfileForGolden(res *ProfileResolution) string {
 			fmt.Fprintf(&sb, "  - %s\n", v)
 		}
 	}
+	writeTier := func(k string, patterns []string) {
+		if opts.SortPatternsInGolden {
+			patterns = slices.Sorted(slices.Values(patterns))
+		}
+		writeSlice(k, patterns)
+	}
 
 	writeLine("output", p.Output)
 	writeLine("format", p.Format)
@@ -918,12 +959,12 @@ func renderProfileForGolden(res *ProfileResolution) string {
 	writeSlice("ignore", p.Ignore)
 	writeSlice("priority_files", p.PriorityFiles)
 	writeSlice("include", p.Include)
-	writeSlice("relevance.tier_0", p.Relevance.Tier0)
-	writeSlice("relevance.tier_1", p.Relevance.Tier1)
-	writeSlice("relevance.tier_2", p.Relevance.Tier2)
-	writeSlice("relevance.tier_3", p.Relevance.Tier3)
-	writeSlice("relevance.tier_4", p.Relevance.Tier4)
-	writeSlice("relevance.tier_5", p.Relevance.Tier5)
+	writeTier("relevance.tier_0", p.Relevance.Tier0)
+	writeTier("relevance.tier_1", p.Relevance.Tier1)
+	writeTier("relevance.tier_2", p.Relevance.Tier2)
+	writeTier("relevance.tier_3", p.Relevance.Tier3)
+	writeTier("relevance.tier_4", p.Relevance.Tier4)
+	writeTier("relevance.tier_5", p.Relevance.Tier5)
 	writeLine("redaction_config.enabled", fmt.Sprintf("%t", p.RedactionConfig.Enabled))
 	writeLine("redaction_config.confidence_threshold", p.RedactionConfig.ConfidenceThreshold)
 	writeSlice("redaction_config.exclude_paths", p.RedactionConfig.ExcludePaths)
@@ -935,3 +976,305 @@ func renderProfileForGolden(res *ProfileResolution) string {
 
 	return sb.String()
 }
+
+// TestRenderProfileForGolden_SortPatterns verifies that sorted golden rendering
+// is independent of tier pattern order, while the default keeps config order.
+func TestRenderProfileForGolden_SortPatterns(t *testing.T) {
+	t.Parallel()
+
+	resolve := func(tier1 ...string) *ProfileResolution {
+		res, err := ResolveProfile("default", makeProfiles(
+			"default", &Profile{Relevance: RelevanceConfig{Tier1: tier1}},
+		))
+		require.NoError(t, err)
+		return res
+	}
+	forward := resolve("cmd/**", "internal/**", "pkg/**")
+	reversed := resolve("pkg/**", "internal/**", "cmd/**")
+
+	sorted := goldenRenderOptions{SortPatternsInGolden: true}
+	assert.Equal(t, renderProfileForGolden(forward, sorted), renderProfileForGolden(reversed, sorted))
+	assert.Contains(t, renderProfileForGolden(reversed, sorted),
+		"relevance.tier_1 =\n  - cmd/**\n  - internal/**\n  - pkg/**\n")
+
+	assert.NotEqual(t, renderProfileForGolden(forward, goldenRenderOptions{}),
+		renderProfileForGolden(reversed, goldenRenderOptions{}))
+	assert.Contains(t, renderProfileForGolden(reversed, goldenRenderOptions{}),
+		"relevance.tier_1 =\n  - pkg/**\n  - internal/**\n  - cmd/**\n")
+	assert.Equal(t, []string{"pkg/**", "internal/**", "cmd/**"}, reversed.Profile.Relevance.Tier1,
+		"sorting must not reorder the profile itself")
+}
+
+// ── FlattenProfile ────────────────────────────────────────────────────────────
+
+// TestFlattenProfile_MaterializesInheritedFields verifies that the flattened
+// profile has no extends and carries every value from its ancestors,
+// including tiers, ignore patterns, and redaction settings.
+func TestFlattenProfile_MaterializesInheritedFields(t *testing.T) {
+	t.Parallel()
+
+	profiles := map[string]*Profile{
+		"base": {
+			Format: "xml",
+			Ignore: []string{"dist/**"},
+			Relevance: RelevanceConfig{
+				Tier0: []string{"go.mod"},
+			},
+			RedactionConfig: RedactionConfig{
+				Enabled:           true,
+				SensitivePatterns: []string{"*.p12"},
+				CustomPatterns: []CustomPatternDefinition{
+					{ID: "internal-token", Regex: `itk_[a-z0-9]{16}`, SecretType: "token", Confidence: "high"},
+				},
+			},
+		},
+		"child": {
+			Extends:         strPtr("base"),
+			MaxTokens:       50000,
+			RedactionConfig: RedactionConfig{Enabled: true},
+		},
+	}
+
+	flat, err := FlattenProfile("child", profiles)
+	require.NoError(t, err)
+
+	assert.Nil(t, flat.Extends)
+	assert.Equal(t, 50000, flat.MaxTokens)
+	assert.Equal(t, "xml", flat.Format)
+	assert.Equal(t, []string{"dist/**"}, flat.Ignore)
+	assert.Equal(t, []string{"go.mod"}, flat.Relevance.Tier0)
+	assert.Equal(t, []string{"*.p12"}, flat.RedactionConfig.SensitivePatterns)
+	require.Len(t, flat.RedactionConfig.CustomPatterns, 1)
+	assert.Equal(t, "internal-token", flat.RedactionConfig.CustomPatterns[0].ID)
+
+	// Fields no profile sets come from the built-in defaults.
+	assert.Equal(t, DefaultProfile().Output, flat.Output)
+	assert.Equal(t, DefaultProfile().Tokenizer, flat.Tokenizer)
+
+	// The source map is left untouched.
+	require.NotNil(t, profiles["child"].Extends)
+	assert.Empty(t, profiles["child"].Format)
+}
+
+// TestFlattenProfile_SerializesStandalone verifies that a flattened profile
+// encodes to TOML without extends and decodes back to the same profile.
+func TestFlattenProfile_SerializesStandalone(t *testing.T) {
+	t.Parallel()
+
+	profiles := map[string]*Profile{
+		"base":  {Format: "xml", Ignore: []string{"dist/**"}},
+		"child": {Extends: strPtr("base"), MaxTokens: 50000},
+	}
+
+	flat, err := FlattenProfile("child", profiles)
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	require.NoError(t, toml.NewEncoder(&buf).Encode(Config{Profile: map[string]*Profile{"child": flat}}))
+	assert.NotContains(t, buf.String(), "extends")
+
+	cfg, err := LoadFromString(buf.String(), "flattened")
+	require.NoError(t, err)
+	// The struct encoder writes every boolean, so the reload records them all
+	// as explicitly set; only the values are compared.
+	loaded := cfg.Profile["child"]
+	loaded.boolKeys = nil
+	assert.Equal(t, flat, loaded)
+}
+
+// TestFlattenProfile_Errors verifies that resolution errors are returned.
+func TestFlattenProfile_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := FlattenProfile("missing", map[string]*Profile{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not defined")
+
+	_, err = FlattenProfile("a", map[string]*Profile{
+		"a": {Extends: strPtr("b")},
+		"b": {Extends: strPtr("a")},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular")
+}
+
+// TestResolveProfile_RenderConfigInheritance verifies that render options are
+// inherited one by one: a child that sets only line_numbers keeps the
+// parent's include_toc.
+func TestResolveProfile_RenderConfigInheritance(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := LoadFromString(`
+[profile.default.render]
+include_toc = true
+line_numbers = true
+
+[profile.child]
+extends = "default"
+
+[profile.child.render]
+line_numbers = false
+`, "test")
+	require.NoError(t, err)
+
+	res, err := ResolveProfile("child", cfg.Profile)
+	require.NoError(t, err)
+	require.NotNil(t, res.Profile.Render.IncludeTOC)
+	assert.True(t, *res.Profile.Render.IncludeTOC, "include_toc is inherited")
+	require.NotNil(t, res.Profile.Render.LineNumbers)
+	assert.False(t, *res.Profile.Render.LineNumbers, "line_numbers is overridden")
+
+	// The merged profile must not share pointers with its parent.
+	*res.Profile.Render.IncludeTOC = false
+	assert.True(t, *cfg.Profile["default"].Render.IncludeTOC)
+}
+
+// ── Cross-file extends ────────────────────────────────────────────────────────
+
+// TestResolveProfile_ExtendsFileReference verifies that "file#profile" loads
+// the parent from a file relative to the declaring config, and that the
+// parent's own plain extends resolve within that file.
+func TestResolveProfile_ExtendsFileReference(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "shared"), 0o755))
+	writeTomlFile(t, filepath.Join(dir, "shared"), "base.toml", `
+[profile.common]
+max_tokens = 64000
+ignore = ["tmp/**"]
+
+[profile.base]
+extends = "common"
+format = "xml"
+`)
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.app]
+extends = "shared/base.toml#base"
+output = "app.md"
+`)
+
+	cfg, err := LoadFromFile(filepath.Join(dir, "harvx.toml"))
+	require.NoError(t, err)
+
+	res, err := ResolveProfile("app", cfg.Profile)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"app", "shared/base.toml#base", "base.toml#common", "base.toml#default"}, res.Chain)
+	assert.Equal(t, "app.md", res.Profile.Output)
+	assert.Equal(t, "xml", res.Profile.Format)
+	assert.Equal(t, 64000, res.Profile.MaxTokens)
+	assert.Equal(t, []string{"tmp/**"}, res.Profile.Ignore)
+	assert.Nil(t, res.Profile.Extends)
+}
+
+// TestResolveProfile_ExtendsFileReference_LoadsOnce verifies that a file
+// referenced by several profiles in one chain is read only once.
+func TestResolveProfile_ExtendsFileReference_LoadsOnce(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "shared.toml", `
+[profile.base]
+format = "xml"
+`)
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.mid]
+extends = "shared.toml#base"
+
+[profile.app]
+extends = "mid"
+`)
+
+	cfg, err := LoadFromFile(filepath.Join(dir, "harvx.toml"))
+	require.NoError(t, err)
+
+	r := &chainResolver{files: make(map[string]map[string]*Profile)}
+	res, err := r.resolve(profileRef{name: "app"}, cfg.Profile, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "xml", res.Profile.Format)
+	assert.Len(t, r.files, 1)
+
+	// A cached file is not read again, even if it changed on disk.
+	writeTomlFile(t, dir, "shared.toml", `
+[profile.base]
+format = "plain"
+`)
+	res, err = r.resolve(profileRef{name: "app"}, cfg.Profile, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "xml", res.Profile.Format)
+}
+
+// TestResolveProfile_ExtendsFileReference_Errors verifies that missing
+// parents, unreadable files, and cycles across files name the file involved.
+func TestResolveProfile_ExtendsFileReference_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	sharedPath := filepath.Join(dir, "shared.toml")
+	writeTomlFile(t, dir, "shared.toml", `
+[profile.base]
+format = "xml"
+
+[profile.loop]
+extends = "harvx.toml#app"
+`)
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.app]
+extends = "shared.toml#loop"
+
+[profile.missing]
+extends = "shared.toml#nope"
+
+[profile.nofile]
+extends = "absent.toml#base"
+
+[profile.bad]
+extends = "shared.toml#"
+`)
+
+	cfg, err := LoadFromFile(filepath.Join(dir, "harvx.toml"))
+	require.NoError(t, err)
+
+	_, err = ResolveProfile("missing", cfg.Profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("profile %q is not defined in %s", "nope", sharedPath))
+
+	_, err = ResolveProfile("nofile", cfg.Profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join(dir, "absent.toml"))
+
+	_, err = ResolveProfile("bad", cfg.Profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `must have the form "file#profile"`)
+
+	_, err = ResolveProfile("app", cfg.Profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "circular profile inheritance")
+	assert.Contains(t, err.Error(), sharedPath+"#loop")
+}
+
+// TestResolveProfile_ExtendsFileReference_FileLimit verifies that a chain of
+// ever-new files stops at maxExtendsFiles instead of loading forever.
+func TestResolveProfile_ExtendsFileReference_FileLimit(t *testing.T) {
+	t.Parallel()
+
+	// Each level extends the same file one directory further down, so no
+	// file path repeats and cycle detection alone would never stop it.
+	dir := t.TempDir()
+	levelDir := dir
+	for range maxExtendsFiles + 2 {
+		writeTomlFile(t, levelDir, "harvx.toml", `
+[profile.app]
+extends = "next/harvx.toml#app"
+`)
+		levelDir = filepath.Join(levelDir, "next")
+		require.NoError(t, os.MkdirAll(levelDir, 0o755))
+	}
+
+	cfg, err := LoadFromFile(filepath.Join(dir, "harvx.toml"))
+	require.NoError(t, err)
+
+	_, err = ResolveProfile("app", cfg.Profile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("more than %d config files", maxExtendsFiles))
+}
@@ -64,7 +64,7 @@ func LoadGoldenQuestions(path string) (*GoldenQuestionsConfig, error) {
 		return nil, fmt.Errorf("parse golden questions %s: %w", path, err)
 	}
 
-	warnUndecodedKeys(meta, path)
+	warnUndecodedKeys(undecodedKeys(meta), path)
 
 	return &cfg, nil
 }
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// relevanceFileDoc is the on-disk schema of a standalone relevance file. It
+// mirrors RelevanceConfig with JSON tags so the same keys work in both TOML
+// and JSON documents:
+//
+//	tier_0 = ["go.mod", "Makefile"]
+//	tier_1 = ["internal/**"]
+type relevanceFileDoc struct {
+	Tier0 []string `toml:"tier_0" json:"tier_0"`
+	Tier1 []string `toml:"tier_1" json:"tier_1"`
+	Tier2 []string `toml:"tier_2" json:"tier_2"`
+	Tier3 []string `toml:"tier_3" json:"tier_3"`
+	Tier4 []string `toml:"tier_4" json:"tier_4"`
+	Tier5 []string `toml:"tier_5" json:"tier_5"`
+}
+
+// LoadRelevanceFile reads tier definitions from a standalone TOML or JSON
+// file. Files with a ".json" extension are decoded as JSON; everything else
+// is decoded as TOML. Unknown keys are rejected so that typos such as
+// "tier0" do not silently leave a tier empty.
+func LoadRelevanceFile(path string) (RelevanceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return RelevanceConfig{}, fmt.Errorf("read relevance file %s: %w", path, err)
+	}
+
+	var doc relevanceFileDoc
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&doc); err != nil {
+			return RelevanceConfig{}, fmt.Errorf("parse relevance file %s: %w", path, err)
+		}
+	} else {
+		meta, err := toml.Decode(string(data), &doc)
+		if err != nil {
+			return RelevanceConfig{}, fmt.Errorf("parse relevance file %s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			keys := make([]string, 0, len(undecoded))
+			for _, k := range undecoded {
+				keys = append(keys, k.String())
+			}
+			return RelevanceConfig{}, fmt.Errorf("parse relevance file %s: unknown keys: %s", path, strings.Join(keys, ", "))
+		}
+	}
+
+	return RelevanceConfig{
+		Tier0: doc.Tier0,
+		Tier1: doc.Tier1,
+		Tier2: doc.Tier2,
+		Tier3: doc.Tier3,
+		Tier4: doc.Tier4,
+		Tier5: doc.Tier5,
+	}, nil
+}
+
+// resolveRelevanceFilePath returns path unchanged when it is absolute and
+// otherwise joins it onto baseDir, the directory of the config file that
+// referenced it.
+func resolveRelevanceFilePath(path, baseDir string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(baseDir, path)
+}
+
+// applyRelevanceFiles loads the relevance_file of every profile in cfg and
+// merges its tiers beneath the profile's inline [relevance] tiers: an inline
+// tier always wins, and the file fills tiers the profile leaves unset.
+// Relative paths are resolved against baseDir.
+func applyRelevanceFiles(cfg *Config, baseDir string) error {
+	for name, p := range cfg.Profile {
+		if p == nil || p.RelevanceFile == "" {
+			continue
+		}
+		fileTiers, err := LoadRelevanceFile(resolveRelevanceFilePath(p.RelevanceFile, baseDir))
+		if err != nil {
+			return fmt.Errorf("profile.%s.relevance_file: %w", name, err)
+		}
+		p.Relevance = mergeRelevance(fileTiers, p.Relevance)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLoadRelevanceFile_TOML verifies that tier_N keys are decoded from a
+// standalone TOML file.
+func TestLoadRelevanceFile_TOML(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := writeTomlFile(t, dir, "tiers.toml", `
+tier_0 = ["go.mod"]
+tier_3 = ["**/*_test.go"]
+`)
+
+	rel, err := LoadRelevanceFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"go.mod"}, rel.Tier0)
+	assert.Equal(t, []string{"**/*_test.go"}, rel.Tier3)
+	assert.Nil(t, rel.Tier1)
+}
+
+// TestLoadRelevanceFile_JSON verifies that a .json file is decoded as JSON
+// using the same tier_N keys.
+func TestLoadRelevanceFile_JSON(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := writeTomlFile(t, dir, "tiers.json", `{"tier_1": ["services/**"], "tier_4": ["**/*.md"]}`)
+
+	rel, err := LoadRelevanceFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"services/**"}, rel.Tier1)
+	assert.Equal(t, []string{"**/*.md"}, rel.Tier4)
+}
+
+// TestLoadRelevanceFile_UnknownKey verifies that misspelled tier keys are
+// rejected rather than silently ignored.
+func TestLoadRelevanceFile_UnknownKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	tomlPath := writeTomlFile(t, dir, "tiers.toml", `tier0 = ["go.mod"]`)
+	jsonPath := writeTomlFile(t, dir, "tiers.json", `{"tier0": ["go.mod"]}`)
+
+	_, err := LoadRelevanceFile(tomlPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tier0")
+
+	_, err = LoadRelevanceFile(jsonPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tier0")
+}
+
+// TestLoadFromFile_RelevanceFile_ClassifiesFile verifies that tiers defined
+// in an external relevance file are merged into the profile and used to
+// classify files, while inline tiers still take precedence.
+func TestLoadFromFile_RelevanceFile_ClassifiesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "tiers.toml", `
+tier_0 = ["schema/**/*.sql"]
+tier_1 = ["from-file/**"]
+`)
+	cfgPath := writeTomlFile(t, dir, "harvx.toml", `
+[profile.db]
+relevance_file = "tiers.toml"
+
+[profile.db.relevance]
+tier_1 = ["internal/**"]
+`)
+
+	cfg, err := LoadFromFile(cfgPath)
+	require.NoError(t, err)
+
+	p := cfg.Profile["db"]
+	require.NotNil(t, p)
+	assert.Equal(t, []string{"schema/**/*.sql"}, p.Relevance.Tier0)
+	assert.Equal(t, []string{"internal/**"}, p.Relevance.Tier1, "inline tier must win over the file")
+
+	res, err := ResolveProfile("db", cfg.Profile)
+	require.NoError(t, err)
+
+	result := ExplainFile("schema/v1/users.sql", "db", res.Profile)
+	assert.True(t, result.Included)
+	assert.Equal(t, 0, result.Tier)
+	assert.Equal(t, "schema/**/*.sql", result.TierPattern)
+}
+
+// TestLoadFromFile_RelevanceFile_Missing verifies that a relevance_file that
+// cannot be read fails the load with the offending profile named.
+func TestLoadFromFile_RelevanceFile_Missing(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfgPath := writeTomlFile(t, dir, "harvx.toml", `
+[profile.db]
+relevance_file = "nope.toml"
+`)
+
+	_, err := LoadFromFile(cfgPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profile.db.relevance_file")
+}
+
+// TestLoadFromFile_RelevanceFile_InvalidGlobReported verifies that invalid
+// patterns loaded from a relevance file are caught by Validate.
+func TestLoadFromFile_RelevanceFile_InvalidGlobReported(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "tiers.toml", `tier_2 = ["src/[unclosed"]`)
+	cfgPath := writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+relevance_file = "tiers.toml"
+`)
+
+	cfg, err := LoadFromFile(cfgPath)
+	require.NoError(t, err)
+
+	errs := errorsWithField(Validate(cfg), "profile.default.relevance.tier_2[0]")
+	require.Len(t, errs, 1)
+	assert.Equal(t, "error", errs[0].Severity)
+}
+
+// TestResolve_RelevanceFile verifies that the resolver loads tiers from the
+// relevance file and attributes them to the layer that referenced it.
+func TestResolve_RelevanceFile(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "tiers.json", `{"tier_0": ["proto/**/*.proto"]}`)
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+relevance_file = "tiers.json"
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "nofile.toml"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "tiers.json", rc.Profile.RelevanceFile)
+	assert.Equal(t, []string{"proto/**/*.proto"}, rc.Profile.Relevance.Tier0)
+	assert.Equal(t, SourceRepo, rc.Sources["relevance.tier_0"])
+	assert.Equal(t, DefaultProfile().Relevance.Tier1, rc.Profile.Relevance.Tier1,
+		"tiers absent from the file keep their defaults")
+}
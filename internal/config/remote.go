@@ -0,0 +1,104 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// remoteConfigMaxBytes caps the size of a config fetched by LoadFromURL. Real
+// configs are a few kilobytes; anything larger is almost certainly the wrong
+// URL.
+const remoteConfigMaxBytes = 1 << 20
+
+// remoteConfigTimeout bounds a single remote config fetch, including reading
+// the response body.
+const remoteConfigTimeout = 10 * time.Second
+
+// LoadFromURL fetches a TOML configuration over HTTP(S) and parses it like
+// LoadFromString. The request is cancelled after 10 seconds or when ctx is
+// done, responses other than 200 OK are errors, and bodies larger than 1 MiB
+// are rejected. A nil client uses http.DefaultClient.
+//
+// Remote configs cannot set relevance_file: there is no directory to resolve
+// the path against, and reading local files named by a remote document would
+// be surprising.
+func LoadFromURL(ctx context.Context, url string, client *http.Client) (*Config, error) {
+	data, err := fetchRemoteConfig(ctx, url, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	meta, err := decodeConfig(string(data), &cfg)
+	if err != nil {
+		return nil, fmt.Errorf("parse config %s: %w", url, err)
+	}
+	for name, p := range cfg.Profile {
+		if p != nil && p.RelevanceFile != "" {
+			return nil, fmt.Errorf("load config %s: profile.%s.relevance_file: %w", url, name, errRemoteRelevanceFile)
+		}
+	}
+
+	warnUndecodedKeys(undecodedKeys(meta), url)
+	return &cfg, nil
+}
+
+// errRemoteRelevanceFile is returned when a remote config sets
+// relevance_file.
+var errRemoteRelevanceFile = errors.New("relevance_file is not supported in remote configs")
+
+// fetchRemoteConfig downloads the config document at url, enforcing
+// remoteConfigTimeout and remoteConfigMaxBytes.
+func fetchRemoteConfig(ctx context.Context, url string, client *http.Client) ([]byte, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, remoteConfigTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch config %s: %w", url, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch config %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch config %s: unexpected status %s", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, remoteConfigMaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("fetch config %s: %w", url, err)
+	}
+	if len(data) > remoteConfigMaxBytes {
+		return nil, fmt.Errorf("fetch config %s: response exceeds %d bytes", url, remoteConfigMaxBytes)
+	}
+	return data, nil
+}
+
+// remoteProfileFlat fetches the config at url and returns the flat map of the
+// fields profileName sets explicitly, or nil when the document does not
+// define that profile.
+func remoteProfileFlat(url, profileName string) (map[string]any, error) {
+	data, err := fetchRemoteConfig(context.Background(), url, nil)
+	if err != nil {
+		return nil, err
+	}
+	flat, err := profileFlatFromTOML(string(data), url, profileName)
+	if err != nil {
+		return nil, fmt.Errorf("loading config %s: %w", url, err)
+	}
+	if _, ok := flat["relevance_file"]; ok {
+		return nil, fmt.Errorf("loading config %s: profile.%s.relevance_file: %w", url, profileName, errRemoteRelevanceFile)
+	}
+	return flat, nil
+}
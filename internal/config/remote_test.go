@@ -0,0 +1,144 @@
+package config
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveConfig starts an httptest.Server that answers every request with body
+// and status.
+func serveConfig(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLoadFromURL(t *testing.T) {
+	t.Parallel()
+
+	srv := serveConfig(t, http.StatusOK, `
+[profile.default]
+format = "xml"
+max_tokens = "64k"
+ignore = ["vendor/**"]
+`)
+
+	cfg, err := LoadFromURL(context.Background(), srv.URL, srv.Client())
+	require.NoError(t, err)
+	require.Contains(t, cfg.Profile, "default")
+	assert.Equal(t, "xml", cfg.Profile["default"].Format)
+	assert.Equal(t, 64000, cfg.Profile["default"].MaxTokens)
+	assert.Equal(t, []string{"vendor/**"}, cfg.Profile["default"].Ignore)
+}
+
+func TestLoadFromURL_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{"not found", http.StatusNotFound, "missing", "unexpected status 404"},
+		{"too large", http.StatusOK, strings.Repeat("#", remoteConfigMaxBytes+1), "exceeds"},
+		{"invalid toml", http.StatusOK, "[profile.default\n", "parse config"},
+		{"relevance file", http.StatusOK, "[profile.default]\nrelevance_file = \"tiers.toml\"\n", "relevance_file is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			srv := serveConfig(t, tt.status, tt.body)
+			_, err := LoadFromURL(context.Background(), srv.URL, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), srv.URL)
+		})
+	}
+}
+
+func TestLoadFromURL_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	srv := serveConfig(t, http.StatusOK, "[profile.default]\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := LoadFromURL(ctx, srv.URL, nil)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// TestResolve_BaseConfigURL verifies that the remote config layers above the
+// defaults and beneath the repo config, with SourceRemote attribution.
+func TestResolve_BaseConfigURL(t *testing.T) {
+	clearHarvxEnv(t)
+
+	srv := serveConfig(t, http.StatusOK, `
+[profile.default]
+format = "xml"
+max_tokens = 50000
+ignore = ["vendor/**"]
+`)
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+max_tokens = 90000
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+		BaseConfigURL:    srv.URL,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "xml", rc.Profile.Format)
+	assert.Equal(t, SourceRemote, rc.Sources["format"])
+	assert.Equal(t, []string{"vendor/**"}, rc.Profile.Ignore)
+	assert.Equal(t, SourceRemote, rc.Sources["ignore"])
+	assert.Equal(t, 90000, rc.Profile.MaxTokens)
+	assert.Equal(t, SourceRepo, rc.Sources["max_tokens"])
+	assert.Equal(t, SourceDefault, rc.Sources["output"])
+}
+
+func TestResolve_BaseConfigURLNamedProfile(t *testing.T) {
+	clearHarvxEnv(t)
+
+	srv := serveConfig(t, http.StatusOK, "[profile.org]\nformat = \"plain\"\n")
+	repoDir := t.TempDir()
+
+	rc, err := Resolve(ResolveOptions{
+		ProfileName:      "org",
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+		BaseConfigURL:    srv.URL,
+	})
+	require.NoError(t, err, "a profile defined only remotely must be found")
+	assert.Equal(t, "plain", rc.Profile.Format)
+}
+
+func TestResolve_BaseConfigURLFetchError(t *testing.T) {
+	clearHarvxEnv(t)
+
+	srv := serveConfig(t, http.StatusInternalServerError, "boom")
+	repoDir := t.TempDir()
+
+	_, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+		BaseConfigURL:    srv.URL,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status 500")
+}
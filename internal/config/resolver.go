@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/BurntSushi/toml"
@@ -26,10 +28,22 @@ type ResolveOptions struct {
 	// Defaults to "." if empty.
 	TargetDir string
 
+	// RepoConfigChain loads every harvx.toml from TargetDir up to the
+	// repository boundary (see DiscoverRepoConfigChain) instead of only the
+	// nearest one. Files are layered farthest first, so a closer file
+	// overrides the settings it sets and inherits the rest, as a profile
+	// does from the profile it extends. Ignored when ProfileFile is set.
+	RepoConfigChain bool
+
 	// GlobalConfigPath overrides the default ~/.config/harvx/config.toml.
 	// Useful for testing.
 	GlobalConfigPath string
 
+	// BaseConfigURL is the URL of an organisation-wide base config, fetched
+	// with LoadFromURL's limits and layered just above the built-in defaults.
+	// Empty disables the remote layer; a failed fetch is an error.
+	BaseConfigURL string
+
 	// CLIFlags holds explicit CLI flag overrides (highest precedence).
 	// Keys are flat Profile field names: "format", "max_tokens", "output", etc.
 	CLIFlags map[string]any
@@ -47,12 +61,14 @@ type ResolvedConfig struct {
 	ProfileName string
 }
 
-// Resolve runs the 5-layer configuration resolution pipeline:
+// Resolve runs the 6-layer configuration resolution pipeline:
 //  1. Built-in defaults
-//  2. Global config (~/.config/harvx/config.toml)
-//  3. Repository config (harvx.toml in TargetDir) OR standalone profile file
-//  4. Environment variables (HARVX_* prefix)
-//  5. CLI flags (highest precedence)
+//  2. Remote base config (ResolveOptions.BaseConfigURL), when set
+//  3. Global config (~/.config/harvx/config.toml)
+//  4. Repository config (harvx.toml in TargetDir, or every harvx.toml up to
+//     the repository root with RepoConfigChain) OR standalone profile file
+//  5. Environment variables (HARVX_* prefix)
+//  6. CLI flags (highest precedence)
 //
 // Missing config files are silently ignored. Invalid files return errors.
 // Named profiles not found in any loaded config return an error listing
@@ -86,7 +102,25 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 	// Track whether the named profile was found in at least one file layer.
 	profileFound := false
 
-	// ── Layer 2: global config ─────────────────────────────────────────────
+	// ── Layer 2: remote base config ───────────────────────────────────────
+	if opts.BaseConfigURL != "" {
+		flat, err := remoteProfileFlat(opts.BaseConfigURL, profileName)
+		if err != nil {
+			return nil, err
+		}
+		if flat != nil {
+			slog.Debug("loading profile from remote config",
+				"profile", profileName,
+				"url", opts.BaseConfigURL,
+			)
+			if err := loadLayer(k, flat, sources, SourceRemote); err != nil {
+				return nil, err
+			}
+			profileFound = true
+		}
+	}
+
+	// ── Layer 3: global config ─────────────────────────────────────────────
 	globalPath := opts.GlobalConfigPath
 	if globalPath == "" {
 		discovered, err := DiscoverGlobalConfig()
@@ -107,7 +141,7 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 		}
 	}
 
-	// ── Layer 3: repo config OR standalone profile file ────────────────────
+	// ── Layer 4: repo config OR standalone profile file ────────────────────
 	if opts.ProfileFile != "" {
 		found, err := loadFileLayer(k, opts.ProfileFile, profileName, sources, SourceRepo)
 		if err != nil {
@@ -122,12 +156,14 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 		if targetDir == "" {
 			targetDir = "."
 		}
-		repoConfigPath, discErr := DiscoverRepoConfig(targetDir)
+		repoConfigPaths, discErr := discoverRepoConfigs(targetDir, opts.RepoConfigChain)
 		if discErr != nil {
 			slog.Debug("repo config discovery error", "err", discErr)
 		}
-		if repoConfigPath != "" {
-			found, err := loadFileLayer(k, repoConfigPath, profileName, sources, SourceRepo)
+		// Paths are nearest first; load the farthest first so closer files
+		// override it.
+		for i := len(repoConfigPaths) - 1; i >= 0; i-- {
+			found, err := loadFileLayer(k, repoConfigPaths[i], profileName, sources, SourceRepo)
 			if err != nil {
 				return nil, err
 			}
@@ -142,8 +178,12 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 		return nil, fmt.Errorf("profile %q not found in any config file", profileName)
 	}
 
-	// ── Layer 4: environment variables ────────────────────────────────────
+	// ── Layer 5: environment variables ────────────────────────────────────
 	envMap := buildEnvMap()
+	if extra, ok := envMap["ignore"].([]string); ok {
+		// HARVX_IGNORE extends the ignore list rather than replacing it.
+		envMap["ignore"] = mergeIgnorePatterns(k.Strings("ignore"), extra)
+	}
 	if len(envMap) > 0 {
 		if err := loadLayer(k, envMap, sources, SourceEnv); err != nil {
 			return nil, fmt.Errorf("loading env vars: %w", err)
@@ -158,12 +198,16 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 			return nil, fmt.Errorf("applying target preset: %w", err)
 		}
 		// Re-load from preset-applied profile; only changed keys get re-attributed.
-		if err := loadLayer(k, profileToFlatMap(presetProfile), sources, SourceEnv); err != nil {
+		// The preset never sets a percentage max_tokens, so that key keeps the
+		// file layer that wrote it.
+		presetMap := profileToFlatMap(presetProfile)
+		delete(presetMap, "max_tokens_percent")
+		if err := loadLayer(k, presetMap, sources, SourceEnv); err != nil {
 			return nil, fmt.Errorf("loading target preset: %w", err)
 		}
 	}
 
-	// ── Layer 5: CLI flags ─────────────────────────────────────────────────
+	// ── Layer 6: CLI flags ─────────────────────────────────────────────────
 	if len(opts.CLIFlags) > 0 {
 		if err := loadLayer(k, opts.CLIFlags, sources, SourceFlag); err != nil {
 			return nil, fmt.Errorf("loading CLI flags: %w", err)
@@ -172,6 +216,16 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 
 	finalProfile := flatMapToProfile(k)
 
+	_, envMaxTokens := envMap["max_tokens"]
+	_, flagMaxTokens := opts.CLIFlags["max_tokens"]
+	if err := resolveMaxTokensPercent(finalProfile, sources, envMaxTokens || flagMaxTokens); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", profileName, err)
+	}
+
+	if err := checkBaseDir(finalProfile.BaseDir); err != nil {
+		return nil, fmt.Errorf("profile %q: %w", profileName, err)
+	}
+
 	slog.Debug("config resolved",
 		"profile", profileName,
 		"format", finalProfile.Format,
@@ -186,6 +240,20 @@ func Resolve(opts ResolveOptions) (*ResolvedConfig, error) {
 	}, nil
 }
 
+// discoverRepoConfigs returns the repo config files for targetDir, nearest
+// first: the whole chain when chain is true, otherwise at most the nearest
+// file.
+func discoverRepoConfigs(targetDir string, chain bool) ([]string, error) {
+	if chain {
+		return DiscoverRepoConfigChain(targetDir)
+	}
+	path, err := DiscoverRepoConfig(targetDir)
+	if err != nil || path == "" {
+		return nil, err
+	}
+	return []string{path}, nil
+}
+
 // loadFileLayer loads a named profile from a TOML config file, merges its
 // explicitly-set fields into k, and records source attribution. Missing files
 // and missing profiles are silently skipped (returns false, nil). Parse errors
@@ -224,19 +292,35 @@ func extractProfileFlat(path, profileName string) (map[string]any, error) {
 		return nil, fmt.Errorf("stat %s: %w", path, err)
 	}
 
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	flat, err := profileFlatFromTOML(string(data), path, profileName)
+	if err != nil || flat == nil {
+		return nil, err
+	}
+	if err := applyRelevanceFileFlat(flat, filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	return flat, nil
+}
+
+// profileFlatFromTOML parses the TOML document data, named name in errors
+// and logs, and returns the flat koanf-compatible map of the fields that
+// profileName sets explicitly. Returns nil when the document has no such
+// profile. A relevance_file setting is left for the caller to resolve.
+func profileFlatFromTOML(data, name, profileName string) (map[string]any, error) {
 	// Parse into a raw map so we only see keys present in the TOML file.
 	var raw map[string]interface{}
-	if _, err := toml.DecodeFile(path, &raw); err != nil {
-		return nil, fmt.Errorf("parse %s: %w", path, err)
+	if _, err := toml.Decode(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
 	}
 
 	profilesRaw, ok := raw["profile"].(map[string]interface{})
 	if !ok {
-		available := listConfigProfileNames(path)
-		slog.Debug("no [profile] section in config",
-			"path", path,
-			"available", strings.Join(available, ", "),
-		)
+		slog.Debug("no [profile] section in config", "path", name)
 		return nil, nil
 	}
 
@@ -249,49 +333,69 @@ func extractProfileFlat(path, profileName string) (map[string]any, error) {
 		sort.Strings(available)
 		slog.Debug("profile not found in config",
 			"profile", profileName,
-			"path", path,
+			"path", name,
 			"available", strings.Join(available, ", "),
 		)
 		return nil, nil
 	}
 
-	return flattenProfileRaw(profileRaw), nil
+	flat, err := flattenProfileRaw(profileRaw)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", profileName, err)
+	}
+	return flat, nil
 }
 
-// listConfigProfileNames returns profile names from a TOML file, for debug
-// logging. Returns nil on any error.
-func listConfigProfileNames(path string) []string {
-	var raw map[string]interface{}
-	if _, err := toml.DecodeFile(path, &raw); err != nil {
+// applyRelevanceFileFlat loads the relevance_file named in flat (if any) and
+// adds its tiers under "relevance.tier_N" keys that the profile did not set
+// inline, so the file's tiers are attributed to the same layer as the profile.
+func applyRelevanceFileFlat(flat map[string]any, baseDir string) error {
+	path, _ := flat["relevance_file"].(string)
+	if path == "" {
 		return nil
 	}
-	profiles, ok := raw["profile"].(map[string]interface{})
-	if !ok {
-		return nil
+
+	rel, err := LoadRelevanceFile(resolveRelevanceFilePath(path, baseDir))
+	if err != nil {
+		return fmt.Errorf("relevance_file: %w", err)
 	}
-	names := make([]string, 0, len(profiles))
-	for name := range profiles {
-		names = append(names, name)
+
+	tiers := []struct {
+		key      string
+		patterns []string
+	}{
+		{"relevance.tier_0", rel.Tier0},
+		{"relevance.tier_1", rel.Tier1},
+		{"relevance.tier_2", rel.Tier2},
+		{"relevance.tier_3", rel.Tier3},
+		{"relevance.tier_4", rel.Tier4},
+		{"relevance.tier_5", rel.Tier5},
+	}
+	for _, tier := range tiers {
+		if _, inline := flat[tier.key]; inline || len(tier.patterns) == 0 {
+			continue
+		}
+		flat[tier.key] = tier.patterns
 	}
-	sort.Strings(names)
-	return names
+	return nil
 }
 
 // flattenProfileRaw converts a raw TOML profile map (as decoded by
 // BurntSushi/toml into map[string]interface{}) into a flat koanf-compatible
-// map. Only fields explicitly present in the raw map are included.
-func flattenProfileRaw(raw map[string]interface{}) map[string]any {
+// map. Only fields explicitly present in the raw map are included. A
+// malformed percentage max_tokens is an error.
+func flattenProfileRaw(raw map[string]interface{}) (map[string]any, error) {
 	flat := make(map[string]any)
 
 	// Scalar string fields.
-	for _, key := range []string{"output", "format", "tokenizer", "target"} {
+	for _, key := range []string{"output", "format", "tokenizer", "target", "base_dir", "sort_order", "relevance_file"} {
 		if v, ok := raw[key]; ok {
 			flat[key] = v
 		}
 	}
 
 	// Integer fields: BurntSushi/toml decodes TOML integers as int64 in raw maps.
-	for _, intKey := range []string{"max_tokens", "brief_max_tokens", "slice_max_tokens", "slice_depth"} {
+	for _, intKey := range []string{"max_tokens", "brief_max_tokens", "max_file_tokens", "slice_max_tokens", "slice_depth"} {
 		if v, ok := raw[intKey]; ok {
 			switch n := v.(type) {
 			case int64:
@@ -304,15 +408,34 @@ func flattenProfileRaw(raw map[string]interface{}) map[string]any {
 		}
 	}
 
+	// max_tokens may be a percentage of the target's context window ("80%")
+	// or a count with a suffix ("128k"). A percentage is kept under
+	// max_tokens_percent and converted once the final target is known; an
+	// absolute value clears any lower-layer percentage.
+	if s, ok := raw["max_tokens"].(string); ok {
+		tokens, pct, err := parseMaxTokensString(s)
+		if err != nil {
+			return nil, err
+		}
+		if pct != 0 {
+			delete(flat, "max_tokens")
+		} else {
+			flat["max_tokens"] = tokens
+		}
+		flat["max_tokens_percent"] = pct
+	} else if _, ok := raw["max_tokens"]; ok {
+		flat["max_tokens_percent"] = 0
+	}
+
 	// Boolean fields.
-	for _, key := range []string{"compression", "redaction"} {
+	for _, key := range []string{"compression", "redaction", "include_only", "priority_globs", "stub_vendored", "exclude_binary", "case_insensitive", "skip_empty"} {
 		if v, ok := raw[key]; ok {
 			flat[key] = v
 		}
 	}
 
 	// Slice fields.
-	for _, key := range []string{"ignore", "priority_files", "include", "assert_include"} {
+	for _, key := range []string{"ignore", "priority_files", "include", "assert_include", "vendor_dirs"} {
 		if v, ok := raw[key]; ok {
 			flat[key] = rawToStringSlice(v)
 		}
@@ -325,6 +448,22 @@ func flattenProfileRaw(raw map[string]interface{}) map[string]any {
 				flat["relevance."+tier] = rawToStringSlice(v)
 			}
 		}
+		if labels, ok := relRaw["labels"].(map[string]interface{}); ok {
+			for tier, v := range labels {
+				flat["relevance.labels."+tier] = v
+			}
+		}
+	}
+
+	// Nested: tier_file_caps and tier_weights, keyed by tier number.
+	for _, table := range []string{"tier_file_caps", "tier_weights"} {
+		if tableRaw, ok := raw[table].(map[string]interface{}); ok {
+			for key, v := range tableRaw {
+				if tier, err := ParseTierKey(key); err == nil {
+					flat[table+"."+strconv.Itoa(tier)] = v
+				}
+			}
+		}
 	}
 
 	// Nested: redaction_config.
@@ -338,9 +477,95 @@ func flattenProfileRaw(raw map[string]interface{}) map[string]any {
 		if v, ok := rcRaw["confidence_threshold"]; ok {
 			flat["redaction_config.confidence_threshold"] = v
 		}
+		if v, ok := rcRaw["mode"]; ok {
+			flat["redaction_config.mode"] = v
+		}
+		if v, ok := rcRaw["override_sensitive_defaults"]; ok {
+			flat["redaction_config.override_sensitive_defaults"] = v
+		}
+		if v, ok := rcRaw["sensitive_patterns"]; ok {
+			flat["redaction_config.sensitive_patterns"] = rawToStringSlice(v)
+		}
+		if v, ok := rcRaw["custom_patterns"]; ok {
+			flat["redaction_config.custom_patterns"] = rawToCustomPatterns(v)
+		}
 	}
 
-	return flat
+	// Nested: render.
+	if rRaw, ok := raw["render"].(map[string]interface{}); ok {
+		for _, key := range []string{"include_toc", "line_numbers"} {
+			if v, ok := rRaw[key]; ok {
+				flat["render."+key] = v
+			}
+		}
+	}
+
+	// Array of tables: overrides. The list is kept whole under one key so a
+	// later layer replaces it rather than merging entries.
+	if v, ok := raw["overrides"]; ok {
+		flat["overrides"] = rawToPathOverrides(v)
+	}
+
+	return flat, nil
+}
+
+// rawTables returns the tables of a raw TOML array of tables. Entries that
+// are not tables are skipped.
+func rawTables(v interface{}) []map[string]interface{} {
+	switch s := v.(type) {
+	case []map[string]interface{}:
+		return s
+	case []interface{}:
+		var tables []map[string]interface{}
+		for _, item := range s {
+			if t, ok := item.(map[string]interface{}); ok {
+				tables = append(tables, t)
+			}
+		}
+		return tables
+	default:
+		return nil
+	}
+}
+
+// rawToPathOverrides converts a raw TOML array of tables into []PathOverride.
+func rawToPathOverrides(v interface{}) []PathOverride {
+	tables := rawTables(v)
+	overrides := make([]PathOverride, 0, len(tables))
+	for _, t := range tables {
+		var ov PathOverride
+		ov.Match, _ = t["match"].(string)
+		if tier, ok := t["tier"].(int64); ok {
+			n := int(tier)
+			ov.Tier = &n
+		}
+		if redact, ok := t["redact"].(bool); ok {
+			ov.Redact = &redact
+		}
+		ov.Priority, _ = t["priority"].(bool)
+		overrides = append(overrides, ov)
+	}
+	return overrides
+}
+
+// rawToCustomPatterns converts a raw TOML array of tables into
+// []CustomPatternDefinition.
+func rawToCustomPatterns(v interface{}) []CustomPatternDefinition {
+	tables := rawTables(v)
+	patterns := make([]CustomPatternDefinition, 0, len(tables))
+	for _, t := range tables {
+		var cp CustomPatternDefinition
+		cp.ID, _ = t["id"].(string)
+		cp.Description, _ = t["description"].(string)
+		cp.Regex, _ = t["regex"].(string)
+		cp.SecretType, _ = t["secret_type"].(string)
+		cp.Confidence, _ = t["confidence"].(string)
+		if kw, ok := t["keywords"]; ok {
+			cp.Keywords = rawToStringSlice(kw)
+		}
+		patterns = append(patterns, cp)
+	}
+	return patterns
 }
 
 // rawToStringSlice converts a raw TOML array value ([]interface{}) into
@@ -378,25 +603,38 @@ func loadLayer(k *koanf.Koanf, m map[string]any, sources SourceMap, src Source)
 
 // profileToFlatMap converts a Profile to a flat map for koanf's confmap
 // provider. All fields are included (used for the defaults layer where every
-// field has an authoritative default value).
+// field has an authoritative default value), plus one key per tier label.
 func profileToFlatMap(p *Profile) map[string]any {
-	return map[string]any{
-		"output":      p.Output,
-		"format":      p.Format,
-		"max_tokens":       p.MaxTokens,
-		"brief_max_tokens": p.BriefMaxTokens,
-		"slice_max_tokens": p.SliceMaxTokens,
-		"slice_depth":      p.SliceDepth,
-		"tokenizer":        p.Tokenizer,
-		"compression": p.Compression,
-		"redaction":   p.Redaction,
-		"target":      p.Target,
+	flat := map[string]any{
+		"output":             p.Output,
+		"format":             p.Format,
+		"max_tokens":         p.MaxTokens,
+		"max_tokens_percent": p.MaxTokensPercent,
+		"brief_max_tokens":   p.BriefMaxTokens,
+		"max_file_tokens":    p.MaxFileTokens,
+		"slice_max_tokens":   p.SliceMaxTokens,
+		"slice_depth":        p.SliceDepth,
+		"tokenizer":          p.Tokenizer,
+		"compression":        p.Compression,
+		"redaction":          p.Redaction,
+		"target":             p.Target,
+		"base_dir":           p.BaseDir,
+		"sort_order":         p.SortOrder,
 
 		"ignore":         p.Ignore,
 		"priority_files": p.PriorityFiles,
 		"include":        p.Include,
+		"include_only":   p.IncludeOnly,
+		"priority_globs": p.PriorityGlobs,
 		"assert_include": p.AssertInclude,
+		"stub_vendored":  p.StubVendored,
+		"vendor_dirs":    p.VendorDirs,
+		"exclude_binary": p.ExcludeBinary,
+
+		"case_insensitive": p.CaseInsensitive,
+		"skip_empty":       p.SkipEmpty,
 
+		"relevance_file":   p.RelevanceFile,
 		"relevance.tier_0": p.Relevance.Tier0,
 		"relevance.tier_1": p.Relevance.Tier1,
 		"relevance.tier_2": p.Relevance.Tier2,
@@ -407,28 +645,115 @@ func profileToFlatMap(p *Profile) map[string]any {
 		"redaction_config.enabled":              p.RedactionConfig.Enabled,
 		"redaction_config.exclude_paths":        p.RedactionConfig.ExcludePaths,
 		"redaction_config.confidence_threshold": p.RedactionConfig.ConfidenceThreshold,
+		"redaction_config.mode":                 p.RedactionConfig.Mode,
+
+		"redaction_config.override_sensitive_defaults": p.RedactionConfig.OverrideSensitiveDefaults,
+		"redaction_config.sensitive_patterns":          p.RedactionConfig.SensitivePatterns,
+		"redaction_config.custom_patterns":             p.RedactionConfig.CustomPatterns,
+
+		"overrides": p.Overrides,
+	}
+	for tier, label := range p.Relevance.Labels {
+		flat["relevance.labels."+tier] = label
 	}
+	for tier, n := range p.TierFileCaps {
+		flat["tier_file_caps."+strconv.Itoa(tier)] = n
+	}
+	for tier, w := range p.TierWeights {
+		flat["tier_weights."+strconv.Itoa(tier)] = w
+	}
+	if p.Render.IncludeTOC != nil {
+		flat["render.include_toc"] = *p.Render.IncludeTOC
+	}
+	if p.Render.LineNumbers != nil {
+		flat["render.line_numbers"] = *p.Render.LineNumbers
+	}
+	return flat
+}
+
+// koanfBoolPtr returns the bool at key in k, or nil when no layer set it.
+func koanfBoolPtr(k *koanf.Koanf, key string) *bool {
+	if !k.Exists(key) {
+		return nil
+	}
+	v := k.Bool(key)
+	return &v
+}
+
+// koanfLabels returns the tier labels merged into k, or nil when there are
+// none.
+func koanfLabels(k *koanf.Koanf) map[string]string {
+	labels := k.StringMap("relevance.labels")
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// koanfTierValues returns the tier-keyed integer table at key (such as
+// tier_file_caps) merged into k, or nil when it has no entries.
+func koanfTierValues(k *koanf.Koanf, key string) map[int]int {
+	raw := k.IntMap(key)
+	if len(raw) == 0 {
+		return nil
+	}
+	values := make(map[int]int, len(raw))
+	for tierKey, n := range raw {
+		if tier, err := ParseTierKey(tierKey); err == nil {
+			values[tier] = n
+		}
+	}
+	return values
+}
+
+// koanfOverrides returns the path overrides merged into k, or nil when no
+// layer set them.
+func koanfOverrides(k *koanf.Koanf) []PathOverride {
+	overrides, _ := k.Get("overrides").([]PathOverride)
+	return overrides
+}
+
+// koanfCustomPatterns returns the custom redaction patterns merged into k, or
+// nil when no layer set them.
+func koanfCustomPatterns(k *koanf.Koanf) []CustomPatternDefinition {
+	patterns, _ := k.Get("redaction_config.custom_patterns").([]CustomPatternDefinition)
+	return patterns
 }
 
 // flatMapToProfile converts the current koanf state into a Profile struct.
 func flatMapToProfile(k *koanf.Koanf) *Profile {
 	return &Profile{
-		Output:         k.String("output"),
-		Format:         k.String("format"),
-		MaxTokens:      k.Int("max_tokens"),
-		BriefMaxTokens: k.Int("brief_max_tokens"),
-		SliceMaxTokens: k.Int("slice_max_tokens"),
-		SliceDepth:     k.Int("slice_depth"),
-		Tokenizer:      k.String("tokenizer"),
-		Compression: k.Bool("compression"),
-		Redaction:   k.Bool("redaction"),
-		Target:      k.String("target"),
+		Output:           k.String("output"),
+		Format:           k.String("format"),
+		MaxTokens:        k.Int("max_tokens"),
+		MaxTokensPercent: k.Int("max_tokens_percent"),
+		BriefMaxTokens:   k.Int("brief_max_tokens"),
+		MaxFileTokens:    k.Int("max_file_tokens"),
+		SliceMaxTokens:   k.Int("slice_max_tokens"),
+		SliceDepth:       k.Int("slice_depth"),
+		Tokenizer:        k.String("tokenizer"),
+		Compression:      k.Bool("compression"),
+		Redaction:        k.Bool("redaction"),
+		Target:           k.String("target"),
+		BaseDir:          k.String("base_dir"),
+		SortOrder:        k.String("sort_order"),
 
 		Ignore:        k.Strings("ignore"),
 		PriorityFiles: k.Strings("priority_files"),
 		Include:       k.Strings("include"),
+		IncludeOnly:   k.Bool("include_only"),
+		PriorityGlobs: k.Bool("priority_globs"),
 		AssertInclude: k.Strings("assert_include"),
+		StubVendored:  k.Bool("stub_vendored"),
+		VendorDirs:    k.Strings("vendor_dirs"),
+		ExcludeBinary: k.Bool("exclude_binary"),
+
+		CaseInsensitive: k.Bool("case_insensitive"),
+		SkipEmpty:       k.Bool("skip_empty"),
+		TierFileCaps:    koanfTierValues(k, "tier_file_caps"),
+		TierWeights:     koanfTierValues(k, "tier_weights"),
 
+		RelevanceFile: k.String("relevance_file"),
 		Relevance: RelevanceConfig{
 			Tier0: k.Strings("relevance.tier_0"),
 			Tier1: k.Strings("relevance.tier_1"),
@@ -436,12 +761,26 @@ func flatMapToProfile(k *koanf.Koanf) *Profile {
 			Tier3: k.Strings("relevance.tier_3"),
 			Tier4: k.Strings("relevance.tier_4"),
 			Tier5: k.Strings("relevance.tier_5"),
+
+			Labels: koanfLabels(k),
 		},
 
 		RedactionConfig: RedactionConfig{
 			Enabled:             k.Bool("redaction_config.enabled"),
 			ExcludePaths:        k.Strings("redaction_config.exclude_paths"),
 			ConfidenceThreshold: k.String("redaction_config.confidence_threshold"),
+			Mode:                k.String("redaction_config.mode"),
+
+			OverrideSensitiveDefaults: k.Bool("redaction_config.override_sensitive_defaults"),
+			SensitivePatterns:         k.Strings("redaction_config.sensitive_patterns"),
+			CustomPatterns:            koanfCustomPatterns(k),
 		},
+
+		Render: RenderConfig{
+			IncludeTOC:  koanfBoolPtr(k, "render.include_toc"),
+			LineNumbers: koanfBoolPtr(k, "render.line_numbers"),
+		},
+
+		Overrides: koanfOverrides(k),
 	}
 }
@@ -237,6 +237,29 @@ max_tokens = 50000
 	assert.Equal(t, SourceEnv, rc.Sources["max_tokens"])
 }
 
+// TestResolve_EnvIgnore_MergesPatterns verifies that HARVX_IGNORE extends the
+// ignore list from lower layers instead of replacing it, and that the merged
+// list is attributed to the env layer.
+func TestResolve_EnvIgnore_MergesPatterns(t *testing.T) {
+	clearHarvxEnv(t)
+	t.Setenv(EnvIgnore, "*.log,tmp/**")
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+ignore = ["vendor/", "*.log"]
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"vendor/", "*.log", "tmp/**"}, rc.Profile.Ignore)
+	assert.Equal(t, SourceEnv, rc.Sources["ignore"])
+}
+
 // TestResolve_EnvProfile_SelectsNamedProfile verifies that HARVX_PROFILE
 // selects a non-default profile from the config file.
 func TestResolve_EnvProfile_SelectsNamedProfile(t *testing.T) {
@@ -325,6 +348,58 @@ max_tokens = 200000
 	assert.Equal(t, SourceFlag, rc.Sources["max_tokens"])
 }
 
+// TestResolve_NoRedactionNoCompressionFlags verifies that the overrides from
+// --no-redaction and --no-compression switch both features off even when the
+// repo profile and HARVX_REDACT/HARVX_COMPRESS enable them.
+func TestResolve_NoRedactionNoCompressionFlags(t *testing.T) {
+	clearHarvxEnv(t)
+	t.Setenv(EnvRedact, "true")
+	t.Setenv(EnvCompress, "true")
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+redaction = true
+compression = true
+`)
+
+	fv := &FlagValues{NoRedaction: true, NoCompression: true}
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "nonexistent.toml"),
+		CLIFlags:         fv.ProfileOverrides(),
+	})
+
+	require.NoError(t, err)
+	assert.False(t, rc.Profile.Redaction)
+	assert.False(t, rc.Profile.Compression)
+	assert.Equal(t, SourceFlag, rc.Sources["redaction"])
+	assert.Equal(t, SourceFlag, rc.Sources["compression"])
+}
+
+// TestResolve_CompressEnvBelowFlags verifies that without the override flags
+// HARVX_COMPRESS still wins over the repo profile.
+func TestResolve_CompressEnvBelowFlags(t *testing.T) {
+	clearHarvxEnv(t)
+	t.Setenv(EnvCompress, "false")
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+compression = true
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "nonexistent.toml"),
+		CLIFlags:         (&FlagValues{}).ProfileOverrides(),
+	})
+
+	require.NoError(t, err)
+	assert.False(t, rc.Profile.Compression)
+	assert.Equal(t, SourceEnv, rc.Sources["compression"])
+}
+
 // ── Target presets ────────────────────────────────────────────────────────────
 
 // TestResolve_TargetPreset_AppliedBeforeCLIFlags verifies that when a target
@@ -710,3 +785,369 @@ format = "markdown"
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "ghost")
 }
+
+// TestResolve_IncludeOnly_RepoConfig verifies that include_only set in the
+// repo config is loaded alongside include and attributed to the repo layer.
+func TestResolve_IncludeOnly_RepoConfig(t *testing.T) {
+	clearHarvxEnv(t)
+
+	dir := t.TempDir()
+	writeTomlFile(t, dir, "harvx.toml", `
+[profile.default]
+include = ["internal/**/*.go"]
+include_only = true
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "nofile.toml"),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, rc.Profile.IncludeOnly)
+	assert.Equal(t, []string{"internal/**/*.go"}, rc.Profile.Include)
+	assert.Equal(t, SourceRepo, rc.Sources["include_only"])
+}
+
+// TestResolve_BaseDir_RepoConfig verifies that base_dir is read from the repo
+// config and attributed to it.
+func TestResolve_BaseDir_RepoConfig(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+base_dir = "packages/api"
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "packages/api", rc.Profile.BaseDir)
+	assert.Equal(t, SourceRepo, rc.Sources["base_dir"])
+}
+
+// TestResolve_BaseDir_Invalid verifies that an absolute or escaping base_dir
+// makes Resolve fail.
+func TestResolve_BaseDir_Invalid(t *testing.T) {
+	for _, dir := range []string{"/srv/app", "../outside"} {
+		t.Run(dir, func(t *testing.T) {
+			clearHarvxEnv(t)
+
+			repoDir := t.TempDir()
+			writeTomlFile(t, repoDir, "harvx.toml", "[profile.default]\nbase_dir = \""+dir+"\"\n")
+
+			_, err := Resolve(ResolveOptions{
+				TargetDir:        repoDir,
+				GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+			})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "base_dir")
+		})
+	}
+}
+
+// TestResolve_MaxTokensPercent_Claude verifies that a percentage max_tokens
+// is derived from the claude context window and attributed to its layer.
+func TestResolve_MaxTokensPercent_Claude(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+target = "claude"
+max_tokens = "80%"
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 160000, rc.Profile.MaxTokens)
+	assert.Equal(t, 80, rc.Profile.MaxTokensPercent)
+	assert.Equal(t, SourceRepo, rc.Sources["max_tokens"])
+}
+
+// TestResolve_MaxTokensSuffix verifies that a suffixed max_tokens in the repo
+// config and in HARVX_MAX_TOKENS resolves to an absolute token count.
+func TestResolve_MaxTokensSuffix(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+max_tokens = "96k"
+`)
+	opts := ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	}
+
+	rc, err := Resolve(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 96000, rc.Profile.MaxTokens)
+	assert.Zero(t, rc.Profile.MaxTokensPercent)
+	assert.Equal(t, SourceRepo, rc.Sources["max_tokens"])
+
+	t.Setenv(EnvMaxTokens, "1M")
+	rc, err = Resolve(opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1000000, rc.Profile.MaxTokens)
+	assert.Equal(t, SourceEnv, rc.Sources["max_tokens"])
+}
+
+// TestResolve_RelevanceLabels verifies that tier labels are read from the
+// relevance.labels table and merged per tier across the global and repo
+// layers.
+func TestResolve_RelevanceLabels(t *testing.T) {
+	clearHarvxEnv(t)
+
+	globalDir := t.TempDir()
+	globalPath := writeTomlFile(t, globalDir, "config.toml", `
+[profile.default.relevance.labels]
+tier_0 = "Schemas"
+tier_1 = "Services"
+`)
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default.relevance]
+tier_1 = ["handlers/**"]
+
+[profile.default.relevance.labels]
+tier_1 = "Handlers"
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: globalPath,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"tier_0": "Schemas", "tier_1": "Handlers"}, rc.Profile.Relevance.Labels)
+	assert.Equal(t, []string{"handlers/**"}, rc.Profile.Relevance.Tier1)
+}
+
+// TestResolve_MaxTokensPercent_CLIFlagWins verifies that an absolute
+// --max-tokens flag replaces a percentage from the config file.
+func TestResolve_MaxTokensPercent_CLIFlagWins(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+target = "claude"
+max_tokens = "80%"
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+		CLIFlags:         map[string]any{"max_tokens": 50000},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 50000, rc.Profile.MaxTokens)
+	assert.Zero(t, rc.Profile.MaxTokensPercent)
+}
+
+// TestResolve_MaxTokensPercent_OutOfRange verifies that a percentage outside
+// 1-100 makes Resolve fail.
+func TestResolve_MaxTokensPercent_OutOfRange(t *testing.T) {
+	for _, pct := range []string{"150%", "0%"} {
+		t.Run(pct, func(t *testing.T) {
+			clearHarvxEnv(t)
+
+			repoDir := t.TempDir()
+			writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+max_tokens = "`+pct+`"
+`)
+
+			_, err := Resolve(ResolveOptions{
+				TargetDir:        repoDir,
+				GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+			})
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "between 1 and 100")
+		})
+	}
+}
+
+func TestResolve_RenderConfig(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default.render]
+include_toc = true
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, rc.Profile.Render.IncludeTOC)
+	assert.True(t, *rc.Profile.Render.IncludeTOC)
+	assert.Nil(t, rc.Profile.Render.LineNumbers, "unset render options stay unset")
+	assert.Equal(t, SourceRepo, rc.Sources["render.include_toc"])
+}
+
+func TestResolve_CaseInsensitive(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+case_insensitive = true
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.True(t, rc.Profile.CaseInsensitive)
+	assert.Equal(t, SourceRepo, rc.Sources["case_insensitive"])
+}
+
+func TestResolve_SkipEmpty(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+max_tokens = 5000
+
+[profile.keep]
+extends = "default"
+skip_empty = false
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.True(t, rc.Profile.SkipEmpty, "skip_empty defaults to true")
+
+	rc, err = Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		ProfileName:      "keep",
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.False(t, rc.Profile.SkipEmpty)
+	assert.Equal(t, SourceRepo, rc.Sources["skip_empty"])
+}
+
+func TestResolve_ExcludeBinary(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+max_tokens = 5000
+
+[profile.text]
+extends = "default"
+exclude_binary = true
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.False(t, rc.Profile.ExcludeBinary, "exclude_binary defaults to false")
+
+	rc, err = Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		ProfileName:      "text",
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.True(t, rc.Profile.ExcludeBinary)
+	assert.Equal(t, SourceRepo, rc.Sources["exclude_binary"])
+}
+
+func TestResolve_MaxFileTokens(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+max_file_tokens = 2000
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2000, rc.Profile.MaxFileTokens)
+	assert.Equal(t, SourceRepo, rc.Sources["max_file_tokens"])
+
+	rc, err = Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+		CLIFlags:         map[string]any{"max_file_tokens": 500},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 500, rc.Profile.MaxFileTokens)
+	assert.Equal(t, SourceFlag, rc.Sources["max_file_tokens"])
+}
+
+// TestResolve_Overrides verifies that path overrides from a config file
+// reach the resolved profile, and that a closer layer replaces the list
+// instead of merging entries.
+func TestResolve_Overrides(t *testing.T) {
+	clearHarvxEnv(t)
+
+	globalDir := t.TempDir()
+	globalPath := writeTomlFile(t, globalDir, "config.toml", `
+[[profile.default.overrides]]
+match = "docs/**"
+tier = 5
+`)
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[[profile.default.overrides]]
+match = "vendor/**"
+tier = 4
+redact = false
+
+[[profile.default.overrides]]
+match = "internal/auth/**"
+priority = true
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: globalPath,
+	})
+	require.NoError(t, err)
+
+	tier := 4
+	redact := false
+	assert.Equal(t, []PathOverride{
+		{Match: "vendor/**", Tier: &tier, Redact: &redact},
+		{Match: "internal/auth/**", Priority: true},
+	}, rc.Profile.Overrides)
+	assert.Equal(t, SourceRepo, rc.Sources["overrides"])
+
+	rc, err = Resolve(ResolveOptions{
+		TargetDir:        t.TempDir(),
+		GlobalConfigPath: globalPath,
+	})
+	require.NoError(t, err)
+	require.Len(t, rc.Profile.Overrides, 1)
+	assert.Equal(t, "docs/**", rc.Profile.Overrides[0].Match)
+	assert.Equal(t, SourceGlobal, rc.Sources["overrides"])
+}
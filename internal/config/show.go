@@ -52,6 +52,12 @@ func ShowProfile(opts ShowOptions) string {
 	if p.Target != "" {
 		writeStringField(&b, "target", p.Target, sourceLabel(src, "target"))
 	}
+	if p.BaseDir != "" {
+		writeStringField(&b, "base_dir", p.BaseDir, sourceLabel(src, "base_dir"))
+	}
+	if p.SortOrder != "" {
+		writeStringField(&b, "sort_order", p.SortOrder, sourceLabel(src, "sort_order"))
+	}
 
 	// Slice fields.
 	writeStringSliceField(&b, "ignore", p.Ignore, sourceLabel(src, "ignore"))
@@ -164,6 +170,9 @@ func writeRedactionConfigSection(b *strings.Builder, rc RedactionConfig, src Sou
 	fmt.Fprintf(b, "[redaction_config]\n")
 	writeBoolSectionField(b, "enabled", rc.Enabled, sourceLabel(src, "redaction_config.enabled"))
 	writeStringSectionField(b, "confidence_threshold", rc.ConfidenceThreshold, sourceLabel(src, "redaction_config.confidence_threshold"))
+	if rc.Mode != "" {
+		writeStringSectionField(b, "mode", rc.Mode, sourceLabel(src, "redaction_config.mode"))
+	}
 	if len(rc.ExcludePaths) > 0 {
 		writeArraySectionField(b, "exclude_paths", rc.ExcludePaths, sourceLabel(src, "redaction_config.exclude_paths"))
 	}
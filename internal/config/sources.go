@@ -7,6 +7,9 @@ type Source int
 const (
 	// SourceDefault is the built-in fallback (lowest precedence).
 	SourceDefault Source = iota
+	// SourceRemote is the organisation base config fetched from
+	// ResolveOptions.BaseConfigURL.
+	SourceRemote
 	// SourceGlobal is the user's global config (~/.config/harvx/config.toml).
 	SourceGlobal
 	// SourceRepo is the project-local harvx.toml in the target directory.
@@ -22,6 +25,8 @@ func (s Source) String() string {
 	switch s {
 	case SourceDefault:
 		return "default"
+	case SourceRemote:
+		return "remote"
 	case SourceGlobal:
 		return "global"
 	case SourceRepo:
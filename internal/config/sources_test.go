@@ -15,6 +15,7 @@ func TestSource_String(t *testing.T) {
 		want   string
 	}{
 		{SourceDefault, "default"},
+		{SourceRemote, "remote"},
 		{SourceGlobal, "global"},
 		{SourceRepo, "repo"},
 		{SourceEnv, "env"},
@@ -31,11 +32,12 @@ func TestSource_String(t *testing.T) {
 }
 
 // TestSource_Precedence verifies the Source iota ordering matches the intended
-// precedence: Default < Global < Repo < Env < Flag.
+// precedence: Default < Remote < Global < Repo < Env < Flag.
 func TestSource_Precedence(t *testing.T) {
 	t.Parallel()
 
-	assert.Less(t, int(SourceDefault), int(SourceGlobal))
+	assert.Less(t, int(SourceDefault), int(SourceRemote))
+	assert.Less(t, int(SourceRemote), int(SourceGlobal))
 	assert.Less(t, int(SourceGlobal), int(SourceRepo))
 	assert.Less(t, int(SourceRepo), int(SourceEnv))
 	assert.Less(t, int(SourceEnv), int(SourceFlag))
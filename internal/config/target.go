@@ -2,10 +2,30 @@ package config
 
 import "fmt"
 
+// targetContextWindows maps each target to the context window, in tokens, of
+// the models it addresses. The claude and chatgpt presets use these values as
+// their default max_tokens.
+var targetContextWindows = map[string]int{
+	"claude":  200000,
+	"chatgpt": 128000,
+	"generic": 128000,
+}
+
+// TargetContextWindow returns the context window size in tokens for target.
+// An empty or unknown target falls back to the generic window.
+func TargetContextWindow(target string) int {
+	if n, ok := targetContextWindows[target]; ok {
+		return n
+	}
+	return targetContextWindows["generic"]
+}
+
 // ApplyTargetPreset modifies p in-place based on the target name.
 // Valid targets: "claude", "chatgpt", "generic".
 // An empty target string is a no-op and returns nil.
 // Unknown target names return an error.
+// A percentage max_tokens (MaxTokensPercent) is left for resolution to
+// derive from the target's context window rather than being overwritten.
 func ApplyTargetPreset(p *Profile, target string) error {
 	if target == "" {
 		return nil
@@ -14,10 +34,14 @@ func ApplyTargetPreset(p *Profile, target string) error {
 	switch target {
 	case "claude":
 		p.Format = "xml"
-		p.MaxTokens = 200000
+		if p.MaxTokensPercent == 0 {
+			p.MaxTokens = targetContextWindows["claude"]
+		}
 	case "chatgpt":
 		p.Format = "markdown"
-		p.MaxTokens = 128000
+		if p.MaxTokensPercent == 0 {
+			p.MaxTokens = targetContextWindows["chatgpt"]
+		}
 	case "generic":
 		p.Format = "markdown"
 	default:
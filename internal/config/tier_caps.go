@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TierFileCaps maps a relevance tier number to the maximum number of files
+// kept in that tier. See Profile.TierFileCaps.
+type TierFileCaps map[int]int
+
+// UnmarshalTOML decodes a tier_file_caps table. TOML keys are always
+// strings, so each key is parsed with ParseTierKey.
+func (c *TierFileCaps) UnmarshalTOML(data any) error {
+	caps, err := decodeTierTable("tier_file_caps", "cap", data)
+	if err != nil {
+		return err
+	}
+	*c = caps
+	return nil
+}
+
+// TierWeights maps a relevance tier number to its weight in budget
+// competition. See Profile.TierWeights.
+type TierWeights map[int]int
+
+// UnmarshalTOML decodes a tier_weights table, keyed like tier_file_caps.
+func (w *TierWeights) UnmarshalTOML(data any) error {
+	weights, err := decodeTierTable("tier_weights", "weight", data)
+	if err != nil {
+		return err
+	}
+	*w = weights
+	return nil
+}
+
+// decodeTierTable decodes a TOML table of integers keyed by tier (see
+// ParseTierKey). table and what name the table and its values in errors.
+func decodeTierTable(table, what string, data any) (map[int]int, error) {
+	raw, ok := data.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected a table, got %T", table, data)
+	}
+
+	values := make(map[int]int, len(raw))
+	for key, v := range raw {
+		tier, err := ParseTierKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", table, err)
+		}
+		n, ok := v.(int64)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s for %q must be an integer, got %T", table, what, key, v)
+		}
+		values[tier] = int(n)
+	}
+	return values, nil
+}
+
+// ParseTierKey parses a tier key of the form "3" or "tier_3" into its tier
+// number. The number is not range-checked; validation reports tiers outside
+// 0-5.
+func ParseTierKey(key string) (int, error) {
+	tier, err := strconv.Atoi(strings.TrimPrefix(key, "tier_"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid tier key %q (want a tier number such as \"3\" or \"tier_3\")", key)
+	}
+	return tier, nil
+}
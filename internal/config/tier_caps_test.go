@@ -0,0 +1,138 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTierFileCaps_Decode(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := LoadFromString(`
+[profile.p.tier_file_caps]
+3 = 50
+tier_4 = 10
+`, "caps.toml")
+	require.NoError(t, err)
+	assert.Equal(t, TierFileCaps{3: 50, 4: 10}, cfg.Profile["p"].TierFileCaps)
+}
+
+func TestTierFileCaps_DecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := LoadFromString(`
+[profile.p.tier_file_caps]
+tests = 5
+`, "caps.toml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid tier key "tests"`)
+
+	_, err = LoadFromString(`
+[profile.p.tier_file_caps]
+3 = "five"
+`, "caps.toml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an integer")
+}
+
+func TestTierFileCaps_EncodeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {TierFileCaps: TierFileCaps{3: 2, 5: 1}},
+		},
+	}
+
+	data, err := EncodeConfig(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[profile.p.tier_file_caps]")
+
+	decoded, err := LoadFromString(string(data), "caps.toml")
+	require.NoError(t, err)
+	assert.Equal(t, cfg, decoded)
+}
+
+func TestResolve_TierFileCapsMergePerTier(t *testing.T) {
+	clearHarvxEnv(t)
+
+	globalDir := t.TempDir()
+	writeTomlFile(t, globalDir, "config.toml", `
+[profile.default.tier_file_caps]
+3 = 50
+5 = 10
+`)
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default.tier_file_caps]
+tier_3 = 2
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(globalDir, "config.toml"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TierFileCaps{3: 2, 5: 10}, rc.Profile.TierFileCaps)
+	assert.Equal(t, SourceRepo, rc.Sources["tier_file_caps.3"])
+	assert.Equal(t, SourceGlobal, rc.Sources["tier_file_caps.5"])
+}
+
+func TestValidate_TierFileCaps(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {TierFileCaps: TierFileCaps{3: 2, 7: 1, 4: -1}},
+		},
+	}
+
+	errs := errorsWithSeverity(Validate(cfg), "error")
+	assert.Empty(t, errorsWithField(errs, "profile.p.tier_file_caps.3"))
+	assert.NotEmpty(t, errorsWithField(errs, "profile.p.tier_file_caps.7"))
+	assert.NotEmpty(t, errorsWithField(errs, "profile.p.tier_file_caps.4"))
+}
+
+func TestTierWeights_DecodeAndResolve(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default.tier_weights]
+tier_4 = 10
+2 = 1
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(t.TempDir(), "config.toml"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TierWeights{4: 10, 2: 1}, rc.Profile.TierWeights)
+	assert.Equal(t, SourceRepo, rc.Sources["tier_weights.4"])
+}
+
+func TestValidate_TierWeights(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				TierWeights: TierWeights{4: 10, 3: 5, 9: 1},
+				Relevance:   RelevanceConfig{Tier0: []string{"go.mod"}, Tier3: []string{}},
+			},
+		},
+	}
+
+	results := Validate(cfg)
+	errs := errorsWithSeverity(results, "error")
+	assert.Empty(t, errorsWithField(errs, "profile.p.tier_weights.4"))
+	assert.NotEmpty(t, errorsWithField(errs, "profile.p.tier_weights.9"))
+
+	warnings := errorsWithSeverity(results, "warning")
+	assert.Empty(t, errorsWithField(warnings, "profile.p.tier_weights.4"), "unset tiers inherit default patterns")
+	assert.NotEmpty(t, errorsWithField(warnings, "profile.p.tier_weights.3"))
+}
@@ -7,6 +7,12 @@ type Config struct {
 	// Profile maps profile names to their configuration. Access via
 	// cfg.Profile["default"] or cfg.Profile["finvault"].
 	Profile map[string]*Profile `toml:"profile"`
+
+	// Root stops the directory cascade at this file, like editorconfig's
+	// root = true. It is only meaningful in a directory-local harvx.toml
+	// below the target directory: settings from configs in ancestor
+	// directories are not inherited past it. See Cascade.
+	Root bool `toml:"root"`
 }
 
 // Profile defines all settings for a single named profile. Fields with zero
@@ -15,24 +21,48 @@ type Config struct {
 type Profile struct {
 	// Extends is the name of a parent profile to inherit from. When set,
 	// all unset fields in this profile are filled from the named parent.
-	// A nil pointer means no inheritance.
+	// A nil pointer means no inheritance. The form "file#profile" names a
+	// profile in another config file (see ResolveProfile).
 	Extends *string `toml:"extends"`
 
+	// configDir is the directory of the config file the profile was loaded
+	// from, used to resolve a relative "file#profile" Extends. Empty means
+	// the working directory.
+	configDir string
+
+	// boolKeys holds the dotted boolean keys the source document set under
+	// [profile.<name>] (e.g. "redaction" or "redaction_config.enabled"), so
+	// that EncodeConfig can write explicit false values back out.
+	boolKeys map[string]bool
+
 	// Output is the file path for the generated context document.
-	// Example: "harvx-output.md" or ".harvx/finvault-context.md"
+	// Example: "harvx-output.md" or ".harvx/finvault-context.md". The
+	// value "-" writes the document to stdout instead of a file.
 	Output string `toml:"output"`
 
-	// Format controls the output format. Valid values: "markdown", "xml", "plain".
+	// Format controls the output format. Valid values: "markdown", "xml", "plain", "json".
 	Format string `toml:"format"`
 
 	// MaxTokens is the token budget cap for the generated output.
 	// Files are pruned from the output if the total exceeds this limit.
+	// In TOML it may also be a percentage of the target model's context
+	// window, e.g. max_tokens = "80%" (see MaxTokensPercent).
 	MaxTokens int `toml:"max_tokens"`
 
+	// MaxTokensPercent holds the percentage when max_tokens is written as
+	// "NN%", and 0 otherwise. Resolution converts it into an absolute
+	// MaxTokens using the target's context window (see TargetContextWindow).
+	MaxTokensPercent int `toml:"-"`
+
 	// BriefMaxTokens is the token budget for the Repo Brief artifact.
 	// Controls the maximum size of output from `harvx brief`. Default: 4000.
 	BriefMaxTokens int `toml:"brief_max_tokens"`
 
+	// MaxFileTokens excludes any single file whose token count exceeds it,
+	// however much of the max_tokens budget remains, so one generated or
+	// vendored file cannot consume the whole budget. 0 means no per-file cap.
+	MaxFileTokens int `toml:"max_file_tokens"`
+
 	// Tokenizer selects the token counting model. Valid values: "cl100k_base", "o200k_base".
 	Tokenizer string `toml:"tokenizer"`
 
@@ -46,6 +76,24 @@ type Profile struct {
 	// Valid values: "claude", "chatgpt", "generic", or empty string.
 	Target string `toml:"target"`
 
+	// BaseDir rebases every glob in the profile (ignore, include,
+	// priority_files, relevance tiers, and redaction exclude_paths) onto a
+	// subdirectory of the target directory. With base_dir = "packages/api",
+	// the pattern "src/**" matches "packages/api/src/main.go", and files
+	// outside packages/api match no profile pattern. It must be a relative
+	// path inside the repository. Empty means patterns are relative to the
+	// target directory.
+	BaseDir string `toml:"base_dir"`
+
+	// SortOrder controls how files are ordered within each relevance tier
+	// before the token budget is applied, and therefore which files of a tier
+	// survive when the budget runs out. Valid values: "path" (the default),
+	// "tokens-desc" (or its shorthand "tokens"), "tokens-asc" (lets more small
+	// files fit), "mtime" (most recently modified first), "size" (largest on
+	// disk first), or empty. Ordering is intra-tier only; tier priority
+	// always dominates.
+	SortOrder string `toml:"sort_order"`
+
 	// Ignore is the list of glob patterns for files and directories to
 	// skip during discovery. Patterns are evaluated with doublestar.
 	Ignore []string `toml:"ignore"`
@@ -54,15 +102,72 @@ type Profile struct {
 	// the output before any tier-based sorting is applied.
 	PriorityFiles []string `toml:"priority_files"`
 
+	// PriorityGlobs lets PriorityFiles contain glob patterns. When true, each
+	// pattern is expanded against the discovered file list into the matching
+	// paths before prioritization (see ExpandPriorityFiles), and the
+	// validation warning for glob-like entries is suppressed.
+	PriorityGlobs bool `toml:"priority_globs"`
+
 	// Include is the list of glob patterns for files to explicitly include
 	// even if they would otherwise be ignored.
 	Include []string `toml:"include"`
 
+	// IncludeOnly turns Include into an exact allowlist. When true, only files
+	// matching an Include glob are harvested, relevance tiers are not applied,
+	// and files are ordered by path. Ignore patterns still apply on top of the
+	// allowlist, so an ignored file is dropped even if it matches Include.
+	IncludeOnly bool `toml:"include_only"`
+
+	// CaseInsensitive makes profile glob patterns (relevance tiers, ignore,
+	// and include) match paths regardless of letter case, so "README.md" also
+	// matches "readme.md". Paths themselves are never rewritten; only the
+	// comparison folds case. Default: false (case-sensitive).
+	CaseInsensitive bool `toml:"case_insensitive"`
+
+	// SkipEmpty drops files whose content is empty or whitespace-only during
+	// discovery, so they add no headings or tree entries to the output.
+	// Default: true.
+	SkipEmpty bool `toml:"skip_empty"`
+
+	// TierFileCaps caps how many files of a relevance tier are kept, keyed by
+	// tier number and set with a [profile.<name>.tier_file_caps] table whose
+	// keys are tier numbers ("3") or tier keys ("tier_3"). The cap applies
+	// after classification: prioritized files are kept first and the rest of
+	// the tier keeps its classification order. A cap of zero or an absent
+	// tier means uncapped.
+	TierFileCaps TierFileCaps `toml:"tier_file_caps"`
+
+	// TierWeights reorders tiers for budget filling, keyed by tier number and
+	// set with a [profile.<name>.tier_weights] table. Tier assignment is
+	// unchanged: a file still gets the tier of the first matching pattern in
+	// tier-number order. Only the order in which tiers compete for the token
+	// budget changes: heavier tiers are filled first, tiers without a weight
+	// count as 0, and equal weights fall back to tier number. For example,
+	// { 4 = 10 } lets documentation outrank source code for a docs-focused
+	// run. Empty means tiers fill in tier-number order.
+	TierWeights TierWeights `toml:"tier_weights"`
+
 	// AssertInclude is the list of glob patterns that must each match at least
 	// one included file. If any pattern matches zero files, the pipeline fails
 	// with exit code 1. Used for CI coverage checks.
 	AssertInclude []string `toml:"assert_include"`
 
+	// StubVendored replaces the content of vendored files (see VendorDirs)
+	// with a one-line stub giving the file's path, line count, and token
+	// count. Stubbed files remain in the output and manifest, so the reader
+	// knows they exist without paying for their content.
+	StubVendored bool `toml:"stub_vendored"`
+
+	// VendorDirs lists the directory names whose files StubVendored stubs,
+	// matched against every directory in a file's path. Empty uses
+	// DefaultVendorDirs.
+	VendorDirs []string `toml:"vendor_dirs"`
+
+	// ExcludeBinary drops files whose content looks binary (a null byte or
+	// invalid UTF-8 in the first 8KB) before relevance classification, and
+	// lists them in the run statistics. Default: false.
+	ExcludeBinary bool `toml:"exclude_binary"`
+
 	// SliceMaxTokens is the token budget for the Review Slice artifact.
 	// Controls the maximum size of output from `harvx review-slice`. Default: 20000.
 	SliceMaxTokens int `toml:"slice_max_tokens"`
@@ -75,8 +180,45 @@ type Profile struct {
 	// a list of glob patterns that match files assigned to that tier.
 	Relevance RelevanceConfig `toml:"relevance"`
 
+	// RelevanceFile is an optional path to a standalone TOML or JSON file
+	// defining tier_0..tier_5 patterns. Relative paths are resolved against
+	// the directory of the config file. Tiers from the file are merged beneath
+	// the inline [relevance] table: an inline tier replaces the file's tier.
+	RelevanceFile string `toml:"relevance_file"`
+
 	// RedactionConfig holds fine-grained redaction settings.
 	RedactionConfig RedactionConfig `toml:"redaction_config"`
+
+	// Render holds output rendering preferences from the [profile.x.render]
+	// table.
+	Render RenderConfig `toml:"render"`
+
+	// Overrides adjusts the treatment of specific paths within this profile,
+	// e.g. never redacting examples/ or always keeping migrations/. Each file
+	// uses the first override whose Match glob matches its path (see
+	// MatchPathOverride).
+	Overrides []PathOverride `toml:"overrides"`
+}
+
+// PathOverride holds per-path settings declared in a [[profile.x.overrides]]
+// array-of-tables entry. Unset fields leave the profile's behavior unchanged
+// for matching files.
+type PathOverride struct {
+	// Match is the doublestar glob selecting the files this override applies
+	// to, relative to the repository root.
+	Match string `toml:"match"`
+
+	// Tier forces the relevance tier (0-5) of matching files, replacing the
+	// tier assigned by the relevance rules. Nil keeps the assigned tier.
+	Tier *int `toml:"tier"`
+
+	// Redact turns secret redaction on or off for matching files. Nil follows
+	// the profile's redaction setting.
+	Redact *bool `toml:"redact"`
+
+	// Priority orders matching files ahead of every other file during budget
+	// enforcement, so they are the last to be dropped when the budget runs out.
+	Priority bool `toml:"priority"`
 }
 
 // RelevanceConfig defines glob patterns for each relevance tier. Files are
@@ -100,6 +242,24 @@ type RelevanceConfig struct {
 
 	// Tier5 contains CI/CD configs, lock files, and lowest-priority files.
 	Tier5 []string `toml:"tier_5"`
+
+	// Labels optionally names tiers in explain and summary output, keyed by
+	// the tier's pattern key ("tier_0" through "tier_5"). It is set with a
+	// [profile.<name>.relevance.labels] table. Tiers without a label keep
+	// their built-in names (Config, Source, and so on).
+	Labels map[string]string `toml:"labels"`
+}
+
+// RenderConfig holds output rendering preferences. The fields are pointers so
+// that a profile can set one option and inherit the others from the profile
+// it extends; nil means unset, and an option left unset everywhere is off.
+type RenderConfig struct {
+	// IncludeTOC adds a table of contents linking to every file after the
+	// directory tree in Markdown output.
+	IncludeTOC *bool `toml:"include_toc"`
+
+	// LineNumbers prefixes each line of file content with its line number.
+	LineNumbers *bool `toml:"line_numbers"`
 }
 
 // RedactionConfig controls secret detection and redaction behavior.
@@ -112,9 +272,16 @@ type RedactionConfig struct {
 	ExcludePaths []string `toml:"exclude_paths"`
 
 	// ConfidenceThreshold controls which detected secrets are redacted.
-	// Valid values: "low", "medium", "high". Defaults to "high".
+	// Valid values: "low", "medium", "high", or "off" to keep every
+	// detection regardless of confidence. Defaults to "high".
 	ConfidenceThreshold string `toml:"confidence_threshold"`
 
+	// Mode selects how detected secrets are replaced. "full" (the default)
+	// substitutes a [REDACTED:type] marker; "partial" keeps the first and last
+	// four characters and masks the middle with asterisks so the secret's
+	// shape survives. Valid values: "full", "partial", or empty.
+	Mode string `toml:"mode"`
+
 	// OverrideSensitiveDefaults suppresses the warning that is emitted when
 	// a sensitive file (e.g., .env, *.pem) is explicitly included by the
 	// profile. Set to true only when you understand the risk (e.g., including
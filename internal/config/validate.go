@@ -1,11 +1,18 @@
 package config
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"unicode"
 
 	"github.com/bmatcuk/doublestar/v4"
 )
@@ -16,6 +23,7 @@ var validFormats = map[string]bool{
 	"markdown": true,
 	"xml":      true,
 	"plain":    true,
+	"json":     true,
 	"":         true,
 }
 
@@ -39,14 +47,46 @@ var validTargets = map[string]bool{
 
 // validConfidenceThresholds lists the only accepted values for
 // RedactionConfig.ConfidenceThreshold. An empty string is also valid
-// (uses the built-in default).
+// (uses the built-in default). "off" disables confidence filtering so every
+// candidate match is redacted; it maps to security.ConfidenceOff.
 var validConfidenceThresholds = map[string]bool{
 	"high":   true,
 	"medium": true,
 	"low":    true,
+	"off":    true,
 	"":       true,
 }
 
+// validSortOrders lists the only accepted values for Profile.SortOrder. An
+// empty string is also valid (orders by path).
+var validSortOrders = map[string]bool{
+	"path":        true,
+	"tokens-desc": true,
+	"tokens-asc":  true,
+	"tokens":      true,
+	"mtime":       true,
+	"size":        true,
+	"":            true,
+}
+
+// validRedactionModes lists the only accepted values for
+// RedactionConfig.Mode. An empty string is also valid (full redaction).
+var validRedactionModes = map[string]bool{
+	"full":    true,
+	"partial": true,
+	"":        true,
+}
+
+// validTierKeys lists the only accepted keys of RelevanceConfig.Labels.
+var validTierKeys = map[string]bool{
+	"tier_0": true,
+	"tier_1": true,
+	"tier_2": true,
+	"tier_3": true,
+	"tier_4": true,
+	"tier_5": true,
+}
+
 // maxTokensHardCap is the absolute upper limit for Profile.MaxTokens.
 // Values above this are almost certainly a configuration mistake.
 const maxTokensHardCap = 2_000_000
@@ -63,27 +103,64 @@ const maxInheritanceWarningDepth = 3
 // literal path. Used to detect priority_files entries that look like patterns.
 const globMetaChars = "*?[{"
 
+// enumSuggest builds the Suggest text for an invalid enum value. When one of
+// the valid values is a close match for input, "did you mean X?" is placed in
+// front of the options list.
+func enumSuggest(input string, valid map[string]bool, options string) string {
+	candidates := make([]string, 0, len(valid))
+	for v := range valid {
+		if v != "" {
+			candidates = append(candidates, v)
+		}
+	}
+	slices.Sort(candidates)
+
+	if m := closestMatch(input, candidates); m != "" {
+		return fmt.Sprintf("did you mean %s? %s", m, options)
+	}
+	return options
+}
+
 // Validate inspects every profile in cfg and returns a slice of
 // ValidationErrors describing hard errors and warnings found in the
 // configuration. It does not stop at the first error; all profiles are
 // checked and all findings are accumulated before returning.
 //
 // The returned slice is nil when no issues are found. Each element carries
-// a Severity field of either "error" or "warning".
+// a Severity field of either "error" or "warning". Results are ordered by
+// profile name, then by field, severity, and message, so the output is the
+// same on every run.
 //
 // Validate does not modify cfg.
 func Validate(cfg *Config) []ValidationError {
+	return ValidateWithOptions(cfg, ValidateOptions{})
+}
+
+// ValidateOptions tunes the checks Validate performs. The zero value gives
+// the default behaviour.
+type ValidateOptions struct {
+	// CanonicalizePatterns compares tier patterns by their NormalizePattern
+	// form when looking for patterns repeated across tiers, so that
+	// "./src/**" and "src/**" are reported as duplicates. By default
+	// patterns are compared as written.
+	CanonicalizePatterns bool
+}
+
+// ValidateWithOptions is Validate with the checks tuned by opts.
+func ValidateWithOptions(cfg *Config, opts ValidateOptions) []ValidationError {
 	if cfg == nil {
 		return nil
 	}
 
 	var results []ValidationError
 
-	for name, profile := range cfg.Profile {
+	for _, name := range slices.Sorted(maps.Keys(cfg.Profile)) {
+		profile := cfg.Profile[name]
 		if profile == nil {
 			continue
 		}
-		errs := validateProfile(name, profile, cfg.Profile)
+		errs := validateProfile(name, profile, cfg.Profile, opts)
+		slices.SortStableFunc(errs, compareValidationErrors)
 		results = append(results, errs...)
 	}
 
@@ -96,9 +173,33 @@ func Validate(cfg *Config) []ValidationError {
 	return results
 }
 
-// validateProfile checks a single named profile and returns all validation
-// errors and warnings for that profile.
-func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) []ValidationError {
+// compareValidationErrors orders validation errors by field, then severity,
+// then message.
+func compareValidationErrors(a, b ValidationError) int {
+	return cmp.Or(
+		strings.Compare(a.Field, b.Field),
+		strings.Compare(a.Severity, b.Severity),
+		strings.Compare(a.Message, b.Message),
+	)
+}
+
+// Validate checks a single profile in isolation and returns all validation
+// errors and warnings for it. name is used only to build the Field paths
+// (e.g. "profile.<name>.format") of the returned errors.
+//
+// Inheritance is not resolved: the extends chain and its depth can only be
+// checked against the full profile map, so those checks are left to the
+// package-level Validate. A nil receiver yields no findings.
+func (p *Profile) Validate(name string) []ValidationError {
+	return p.validate(name, ValidateOptions{})
+}
+
+// validate implements Validate with the checks tuned by opts.
+func (p *Profile) validate(name string, opts ValidateOptions) []ValidationError {
+	if p == nil {
+		return nil
+	}
+
 	var results []ValidationError
 
 	field := func(f string) string {
@@ -113,7 +214,7 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 			Severity: "error",
 			Field:    field("format"),
 			Message:  fmt.Sprintf("format %q is invalid", p.Format),
-			Suggest:  "Valid formats: markdown, xml, plain",
+			Suggest:  enumSuggest(p.Format, validFormats, "Valid formats: markdown, xml, plain, json"),
 		})
 	}
 
@@ -123,7 +224,7 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 			Severity: "error",
 			Field:    field("tokenizer"),
 			Message:  fmt.Sprintf("tokenizer %q is invalid", p.Tokenizer),
-			Suggest:  "Valid tokenizers: cl100k_base, o200k_base, none",
+			Suggest:  enumSuggest(p.Tokenizer, validTokenizers, "Valid tokenizers: cl100k_base, o200k_base, none"),
 		})
 	}
 
@@ -133,7 +234,17 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 			Severity: "error",
 			Field:    field("target"),
 			Message:  fmt.Sprintf("target %q is invalid", p.Target),
-			Suggest:  "Valid targets: claude, chatgpt, generic (or leave empty)",
+			Suggest:  enumSuggest(p.Target, validTargets, "Valid targets: claude, chatgpt, generic (or leave empty)"),
+		})
+	}
+
+	// sort_order
+	if !validSortOrders[p.SortOrder] {
+		results = append(results, ValidationError{
+			Severity: "error",
+			Field:    field("sort_order"),
+			Message:  fmt.Sprintf("sort_order %q is invalid", p.SortOrder),
+			Suggest:  enumSuggest(p.SortOrder, validSortOrders, "Valid values: path, tokens-desc, tokens-asc, tokens, mtime, size"),
 		})
 	}
 
@@ -143,7 +254,17 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 			Severity: "error",
 			Field:    field("redaction_config.confidence_threshold"),
 			Message:  fmt.Sprintf("confidence_threshold %q is invalid", p.RedactionConfig.ConfidenceThreshold),
-			Suggest:  "Valid values: high, medium, low",
+			Suggest:  enumSuggest(p.RedactionConfig.ConfidenceThreshold, validConfidenceThresholds, "Valid values: high, medium, low, off"),
+		})
+	}
+
+	// redaction_config.mode
+	if !validRedactionModes[p.RedactionConfig.Mode] {
+		results = append(results, ValidationError{
+			Severity: "error",
+			Field:    field("redaction_config.mode"),
+			Message:  fmt.Sprintf("mode %q is invalid", p.RedactionConfig.Mode),
+			Suggest:  enumSuggest(p.RedactionConfig.Mode, validRedactionModes, "Valid values: full, partial"),
 		})
 	}
 
@@ -157,6 +278,16 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 		})
 	}
 
+	// max_tokens: percentage of the target's context window
+	if p.MaxTokensPercent != 0 && (p.MaxTokensPercent < 1 || p.MaxTokensPercent > 100) {
+		results = append(results, ValidationError{
+			Severity: "error",
+			Field:    field("max_tokens"),
+			Message:  fmt.Sprintf("max_tokens \"%d%%\" is out of range", p.MaxTokensPercent),
+			Suggest:  "Use a percentage between 1% and 100%, e.g. \"80%\"",
+		})
+	}
+
 	// max_tokens: sanity cap (hard)
 	if p.MaxTokens > maxTokensHardCap {
 		results = append(results, ValidationError{
@@ -167,35 +298,53 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 		})
 	}
 
+	// max_file_tokens: negative
+	if p.MaxFileTokens < 0 {
+		results = append(results, ValidationError{
+			Severity: "error",
+			Field:    field("max_file_tokens"),
+			Message:  fmt.Sprintf("max_file_tokens %d is negative", p.MaxFileTokens),
+			Suggest:  "Set max_file_tokens to a positive integer, or 0 for no per-file cap",
+		})
+	}
+
+	// base_dir must be relative and stay inside the repository
+	if err := checkBaseDir(p.BaseDir); err != nil {
+		results = append(results, ValidationError{
+			Severity: "error",
+			Field:    field("base_dir"),
+			Message:  err.Error(),
+			Suggest:  "Use a path relative to the repository root, e.g. \"packages/api\"",
+		})
+	}
+
+	// include_only requires a non-empty include allowlist
+	if p.IncludeOnly && len(p.Include) == 0 {
+		results = append(results, ValidationError{
+			Severity: "error",
+			Field:    field("include_only"),
+			Message:  "include_only is true but include is empty; no files would be harvested",
+			Suggest:  "Add glob patterns to include or set include_only = false",
+		})
+	}
+
 	// glob pattern validity
 	results = append(results, validateGlobPatterns(name, p)...)
 
-	// circular inheritance
-	if p.Extends != nil && *p.Extends != "" {
-		if _, err := ResolveProfile(name, allProfiles); err != nil {
-			// Report circular or missing parent.
-			if strings.Contains(err.Error(), "circular") {
-				results = append(results, ValidationError{
-					Severity: "error",
-					Field:    field("extends"),
-					Message:  err.Error(),
-					Suggest:  "Remove or restructure the extends chain to eliminate the cycle",
-				})
-			} else {
-				results = append(results, ValidationError{
-					Severity: "error",
-					Field:    field("extends"),
-					Message:  fmt.Sprintf("extends %q: %s", *p.Extends, err.Error()),
-					Suggest:  fmt.Sprintf("Define a profile named %q or update the extends value", *p.Extends),
-				})
-			}
-		}
-	}
+	// path overrides
+	results = append(results, validateOverrides(name, p)...)
+
+	// relevance tier labels
+	results = append(results, validateTierLabels(name, p)...)
+
+	// per-tier file caps
+	results = append(results, validateTierFileCaps(name, p)...)
+	results = append(results, validateTierWeights(name, p)...)
 
 	// ── Warnings ───────────────────────────────────────────────────────────
 
 	// Overlapping tier patterns (same exact pattern string in multiple tiers).
-	results = append(results, warnOverlappingTiers(name, p)...)
+	results = append(results, warnOverlappingTiers(name, p, opts.CanonicalizePatterns)...)
 
 	// Empty relevance tiers.
 	results = append(results, warnEmptyTiers(name, p)...)
@@ -203,18 +352,26 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 	// priority_files entries that also appear in ignore (contradictory).
 	results = append(results, warnPriorityFilesInIgnore(name, p)...)
 
-	// priority_files with glob metacharacters (should be exact paths).
-	results = append(results, warnPriorityFilesWithGlobs(name, p)...)
+	// priority_files with glob metacharacters (should be exact paths unless
+	// priority_globs is set).
+	if !p.PriorityGlobs {
+		results = append(results, warnPriorityFilesWithGlobs(name, p)...)
+	}
 
 	// redaction_config.exclude_paths overlapping with ignore (redundant).
 	results = append(results, warnRedactionExcludeOverlap(name, p)...)
 
+	// Letter character classes under case_insensitive matching.
+	if p.CaseInsensitive {
+		results = append(results, warnCaseFoldedCharClasses(name, p)...)
+	}
+
+	// redaction_config with patterns or exclusions but enabled = false.
+	results = append(results, warnRedactionConfigDisabled(name, p)...)
+
 	// Validate custom pattern regexes
 	results = append(results, validateCustomPatterns(name, p)...)
 
-	// Inheritance depth > 3.
-	results = append(results, warnDeepInheritance(name, p, allProfiles)...)
-
 	// max_tokens soft cap.
 	if p.MaxTokens > maxTokensSoftCap && p.MaxTokens <= maxTokensHardCap {
 		results = append(results, ValidationError{
@@ -225,8 +382,9 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 		})
 	}
 
-	// Output path outside the current directory tree.
-	if p.Output != "" {
+	// Output path outside the current directory tree. "-" selects stdout and
+	// is never a file path.
+	if p.Output != "" && p.Output != "-" {
 		if strings.HasPrefix(p.Output, "../") || filepath.IsAbs(p.Output) {
 			results = append(results, ValidationError{
 				Severity: "warning",
@@ -240,6 +398,45 @@ func validateProfile(name string, p *Profile, allProfiles map[string]*Profile) [
 	return results
 }
 
+// validateProfile checks a single named profile and returns all validation
+// errors and warnings for that profile, including the inheritance checks
+// that need the full profile map.
+func validateProfile(name string, p *Profile, allProfiles map[string]*Profile, opts ValidateOptions) []ValidationError {
+	results := p.validate(name, opts)
+
+	field := func(f string) string {
+		return fmt.Sprintf("profile.%s.%s", name, f)
+	}
+
+	// circular inheritance
+	if p.Extends != nil && *p.Extends != "" {
+		if _, err := ResolveProfile(name, allProfiles); err != nil {
+			// Report circular or missing parent.
+			var cycleErr *CircularInheritanceError
+			if errors.As(err, &cycleErr) {
+				results = append(results, ValidationError{
+					Severity: "error",
+					Field:    field("extends"),
+					Message:  cycleErr.Error(),
+					Suggest:  "Remove the extends from one profile in the cycle to break it",
+				})
+			} else {
+				results = append(results, ValidationError{
+					Severity: "error",
+					Field:    field("extends"),
+					Message:  fmt.Sprintf("extends %q: %s", *p.Extends, err.Error()),
+					Suggest:  fmt.Sprintf("Define a profile named %q or update the extends value", *p.Extends),
+				})
+			}
+		}
+	}
+
+	// Inheritance depth > 3.
+	results = append(results, warnDeepInheritance(name, p, allProfiles)...)
+
+	return results
+}
+
 // validateGlobPatterns validates all glob pattern lists in the profile and
 // returns errors for any invalid patterns.
 func validateGlobPatterns(profileName string, p *Profile) []ValidationError {
@@ -283,6 +480,149 @@ func validateGlobPatterns(profileName string, p *Profile) []ValidationError {
 	return results
 }
 
+// validateOverrides checks that each overrides entry has a valid match glob
+// and, when set, a tier in the 0-5 range.
+func validateOverrides(profileName string, p *Profile) []ValidationError {
+	var results []ValidationError
+	for i, ov := range p.Overrides {
+		field := fmt.Sprintf("profile.%s.overrides[%d]", profileName, i)
+		if ov.Match == "" {
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field + ".match",
+				Message:  "override has empty match",
+				Suggest:  "Provide a glob selecting the files to override, e.g. \"examples/**\"",
+			})
+		} else if err := validateGlobPattern(ov.Match); err != nil {
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field + ".match",
+				Message:  fmt.Sprintf("invalid glob pattern %q: %s", ov.Match, err.Error()),
+				Suggest:  "Use doublestar glob syntax, e.g. \"**/*.go\" or \"src/**\"",
+			})
+		}
+		if ov.Tier != nil && (*ov.Tier < 0 || *ov.Tier > 5) {
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field + ".tier",
+				Message:  fmt.Sprintf("override tier %d is out of range", *ov.Tier),
+				Suggest:  "Use a tier between 0 and 5",
+			})
+		}
+	}
+	return results
+}
+
+// validateTierLabels checks that every relevance.labels key names one of the
+// tiers tier_0 through tier_5 and that no label is blank.
+func validateTierLabels(profileName string, p *Profile) []ValidationError {
+	keys := make([]string, 0, len(p.Relevance.Labels))
+	for key := range p.Relevance.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var results []ValidationError
+	for _, key := range keys {
+		field := fmt.Sprintf("profile.%s.relevance.labels.%s", profileName, key)
+		switch {
+		case !validTierKeys[key]:
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field,
+				Message:  fmt.Sprintf("unknown tier %q", key),
+				Suggest:  "Label one of tier_0, tier_1, tier_2, tier_3, tier_4, tier_5",
+			})
+		case strings.TrimSpace(p.Relevance.Labels[key]) == "":
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field,
+				Message:  "tier label is empty",
+				Suggest:  "Remove the entry to use the built-in tier name",
+			})
+		}
+	}
+	return results
+}
+
+// validateTierFileCaps checks that every tier_file_caps entry names one of
+// the tiers 0 through 5 and that no cap is negative.
+func validateTierFileCaps(profileName string, p *Profile) []ValidationError {
+	tiers := slices.Sorted(maps.Keys(p.TierFileCaps))
+
+	var results []ValidationError
+	for _, tier := range tiers {
+		field := fmt.Sprintf("profile.%s.tier_file_caps.%d", profileName, tier)
+		switch {
+		case tier < 0 || tier > 5:
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field,
+				Message:  fmt.Sprintf("unknown tier %d", tier),
+				Suggest:  "Cap one of tiers 0 through 5",
+			})
+		case p.TierFileCaps[tier] < 0:
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field,
+				Message:  fmt.Sprintf("file cap must be non-negative, got %d", p.TierFileCaps[tier]),
+				Suggest:  "Use 0 or remove the entry to leave the tier uncapped",
+			})
+		}
+	}
+	return results
+}
+
+// validateTierWeights checks that every tier_weights entry names one of the
+// tiers 0 through 5, and warns about a weight on a tier the profile defines
+// with an empty pattern list, since no file is ever assigned that tier.
+func validateTierWeights(profileName string, p *Profile) []ValidationError {
+	tiers := slices.Sorted(maps.Keys(p.TierWeights))
+
+	var results []ValidationError
+	for _, tier := range tiers {
+		field := fmt.Sprintf("profile.%s.tier_weights.%d", profileName, tier)
+		if tier < 0 || tier > 5 {
+			results = append(results, ValidationError{
+				Severity: "error",
+				Field:    field,
+				Message:  fmt.Sprintf("unknown tier %d", tier),
+				Suggest:  "Weight one of tiers 0 through 5",
+			})
+			continue
+		}
+		if patterns := tierPatterns(p.Relevance, tier); patterns != nil && len(patterns) == 0 {
+			results = append(results, ValidationError{
+				Severity: "warning",
+				Field:    field,
+				Message:  fmt.Sprintf("tier %d has no patterns, so its weight never applies", tier),
+				Suggest:  fmt.Sprintf("Add patterns to relevance.tier_%d or remove the weight", tier),
+			})
+		}
+	}
+	return results
+}
+
+// tierPatterns returns the pattern list rel sets for tier, or nil when the
+// tier is unset (and so inherits the default patterns) or out of range.
+func tierPatterns(rel RelevanceConfig, tier int) []string {
+	switch tier {
+	case 0:
+		return rel.Tier0
+	case 1:
+		return rel.Tier1
+	case 2:
+		return rel.Tier2
+	case 3:
+		return rel.Tier3
+	case 4:
+		return rel.Tier4
+	case 5:
+		return rel.Tier5
+	}
+	return nil
+}
+
 // validateGlobPattern checks whether pattern is syntactically valid according
 // to the doublestar library. It uses doublestar.ValidatePattern which returns
 // false for malformed patterns (e.g. unclosed character classes or alternations).
@@ -293,9 +633,26 @@ func validateGlobPattern(pattern string) error {
 	return nil
 }
 
-// warnOverlappingTiers returns warnings for glob patterns that appear
-// identically in more than one relevance tier.
-func warnOverlappingTiers(profileName string, p *Profile) []ValidationError {
+// NormalizePattern returns the canonical form of a glob pattern: the path is
+// cleaned, which drops a leading "./", repeated slashes and a trailing slash,
+// so "./src/**" and "src//**" both become "src/**". A leading "!" negation is
+// kept in front. Backslashes are left alone since they escape glob
+// metacharacters.
+func NormalizePattern(pattern string) string {
+	neg := ""
+	if strings.HasPrefix(pattern, "!") {
+		neg, pattern = "!", pattern[1:]
+	}
+	if pattern == "" {
+		return neg
+	}
+	return neg + path.Clean(pattern)
+}
+
+// warnOverlappingTiers returns warnings for glob patterns that appear in more
+// than one relevance tier. Patterns are compared as written, or by their
+// NormalizePattern form when canonical is set.
+func warnOverlappingTiers(profileName string, p *Profile, canonical bool) []ValidationError {
 	tiers := []struct {
 		name     string
 		patterns []string
@@ -314,7 +671,11 @@ func warnOverlappingTiers(profileName string, p *Profile) []ValidationError {
 
 	for _, tier := range tiers {
 		for _, pattern := range tier.patterns {
-			if firstTier, exists := seen[pattern]; exists {
+			key := pattern
+			if canonical {
+				key = NormalizePattern(pattern)
+			}
+			if firstTier, exists := seen[key]; exists {
 				results = append(results, ValidationError{
 					Severity: "warning",
 					Field:    fmt.Sprintf("profile.%s.relevance.%s", profileName, tier.name),
@@ -325,7 +686,7 @@ func warnOverlappingTiers(profileName string, p *Profile) []ValidationError {
 					Suggest: fmt.Sprintf("Remove the duplicate pattern from %s", tier.name),
 				})
 			} else {
-				seen[pattern] = tier.name
+				seen[key] = tier.name
 			}
 		}
 	}
@@ -402,13 +763,71 @@ func warnPriorityFilesWithGlobs(profileName string, p *Profile) []ValidationErro
 				Severity: "warning",
 				Field:    fmt.Sprintf("profile.%s.priority_files[%d]", profileName, i),
 				Message:  fmt.Sprintf("priority_files entry %q looks like a glob pattern", pf),
-				Suggest:  "priority_files should contain exact file paths; use relevance tiers for glob patterns",
+				Suggest:  "priority_files should contain exact file paths; set priority_globs = true to expand globs, or use relevance tiers",
+			})
+		}
+	}
+	return results
+}
+
+// warnCaseFoldedCharClasses returns warnings for ignore, include, and tier
+// patterns whose character classes contain letters. case_insensitive matching
+// folds the pattern as well as the path, so a class such as "[A-Z]" also
+// matches lowercase letters, which is rarely what the class was written for.
+func warnCaseFoldedCharClasses(profileName string, p *Profile) []ValidationError {
+	lists := []struct {
+		field    string
+		patterns []string
+	}{
+		{"ignore", p.Ignore},
+		{"include", p.Include},
+		{"relevance.tier_0", p.Relevance.Tier0},
+		{"relevance.tier_1", p.Relevance.Tier1},
+		{"relevance.tier_2", p.Relevance.Tier2},
+		{"relevance.tier_3", p.Relevance.Tier3},
+		{"relevance.tier_4", p.Relevance.Tier4},
+		{"relevance.tier_5", p.Relevance.Tier5},
+	}
+
+	var results []ValidationError
+	for _, list := range lists {
+		for i, pattern := range list.patterns {
+			if !hasLetterCharClass(pattern) {
+				continue
+			}
+			results = append(results, ValidationError{
+				Severity: "warning",
+				Field:    fmt.Sprintf("profile.%s.%s[%d]", profileName, list.field, i),
+				Message:  fmt.Sprintf("pattern %q has a letter character class, which matches both cases under case_insensitive", pattern),
+				Suggest:  "Drop the character class or set case_insensitive = false if the class should distinguish case",
 			})
 		}
 	}
 	return results
 }
 
+// hasLetterCharClass reports whether pattern contains an unescaped character
+// class ("[...]") with at least one letter in it.
+func hasLetterCharClass(pattern string) bool {
+	inClass, letter := false, false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case !inClass && c == '[':
+			inClass, letter = true, false
+		case inClass && c == ']':
+			if letter {
+				return true
+			}
+			inClass = false
+		case inClass && unicode.IsLetter(rune(c)):
+			letter = true
+		}
+	}
+	return false
+}
+
 // warnRedactionExcludeOverlap returns warnings for redaction_config.exclude_paths
 // entries that also appear exactly in the ignore list (redundant configuration).
 func warnRedactionExcludeOverlap(profileName string, p *Profile) []ValidationError {
@@ -436,6 +855,37 @@ func warnRedactionExcludeOverlap(profileName string, p *Profile) []ValidationErr
 	return results
 }
 
+// warnRedactionConfigDisabled returns a warning for each of custom_patterns and
+// exclude_paths that is set while redaction_config.enabled is false. Both only
+// take effect when redaction runs, so configuring them with redaction off is
+// almost always a mistake. enabled is not inherited (false is an explicit
+// value), so a child profile that sets exclude_paths must also set enabled.
+func warnRedactionConfigDisabled(profileName string, p *Profile) []ValidationError {
+	rc := p.RedactionConfig
+	if rc.Enabled {
+		return nil
+	}
+
+	var results []ValidationError
+	if len(rc.CustomPatterns) > 0 {
+		results = append(results, ValidationError{
+			Severity: "warning",
+			Field:    fmt.Sprintf("profile.%s.redaction_config.custom_patterns", profileName),
+			Message:  "custom_patterns are set but redaction_config.enabled is false; they will never be applied",
+			Suggest:  "Set redaction_config.enabled = true or remove custom_patterns",
+		})
+	}
+	if len(rc.ExcludePaths) > 0 {
+		results = append(results, ValidationError{
+			Severity: "warning",
+			Field:    fmt.Sprintf("profile.%s.redaction_config.exclude_paths", profileName),
+			Message:  "exclude_paths are set but redaction_config.enabled is false; they have no effect",
+			Suggest:  "Set redaction_config.enabled = true or remove exclude_paths",
+		})
+	}
+	return results
+}
+
 // warnDeepInheritance returns a warning when the inheritance chain for the
 // profile exceeds maxInheritanceWarningDepth levels.
 func warnDeepInheritance(profileName string, p *Profile, allProfiles map[string]*Profile) []ValidationError {
@@ -534,7 +984,18 @@ func validateCustomPatterns(profileName string, p *Profile) []ValidationError {
 //     meaning they match any file name regardless of type.
 //   - Complexity score: profiles with many non-default fields set are flagged
 //     to encourage splitting into focused sub-profiles.
+//   - Ignored tier patterns: tier patterns whose literal path prefix lies
+//     inside an ignore entry, so they can never classify any file.
+//   - Shallow globs: tier or ignore patterns ending in a single "/*" segment,
+//     which match only direct children where "/**" was likely intended.
+//   - Include without tiers: profiles that set include globs but define no
+//     relevance tiers, so included files are ranked only by the built-in
+//     defaults.
+//   - Redundant redaction excludes: redaction_config.exclude_paths entries
+//     already covered by another entry in the list.
 //
+// Results are ordered as Validate orders them, with the Validate and
+// lint-only findings of each profile sorted together and Code breaking ties.
 // The returned slice is nil when no issues are found.
 func Lint(cfg *Config) []LintResult {
 	if cfg == nil {
@@ -543,28 +1004,63 @@ func Lint(cfg *Config) []LintResult {
 
 	var results []LintResult
 
-	// Include all Validate results as LintResults (Code left empty for these).
-	for _, ve := range Validate(cfg) {
-		results = append(results, LintResult{ValidationError: ve})
-	}
-
-	// Perform deeper lint-only analysis per profile.
-	for name, profile := range cfg.Profile {
+	for _, name := range slices.Sorted(maps.Keys(cfg.Profile)) {
+		profile := cfg.Profile[name]
 		if profile == nil {
 			continue
 		}
-		results = append(results, lintProfile(name, profile)...)
+
+		// Include the Validate results as LintResults (Code left empty for
+		// these), then the deeper lint-only analysis.
+		var profileResults []LintResult
+		for _, ve := range validateProfile(name, profile, cfg.Profile, ValidateOptions{}) {
+			profileResults = append(profileResults, LintResult{ValidationError: ve})
+		}
+		profileResults = append(profileResults, lintProfile(name, profile)...)
+
+		slices.SortStableFunc(profileResults, func(a, b LintResult) int {
+			return cmp.Or(
+				compareValidationErrors(a.ValidationError, b.ValidationError),
+				strings.Compare(a.Code, b.Code),
+			)
+		})
+		results = append(results, profileResults...)
 	}
 
 	return results
 }
 
+// LintWithConfig runs Lint and drops every result whose Code is a key in
+// suppress with a true value. Validate-derived results carry an empty Code and
+// are therefore never suppressed, so hard errors always survive. A nil or
+// empty suppress map makes LintWithConfig equivalent to Lint.
+func LintWithConfig(cfg *Config, suppress map[string]bool) []LintResult {
+	results := Lint(cfg)
+	if len(suppress) == 0 {
+		return results
+	}
+
+	var kept []LintResult
+	for _, r := range results {
+		if r.Code != "" && suppress[r.Code] {
+			continue
+		}
+		kept = append(kept, r)
+	}
+	return kept
+}
+
 // lintProfile performs the deeper lint-only analysis for a single profile.
 func lintProfile(profileName string, p *Profile) []LintResult {
 	var results []LintResult
 
 	results = append(results, lintUnreachableTiers(profileName, p)...)
 	results = append(results, lintNoExtPatterns(profileName, p)...)
+	results = append(results, lintTierPatternIgnored(profileName, p)...)
+	results = append(results, lintShallowGlobs(profileName, p)...)
+	results = append(results, lintBoundedDepthPatterns(profileName, p)...)
+	results = append(results, lintIncludeWithoutTiers(profileName, p)...)
+	results = append(results, lintRedundantRedactionExcludes(profileName, p)...)
 	results = append(results, lintComplexity(profileName, p)...)
 
 	return results
@@ -689,6 +1185,273 @@ func patternHasExtension(pattern string) bool {
 	return true
 }
 
+// lintTierPatternIgnored detects tier patterns whose literal path prefix is
+// covered by an ignore entry. Discovery drops ignored files before the
+// relevance engine runs, so such patterns can never classify any file.
+func lintTierPatternIgnored(profileName string, p *Profile) []LintResult {
+	if len(p.Ignore) == 0 {
+		return nil
+	}
+
+	tiers := []struct {
+		name     string
+		patterns []string
+	}{
+		{"tier_0", p.Relevance.Tier0},
+		{"tier_1", p.Relevance.Tier1},
+		{"tier_2", p.Relevance.Tier2},
+		{"tier_3", p.Relevance.Tier3},
+		{"tier_4", p.Relevance.Tier4},
+		{"tier_5", p.Relevance.Tier5},
+	}
+
+	var results []LintResult
+
+	for _, tier := range tiers {
+		for i, pattern := range tier.patterns {
+			ig, covered := ignoreCoveringPattern(pattern, p.Ignore)
+			if !covered {
+				continue
+			}
+			results = append(results, LintResult{
+				ValidationError: ValidationError{
+					Severity: "warning",
+					Field:    fmt.Sprintf("profile.%s.relevance.%s[%d]", profileName, tier.name, i),
+					Message:  fmt.Sprintf("pattern %q is under ignored path %q; it can never match a file", pattern, ig),
+					Suggest:  fmt.Sprintf("Remove %q from %s or remove %q from ignore", pattern, tier.name, ig),
+				},
+				Code: "tier-pattern-ignored",
+			})
+		}
+	}
+
+	return results
+}
+
+// ignoreCoveringPattern returns the first ignore entry whose literal path
+// contains the literal prefix of pattern. Only ignore entries without glob
+// metacharacters (after stripping a trailing "/" or "/**") are considered,
+// mirroring the exact-string heuristics used by the other overlap checks.
+func ignoreCoveringPattern(pattern string, ignore []string) (string, bool) {
+	prefix := literalPathPrefix(pattern)
+	if prefix == "" {
+		return "", false
+	}
+
+	for _, ig := range ignore {
+		dir := strings.TrimSuffix(strings.TrimSuffix(ig, "/**"), "/")
+		if dir == "" || strings.ContainsAny(dir, globMetaChars) {
+			continue
+		}
+		if prefix == dir || strings.HasPrefix(prefix, dir+"/") {
+			return ig, true
+		}
+	}
+
+	return "", false
+}
+
+// literalPathPrefix returns the leading path segments of pattern that contain
+// no glob metacharacters, joined with "/". For "vendor/**/*.go" it returns
+// "vendor"; for "**/*.go" it returns "". A pattern with no metacharacters is
+// returned unchanged.
+func literalPathPrefix(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	var literal []string
+	for _, seg := range segments {
+		if strings.ContainsAny(seg, globMetaChars) {
+			break
+		}
+		literal = append(literal, seg)
+	}
+	return strings.Join(literal, "/")
+}
+
+// lintShallowGlobs detects tier and ignore patterns whose final segment is a
+// bare "*", such as "src/*". A single star does not cross directory
+// boundaries, so the pattern matches only direct children of the directory;
+// with no extension in the last segment the author most likely meant "src/**".
+func lintShallowGlobs(profileName string, p *Profile) []LintResult {
+	lists := []struct {
+		field    string
+		patterns []string
+	}{
+		{"relevance.tier_0", p.Relevance.Tier0},
+		{"relevance.tier_1", p.Relevance.Tier1},
+		{"relevance.tier_2", p.Relevance.Tier2},
+		{"relevance.tier_3", p.Relevance.Tier3},
+		{"relevance.tier_4", p.Relevance.Tier4},
+		{"relevance.tier_5", p.Relevance.Tier5},
+		{"ignore", p.Ignore},
+	}
+
+	var results []LintResult
+
+	for _, list := range lists {
+		for i, pattern := range list.patterns {
+			if !strings.HasSuffix(pattern, "/*") {
+				continue
+			}
+			recursive := pattern + "*"
+			results = append(results, LintResult{
+				ValidationError: ValidationError{
+					Severity: "warning",
+					Field:    fmt.Sprintf("profile.%s.%s[%d]", profileName, list.field, i),
+					Message:  fmt.Sprintf("pattern %q matches only direct children, not files in subdirectories", pattern),
+					Suggest:  fmt.Sprintf("Use %q to match recursively", recursive),
+				},
+				Code: "shallow-glob",
+			})
+		}
+	}
+
+	return results
+}
+
+// lintBoundedDepthPatterns notes tier patterns under a directory whose single
+// "*" limits them to a fixed depth, such as "src/*.go", which matches
+// src/main.go but not src/api/handler.go. The note is informational: the
+// fixed depth is often intended. Root-level patterns like "*.config.*" and
+// the "src/*" form reported by lintShallowGlobs are skipped.
+func lintBoundedDepthPatterns(profileName string, p *Profile) []LintResult {
+	var results []LintResult
+
+	for tier := 0; tier <= 5; tier++ {
+		for i, pattern := range tierPatterns(p.Relevance, tier) {
+			depth := PatternDepth(pattern)
+			if depth <= 0 || !strings.Contains(pattern, "/") || strings.HasSuffix(pattern, "/*") {
+				continue
+			}
+			prefix := literalPathPrefix(NormalizePattern(pattern))
+			if prefix == "" {
+				continue
+			}
+			results = append(results, LintResult{
+				ValidationError: ValidationError{
+					Severity: "info",
+					Field:    fmt.Sprintf("profile.%s.relevance.tier_%d[%d]", profileName, tier, i),
+					Message: fmt.Sprintf(
+						"pattern %q only matches files %d level(s) below %s/; a single * does not cross \"/\"",
+						pattern, depth, prefix,
+					),
+					Suggest: fmt.Sprintf("Use \"**\" (e.g. %q) to match at any depth", prefix+"/**/"+pattern[strings.LastIndex(pattern, "/")+1:]),
+				},
+				Code: "bounded-depth",
+			})
+		}
+	}
+
+	return results
+}
+
+// lintRedundantRedactionExcludes detects redaction_config.exclude_paths
+// entries whose matches are a subset of another entry's, such as
+// "docs/api/**" next to "docs/**". Of two entries that are the same after
+// NormalizePattern, the later one is reported. Negated entries are skipped.
+func lintRedundantRedactionExcludes(profileName string, p *Profile) []LintResult {
+	excludes := p.RedactionConfig.ExcludePaths
+	if len(excludes) < 2 {
+		return nil
+	}
+
+	var results []LintResult
+
+	for i, pattern := range excludes {
+		if strings.HasPrefix(pattern, "!") {
+			continue
+		}
+		for j, other := range excludes {
+			if i == j || strings.HasPrefix(other, "!") {
+				continue
+			}
+			duplicate := NormalizePattern(pattern) == NormalizePattern(other)
+			if duplicate && j > i {
+				continue
+			}
+			if !duplicate && !globCovers(other, pattern) {
+				continue
+			}
+			results = append(results, LintResult{
+				ValidationError: ValidationError{
+					Severity: "warning",
+					Field:    fmt.Sprintf("profile.%s.redaction_config.exclude_paths[%d]", profileName, i),
+					Message:  fmt.Sprintf("redaction exclude path %q is already covered by %q", pattern, other),
+					Suggest:  fmt.Sprintf("Remove %q from redaction_config.exclude_paths", pattern),
+				},
+				Code: "redundant-redaction-exclude",
+			})
+			break
+		}
+	}
+
+	return results
+}
+
+// globCovers reports whether every path matched by inner is also matched by
+// outer, using heuristics rather than a full glob containment check: "**"
+// covers everything, "dir/**" covers any pattern whose literal path prefix is
+// dir or lies below it, and any outer pattern covers a literal inner path it
+// matches. It returns false when containment cannot be established.
+func globCovers(outer, inner string) bool {
+	outer, inner = NormalizePattern(outer), NormalizePattern(inner)
+	if outer == inner {
+		return true
+	}
+	if outer == "**" {
+		return true
+	}
+
+	if dir, ok := strings.CutSuffix(outer, "/**"); ok && !strings.ContainsAny(dir, globMetaChars) {
+		prefix := literalPathPrefix(inner)
+		if prefix == dir || strings.HasPrefix(prefix, dir+"/") {
+			return true
+		}
+	}
+
+	if !strings.ContainsAny(inner, globMetaChars) {
+		matched, err := doublestar.Match(outer, inner)
+		return err == nil && matched
+	}
+	return false
+}
+
+// lintIncludeWithoutTiers detects profiles that set include globs but leave
+// every relevance tier empty. The included files are then ranked only by the
+// built-in default tiers, and any that match none of them fall to the default
+// unmatched tier, so the budget gets no project-specific prioritisation.
+//
+// The check does not fire when relying on the defaults is clearly intended or
+// the tiers come from elsewhere: include_only profiles (tiers are not
+// applied), profiles that extend a parent (tiers are inherited), and profiles
+// with a relevance_file.
+func lintIncludeWithoutTiers(profileName string, p *Profile) []LintResult {
+	if len(p.Include) == 0 || p.IncludeOnly || p.Extends != nil || p.RelevanceFile != "" {
+		return nil
+	}
+
+	rel := p.Relevance
+	for _, tier := range [][]string{rel.Tier0, rel.Tier1, rel.Tier2, rel.Tier3, rel.Tier4, rel.Tier5} {
+		if len(tier) > 0 {
+			return nil
+		}
+	}
+
+	return []LintResult{
+		{
+			ValidationError: ValidationError{
+				Severity: "warning",
+				Field:    fmt.Sprintf("profile.%s.include", profileName),
+				Message: fmt.Sprintf(
+					"profile sets %d include patterns but no relevance tiers; included files are ranked only by the default tiers",
+					len(p.Include),
+				),
+				Suggest: "Add [relevance] tiers for the included paths so they are prioritised within the token budget",
+			},
+			Code: "include-without-tiers",
+		},
+	}
+}
+
 // complexityThreshold is the number of non-default fields above which a
 // profile is considered overly complex.
 const complexityThreshold = 8
@@ -725,7 +1488,7 @@ func profileComplexityScore(p *Profile) int {
 	if p.Format != "" {
 		score++
 	}
-	if p.MaxTokens != 0 {
+	if p.MaxTokens != 0 || p.MaxTokensPercent != 0 {
 		score++
 	}
 	if p.Tokenizer != "" {
@@ -749,6 +1512,9 @@ func profileComplexityScore(p *Profile) int {
 	if len(p.Include) > 0 {
 		score++
 	}
+	if p.IncludeOnly {
+		score++
+	}
 	if len(p.Relevance.Tier0) > 0 {
 		score++
 	}
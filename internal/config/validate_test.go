@@ -272,7 +272,7 @@ func TestValidate_AllValidTargets(t *testing.T) {
 func TestValidate_AllValidConfidenceThresholds(t *testing.T) {
 	t.Parallel()
 
-	valid := []string{"high", "medium", "low", ""}
+	valid := []string{"high", "medium", "low", "off", ""}
 	for _, ct := range valid {
 		ct := ct
 		t.Run("confidence_threshold="+ct, func(t *testing.T) {
@@ -313,6 +313,95 @@ func TestValidate_InvalidFormat(t *testing.T) {
 	assert.Contains(t, formatErrs[0].Suggest, "markdown")
 }
 
+// TestValidate_EnumDidYouMean verifies that a near-miss enum value gets a
+// "did you mean" hint in front of the valid options list, and that a value
+// with no close match gets the options list alone.
+func TestValidate_EnumDidYouMean(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		profile *Profile
+		field   string
+		want    string
+	}{
+		{
+			name:    "format",
+			profile: &Profile{Format: "markdow"},
+			field:   "profile.default.format",
+			want:    "did you mean markdown? Valid formats: markdown, xml, plain, json",
+		},
+		{
+			name:    "tokenizer",
+			profile: &Profile{Tokenizer: "o200k_bse"},
+			field:   "profile.default.tokenizer",
+			want:    "did you mean o200k_base? Valid tokenizers: cl100k_base, o200k_base, none",
+		},
+		{
+			name:    "target",
+			profile: &Profile{Target: "Claude"},
+			field:   "profile.default.target",
+			want:    "did you mean claude? Valid targets: claude, chatgpt, generic (or leave empty)",
+		},
+		{
+			name:    "confidence_threshold",
+			profile: &Profile{RedactionConfig: RedactionConfig{ConfidenceThreshold: "meduim"}},
+			field:   "profile.default.redaction_config.confidence_threshold",
+			want:    "did you mean medium? Valid values: high, medium, low, off",
+		},
+		{
+			name:    "sort_order",
+			profile: &Profile{SortOrder: "tokens_asc"},
+			field:   "profile.default.sort_order",
+			want:    "did you mean tokens-asc? Valid values: path, tokens-desc, tokens-asc, tokens, mtime, size",
+		},
+		{
+			name:    "redaction mode",
+			profile: &Profile{RedactionConfig: RedactionConfig{Mode: "partal"}},
+			field:   "profile.default.redaction_config.mode",
+			want:    "did you mean partial? Valid values: full, partial",
+		},
+		{
+			name:    "no close match",
+			profile: &Profile{Format: "yaml-document"},
+			field:   "profile.default.format",
+			want:    "Valid formats: markdown, xml, plain, json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := Validate(&Config{Profile: map[string]*Profile{"default": tt.profile}})
+			errs := errorsWithField(errorsWithSeverity(result, "error"), tt.field)
+			require.Len(t, errs, 1)
+			assert.Equal(t, tt.want, errs[0].Suggest)
+		})
+	}
+}
+
+// TestValidate_MaxTokensPercentRange verifies that a percentage max_tokens
+// outside 1-100 is an error, both when loading a config file and when
+// validating a profile built in code.
+func TestValidate_MaxTokensPercentRange(t *testing.T) {
+	t.Parallel()
+
+	for _, pct := range []string{"150%", "0%"} {
+		_, err := LoadFromString("[profile.default]\nmax_tokens = \""+pct+"\"\n", "<test>")
+		require.Error(t, err, pct)
+		assert.Contains(t, err.Error(), "between 1 and 100", pct)
+	}
+
+	cfg := &Config{Profile: map[string]*Profile{"default": {MaxTokensPercent: 150}}}
+	errs := errorsWithField(errorsWithSeverity(Validate(cfg), "error"), "profile.default.max_tokens")
+	require.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Message, "150%")
+
+	ok := Validate(&Config{Profile: map[string]*Profile{"default": {MaxTokensPercent: 80}}})
+	assert.Empty(t, errorsWithField(errorsWithSeverity(ok, "error"), "profile.default.max_tokens"))
+}
+
 // TestValidate_InvalidTokenizer verifies that an unrecognised tokenizer value
 // produces a hard error with valid options in the Suggest field.
 func TestValidate_InvalidTokenizer(t *testing.T) {
@@ -352,6 +441,45 @@ func TestValidate_InvalidTarget(t *testing.T) {
 	assert.NotEmpty(t, targetErrs[0].Suggest)
 }
 
+// TestValidate_SortOrder verifies the accepted values for sort_order and that
+// anything else is a hard error.
+func TestValidate_SortOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		order   string
+		wantErr bool
+	}{
+		{order: ""},
+		{order: "path"},
+		{order: "tokens"},
+		{order: "tokens-desc"},
+		{order: "tokens-asc"},
+		{order: "mtime"},
+		{order: "size"},
+		{order: "random", wantErr: true},
+		{order: "Size", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run("sort_order="+tt.order, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{
+				Profile: map[string]*Profile{
+					"p": {SortOrder: tt.order},
+				},
+			}
+			errs := errorsWithField(errorsWithSeverity(Validate(cfg), "error"), "profile.p.sort_order")
+			if tt.wantErr {
+				require.Len(t, errs, 1)
+				assert.Contains(t, errs[0].Message, tt.order)
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
+
 // TestValidate_InvalidConfidenceThreshold verifies that an unrecognised
 // confidence_threshold value produces a hard error.
 func TestValidate_InvalidConfidenceThreshold(t *testing.T) {
@@ -392,6 +520,24 @@ func TestValidate_NegativeMaxTokens(t *testing.T) {
 	assert.NotEmpty(t, tokenErrs[0].Suggest)
 }
 
+// TestValidate_NegativeMaxFileTokens verifies that a negative max_file_tokens
+// value produces a hard error.
+func TestValidate_NegativeMaxFileTokens(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"default": {MaxFileTokens: -1},
+		},
+	}
+
+	result := Validate(cfg)
+	errs := errorsWithSeverity(result, "error")
+	capErrs := errorsWithField(errs, "profile.default.max_file_tokens")
+	require.NotEmpty(t, capErrs)
+	assert.Contains(t, capErrs[0].Message, "-1")
+}
+
 // TestValidate_MaxTokensExceedsHardCap verifies that a max_tokens value above
 // 2,000,000 produces a hard error.
 func TestValidate_MaxTokensExceedsHardCap(t *testing.T) {
@@ -622,6 +768,24 @@ func TestValidate_CircularInheritance(t *testing.T) {
 	require.NotEmpty(t, circularErrs, "circular inheritance must produce a hard error")
 }
 
+// TestValidate_CircularInheritance_CyclePath verifies that the circular
+// error message is the ordered cycle without the resolution wrapping.
+func TestValidate_CircularInheritance_CyclePath(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"a": {Extends: strPtr("b")},
+			"b": {Extends: strPtr("c")},
+			"c": {Extends: strPtr("a")},
+		},
+	}
+
+	errs := errorsWithField(Validate(cfg), "profile.a.extends")
+	require.Len(t, errs, 1)
+	assert.Equal(t, "circular profile inheritance: a -> b -> c -> a", errs[0].Message)
+}
+
 // ── Validate: warnings ────────────────────────────────────────────────────────
 
 // TestValidate_OverlappingTierPatterns verifies that a pattern appearing in
@@ -647,6 +811,50 @@ func TestValidate_OverlappingTierPatterns(t *testing.T) {
 	assert.Contains(t, tierWarnings[0].Message, "go.mod")
 }
 
+// TestValidateWithOptions_CanonicalizePatterns verifies that spellings of the
+// same pattern are reported as overlapping only in canonical mode.
+func TestValidateWithOptions_CanonicalizePatterns(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"default": {
+				Relevance: RelevanceConfig{
+					Tier1: []string{"./src/**"},
+					Tier2: []string{"src/**"},
+				},
+			},
+		},
+	}
+
+	raw := errorsWithField(errorsWithSeverity(Validate(cfg), "warning"), "profile.default.relevance.tier_2")
+	assert.Empty(t, raw, "raw comparison must not flag differently spelled patterns")
+
+	canonical := errorsWithField(
+		errorsWithSeverity(ValidateWithOptions(cfg, ValidateOptions{CanonicalizePatterns: true}), "warning"),
+		"profile.default.relevance.tier_2",
+	)
+	require.Len(t, canonical, 1)
+	assert.Contains(t, canonical[0].Message, `"src/**" also appears in tier_1`)
+}
+
+func TestNormalizePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"./src/**":     "src/**",
+		"src//**":      "src/**",
+		"docs/":        "docs",
+		"!./vendor/**": "!vendor/**",
+		`src/\*.go`:   `src/\*.go`,
+		"go.mod":       "go.mod",
+		"":             "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NormalizePattern(in), "NormalizePattern(%q)", in)
+	}
+}
+
 // TestValidate_PriorityFileInIgnoreList verifies that a priority_files entry
 // that also appears in the ignore list produces a warning.
 func TestValidate_PriorityFileInIgnoreList(t *testing.T) {
@@ -720,6 +928,27 @@ func TestValidate_PriorityFilesWithExactPaths(t *testing.T) {
 	assert.Empty(t, pfWarnings, "exact paths must not produce a glob warning")
 }
 
+// TestValidate_PriorityGlobsSuppressesGlobWarning verifies that the glob
+// warning fires while priority_globs is off and is suppressed once it is on.
+func TestValidate_PriorityGlobsSuppressesGlobWarning(t *testing.T) {
+	t.Parallel()
+
+	for _, globs := range []bool{false, true} {
+		cfg := &Config{
+			Profile: map[string]*Profile{
+				"p": {PriorityFiles: []string{"docs/*.md"}, PriorityGlobs: globs},
+			},
+		}
+		warnings := errorsWithField(errorsWithSeverity(Validate(cfg), "warning"), "profile.p.priority_files")
+		if globs {
+			assert.Empty(t, warnings, "priority_globs = true must suppress the glob warning")
+		} else {
+			require.Len(t, warnings, 1, "priority_globs = false must keep the glob warning")
+			assert.Contains(t, warnings[0].Suggest, "priority_globs")
+		}
+	}
+}
+
 // TestValidate_MaxTokensAboveSoftCap verifies that max_tokens between 500,001
 // and 2,000,000 produces a warning (but no hard error).
 func TestValidate_MaxTokensAboveSoftCap(t *testing.T) {
@@ -836,6 +1065,51 @@ func TestValidate_EmptyOutput(t *testing.T) {
 	assert.Empty(t, outputWarnings)
 }
 
+// TestValidate_StdoutOutput verifies that output = "-", which selects stdout,
+// does NOT produce an output path warning.
+func TestValidate_StdoutOutput(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {Output: "-"},
+		},
+	}
+
+	result := Validate(cfg)
+	warnings := errorsWithSeverity(result, "warning")
+	outputWarnings := errorsWithField(warnings, "profile.p.output")
+	assert.Empty(t, outputWarnings)
+}
+
+// TestValidate_CaseInsensitiveCharClassWarning verifies that letter character
+// classes warn only when case_insensitive is set.
+func TestValidate_CaseInsensitiveCharClassWarning(t *testing.T) {
+	t.Parallel()
+
+	newCfg := func(caseInsensitive bool) *Config {
+		return &Config{
+			Profile: map[string]*Profile{
+				"p": {
+					CaseInsensitive: caseInsensitive,
+					Ignore:          []string{"[A-Z]*.log", "tmp/[0-9]*", `notes\[a]`},
+					Relevance:       RelevanceConfig{Tier1: []string{"src/[Mm]ain.go"}},
+				},
+			},
+		}
+	}
+
+	warnings := errorsWithSeverity(Validate(newCfg(true)), "warning")
+	assert.NotEmpty(t, errorsWithField(warnings, "profile.p.ignore[0]"))
+	assert.Empty(t, errorsWithField(warnings, "profile.p.ignore[1]"), "digit classes do not fold")
+	assert.Empty(t, errorsWithField(warnings, "profile.p.ignore[2]"), "escaped brackets are not a class")
+	assert.NotEmpty(t, errorsWithField(warnings, "profile.p.relevance.tier_1[0]"))
+
+	warnings = errorsWithSeverity(Validate(newCfg(false)), "warning")
+	assert.Empty(t, errorsWithField(warnings, "profile.p.ignore[0]"))
+	assert.Empty(t, errorsWithField(warnings, "profile.p.relevance.tier_1[0]"))
+}
+
 // TestValidate_EmptyTierWarning verifies that a non-nil but empty relevance
 // tier slice produces a warning.
 func TestValidate_EmptyTierWarning(t *testing.T) {
@@ -898,11 +1172,63 @@ func TestValidate_RedactionExcludeOverlapsIgnore(t *testing.T) {
 
 	result := Validate(cfg)
 	warnings := errorsWithSeverity(result, "warning")
-	redactionWarnings := errorsWithField(warnings, "profile.p.redaction_config.exclude_paths")
+	redactionWarnings := errorsWithField(warnings, "profile.p.redaction_config.exclude_paths[0]")
 	require.NotEmpty(t, redactionWarnings)
 	assert.Contains(t, redactionWarnings[0].Message, "testdata")
 }
 
+// TestValidate_RedactionConfigDisabled verifies that custom_patterns and
+// exclude_paths set while redaction_config.enabled is false each produce a
+// warning, and that the same settings with enabled = true do not.
+func TestValidate_RedactionConfigDisabled(t *testing.T) {
+	t.Parallel()
+
+	rc := RedactionConfig{
+		ExcludePaths: []string{"fixtures/**"},
+		CustomPatterns: []CustomPatternDefinition{
+			{ID: "internal-key", Regex: `ik_[a-z0-9]{16}`, SecretType: "internal_key", Confidence: "high"},
+		},
+	}
+	enabled := rc
+	enabled.Enabled = true
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"off": {RedactionConfig: rc},
+			"on":  {RedactionConfig: enabled},
+		},
+	}
+
+	warnings := errorsWithSeverity(Validate(cfg), "warning")
+
+	custom := errorsWithField(warnings, "profile.off.redaction_config.custom_patterns")
+	require.Len(t, custom, 1)
+	assert.Contains(t, custom[0].Message, "enabled is false")
+	assert.Contains(t, custom[0].Suggest, "enabled = true")
+
+	exclude := errorsWithField(warnings, "profile.off.redaction_config.exclude_paths")
+	require.Len(t, exclude, 1)
+	assert.Contains(t, exclude[0].Message, "enabled is false")
+
+	assert.Empty(t, errorsWithField(warnings, "profile.on.redaction_config"),
+		"consistent redaction config must not warn")
+}
+
+// TestValidate_RedactionConfigDisabled_NothingSet verifies that a disabled
+// redaction config with no patterns or exclusions does not warn.
+func TestValidate_RedactionConfigDisabled_NothingSet(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {RedactionConfig: RedactionConfig{Enabled: false}},
+		},
+	}
+
+	warnings := errorsWithSeverity(Validate(cfg), "warning")
+	assert.Empty(t, errorsWithField(warnings, "profile.p.redaction_config"))
+}
+
 // TestValidate_DeepInheritanceWarning verifies that a profile inheritance
 // chain longer than 3 levels produces a warning.
 func TestValidate_DeepInheritanceWarning(t *testing.T) {
@@ -1188,6 +1514,319 @@ func TestLint_NoExtensionPattern_DottedHiddenFile(t *testing.T) {
 	require.NotEmpty(t, noExt, ".gitignore is treated as having no real extension")
 }
 
+// TestValidate_IncludeOnlyWithoutInclude verifies that include_only = true
+// with an empty include list is a hard error.
+func TestValidate_IncludeOnlyWithoutInclude(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {IncludeOnly: true},
+		},
+	}
+
+	errs := errorsWithField(Validate(cfg), "profile.p.include_only")
+	require.Len(t, errs, 1)
+	assert.Equal(t, "error", errs[0].Severity)
+	assert.NotEmpty(t, errs[0].Suggest)
+}
+
+// TestValidate_IncludeOnlyWithInclude verifies that include_only with a
+// non-empty include list produces no include_only findings.
+func TestValidate_IncludeOnlyWithInclude(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Include:     []string{"internal/**/*.go"},
+				IncludeOnly: true,
+			},
+		},
+	}
+
+	errs := errorsWithField(Validate(cfg), "profile.p.include_only")
+	assert.Empty(t, errs)
+}
+
+// ── Lint: tier-pattern-ignored ────────────────────────────────────────────────
+
+// TestLint_TierPatternIgnored_ExactPrefix verifies that a tier pattern rooted
+// in an ignored directory is flagged with Code = "tier-pattern-ignored".
+func TestLint_TierPatternIgnored_ExactPrefix(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore: []string{"vendor"},
+				Relevance: RelevanceConfig{
+					Tier1: []string{"vendor/**/*.go"},
+				},
+			},
+		},
+	}
+
+	lintResults := Lint(cfg)
+	ignored := lintResultsWithCode(lintResults, "tier-pattern-ignored")
+	require.Len(t, ignored, 1)
+	assert.Equal(t, "profile.p.relevance.tier_1[0]", ignored[0].Field)
+	assert.Equal(t, "warning", ignored[0].Severity)
+	assert.Contains(t, ignored[0].Message, "vendor/**/*.go")
+	assert.NotEmpty(t, ignored[0].Suggest)
+}
+
+// TestLint_TierPatternIgnored_NoOverlap verifies that tier patterns outside
+// every ignored path are not flagged, including sibling directories that
+// merely share a name prefix.
+func TestLint_TierPatternIgnored_NoOverlap(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore: []string{"vendor/", "dist/**"},
+				Relevance: RelevanceConfig{
+					Tier0: []string{"go.mod"},
+					Tier1: []string{"internal/**/*.go", "vendored/**/*.go"},
+					Tier3: []string{"**/*_test.go"},
+				},
+			},
+		},
+	}
+
+	lintResults := Lint(cfg)
+	ignored := lintResultsWithCode(lintResults, "tier-pattern-ignored")
+	assert.Empty(t, ignored, "patterns outside ignored paths must not be flagged")
+}
+
+// TestLint_TierPatternIgnored_PartialTier verifies that only the covered index
+// of a tier list is flagged when the remaining patterns are reachable.
+func TestLint_TierPatternIgnored_PartialTier(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore: []string{"node_modules/**", "build"},
+				Relevance: RelevanceConfig{
+					Tier2: []string{"src/**/*.ts", "build/gen/*.ts", "lib/**/*.ts"},
+				},
+			},
+		},
+	}
+
+	lintResults := Lint(cfg)
+	ignored := lintResultsWithCode(lintResults, "tier-pattern-ignored")
+	require.Len(t, ignored, 1)
+	assert.Equal(t, "profile.p.relevance.tier_2[1]", ignored[0].Field)
+	assert.Contains(t, ignored[0].Message, "build")
+}
+
+// ── Lint: shallow-glob ────────────────────────────────────────────────────────
+
+// TestLint_ShallowGlob_Flagged verifies that tier and ignore patterns ending in
+// a bare "/*" are flagged with Code = "shallow-glob" and a "/**" suggestion.
+func TestLint_ShallowGlob_Flagged(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore: []string{"tmp/*"},
+				Relevance: RelevanceConfig{
+					Tier1: []string{"internal/**/*.go", "src/*"},
+				},
+			},
+		},
+	}
+
+	shallow := lintResultsWithCode(Lint(cfg), "shallow-glob")
+	require.Len(t, shallow, 2)
+
+	byField := make(map[string]LintResult)
+	for _, r := range shallow {
+		byField[r.Field] = r
+	}
+
+	tier, ok := byField["profile.p.relevance.tier_1[1]"]
+	require.True(t, ok, "src/* must be flagged")
+	assert.Equal(t, "warning", tier.Severity)
+	assert.Contains(t, tier.Message, "src/*")
+	assert.Contains(t, tier.Suggest, `"src/**"`)
+
+	ignore, ok := byField["profile.p.ignore[0]"]
+	require.True(t, ok, "tmp/* must be flagged")
+	assert.Contains(t, ignore.Suggest, `"tmp/**"`)
+}
+
+// TestLint_ShallowGlob_NotFlagged verifies that single-star patterns with an
+// extension, recursive patterns, and literal paths are not flagged.
+func TestLint_ShallowGlob_NotFlagged(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Ignore: []string{"dist/**", "vendor"},
+				Relevance: RelevanceConfig{
+					Tier0: []string{"go.mod"},
+					Tier1: []string{"src/*.go", "src/**", "cmd/*/main.go"},
+				},
+			},
+		},
+	}
+
+	assert.Empty(t, lintResultsWithCode(Lint(cfg), "shallow-glob"))
+}
+
+// ── Lint: bounded-depth ───────────────────────────────────────────────────────
+
+// TestLint_BoundedDepth verifies that single-star tier patterns under a
+// directory get an info note with their depth, while recursive, root-level,
+// literal, and shallow-glob patterns do not.
+func TestLint_BoundedDepth(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Relevance: RelevanceConfig{
+					Tier0: []string{"go.mod", "*.config.*"},
+					Tier1: []string{"src/*.go", "src/**", "src/*"},
+					Tier2: []string{"cmd/*/main.go"},
+				},
+			},
+		},
+	}
+
+	notes := lintResultsWithCode(Lint(cfg), "bounded-depth")
+	require.Len(t, notes, 2)
+
+	byField := make(map[string]LintResult)
+	for _, r := range notes {
+		byField[r.Field] = r
+	}
+
+	goFiles, ok := byField["profile.p.relevance.tier_1[0]"]
+	require.True(t, ok, "src/*.go must be noted")
+	assert.Equal(t, "info", goFiles.Severity)
+	assert.Contains(t, goFiles.Message, "1 level(s) below src/")
+	assert.Contains(t, goFiles.Suggest, `"src/**/*.go"`)
+
+	mains, ok := byField["profile.p.relevance.tier_2[0]"]
+	require.True(t, ok, "cmd/*/main.go must be noted")
+	assert.Contains(t, mains.Message, "2 level(s) below cmd/")
+}
+
+// ── Lint: redundant-redaction-exclude ─────────────────────────────────────────
+
+// TestLint_RedundantRedactionExclude_Nested verifies that an exclude path
+// inside another exclude path's directory is flagged at its own index.
+func TestLint_RedundantRedactionExclude_Nested(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				RedactionConfig: RedactionConfig{
+					ExcludePaths: []string{"docs/api/**", "testdata/**", "docs/**", "docs/README.md"},
+				},
+			},
+		},
+	}
+
+	results := lintResultsWithCode(Lint(cfg), "redundant-redaction-exclude")
+	require.Len(t, results, 2)
+	assert.Equal(t, "profile.p.redaction_config.exclude_paths[0]", results[0].Field)
+	assert.Equal(t, "warning", results[0].Severity)
+	assert.Contains(t, results[0].Message, `"docs/**"`)
+	assert.Equal(t, "profile.p.redaction_config.exclude_paths[3]", results[1].Field)
+}
+
+// TestLint_RedundantRedactionExclude_Unrelated verifies that exclude paths
+// with no containment between them are not flagged.
+func TestLint_RedundantRedactionExclude_Unrelated(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				RedactionConfig: RedactionConfig{
+					ExcludePaths: []string{"docs/**", "testdata/**", "**/*.md", "docsite/**"},
+				},
+			},
+		},
+	}
+
+	assert.Empty(t, lintResultsWithCode(Lint(cfg), "redundant-redaction-exclude"))
+}
+
+// TestLint_RedundantRedactionExclude_Duplicate verifies that only the later
+// of two identical exclude paths is flagged.
+func TestLint_RedundantRedactionExclude_Duplicate(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				RedactionConfig: RedactionConfig{
+					ExcludePaths: []string{"fixtures/**", "vendor/**", "./fixtures/**"},
+				},
+			},
+		},
+	}
+
+	results := lintResultsWithCode(Lint(cfg), "redundant-redaction-exclude")
+	require.Len(t, results, 1)
+	assert.Equal(t, "profile.p.redaction_config.exclude_paths[2]", results[0].Field)
+}
+
+// ── Lint: include-without-tiers ───────────────────────────────────────────────
+
+// TestLint_IncludeWithoutTiers_Flagged verifies that a profile with include
+// globs and no relevance tiers is flagged on its include field.
+func TestLint_IncludeWithoutTiers_Flagged(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {Include: []string{"src/**", "docs/**/*.md"}},
+		},
+	}
+
+	results := lintResultsWithCode(Lint(cfg), "include-without-tiers")
+	require.Len(t, results, 1)
+	assert.Equal(t, "warning", results[0].Severity)
+	assert.Equal(t, "profile.p.include", results[0].Field)
+	assert.Contains(t, results[0].Message, "2 include patterns")
+	assert.NotEmpty(t, results[0].Suggest)
+}
+
+// TestLint_IncludeWithoutTiers_NotFlagged verifies that the check stays quiet
+// when tiers are customised, when there is nothing included, and when the
+// tiers are intentionally not set on the profile itself.
+func TestLint_IncludeWithoutTiers_NotFlagged(t *testing.T) {
+	t.Parallel()
+
+	parent := "default"
+	profiles := map[string]*Profile{
+		"no-include": {},
+		"with-tiers": {
+			Include:   []string{"src/**"},
+			Relevance: RelevanceConfig{Tier1: []string{"src/**/*.go"}},
+		},
+		"include-only":   {Include: []string{"src/**"}, IncludeOnly: true},
+		"extends":        {Include: []string{"src/**"}, Extends: &parent},
+		"relevance-file": {Include: []string{"src/**"}, RelevanceFile: "tiers.toml"},
+	}
+
+	for name, p := range profiles {
+		cfg := &Config{Profile: map[string]*Profile{name: p}}
+		assert.Empty(t, lintResultsWithCode(Lint(cfg), "include-without-tiers"), name)
+	}
+}
+
 // ── Lint: complexity ──────────────────────────────────────────────────────────
 
 // TestLint_Complexity_HighScore verifies that a profile with more than 8
@@ -1312,6 +1951,108 @@ func TestLint_CombinedScenario(t *testing.T) {
 	assert.True(t, codes["no-ext-match"], "must detect no-ext-match")
 }
 
+// TestValidate_BaseDir verifies that an absolute or escaping base_dir is a
+// hard error while a relative path inside the repo is accepted.
+func TestValidate_BaseDir(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"ok":     {BaseDir: "packages/api"},
+			"abs":    {BaseDir: "/srv/app"},
+			"escape": {BaseDir: "../outside"},
+		},
+	}
+	errs := errorsWithSeverity(Validate(cfg), "error")
+
+	assert.Empty(t, errorsWithField(errs, "profile.ok.base_dir"))
+	assert.NotEmpty(t, errorsWithField(errs, "profile.abs.base_dir"))
+	assert.NotEmpty(t, errorsWithField(errs, "profile.escape.base_dir"))
+}
+
+// ── LintWithConfig ────────────────────────────────────────────────────────────
+
+// lintSuppressConfig returns a profile that triggers complexity, unreachable
+// tier, and no-ext-match lints alongside a hard format error.
+func lintSuppressConfig() *Config {
+	return &Config{
+		Profile: map[string]*Profile{
+			"mega": {
+				Output:        "out.md",
+				Format:        "html",
+				MaxTokens:     64000,
+				Tokenizer:     "cl100k_base",
+				Compression:   true,
+				Redaction:     true,
+				Target:        "claude",
+				Ignore:        []string{"node_modules"},
+				PriorityFiles: []string{"go.mod"},
+				Include:       []string{"src/**"},
+				Relevance: RelevanceConfig{
+					Tier0: []string{"go.mod", "internal/**"},
+					Tier1: []string{"go.mod"},
+				},
+			},
+		},
+	}
+}
+
+// TestLintWithConfig_SuppressComplexity verifies that suppressing "complexity"
+// removes only complexity results and leaves every other result in place.
+func TestLintWithConfig_SuppressComplexity(t *testing.T) {
+	t.Parallel()
+
+	all := Lint(lintSuppressConfig())
+	require.NotEmpty(t, lintResultsWithCode(all, "complexity"))
+
+	got := LintWithConfig(lintSuppressConfig(), map[string]bool{"complexity": true})
+
+	assert.Empty(t, lintResultsWithCode(got, "complexity"))
+	assert.Len(t, got, len(all)-len(lintResultsWithCode(all, "complexity")))
+	assert.NotEmpty(t, lintResultsWithCode(got, "unreachable-tier"))
+	assert.NotEmpty(t, lintResultsWithCode(got, "no-ext-match"))
+}
+
+// TestLintWithConfig_HardErrorsSurvive verifies that Validate-derived results
+// (empty Code) are kept even when every lint code and the empty code are
+// suppressed.
+func TestLintWithConfig_HardErrorsSurvive(t *testing.T) {
+	t.Parallel()
+
+	suppress := map[string]bool{
+		"":                     true,
+		"complexity":           true,
+		"unreachable-tier":     true,
+		"no-ext-match":         true,
+		"tier-pattern-ignored": true,
+	}
+	got := LintWithConfig(lintSuppressConfig(), suppress)
+
+	require.NotEmpty(t, got)
+	for _, r := range got {
+		assert.Empty(t, r.Code, "only Validate-derived results may remain")
+	}
+	formatErrs := errorsWithField(lintErrors(got), "profile.mega.format")
+	assert.NotEmpty(t, formatErrs, "invalid format must survive suppression")
+}
+
+// TestLintWithConfig_NilSuppressMatchesLint verifies that a nil suppress map
+// returns the same results as Lint.
+func TestLintWithConfig_NilSuppressMatchesLint(t *testing.T) {
+	t.Parallel()
+
+	assert.Len(t, LintWithConfig(lintSuppressConfig(), nil), len(Lint(lintSuppressConfig())))
+}
+
+// lintErrors unwraps the embedded ValidationError of each LintResult.
+func lintErrors(results []LintResult) []ValidationError {
+	errs := make([]ValidationError, 0, len(results))
+	for _, r := range results {
+		errs = append(errs, r.ValidationError)
+	}
+	return errs
+}
+
 // ── Determinism: map iteration independence ───────────────────────────────────
 
 // TestValidate_DeterministicAcrossRuns verifies that running Validate multiple
@@ -1346,6 +2087,39 @@ func TestValidate_DeterministicAcrossRuns(t *testing.T) {
 	}
 }
 
+// TestValidate_SortedOutput verifies that Validate and Lint return their
+// results ordered by profile name, then field, on every run.
+func TestValidate_SortedOutput(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"zeta":  {Format: "html", Tokenizer: "gpt2"},
+			"alpha": {Target: "gemini", Format: "yaml"},
+			"mid":   {MaxTokens: -1},
+		},
+	}
+
+	first := Validate(cfg)
+	fields := make([]string, 0, len(first))
+	for _, e := range first {
+		fields = append(fields, e.Field)
+	}
+	assert.Equal(t, []string{
+		"profile.alpha.format",
+		"profile.alpha.target",
+		"profile.mid.max_tokens",
+		"profile.zeta.format",
+		"profile.zeta.tokenizer",
+	}, fields)
+
+	firstLint := Lint(cfg)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, first, Validate(cfg), "run %d", i+2)
+		assert.Equal(t, firstLint, Lint(cfg), "run %d", i+2)
+	}
+}
+
 // ── Boundary: max_tokens exact boundaries ────────────────────────────────────
 
 // TestValidate_MaxTokensBoundaries exercises all relevant boundary values for
@@ -1859,6 +2633,37 @@ func TestValidate_ExcludePaths_InvalidGlob(t *testing.T) {
 	assert.Contains(t, pathErrs[0].Field, "[1]", "field must contain the index of the bad pattern")
 }
 
+// TestValidate_RelevanceLabels verifies that labels for unknown tiers and
+// blank labels are hard errors.
+func TestValidate_RelevanceLabels(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{
+		Profile: map[string]*Profile{
+			"p": {
+				Relevance: RelevanceConfig{
+					Labels: map[string]string{
+						"tier_0": "Schemas",
+						"tier_6": "Extra",
+						"tier_2": "  ",
+					},
+				},
+			},
+		},
+	}
+
+	errs := errorsWithSeverity(Validate(cfg), "error")
+	assert.Empty(t, errorsWithField(errs, "profile.p.relevance.labels.tier_0"))
+
+	unknown := errorsWithField(errs, "profile.p.relevance.labels.tier_6")
+	require.Len(t, unknown, 1)
+	assert.Contains(t, unknown[0].Message, "unknown tier")
+
+	blank := errorsWithField(errs, "profile.p.relevance.labels.tier_2")
+	require.Len(t, blank, 1)
+	assert.Contains(t, blank[0].Message, "empty")
+}
+
 // TestValidate_CustomPatterns_NoPatterns verifies that a profile with no
 // custom patterns does not produce any custom pattern errors.
 func TestValidate_CustomPatterns_NoPatterns(t *testing.T) {
@@ -1913,3 +2718,112 @@ func TestValidate_CustomPatterns_IndexInFieldPath(t *testing.T) {
 	assert.Contains(t, cpErrs[0].Field, "[1]",
 		"field path must include the index of the failing pattern")
 }
+
+// ── Profile.Validate ──────────────────────────────────────────────────────────
+
+// TestProfileValidate_NilProfile verifies that a nil receiver yields no
+// findings rather than panicking.
+func TestProfileValidate_NilProfile(t *testing.T) {
+	t.Parallel()
+
+	var p *Profile
+	assert.Nil(t, p.Validate("default"))
+}
+
+// TestProfileValidate_ValidProfile verifies that a well-formed profile
+// produces no hard errors when validated on its own.
+func TestProfileValidate_ValidProfile(t *testing.T) {
+	t.Parallel()
+
+	p := &Profile{
+		Format:    "xml",
+		Tokenizer: "o200k_base",
+		MaxTokens: 128000,
+	}
+
+	assert.Empty(t, errorsWithSeverity(p.Validate("api"), "error"))
+}
+
+// TestProfileValidate_InvalidFields verifies the scalar checks for format,
+// tokenizer, and max_tokens, and that Field paths use the supplied name.
+func TestProfileValidate_InvalidFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		profile Profile
+		field   string
+		want    string
+	}{
+		{
+			name:    "invalid format",
+			profile: Profile{Format: "html"},
+			field:   "profile.api.format",
+			want:    "html",
+		},
+		{
+			name:    "invalid tokenizer",
+			profile: Profile{Tokenizer: "gpt2"},
+			field:   "profile.api.tokenizer",
+			want:    "gpt2",
+		},
+		{
+			name:    "negative max_tokens",
+			profile: Profile{MaxTokens: -100},
+			field:   "profile.api.max_tokens",
+			want:    "-100",
+		},
+		{
+			name:    "max_tokens above hard cap",
+			profile: Profile{MaxTokens: 2_000_001},
+			field:   "profile.api.max_tokens",
+			want:    "2000001",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			errs := errorsWithField(errorsWithSeverity(tt.profile.Validate("api"), "error"), tt.field)
+			require.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Message, tt.want)
+			assert.NotEmpty(t, errs[0].Suggest)
+		})
+	}
+}
+
+// TestProfileValidate_SkipsInheritance verifies that an extends reference to
+// an unknown profile is not reported, because inheritance can only be checked
+// against the full profile map.
+func TestProfileValidate_SkipsInheritance(t *testing.T) {
+	t.Parallel()
+
+	p := &Profile{Extends: strPtr("ghost")}
+
+	assert.Empty(t, errorsWithField(p.Validate("child"), "profile.child.extends"))
+
+	// The package-level Validate still reports it.
+	cfg := &Config{Profile: map[string]*Profile{"child": p}}
+	assert.NotEmpty(t, errorsWithField(Validate(cfg), "profile.child.extends"))
+}
+
+// TestProfileValidate_MatchesValidate verifies that Validate on a config with
+// a single standalone profile reports the same findings as Profile.Validate.
+func TestProfileValidate_MatchesValidate(t *testing.T) {
+	t.Parallel()
+
+	p := &Profile{
+		Format:    "html",
+		Tokenizer: "gpt2",
+		MaxTokens: 600_000,
+		Output:    "../out.md",
+	}
+
+	direct := p.Validate("default")
+	viaConfig := Validate(&Config{Profile: map[string]*Profile{"default": p}})
+
+	sortValidationErrors(direct)
+	sortValidationErrors(viaConfig)
+	assert.Equal(t, direct, viaConfig)
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultVendorDirs are the directory names treated as vendored when a
+// profile enables stub_vendored without setting vendor_dirs.
+var DefaultVendorDirs = []string{"vendor", "third_party", "node_modules"}
+
+// IsVendoredPath reports whether filePath, relative to the repository root,
+// lies under a directory named in dirs at any depth. An empty dirs uses
+// DefaultVendorDirs. The file name itself is never compared, so a file
+// called "vendor" is not vendored.
+func IsVendoredPath(dirs []string, filePath string) bool {
+	if len(dirs) == 0 {
+		dirs = DefaultVendorDirs
+	}
+	parts := strings.Split(filepath.ToSlash(filePath), "/")
+	for _, dir := range parts[:len(parts)-1] {
+		if slices.Contains(dirs, dir) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsVendoredPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		dirs []string
+		path string
+		want bool
+	}{
+		{"top-level vendor", nil, "vendor/github.com/x/y.go", true},
+		{"nested third_party", nil, "web/third_party/lib.js", true},
+		{"source file", nil, "internal/app.go", false},
+		{"file named vendor", nil, "cmd/vendor", false},
+		{"prefix is not a match", nil, "vendored/x.go", false},
+		{"custom dirs replace defaults", []string{"deps"}, "deps/x.go", true},
+		{"custom dirs drop vendor", []string{"deps"}, "vendor/x.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, IsVendoredPath(tt.dirs, tt.path))
+		})
+	}
+}
+
+func TestResolve_StubVendored(t *testing.T) {
+	clearHarvxEnv(t)
+
+	repoDir := t.TempDir()
+	writeTomlFile(t, repoDir, "harvx.toml", `
+[profile.default]
+stub_vendored = true
+vendor_dirs = ["deps"]
+`)
+
+	rc, err := Resolve(ResolveOptions{
+		TargetDir:        repoDir,
+		GlobalConfigPath: filepath.Join(repoDir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+	assert.True(t, rc.Profile.StubVendored)
+	assert.Equal(t, []string{"deps"}, rc.Profile.VendorDirs)
+	assert.Equal(t, SourceRepo, rc.Sources["stub_vendored"])
+}
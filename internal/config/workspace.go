@@ -68,7 +68,7 @@ func LoadWorkspaceConfig(path string) (*WorkspaceConfig, error) {
 		return nil, fmt.Errorf("parse workspace config %s: %w", path, err)
 	}
 
-	warnUndecodedKeys(meta, path)
+	warnUndecodedKeys(undecodedKeys(meta), path)
 
 	return &cfg, nil
 }
@@ -68,6 +68,7 @@ var DefaultIgnorePatterns = []string{
 	"Cargo.lock",
 	"go.sum",
 	"poetry.lock",
+	".harvx.lock",
 
 	// Compiled artifacts
 	"*.pyc",
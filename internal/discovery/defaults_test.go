@@ -54,6 +54,7 @@ func TestDefaultIgnorePatterns_ContainsExpected(t *testing.T) {
 		"Cargo.lock",
 		"go.sum",
 		"poetry.lock",
+		".harvx.lock",
 		"*.pyc",
 		"*.pyo",
 		"*.class",
@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/harvx/harvx/internal/config"
 )
 
 // PatternFilter applies include, exclude, and extension-based filtering to file
@@ -19,12 +21,15 @@ import (
 //   - Exclude patterns take precedence over includes: if a file matches any
 //     exclude pattern, it is removed regardless of include matches.
 //   - Extension matching is case-insensitive.
+//   - Pattern matching is case-sensitive unless CaseInsensitive is set.
 //   - Patterns use doublestar syntax (e.g., "**/*.ts" matches deeply nested files).
 type PatternFilter struct {
-	includes   []string
-	excludes   []string
-	extensions []string // normalized to lowercase, without leading dot
-	logger     *slog.Logger
+	includes        []string
+	excludes        []string
+	extensions      []string // normalized to lowercase, without leading dot
+	caseInsensitive bool     // includes and excludes are stored lowercased
+	baseDir         string   // includes and excludes are relative to this directory
+	logger          *slog.Logger
 }
 
 // PatternFilterOptions holds the configuration for creating a new PatternFilter.
@@ -40,6 +45,16 @@ type PatternFilterOptions struct {
 	// Extensions is a list of file extensions (without leading dots). This is
 	// the shorthand for -f flag. Extensions are case-insensitive.
 	Extensions []string
+
+	// CaseInsensitive matches Includes and Excludes regardless of letter
+	// case, mirroring config.Profile.CaseInsensitive. Patterns and paths are
+	// folded to lowercase for the comparison only.
+	CaseInsensitive bool
+
+	// BaseDir evaluates Includes and Excludes relative to this directory,
+	// mirroring config.Profile.BaseDir. Paths outside it match neither list.
+	// Extensions are unaffected. Empty means the repository root.
+	BaseDir string
 }
 
 // NewPatternFilter creates a new PatternFilter from the provided options.
@@ -53,13 +68,22 @@ func NewPatternFilter(opts PatternFilterOptions) *PatternFilter {
 		extensions[i] = strings.ToLower(ext)
 	}
 
-	// Copy includes and excludes.
+	// Copy includes and excludes, folding case when requested.
 	includes := make([]string, len(opts.Includes))
 	copy(includes, opts.Includes)
 
 	excludes := make([]string, len(opts.Excludes))
 	copy(excludes, opts.Excludes)
 
+	if opts.CaseInsensitive {
+		for i := range includes {
+			includes[i] = strings.ToLower(includes[i])
+		}
+		for i := range excludes {
+			excludes[i] = strings.ToLower(excludes[i])
+		}
+	}
+
 	logger := slog.Default().With("component", "pattern-filter")
 	logger.Debug("pattern filter initialized",
 		"includes", len(includes),
@@ -68,10 +92,12 @@ func NewPatternFilter(opts PatternFilterOptions) *PatternFilter {
 	)
 
 	return &PatternFilter{
-		includes:   includes,
-		excludes:   excludes,
-		extensions: extensions,
-		logger:     logger,
+		includes:        includes,
+		excludes:        excludes,
+		extensions:      extensions,
+		caseInsensitive: opts.CaseInsensitive,
+		baseDir:         opts.BaseDir,
+		logger:          logger,
 	}
 }
 
@@ -79,6 +105,9 @@ func NewPatternFilter(opts PatternFilterOptions) *PatternFilter {
 // The path should be relative to the repository root, using forward slashes.
 // Returns true if the file passes all filter criteria.
 //
+// Include and exclude patterns are matched against the path rebased onto
+// the filter's base directory; a path outside it matches neither list.
+//
 // Logic:
 //  1. If the path matches any exclude pattern, return false (exclude wins).
 //  2. If no include patterns and no extension filters are set, return true (pass-through).
@@ -93,9 +122,19 @@ func (f *PatternFilter) Matches(path string) bool {
 		return false
 	}
 
+	// Patterns were folded at construction; fold the path to match them.
+	matchPath, inBase := config.RebasePath(f.baseDir, normalizedPath)
+	if f.caseInsensitive {
+		matchPath = strings.ToLower(matchPath)
+	}
+	excludes, includes := f.excludes, f.includes
+	if !inBase {
+		excludes, includes = nil, nil
+	}
+
 	// Step 1: Check excludes first (exclude always wins).
-	for _, pattern := range f.excludes {
-		matched, err := doublestar.Match(pattern, normalizedPath)
+	for _, pattern := range excludes {
+		matched, err := doublestar.Match(pattern, matchPath)
 		if err != nil {
 			f.logger.Debug("invalid exclude pattern",
 				"pattern", pattern,
@@ -118,8 +157,8 @@ func (f *PatternFilter) Matches(path string) bool {
 	}
 
 	// Step 3: Check include patterns (OR logic).
-	for _, pattern := range f.includes {
-		matched, err := doublestar.Match(pattern, normalizedPath)
+	for _, pattern := range includes {
+		matched, err := doublestar.Match(pattern, matchPath)
 		if err != nil {
 			f.logger.Debug("invalid include pattern",
 				"pattern", pattern,
@@ -136,6 +136,39 @@ func TestPatternFilter_Matches_ExtensionCaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestPatternFilter_Matches_CaseInsensitivePatterns(t *testing.T) {
+	t.Parallel()
+
+	opts := PatternFilterOptions{
+		Includes: []string{"docs/**", "README.md"},
+		Excludes: []string{"**/Generated/**"},
+	}
+	folded := opts
+	folded.CaseInsensitive = true
+	sensitive := NewPatternFilter(opts)
+	insensitive := NewPatternFilter(folded)
+
+	tests := []struct {
+		name          string
+		path          string
+		wantSensitive bool
+		wantFolded    bool
+	}{
+		{name: "exact case include", path: "README.md", wantSensitive: true, wantFolded: true},
+		{name: "lowercase literal include", path: "readme.md", wantSensitive: false, wantFolded: true},
+		{name: "uppercase directory include", path: "Docs/intro.md", wantSensitive: false, wantFolded: true},
+		{name: "exclude folds too", path: "docs/generated/api.md", wantSensitive: true, wantFolded: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.wantSensitive, sensitive.Matches(tt.path), "case-sensitive")
+			assert.Equal(t, tt.wantFolded, insensitive.Matches(tt.path), "case-insensitive")
+		})
+	}
+}
+
 func TestPatternFilter_Matches_ExtensionWithDotNormalized(t *testing.T) {
 	t.Parallel()
 
@@ -411,3 +444,38 @@ func BenchmarkPatternFilter_Matches(b *testing.B) {
 		}
 	}
 }
+
+func TestPatternFilter_Matches_BaseDir(t *testing.T) {
+	t.Parallel()
+
+	f := NewPatternFilter(PatternFilterOptions{
+		Includes: []string{"src/**"},
+		Excludes: []string{"src/gen/**"},
+		BaseDir:  "services/api",
+	})
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{name: "include under base dir", path: "services/api/src/main.go", want: true},
+		{name: "exclude under base dir", path: "services/api/src/gen/types.go", want: false},
+		{name: "include pattern at repo root", path: "src/main.go", want: false},
+		{name: "outside base dir", path: "services/web/src/main.go", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, f.Matches(tt.path))
+		})
+	}
+
+	// Without includes, a path outside the base dir is not excluded either.
+	excludeOnly := NewPatternFilter(PatternFilterOptions{
+		Excludes: []string{"**/*.log"},
+		BaseDir:  "services/api",
+	})
+	assert.False(t, excludeOnly.Matches("services/api/debug.log"))
+	assert.True(t, excludeOnly.Matches("debug.log"))
+}
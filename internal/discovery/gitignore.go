@@ -18,13 +18,20 @@ import (
 // GitignoreMatcher loads and evaluates .gitignore patterns hierarchically.
 // It supports nested .gitignore files where each directory level can add
 // patterns that apply only to files within that directory subtree. Parent
-// .gitignore rules are inherited by all subdirectories.
+// .gitignore rules are inherited by all subdirectories, and as in git a
+// deeper .gitignore takes precedence: a negation pattern such as !keep.log in
+// src/.gitignore re-includes src/keep.log even when the root .gitignore
+// ignores *.log.
 //
 // Paths passed to IsIgnored must be relative to the root directory that was
 // used to construct the matcher.
 type GitignoreMatcher struct {
 	root     string
 	matchers map[string]*gitignore.GitIgnore
+	// reincluders holds, for each .gitignore that contains negation
+	// patterns, a copy of its patterns preceded by a match-all pattern. A
+	// path that this copy does not match was re-included by the file.
+	reincluders map[string]*gitignore.GitIgnore
 	// dirs stores the sorted list of directory keys for deterministic
 	// iteration from root toward the file's parent directory.
 	dirs   []string
@@ -55,9 +62,10 @@ func NewGitignoreMatcher(rootDir string) (*GitignoreMatcher, error) {
 	logger := slog.Default().With("component", "gitignore")
 
 	m := &GitignoreMatcher{
-		root:     absRoot,
-		matchers: make(map[string]*gitignore.GitIgnore),
-		logger:   logger,
+		root:        absRoot,
+		matchers:    make(map[string]*gitignore.GitIgnore),
+		reincluders: make(map[string]*gitignore.GitIgnore),
+		logger:      logger,
 	}
 
 	if err := m.discoverGitignoreFiles(); err != nil {
@@ -102,19 +110,23 @@ func (m *GitignoreMatcher) discoverGitignoreFiles() error {
 			return nil
 		}
 
-		compiled, err := gitignore.CompileIgnoreFile(path)
+		data, err := os.ReadFile(path)
 		if err != nil {
 			m.logger.Debug("skipping unreadable .gitignore",
 				"path", path, "error", err)
 			return nil
 		}
+		lines := strings.Split(string(data), "\n")
 
 		// Normalize to use "." for the root directory.
 		if relDir == "" {
 			relDir = "."
 		}
 
-		m.matchers[relDir] = compiled
+		m.matchers[relDir] = gitignore.CompileIgnoreLines(lines...)
+		if hasNegation(lines) {
+			m.reincluders[relDir] = gitignore.CompileIgnoreLines(append([]string{"*"}, lines...)...)
+		}
 		m.logger.Debug("loaded .gitignore", "dir", relDir, "path", path)
 
 		return nil
@@ -140,9 +152,10 @@ func (m *GitignoreMatcher) discoverGitignoreFiles() error {
 // needed for directory-only patterns (patterns ending in /).
 //
 // The matcher evaluates .gitignore files from the root directory down to
-// the file's parent directory. A file is ignored if any ancestor's
-// .gitignore matches it. Negation patterns in a .gitignore can override
-// matches from the same .gitignore file.
+// the file's parent directory, and the deepest .gitignore with a matching
+// pattern decides: a file ignored by an ancestor's .gitignore can be
+// re-included by a negation pattern in a deeper one, and vice versa. A
+// .gitignore never affects paths outside its own directory.
 //
 // Performance: matching is O(number of patterns across all applicable
 // .gitignore files), not O(number of files).
@@ -165,7 +178,10 @@ func (m *GitignoreMatcher) IsIgnored(path string, isDir bool) bool {
 	}
 
 	// Check each applicable .gitignore from root toward the file's parent.
-	// A .gitignore at directory D applies to paths under D.
+	// A .gitignore at directory D applies to paths under D. Sorting puts
+	// every ancestor directory before its descendants, so later verdicts
+	// come from deeper files and override earlier ones.
+	ignored := false
 	for _, dir := range m.dirs {
 		matcher := m.matchers[dir]
 
@@ -195,10 +211,31 @@ func (m *GitignoreMatcher) IsIgnored(path string, isDir bool) bool {
 				"gitignore_dir", dir,
 				"rel_path", relPath,
 			)
-			return true
+			ignored = true
+			continue
+		}
+
+		if reincluder := m.reincluders[dir]; reincluder != nil && !reincluder.MatchesPath(relPath) {
+			m.logger.Debug("path re-included by gitignore negation",
+				"path", normalizedPath,
+				"gitignore_dir", dir,
+				"rel_path", relPath,
+			)
+			ignored = false
 		}
 	}
 
+	return ignored
+}
+
+// hasNegation reports whether any of the .gitignore lines is a negation
+// pattern.
+func hasNegation(lines []string) bool {
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "!") {
+			return true
+		}
+	}
 	return false
 }
 
@@ -295,6 +295,69 @@ func TestGitignoreMatcher_DeeplyNestedGitignore(t *testing.T) {
 	}
 }
 
+func TestGitignoreMatcher_NestedScopeAndPrecedence(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	// Root: ignore *.log and the top-level tmp directory only.
+	writeGitignore(t, dir, "*.log\n/tmp/\n")
+
+	// subdir: anchored rule, re-include keep.log, and ignore *.bak.
+	subDir := filepath.Join(dir, "subdir")
+	require.NoError(t, os.MkdirAll(subDir, 0755))
+	writeGitignore(t, subDir, "/cache/\n!keep.log\n*.bak\n")
+
+	// subdir/inner re-ignores keep.log.
+	innerDir := filepath.Join(subDir, "inner")
+	require.NoError(t, os.MkdirAll(innerDir, 0755))
+	writeGitignore(t, innerDir, "keep.log\n")
+
+	m, err := NewGitignoreMatcher(dir)
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.PatternCount())
+
+	tests := []struct {
+		name   string
+		path   string
+		isDir  bool
+		expect bool
+	}{
+		// Root rules apply in every directory; the anchored one only at root.
+		{name: "root log", path: "app.log", isDir: false, expect: true},
+		{name: "root log in subdir", path: "subdir/app.log", isDir: false, expect: true},
+		{name: "anchored tmp at root", path: "tmp", isDir: true, expect: true},
+		{name: "anchored tmp in subdir", path: "subdir/tmp", isDir: true, expect: false},
+
+		// subdir rules are relative to subdir.
+		{name: "anchored cache in subdir", path: "subdir/cache", isDir: true, expect: true},
+		{name: "anchored cache below subdir", path: "subdir/inner/cache", isDir: true, expect: false},
+		{name: "bak in subdir", path: "subdir/a.bak", isDir: false, expect: true},
+		{name: "bak below subdir", path: "subdir/inner/a.bak", isDir: false, expect: true},
+
+		// A deeper negation overrides the root rule, and a deeper rule
+		// overrides that negation again.
+		{name: "keep.log re-included in subdir", path: "subdir/keep.log", isDir: false, expect: false},
+		{name: "keep.log at root still ignored", path: "keep.log", isDir: false, expect: true},
+		{name: "keep.log ignored again in inner", path: "subdir/inner/keep.log", isDir: false, expect: true},
+
+		// subdir rules do not leak to siblings, even ones sharing a prefix.
+		{name: "bak at root", path: "a.bak", isDir: false, expect: false},
+		{name: "bak in sibling", path: "other/a.bak", isDir: false, expect: false},
+		{name: "bak in prefix sibling", path: "subdir2/a.bak", isDir: false, expect: false},
+		{name: "cache at root", path: "cache", isDir: true, expect: false},
+		{name: "keep.log in sibling", path: "other/keep.log", isDir: false, expect: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := m.IsIgnored(tt.path, tt.isDir)
+			assert.Equal(t, tt.expect, got, "IsIgnored(%q, %v)", tt.path, tt.isDir)
+		})
+	}
+}
+
 func TestGitignoreMatcher_CommentsAndBlankLines(t *testing.T) {
 	t.Parallel()
 
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"sort"
+	"strings"
 	"sync"
 
 	"golang.org/x/sync/errgroup"
@@ -44,6 +45,11 @@ type WalkerConfig struct {
 	// Defaults to runtime.NumCPU() if <= 0.
 	Concurrency int
 
+	// SkipEmpty drops files whose content is empty or whitespace-only after
+	// reading, recording them under the "skipped_empty" skip reason. Files
+	// that failed to read are kept so their error is reported.
+	SkipEmpty bool
+
 	// SuppressSensitiveWarnings disables the slog.Warn emitted when a file
 	// matching a sensitive pattern is discovered. Set when
 	// RedactionConfig.OverrideSensitiveDefaults = true.
@@ -74,7 +80,8 @@ func NewWalker() *Walker {
 //     collected as FileDescriptors.
 //  2. Content loading: errgroup workers read file contents in parallel with
 //     bounded concurrency. Per-file errors are captured in FileDescriptor.Error
-//     rather than aborting the entire walk.
+//     rather than aborting the entire walk. With cfg.SkipEmpty, files whose
+//     content is empty or whitespace-only are dropped afterwards.
 //
 // Context cancellation stops both phases promptly.
 func (w *Walker) Walk(ctx context.Context, cfg WalkerConfig) (*pipeline.DiscoveryResult, error) {
@@ -301,6 +308,7 @@ func (w *Walker) Walk(ctx context.Context, cfg WalkerConfig) (*pipeline.Discover
 			Path:      relPath,
 			AbsPath:   absPath,
 			Size:      fileInfo.Size(),
+			ModTime:   fileInfo.ModTime(),
 			IsSymlink: isSymlink,
 			Tier:      pipeline.DefaultTier,
 		}
@@ -346,9 +354,16 @@ func (w *Walker) Walk(ctx context.Context, cfg WalkerConfig) (*pipeline.Discover
 	}
 
 	// Build result slice (convert pointers to values).
-	resultFiles := make([]pipeline.FileDescriptor, len(files))
-	for i, fd := range files {
-		resultFiles[i] = *fd
+	resultFiles := make([]pipeline.FileDescriptor, 0, len(files))
+	for _, fd := range files {
+		if cfg.SkipEmpty && fd.Error == nil && strings.TrimSpace(fd.Content) == "" {
+			w.logger.Debug("empty file skipped",
+				"path", fd.Path,
+			)
+			skipReasons["skipped_empty"]++
+			continue
+		}
+		resultFiles = append(resultFiles, *fd)
 	}
 
 	totalSkipped := 0
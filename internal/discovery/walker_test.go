@@ -170,6 +170,47 @@ func TestWalkerGitignoreRespected(t *testing.T) {
 	}
 }
 
+func TestWalkerNestedGitignoreScoped(t *testing.T) {
+	root := createTestRepo(t)
+
+	// Root .gitignore ignores *.log; src/.gitignore ignores *.gen.go and
+	// re-includes keep.log, but only within src/.
+	files := map[string]string{
+		".gitignore":      "*.log\n",
+		"src/.gitignore":  "*.gen.go\n!keep.log\n",
+		"debug.log":       "root log\n",
+		"src/keep.log":    "kept\n",
+		"src/other.log":   "ignored\n",
+		"src/api.gen.go":  "package src\n",
+		"docs/keep.log":   "ignored\n",
+		"docs/ref.gen.go": "package docs\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), []byte(content), 0o644))
+	}
+
+	gitMatcher, err := NewGitignoreMatcher(root)
+	require.NoError(t, err)
+
+	w := NewWalker()
+	result, err := w.Walk(context.Background(), WalkerConfig{
+		Root:             root,
+		GitignoreMatcher: gitMatcher,
+	})
+	require.NoError(t, err)
+
+	paths := make(map[string]bool, len(result.Files))
+	for _, f := range result.Files {
+		paths[f.Path] = true
+	}
+	assert.True(t, paths["src/keep.log"], "src/.gitignore negation should re-include src/keep.log")
+	assert.True(t, paths["docs/ref.gen.go"], "src/.gitignore rules must not apply to docs/")
+	assert.False(t, paths["debug.log"])
+	assert.False(t, paths["src/other.log"])
+	assert.False(t, paths["src/api.gen.go"])
+	assert.False(t, paths["docs/keep.log"], "src/.gitignore negation must not apply to docs/")
+}
+
 func TestWalkerHarvxignoreRespected(t *testing.T) {
 	root := createTestRepo(t)
 
@@ -250,6 +291,48 @@ func TestWalkerLargeFilesSkipped(t *testing.T) {
 	assert.Equal(t, 1, result.SkipReasons["large_file"], "should record large_file skip reason")
 }
 
+func TestWalkerEmptyFilesSkipped(t *testing.T) {
+	root := createTestRepo(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "empty.txt"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "blank.txt"), []byte(" \n\t\r\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "one.txt"), []byte("x"), 0o644))
+
+	w := NewWalker()
+	result, err := w.Walk(context.Background(), WalkerConfig{
+		Root:      root,
+		SkipEmpty: true,
+	})
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(result.Files))
+	for _, f := range result.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.NotContains(t, paths, "empty.txt", "zero-byte files should be skipped")
+	assert.NotContains(t, paths, "blank.txt", "whitespace-only files should be skipped")
+	assert.Contains(t, paths, "one.txt", "a single non-whitespace char should be kept")
+	assert.Equal(t, 2, result.SkipReasons["skipped_empty"], "should record skipped_empty skip reason")
+	assert.Equal(t, 2, result.TotalSkipped)
+}
+
+func TestWalkerEmptyFilesKeptWithoutSkipEmpty(t *testing.T) {
+	root := createTestRepo(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "empty.txt"), nil, 0o644))
+
+	w := NewWalker()
+	result, err := w.Walk(context.Background(), WalkerConfig{Root: root})
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(result.Files))
+	for _, f := range result.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.Contains(t, paths, "empty.txt")
+	assert.Zero(t, result.SkipReasons["skipped_empty"])
+}
+
 func TestWalkerExtensionFilter(t *testing.T) {
 	root := createTestRepo(t)
 
@@ -444,6 +527,7 @@ func TestWalkerFileDescriptorFields(t *testing.T) {
 		assert.NotEmpty(t, f.AbsPath, "AbsPath should not be empty")
 		assert.True(t, filepath.IsAbs(f.AbsPath), "AbsPath should be absolute: %s", f.AbsPath)
 		assert.Greater(t, f.Size, int64(0), "Size should be > 0 for %s", f.Path)
+		assert.False(t, f.ModTime.IsZero(), "ModTime should be set for %s", f.Path)
 		assert.Equal(t, 2, f.Tier, "Tier should be DefaultTier (2) for %s", f.Path)
 	}
 }
@@ -5,6 +5,7 @@
 package doctor
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/harvx/harvx/internal/config"
 	"github.com/harvx/harvx/internal/discovery"
+	"github.com/harvx/harvx/internal/tokenizer"
 )
 
 // Status represents the result status of a doctor check.
@@ -35,6 +37,9 @@ type CheckResult struct {
 	Status  Status   `json:"status"`
 	Message string   `json:"message"`
 	Details []string `json:"details,omitempty"`
+	// Fix is the suggested remedy for a warning or failure; empty when the
+	// check passed or there is nothing actionable.
+	Fix string `json:"fix,omitempty"`
 }
 
 // DoctorReport is the full output of a doctor run.
@@ -44,6 +49,9 @@ type DoctorReport struct {
 	Checks    []CheckResult `json:"checks"`
 	HasFail   bool          `json:"has_fail"`
 	HasWarn   bool          `json:"has_warn"`
+	// Fixes lists the Fix of every failing check, then of every warning, in
+	// check order: the actions to take, most important first.
+	Fixes []string `json:"fixes,omitempty"`
 }
 
 // Options configures the doctor run.
@@ -88,6 +96,9 @@ func Run(opts Options) (*DoctorReport, error) {
 		checkOversizedTextFiles,
 		checkBuildArtifacts,
 		checkConfig,
+		checkGlobalConfig,
+		checkEnvironment,
+		checkTokenizer,
 		checkStaleCache,
 	}
 
@@ -101,10 +112,25 @@ func Run(opts Options) (*DoctorReport, error) {
 			report.HasWarn = true
 		}
 	}
+	report.Fixes = prioritizedFixes(report.Checks)
 
 	return report, nil
 }
 
+// prioritizedFixes returns the Fix of each failing check followed by the Fix
+// of each warning, preserving check order within each group.
+func prioritizedFixes(checks []CheckResult) []string {
+	var fixes []string
+	for _, status := range []Status{StatusFail, StatusWarn} {
+		for _, c := range checks {
+			if c.Status == status && c.Fix != "" {
+				fixes = append(fixes, c.Fix)
+			}
+		}
+	}
+	return fixes
+}
+
 // checkGitRepo reports git repository status (branch, HEAD SHA, clean/dirty).
 func checkGitRepo(dir string, _ bool) CheckResult {
 	result := CheckResult{Name: "Git Repository"}
@@ -305,7 +331,8 @@ func checkBuildArtifacts(dir string, fix bool) CheckResult {
 	return result
 }
 
-// checkConfig validates harvx.toml if present in the target directory.
+// checkConfig validates and lints harvx.toml if present in the target
+// directory.
 func checkConfig(dir string, _ bool) CheckResult {
 	result := CheckResult{Name: "Configuration"}
 
@@ -323,43 +350,181 @@ func checkConfig(dir string, _ bool) CheckResult {
 		return result
 	}
 
-	cfg, err := config.LoadFromFile(configPath)
+	rel, _ := filepath.Rel(dir, configPath)
+	if rel == "" {
+		rel = configPath
+	}
+
+	cfg, unknownKeys, err := config.LoadWithReport(configPath)
 	if err != nil {
 		result.Status = StatusFail
 		result.Message = fmt.Sprintf("Invalid config: %s", err)
+		result.Fix = fmt.Sprintf("Fix the TOML syntax in %s", rel)
 		return result
 	}
 
-	validationErrors := config.Validate(cfg)
-	if len(validationErrors) == 0 {
-		rel, _ := filepath.Rel(dir, configPath)
-		if rel == "" {
-			rel = configPath
+	var problems []config.ValidationError
+	for _, lr := range config.Lint(cfg) {
+		// Info notes are educational and do not make the config unhealthy.
+		if lr.Severity == "info" {
+			continue
 		}
+		problems = append(problems, lr.ValidationError)
+	}
+	problems = append(problems, config.UnknownKeyWarnings(unknownKeys)...)
+	if len(problems) == 0 {
 		result.Status = StatusPass
 		result.Message = fmt.Sprintf("Config valid: %s", rel)
 		return result
 	}
 
-	var errCount, warnCount int
-	var details []string
-	for _, ve := range validationErrors {
+	result.Status, result.Message, result.Details = summarizeProblems("Config", problems)
+	result.Fix = fmt.Sprintf("Address the issues reported for %s; run 'harvx profiles lint' to re-check", rel)
+	return result
+}
+
+// checkGlobalConfig validates the user's global config file, if any. Its
+// settings apply to every repository, so a broken global config affects all
+// runs.
+func checkGlobalConfig(_ string, _ bool) CheckResult {
+	result := CheckResult{Name: "Global Configuration"}
+
+	path, err := config.DiscoverGlobalConfig()
+	if err != nil {
+		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("Error searching for global config: %s", err)
+		return result
+	}
+	if path == "" {
+		result.Status = StatusPass
+		result.Message = "No global config found (optional)"
+		return result
+	}
+
+	cfg, unknownKeys, err := config.LoadWithReport(path)
+	if err != nil {
+		result.Status = StatusFail
+		result.Message = fmt.Sprintf("Invalid global config: %s", err)
+		result.Fix = fmt.Sprintf("Fix the TOML syntax in %s", path)
+		return result
+	}
+
+	problems := append(config.Validate(cfg), config.UnknownKeyWarnings(unknownKeys)...)
+	if len(problems) == 0 {
+		result.Status = StatusPass
+		result.Message = fmt.Sprintf("Global config valid: %s", path)
+		return result
+	}
+
+	result.Status, result.Message, result.Details = summarizeProblems("Global config", problems)
+	result.Fix = fmt.Sprintf("Address the issues reported for %s", path)
+	return result
+}
+
+// summarizeProblems turns validation problems into a check status, message,
+// and details, listing errors before warnings. subject names the config in
+// the message, e.g. "Config has 1 error(s) and 2 warning(s)".
+func summarizeProblems(subject string, problems []config.ValidationError) (Status, string, []string) {
+	var errs, warns []string
+	for _, ve := range problems {
 		if ve.Severity == "error" {
-			errCount++
+			errs = append(errs, ve.Error())
 		} else {
-			warnCount++
+			warns = append(warns, ve.Error())
 		}
-		details = append(details, ve.Error())
 	}
 
-	if errCount > 0 {
-		result.Status = StatusFail
-	} else {
+	status := StatusWarn
+	if len(errs) > 0 {
+		status = StatusFail
+	}
+	message := fmt.Sprintf("%s has %d error(s) and %d warning(s)", subject, len(errs), len(warns))
+	return status, message, append(errs, warns...)
+}
+
+// checkEnvironment reports the HARVX_* environment variables that are set.
+// They override every config file, so a forgotten export silently shadows
+// harvx.toml; CLI flags still take precedence over them.
+func checkEnvironment(dir string, _ bool) CheckResult {
+	result := CheckResult{Name: "Environment Variables"}
+
+	debug, err := config.BuildDebugOutput(config.DebugOptions{TargetDir: dir})
+	if err != nil {
 		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("Could not resolve configuration: %s", err)
+		return result
+	}
+
+	// Map each env var to the config keys it set, from the source labels
+	// BuildDebugOutput attaches ("env (HARVX_MAX_TOKENS)").
+	overrides := make(map[string][]string)
+	for _, entry := range debug.Config {
+		if name, ok := strings.CutPrefix(entry.Source, "env ("); ok {
+			name = strings.TrimSuffix(name, ")")
+			overrides[name] = append(overrides[name], entry.Key)
+		}
+	}
+
+	var details []string
+	for _, ev := range debug.EnvVars {
+		if !ev.Applied {
+			continue
+		}
+		detail := fmt.Sprintf("%s=%s", ev.Name, ev.Value)
+		if keys := overrides[ev.Name]; len(keys) > 0 {
+			detail += fmt.Sprintf(" (overrides %s)", strings.Join(keys, ", "))
+		}
+		details = append(details, detail)
+	}
+
+	if len(details) == 0 {
+		result.Status = StatusPass
+		result.Message = "No HARVX_* environment variables set"
+		return result
 	}
-	result.Message = fmt.Sprintf("Config has %d error(s) and %d warning(s)", errCount, warnCount)
+
+	result.Status = StatusWarn
+	result.Message = fmt.Sprintf("%d HARVX_* environment variable(s) override config files", len(details))
 	result.Details = details
+	result.Fix = "Unset the HARVX_* variables listed above unless they are intentional; run 'harvx config debug' to see the resolved values"
+	return result
+}
+
+// checkTokenizer verifies that the resolved profile's tokenizer can be
+// loaded. The tiktoken encodings are downloaded on first use, so an offline
+// machine without a TIKTOKEN_CACHE_DIR cache cannot count tokens with them.
+func checkTokenizer(dir string, _ bool) CheckResult {
+	result := CheckResult{Name: "Tokenizer"}
+
+	resolved, err := config.Resolve(config.ResolveOptions{TargetDir: dir})
+	if err != nil {
+		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("Could not resolve configuration: %s", err)
+		return result
+	}
 
+	name := resolved.Profile.Tokenizer
+	if name == "" {
+		name = tokenizer.NameCL100K
+	}
+	if _, err := tokenizer.NewTokenizer(name); err != nil {
+		result.Details = []string{err.Error()}
+		if errors.Is(err, tokenizer.ErrUnknownTokenizer) {
+			result.Status = StatusFail
+			result.Message = fmt.Sprintf("Tokenizer %q is not supported", name)
+			result.Fix = "Set tokenizer to cl100k_base, o200k_base, or \"none\""
+			return result
+		}
+		// A supported encoding whose data cannot be fetched (e.g. offline) is
+		// an environment problem rather than a broken config.
+		result.Status = StatusWarn
+		result.Message = fmt.Sprintf("Tokenizer %q could not be loaded", name)
+		result.Fix = fmt.Sprintf("Make the %s encoding available (network access or a TIKTOKEN_CACHE_DIR cache), or set tokenizer = \"none\" to use the character estimator", name)
+		return result
+	}
+
+	result.Status = StatusPass
+	result.Message = fmt.Sprintf("Tokenizer %q loaded", name)
 	return result
 }
 
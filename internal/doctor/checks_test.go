@@ -22,7 +22,7 @@ func TestRun_ReturnsReport(t *testing.T) {
 	require.NoError(t, absErr)
 	assert.Equal(t, absDir, report.Directory)
 	assert.NotEmpty(t, report.Timestamp)
-	assert.Len(t, report.Checks, 6, "expected 6 checks")
+	assert.Len(t, report.Checks, 9, "expected 9 checks")
 
 	// Verify all check names are present.
 	names := make([]string, len(report.Checks))
@@ -34,6 +34,9 @@ func TestRun_ReturnsReport(t *testing.T) {
 	assert.Contains(t, names, "Oversized Text Files")
 	assert.Contains(t, names, "Build Artifacts")
 	assert.Contains(t, names, "Configuration")
+	assert.Contains(t, names, "Global Configuration")
+	assert.Contains(t, names, "Environment Variables")
+	assert.Contains(t, names, "Tokenizer")
 	assert.Contains(t, names, "State Cache")
 }
 
@@ -236,6 +239,134 @@ func TestCheckConfig_InvalidConfig(t *testing.T) {
 	assert.Contains(t, result.Message, "Invalid config")
 }
 
+func TestCheckConfig_UnknownKeyWarns(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+
+	typoConfig := `[profile.default]
+format = "markdown"
+max_token = 128000
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"), []byte(typoConfig), 0o644))
+
+	result := checkConfig(dir, false)
+
+	assert.Equal(t, StatusWarn, result.Status)
+	require.Len(t, result.Details, 1)
+	assert.Contains(t, result.Details[0], "profile.default.max_token")
+	assert.Contains(t, result.Details[0], "did you mean max_tokens?")
+}
+
+func TestCheckConfig_ErrorsListedBeforeWarnings(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+
+	cfg := `[profile.default]
+max_token = 128000
+format = "yaml"
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"), []byte(cfg), 0o644))
+
+	result := checkConfig(dir, false)
+
+	assert.Equal(t, StatusFail, result.Status)
+	require.Len(t, result.Details, 2)
+	assert.Contains(t, result.Details[0], "[error]")
+	assert.Contains(t, result.Details[1], "[warning]")
+	assert.Contains(t, result.Fix, "harvx profiles lint")
+}
+
+func TestCheckGlobalConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantStatus Status
+		wantMsg    string
+	}{
+		{name: "missing", wantStatus: StatusPass, wantMsg: "No global config found"},
+		{name: "valid", content: "[profile.default]\nformat = \"xml\"\n", wantStatus: StatusPass, wantMsg: "Global config valid"},
+		{name: "invalid toml", content: "{{invalid", wantStatus: StatusFail, wantMsg: "Invalid global config"},
+		{name: "invalid value", content: "[profile.default]\nformat = \"yaml\"\n", wantStatus: StatusFail, wantMsg: "1 error(s)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			xdg := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", xdg)
+			if tt.content != "" {
+				require.NoError(t, os.MkdirAll(filepath.Join(xdg, "harvx"), 0o755))
+				require.NoError(t, os.WriteFile(filepath.Join(xdg, "harvx", "config.toml"), []byte(tt.content), 0o644))
+			}
+
+			result := checkGlobalConfig(t.TempDir(), false)
+			assert.Equal(t, tt.wantStatus, result.Status)
+			assert.Contains(t, result.Message, tt.wantMsg)
+			if tt.wantStatus == StatusFail {
+				assert.NotEmpty(t, result.Fix)
+			}
+		})
+	}
+}
+
+func TestCheckEnvironment(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+
+	t.Setenv("HARVX_MAX_TOKENS", "")
+	result := checkEnvironment(dir, false)
+	assert.Equal(t, StatusPass, result.Status)
+
+	t.Setenv("HARVX_MAX_TOKENS", "5000")
+	result = checkEnvironment(dir, false)
+	assert.Equal(t, StatusWarn, result.Status)
+	require.Len(t, result.Details, 1)
+	assert.Equal(t, "HARVX_MAX_TOKENS=5000 (overrides max_tokens)", result.Details[0])
+	assert.NotEmpty(t, result.Fix)
+}
+
+func TestCheckTokenizer(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HARVX_TOKENIZER", "")
+
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	writeConfig := func(tokenizer string) {
+		content := "[profile.default]\ntokenizer = \"" + tokenizer + "\"\n"
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"), []byte(content), 0o644))
+	}
+
+	writeConfig("none")
+	result := checkTokenizer(dir, false)
+	assert.Equal(t, StatusPass, result.Status)
+	assert.Contains(t, result.Message, `"none"`)
+
+	writeConfig("p50k_base")
+	result = checkTokenizer(dir, false)
+	assert.Equal(t, StatusFail, result.Status)
+	assert.Contains(t, result.Message, `"p50k_base"`)
+	assert.Contains(t, result.Fix, `"none"`)
+
+	// A supported encoding never fails the check: loading it needs network
+	// access or a cache, so an unavailable encoding is only a warning.
+	writeConfig("cl100k_base")
+	result = checkTokenizer(dir, false)
+	assert.NotEqual(t, StatusFail, result.Status)
+}
+
+func TestPrioritizedFixes(t *testing.T) {
+	checks := []CheckResult{
+		{Name: "a", Status: StatusWarn, Fix: "warn a"},
+		{Name: "b", Status: StatusPass},
+		{Name: "c", Status: StatusFail, Fix: "fail c"},
+		{Name: "d", Status: StatusWarn},
+		{Name: "e", Status: StatusFail, Fix: "fail e"},
+	}
+
+	assert.Equal(t, []string{"fail c", "fail e", "warn a"}, prioritizedFixes(checks))
+	assert.Nil(t, prioritizedFixes(checks[1:2]))
+}
+
 func TestCheckStaleCache_NoCache(t *testing.T) {
 	dir := t.TempDir()
 
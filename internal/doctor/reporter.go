@@ -51,6 +51,14 @@ func FormatText(w io.Writer, report *DoctorReport) {
 
 	fmt.Fprintln(w, strings.Repeat("─", 50))
 	fmt.Fprintf(w, "  %d passed, %d warnings, %d failures\n", pass, warn, fail)
+
+	if len(report.Fixes) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "Suggested fixes (most important first):")
+		for i, fix := range report.Fixes {
+			fmt.Fprintf(w, "  %d. %s\n", i+1, fix)
+		}
+	}
 }
 
 // FormatJSON writes the doctor report as indented JSON to w.
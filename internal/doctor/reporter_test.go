@@ -71,6 +71,24 @@ func TestFormatText(t *testing.T) {
 
 	// Verify summary line.
 	assert.Contains(t, output, "1 passed, 1 warnings, 1 failures")
+	assert.NotContains(t, output, "Suggested fixes")
+}
+
+func TestFormatText_Fixes(t *testing.T) {
+	report := &DoctorReport{
+		Directory: "/tmp/test",
+		Checks: []CheckResult{
+			{Name: "Configuration", Status: StatusFail, Message: "Invalid config", Fix: "Fix the TOML syntax in harvx.toml"},
+			{Name: "Environment Variables", Status: StatusWarn, Message: "1 set", Fix: "Unset HARVX_*"},
+		},
+		Fixes: []string{"Fix the TOML syntax in harvx.toml", "Unset HARVX_*"},
+	}
+
+	var buf bytes.Buffer
+	FormatText(&buf, report)
+	output := buf.String()
+
+	assert.Contains(t, output, "Suggested fixes (most important first):\n  1. Fix the TOML syntax in harvx.toml\n  2. Unset HARVX_*\n")
 }
 
 func TestFormatText_EmptyReport(t *testing.T) {
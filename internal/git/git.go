@@ -0,0 +1,87 @@
+// Package git provides the small set of git queries harvx needs for
+// incremental runs. It shells out to the git CLI rather than linking a git
+// implementation.
+package git
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+)
+
+// ErrGitNotFound is returned when the git executable is not found on PATH.
+var ErrGitNotFound = errors.New("git executable not found")
+
+// ErrNotGitRepo is returned when the directory is not inside a git
+// repository.
+var ErrNotGitRepo = errors.New("not a git repository")
+
+// ChangedFiles returns the files that changed since ref in the repository
+// containing repoRoot, plus untracked files that are not ignored. Changes are
+// taken from the working tree against the merge base of ref and HEAD, so
+// files committed on HEAD since it diverged from ref and uncommitted edits to
+// tracked files, staged or not, are both reported. Deleted files are omitted
+// because there is nothing left to harvest.
+//
+// Paths are slash-separated, relative to repoRoot, sorted, and unique. When
+// repoRoot is a subdirectory of the repository, only changes beneath it are
+// reported. A clean tree yields an empty, non-nil slice.
+func ChangedFiles(repoRoot, ref string) ([]string, error) {
+	if _, err := runGit(repoRoot, "rev-parse", "--show-toplevel"); err != nil {
+		return nil, fmt.Errorf("listing files changed since %s in %s: %w", ref, repoRoot, err)
+	}
+
+	base, err := runGit(repoRoot, "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("finding merge base of %s and HEAD: %w", ref, err)
+	}
+
+	diffOut, err := runGit(repoRoot, "diff", "--name-only", "--relative", "--diff-filter=d", "-z", strings.TrimSpace(base))
+	if err != nil {
+		return nil, fmt.Errorf("listing files changed since %s: %w", ref, err)
+	}
+
+	untrackedOut, err := runGit(repoRoot, "ls-files", "--others", "--exclude-standard", "-z")
+	if err != nil {
+		return nil, fmt.Errorf("listing untracked files: %w", err)
+	}
+
+	files := make([]string, 0)
+	for _, out := range []string{diffOut, untrackedOut} {
+		for _, p := range strings.Split(out, "\x00") {
+			if p != "" {
+				files = append(files, p)
+			}
+		}
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
+
+// runGit runs git with args in dir and returns its stdout. Output is not
+// trimmed so that NUL-separated listings stay intact.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir, "--no-pager"}, args...)...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("running git: %w", ErrGitNotFound)
+		}
+
+		stderrStr := strings.TrimSpace(stderr.String())
+		if strings.Contains(stderrStr, "not a git repository") {
+			return "", fmt.Errorf("%s: %w", stderrStr, ErrNotGitRepo)
+		}
+
+		return "", fmt.Errorf("git %s: %w: %s", args[0], err, stderrStr)
+	}
+
+	return stdout.String(), nil
+}
@@ -0,0 +1,133 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireGit skips the test when the git executable is not available.
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+}
+
+// runGitT executes a git command in dir and fails the test on error.
+func runGitT(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, "git %v failed: %s", args, string(out))
+}
+
+// writeFile writes content to rel under dir, creating parent directories.
+func writeFile(t *testing.T, dir, rel, content string) {
+	t.Helper()
+	path := filepath.Join(dir, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// initRepo creates a git repository in a temp dir with one commit containing
+// a.go, b.go, and docs/guide.md, and returns its path.
+func initRepo(t *testing.T) string {
+	t.Helper()
+	requireGit(t)
+
+	dir := t.TempDir()
+	runGitT(t, dir, "init", "-q")
+	runGitT(t, dir, "config", "user.email", "test@test.com")
+	runGitT(t, dir, "config", "user.name", "Test")
+	runGitT(t, dir, "config", "commit.gpgsign", "false")
+
+	writeFile(t, dir, "a.go", "package a\n")
+	writeFile(t, dir, "b.go", "package b\n")
+	writeFile(t, dir, "docs/guide.md", "# Guide\n")
+	writeFile(t, dir, ".gitignore", "*.log\n")
+	runGitT(t, dir, "add", ".")
+	runGitT(t, dir, "commit", "-q", "-m", "initial")
+	runGitT(t, dir, "tag", "base")
+	return dir
+}
+
+func TestChangedFiles_CommittedAndUntracked(t *testing.T) {
+	t.Parallel()
+	dir := initRepo(t)
+
+	writeFile(t, dir, "a.go", "package a\n\nfunc A() {}\n")
+	writeFile(t, dir, "internal/new.go", "package internal\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "b.go")))
+	runGitT(t, dir, "add", "-A")
+	runGitT(t, dir, "commit", "-q", "-m", "change")
+
+	writeFile(t, dir, "scratch.go", "package scratch\n")
+	writeFile(t, dir, "debug.log", "ignored\n")
+
+	files, err := ChangedFiles(dir, "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "internal/new.go", "scratch.go"}, files,
+		"deleted and ignored files must be omitted")
+}
+
+func TestChangedFiles_UncommittedEdits(t *testing.T) {
+	t.Parallel()
+	dir := initRepo(t)
+
+	writeFile(t, dir, "a.go", "package a\n\nvar Unstaged = 1\n")
+	writeFile(t, dir, "docs/guide.md", "# Guide\n\nStaged.\n")
+	runGitT(t, dir, "add", "docs/guide.md")
+
+	files, err := ChangedFiles(dir, "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "docs/guide.md"}, files)
+}
+
+func TestChangedFiles_CleanTree(t *testing.T) {
+	t.Parallel()
+	dir := initRepo(t)
+
+	files, err := ChangedFiles(dir, "base")
+	require.NoError(t, err)
+	require.NotNil(t, files)
+	assert.Empty(t, files)
+}
+
+func TestChangedFiles_Subdirectory(t *testing.T) {
+	t.Parallel()
+	dir := initRepo(t)
+
+	writeFile(t, dir, "a.go", "package a\n\nvar X = 1\n")
+	writeFile(t, dir, "docs/guide.md", "# Guide\n\nMore.\n")
+	runGitT(t, dir, "commit", "-q", "-am", "change")
+
+	files, err := ChangedFiles(filepath.Join(dir, "docs"), "base")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"guide.md"}, files)
+}
+
+func TestChangedFiles_NotGitRepo(t *testing.T) {
+	t.Parallel()
+	requireGit(t)
+
+	_, err := ChangedFiles(t.TempDir(), "HEAD~1")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrNotGitRepo)
+	assert.Contains(t, err.Error(), "HEAD~1")
+}
+
+func TestChangedFiles_UnknownRef(t *testing.T) {
+	t.Parallel()
+	dir := initRepo(t)
+
+	_, err := ChangedFiles(dir, "no-such-ref")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrNotGitRepo)
+	assert.Contains(t, err.Error(), "no-such-ref")
+}
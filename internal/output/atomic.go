@@ -0,0 +1,62 @@
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// WriteOutput writes the output document to path atomically. render is called
+// with a temporary file in the same directory as path; once it returns
+// successfully the file is synced and renamed into place, so readers see
+// either the previous file or the complete new one, never a truncated write.
+// Missing parent directories are created.
+//
+// If render or any later step fails, the temporary file is removed and an
+// existing file at path is left untouched. Absolute and "../" paths are
+// allowed: config validation warns about them, but writing still honors the
+// path the user chose.
+func WriteOutput(path string, render func(io.Writer) error) (retErr error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("writing output: creating directory %q: %w", dir, err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, ".harvx-*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing output: creating temp file in %q: %w", dir, err)
+	}
+	tmpPath := tmpFile.Name()
+
+	// Clean up the temp file on any error.
+	defer func() {
+		if retErr != nil {
+			tmpFile.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if err := render(tmpFile); err != nil {
+		return fmt.Errorf("writing output to temp file: %w", err)
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		return fmt.Errorf("writing output: syncing temp file: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("writing output: closing temp file: %w", err)
+	}
+
+	// CreateTemp uses 0600; output documents get the usual file mode.
+	if err := os.Chmod(tmpPath, 0o644); err != nil {
+		return fmt.Errorf("writing output: setting permissions: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("writing output: renaming %q to %q: %w", tmpPath, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,78 @@
+package output
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteOutput_WritesFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "output.md")
+	require.NoError(t, os.WriteFile(outPath, []byte("old"), 0o644))
+
+	err := WriteOutput(outPath, func(w io.Writer) error {
+		_, err := io.WriteString(w, "# Context\n")
+		return err
+	})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "# Context\n", string(got))
+
+	info, err := os.Stat(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o644), info.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "no temp file should remain")
+}
+
+func TestWriteOutput_RenderErrorLeavesTargetUntouched(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "output.md")
+	require.NoError(t, os.WriteFile(outPath, []byte("previous run"), 0o644))
+
+	renderErr := errors.New("render failed")
+	err := WriteOutput(outPath, func(w io.Writer) error {
+		_, _ = io.WriteString(w, "partial")
+		return renderErr
+	})
+	require.ErrorIs(t, err, renderErr)
+
+	got, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "previous run", string(got))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "temp file must be removed")
+	assert.Equal(t, "output.md", entries[0].Name())
+}
+
+func TestWriteOutput_CreatesParentDirectories(t *testing.T) {
+	t.Parallel()
+
+	outPath := filepath.Join(t.TempDir(), ".harvx", "nested", "context.md")
+
+	err := WriteOutput(outPath, func(w io.Writer) error {
+		_, err := io.WriteString(w, "content")
+		return err
+	})
+	require.NoError(t, err)
+
+	got, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(got))
+}
@@ -13,6 +13,12 @@ const (
 
 	// FormatXML selects XML rendering.
 	FormatXML = "xml"
+
+	// FormatJSON selects JSON rendering.
+	FormatJSON = "json"
+
+	// FormatPlain selects plain-text rendering.
+	FormatPlain = "plain"
 )
 
 // Default output filename constants.
@@ -25,34 +31,82 @@ const (
 
 	// ExtensionXML is the file extension for XML output.
 	ExtensionXML = ".xml"
+
+	// ExtensionJSON is the file extension for JSON output.
+	ExtensionJSON = ".json"
+
+	// ExtensionPlain is the file extension for plain-text output.
+	ExtensionPlain = ".txt"
+
+	// StdoutPath is the output path that selects stdout instead of a file,
+	// as in output = "-" or --output -.
+	StdoutPath = "-"
 )
 
 // NewRenderer returns a Renderer for the given format string. It returns a
-// *MarkdownRenderer for FormatMarkdown and a *XMLRenderer for FormatXML.
-// An error is returned for unknown format values.
+// *MarkdownRenderer for FormatMarkdown, a *XMLRenderer for FormatXML, a
+// *JSONRenderer for FormatJSON, and a *PlainRenderer for FormatPlain. An error
+// is returned for unknown format values.
 func NewRenderer(format string) (Renderer, error) {
 	switch strings.ToLower(format) {
 	case FormatMarkdown:
 		return NewMarkdownRenderer(), nil
 	case FormatXML:
 		return NewXMLRenderer(), nil
+	case FormatJSON:
+		return NewJSONRenderer(), nil
+	case FormatPlain:
+		return NewPlainRenderer(), nil
 	default:
 		return nil, fmt.Errorf("unknown output format: %q", format)
 	}
 }
 
+// NewRendererVersion is like NewRenderer but selects the XML schema version
+// (see NewXMLRendererVersion) when format is FormatXML. xmlSchema is ignored
+// for other formats.
+func NewRendererVersion(format string, xmlSchema int) (Renderer, error) {
+	if strings.ToLower(format) == FormatXML {
+		return NewXMLRendererVersion(xmlSchema)
+	}
+	return NewRenderer(format)
+}
+
 // ExtensionForFormat returns the file extension for the given format string.
-// It returns ".xml" for FormatXML and ".md" for everything else (including
-// FormatMarkdown and unknown formats).
+// It returns ".xml" for FormatXML, ".json" for FormatJSON, ".txt" for
+// FormatPlain, and ".md" for everything else (including FormatMarkdown and
+// unknown formats).
 func ExtensionForFormat(format string) string {
 	switch strings.ToLower(format) {
 	case FormatXML:
 		return ExtensionXML
+	case FormatJSON:
+		return ExtensionJSON
+	case FormatPlain:
+		return ExtensionPlain
 	default:
 		return ExtensionMarkdown
 	}
 }
 
+// TruncationMarkerForFormat returns a truncation marker generator suited to
+// format, for use as tokenizer.BudgetEnforcer.TruncationMarker. Markdown and
+// XML (and unknown formats) get a comment that both renderers leave inert;
+// JSON and "plain" get a plain-text banner, since a comment would show up
+// verbatim in the content.
+func TruncationMarkerForFormat(format string) func(shown, total int) string {
+	switch strings.ToLower(format) {
+	case FormatJSON, FormatPlain:
+		return func(shown, total int) string {
+			return fmt.Sprintf("[Content truncated: %d of %d tokens shown]", shown, total)
+		}
+	default:
+		return func(shown, total int) string {
+			return fmt.Sprintf("<!-- Content truncated: %d of %d tokens shown -->", shown, total)
+		}
+	}
+}
+
 // DefaultOutputPath returns the default output file path for the given format.
 // For example, "markdown" yields "harvx-output.md" and "xml" yields "harvx-output.xml".
 func DefaultOutputPath(format string) string {
@@ -67,7 +121,7 @@ func DefaultOutputPath(format string) string {
 //  3. DefaultOutputPath(format) -- the default based on format
 //
 // If the resolved path has no file extension, the correct extension for the
-// format is appended (.md or .xml).
+// format is appended (.md, .xml, or .json). StdoutPath is returned unchanged.
 func ResolveOutputPath(outputFlag, profileOutput, format string) string {
 	resolved := outputFlag
 	if resolved == "" {
@@ -76,6 +130,9 @@ func ResolveOutputPath(outputFlag, profileOutput, format string) string {
 	if resolved == "" {
 		return DefaultOutputPath(format)
 	}
+	if resolved == StdoutPath {
+		return resolved
+	}
 
 	// Append extension if the resolved path has none.
 	if filepath.Ext(resolved) == "" {
@@ -92,6 +92,7 @@ func TestExtensionForFormat(t *testing.T) {
 	}{
 		{name: "markdown returns .md", format: FormatMarkdown, want: ExtensionMarkdown},
 		{name: "xml returns .xml", format: FormatXML, want: ExtensionXML},
+		{name: "plain returns .txt", format: FormatPlain, want: ExtensionPlain},
 		{name: "unknown defaults to .md", format: "html", want: ExtensionMarkdown},
 		{name: "empty defaults to .md", format: "", want: ExtensionMarkdown},
 		{name: "uppercase XML returns .xml", format: "XML", want: ExtensionXML},
@@ -106,6 +107,29 @@ func TestExtensionForFormat(t *testing.T) {
 	}
 }
 
+func TestTruncationMarkerForFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: FormatMarkdown, want: "<!-- Content truncated: 3 of 9 tokens shown -->"},
+		{format: FormatXML, want: "<!-- Content truncated: 3 of 9 tokens shown -->"},
+		{format: FormatJSON, want: "[Content truncated: 3 of 9 tokens shown]"},
+		{format: "JSON", want: "[Content truncated: 3 of 9 tokens shown]"},
+		{format: "plain", want: "[Content truncated: 3 of 9 tokens shown]"},
+		{format: "", want: "<!-- Content truncated: 3 of 9 tokens shown -->"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, TruncationMarkerForFormat(tt.format)(3, 9))
+		})
+	}
+}
+
 func TestDefaultOutputPath(t *testing.T) {
 	t.Parallel()
 
@@ -215,6 +239,13 @@ func TestResolveOutputPath(t *testing.T) {
 			format:        FormatXML,
 			want:          "mine.xml",
 		},
+		{
+			name:          "stdout path gets no extension",
+			outputFlag:    "",
+			profileOutput: "-",
+			format:        FormatMarkdown,
+			want:          "-",
+		},
 	}
 
 	for _, tt := range tests {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"unicode"
 )
 
 // languageFromExt maps file extensions to Markdown code fence language identifiers.
@@ -202,9 +203,35 @@ func tierLabel(tier int) string {
 	return fmt.Sprintf("tier%d", tier)
 }
 
-// escapeTripleBackticks escapes triple backticks within file content to prevent
-// breaking Markdown fenced code blocks. Replaces ``` with `` ` (two backticks,
-// space, one backtick).
-func escapeTripleBackticks(content string) string {
-	return strings.ReplaceAll(content, "```", "`` `")
+// fileAnchor returns the GitHub-style anchor of the "### `path`" heading
+// rendered for filePath: lowercase, with every character other than letters,
+// digits, '-' and '_' removed and spaces replaced by '-'.
+func fileAnchor(filePath string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == ' ':
+			return '-'
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		default:
+			return -1
+		}
+	}, filePath)
+}
+
+// codeFence returns the backtick fence for a Markdown code block holding
+// content: three backticks, or one more than the longest backtick run in
+// content, so that fences inside the file cannot close the block early and
+// the content is emitted verbatim.
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for i := 0; i < len(content); i++ {
+		if content[i] != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return strings.Repeat("`", max(3, longest+1))
 }
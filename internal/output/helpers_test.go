@@ -325,10 +325,10 @@ func TestTierLabel(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// TestEscapeTripleBackticks
+// TestCodeFence
 // ---------------------------------------------------------------------------
 
-func TestEscapeTripleBackticks(t *testing.T) {
+func TestCodeFence(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -336,48 +336,18 @@ func TestEscapeTripleBackticks(t *testing.T) {
 		content string
 		want    string
 	}{
-		{
-			name:    "no backticks",
-			content: "func main() {}",
-			want:    "func main() {}",
-		},
-		{
-			name:    "single backtick unchanged",
-			content: "use `code` here",
-			want:    "use `code` here",
-		},
-		{
-			name:    "double backtick unchanged",
-			content: "use ``code`` here",
-			want:    "use ``code`` here",
-		},
-		{
-			name:    "triple backtick escaped",
-			content: "```go\nfunc main() {}\n```",
-			want:    "`` `go\nfunc main() {}\n`` `",
-		},
-		{
-			name:    "multiple triple backticks",
-			content: "start\n```\nmiddle\n```\nend",
-			want:    "start\n`` `\nmiddle\n`` `\nend",
-		},
-		{
-			name:    "quadruple backticks partial escape",
-			content: "````",
-			want:    "`` ``",
-		},
-		{
-			name:    "empty content",
-			content: "",
-			want:    "",
-		},
+		{name: "no backticks", content: "func main() {}", want: "```"},
+		{name: "single backtick", content: "use `code` here", want: "```"},
+		{name: "double backtick", content: "use ``code`` here", want: "```"},
+		{name: "triple backtick", content: "```go\nfunc main() {}\n```", want: "````"},
+		{name: "longest run wins", content: "```\n`````\n``", want: "``````"},
+		{name: "empty content", content: "", want: "```"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := escapeTripleBackticks(tt.content)
-			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want, codeFence(tt.content))
 		})
 	}
 }
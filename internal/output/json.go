@@ -0,0 +1,172 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+// Compile-time interface compliance check.
+var _ Renderer = (*JSONRenderer)(nil)
+
+// JSONRenderer produces the context document as a single JSON object for
+// agent frameworks that ingest JSON rather than Markdown or XML:
+//
+//	{
+//	  "meta": {"project": ..., "generated": ..., "content_hash": ...,
+//	           "profile": ..., "tokenizer": ..., "total_tokens": ...,
+//	           "total_files": ...},
+//	  "files": [
+//	    {"path": "src/main.go", "tier": 1, "language": "go",
+//	     "tokens": 1200, "content": "package main\n..."}
+//	  ]
+//	}
+//
+// Files appear in the order given in RenderData. A file that failed to load
+// carries an "error" field and empty content. Content is a JSON string, so
+// byte offsets are not recorded for the manifest. It implements the Renderer
+// interface.
+type JSONRenderer struct{}
+
+// NewJSONRenderer creates a new JSONRenderer.
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+// jsonMeta is the "meta" object of a JSON context document.
+type jsonMeta struct {
+	Project     string `json:"project"`
+	Generated   string `json:"generated"`
+	ContentHash string `json:"content_hash"`
+	Profile     string `json:"profile"`
+	Tokenizer   string `json:"tokenizer"`
+	TotalTokens int    `json:"total_tokens"`
+	TotalFiles  int    `json:"total_files"`
+}
+
+// jsonFile is one element of the "files" array of a JSON context document.
+type jsonFile struct {
+	Path     string `json:"path"`
+	Tier     int    `json:"tier"`
+	Language string `json:"language"`
+	Tokens   int    `json:"tokens"`
+	Content  string `json:"content"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Render writes the JSON context document to w. Files are encoded and
+// written one at a time so the full document is never held in memory.
+func (r *JSONRenderer) Render(ctx context.Context, w io.Writer, data *RenderData) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	if data == nil {
+		return fmt.Errorf("render data is nil")
+	}
+
+	meta := jsonMeta{
+		Project:     data.ProjectName,
+		Generated:   data.Timestamp.UTC().Format(time.RFC3339),
+		ContentHash: data.ContentHash,
+		Profile:     data.ProfileName,
+		Tokenizer:   data.TokenizerName,
+		TotalTokens: data.TotalTokens,
+		TotalFiles:  data.TotalFiles,
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("  ", "  ")
+
+	if err := enc.Encode(meta); err != nil {
+		return fmt.Errorf("encoding JSON meta: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "{\n  \"meta\": %s,\n  \"files\": [", bytes.TrimSpace(buf.Bytes())); err != nil {
+		return err
+	}
+
+	enc.SetIndent("    ", "  ")
+	for i, f := range data.Files {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		entry := jsonFile{
+			Path:     f.Path,
+			Tier:     f.Tier,
+			Language: f.Language,
+			Tokens:   f.TokenCount,
+			Content:  f.Content,
+			Error:    f.Error,
+		}
+		if entry.Language == "" {
+			entry.Language = languageFromExt(f.Path)
+		}
+		if entry.Error != "" {
+			entry.Content = ""
+		}
+
+		buf.Reset()
+		if err := enc.Encode(entry); err != nil {
+			return fmt.Errorf("encoding JSON file %s: %w", f.Path, err)
+		}
+
+		sep := ","
+		if i == 0 {
+			sep = ""
+		}
+		if _, err := fmt.Fprintf(w, "%s\n    %s", sep, bytes.TrimSpace(buf.Bytes())); err != nil {
+			return err
+		}
+	}
+
+	closing := "\n  ]\n}\n"
+	if len(data.Files) == 0 {
+		closing = "]\n}\n"
+	}
+	_, err := io.WriteString(w, closing)
+	return err
+}
+
+// RenderJSON writes files as a JSON context document (see JSONRenderer) to w.
+// Files are ordered by tier and then by path regardless of input order, and
+// the meta object is filled from opts and totals computed over files. files
+// is not modified.
+func RenderJSON(files []*pipeline.FileDescriptor, w io.Writer, opts pipeline.RenderOptions) error {
+	sorted := sortedDescriptors(files)
+
+	entries := toFileRenderEntries(sorted)
+	hash, err := NewContentHasher().ComputeContentHash(toFileHashEntries(sorted))
+	if err != nil {
+		return fmt.Errorf("computing content hash: %w", err)
+	}
+
+	ts := opts.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	data := &RenderData{
+		ProjectName:   opts.ProjectName,
+		Timestamp:     ts,
+		ContentHash:   FormatHash(hash),
+		ProfileName:   opts.ProfileName,
+		TokenizerName: opts.TokenizerName,
+		TotalTokens:   computeTotalTokens(entries),
+		TotalFiles:    len(entries),
+		Files:         entries,
+	}
+
+	return NewJSONRenderer().Render(context.Background(), w, data)
+}
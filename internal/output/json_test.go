@@ -0,0 +1,166 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harvx/harvx/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// jsonTestDocument mirrors the JSON context document for decoding in tests.
+type jsonTestDocument struct {
+	Meta  jsonMeta   `json:"meta"`
+	Files []jsonFile `json:"files"`
+}
+
+// decodeJSONDocument unmarshals rendered output, failing the test if the
+// output is not a single valid JSON document.
+func decodeJSONDocument(t *testing.T, out []byte) jsonTestDocument {
+	t.Helper()
+
+	require.True(t, json.Valid(out), "output is not valid JSON:\n%s", out)
+	var doc jsonTestDocument
+	require.NoError(t, json.Unmarshal(out, &doc))
+	return doc
+}
+
+func TestJSONRenderer_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	data := xmlTestRenderData()
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONRenderer().Render(context.Background(), &buf, data))
+
+	doc := decodeJSONDocument(t, buf.Bytes())
+	assert.Equal(t, "test-project", doc.Meta.Project)
+	assert.Equal(t, "2026-01-15T10:30:00Z", doc.Meta.Generated)
+	assert.Equal(t, "abc123def456", doc.Meta.ContentHash)
+	assert.Equal(t, "default", doc.Meta.Profile)
+	assert.Equal(t, "cl100k_base", doc.Meta.Tokenizer)
+	assert.Equal(t, 2500, doc.Meta.TotalTokens)
+	assert.Equal(t, 3, doc.Meta.TotalFiles)
+
+	require.Len(t, doc.Files, len(data.Files))
+	for i, f := range data.Files {
+		assert.Equal(t, f.Path, doc.Files[i].Path)
+		assert.Equal(t, f.Tier, doc.Files[i].Tier)
+		assert.Equal(t, f.Language, doc.Files[i].Language)
+		assert.Equal(t, f.TokenCount, doc.Files[i].Tokens)
+		assert.Equal(t, f.Content, doc.Files[i].Content)
+		assert.Empty(t, doc.Files[i].Error)
+	}
+}
+
+func TestJSONRenderer_ContentEscaping(t *testing.T) {
+	t.Parallel()
+
+	content := "say \"hi\"\n\tif a < b && c > d {}\n]]> \\ end\n"
+	data := xmlTestRenderData()
+	data.Files = []FileRenderEntry{{Path: "tricky.go", Language: "go", Content: content}}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONRenderer().Render(context.Background(), &buf, data))
+
+	doc := decodeJSONDocument(t, buf.Bytes())
+	require.Len(t, doc.Files, 1)
+	assert.Equal(t, content, doc.Files[0].Content)
+	assert.Contains(t, buf.String(), "a < b && c > d", "HTML characters should not be escaped")
+}
+
+func TestJSONRenderer_ErrorFile(t *testing.T) {
+	t.Parallel()
+
+	data := xmlTestRenderData()
+	data.Files = []FileRenderEntry{{Path: "broken.bin", Content: "partial", Error: "permission denied"}}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONRenderer().Render(context.Background(), &buf, data))
+
+	doc := decodeJSONDocument(t, buf.Bytes())
+	require.Len(t, doc.Files, 1)
+	assert.Equal(t, "permission denied", doc.Files[0].Error)
+	assert.Empty(t, doc.Files[0].Content)
+}
+
+func TestJSONRenderer_EmptyFiles(t *testing.T) {
+	t.Parallel()
+
+	data := xmlTestRenderData()
+	data.Files = nil
+
+	var buf bytes.Buffer
+	require.NoError(t, NewJSONRenderer().Render(context.Background(), &buf, data))
+
+	doc := decodeJSONDocument(t, buf.Bytes())
+	assert.Empty(t, doc.Files)
+}
+
+func TestJSONRenderer_NilData(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	err := NewJSONRenderer().Render(context.Background(), &buf, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nil")
+}
+
+func TestJSONRenderer_CancelledContext(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	err := NewJSONRenderer().Render(ctx, &buf, xmlTestRenderData())
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRenderJSON_OrdersByTierThenPath(t *testing.T) {
+	t.Parallel()
+
+	files := []*pipeline.FileDescriptor{
+		{Path: "docs/guide.md", Tier: 3, TokenCount: 40, Content: "# Guide"},
+		{Path: "src/z.go", Tier: 1, TokenCount: 20, Content: "package z"},
+		{Path: "go.mod", Tier: 0, TokenCount: 10, Content: "module x"},
+		{Path: "src/a.go", Tier: 1, TokenCount: 30, Content: "package a"},
+	}
+	opts := pipeline.RenderOptions{
+		Format:        FormatJSON,
+		ProjectName:   "demo",
+		ProfileName:   "default",
+		TokenizerName: "cl100k_base",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderJSON(files, &buf, opts))
+
+	doc := decodeJSONDocument(t, buf.Bytes())
+	require.Len(t, doc.Files, 4)
+	var paths []string
+	for _, f := range doc.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"go.mod", "src/a.go", "src/z.go", "docs/guide.md"}, paths)
+	assert.Equal(t, "go", doc.Files[1].Language)
+
+	assert.Equal(t, "demo", doc.Meta.Project)
+	assert.Equal(t, 100, doc.Meta.TotalTokens)
+	assert.Equal(t, 4, doc.Meta.TotalFiles)
+	assert.NotEmpty(t, doc.Meta.ContentHash)
+
+	// The caller's slice is left untouched.
+	assert.Equal(t, "docs/guide.md", files[0].Path)
+}
+
+func TestNewRenderer_JSON(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRenderer(FormatJSON)
+	require.NoError(t, err)
+	assert.IsType(t, &JSONRenderer{}, r)
+	assert.Equal(t, ExtensionJSON, ExtensionForFormat(FormatJSON))
+}
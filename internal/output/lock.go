@@ -0,0 +1,137 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/harvx/harvx/internal/buildinfo"
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+// LockFileName is the conventional name of the lock file, written at the
+// repository root.
+const LockFileName = ".harvx.lock"
+
+// lockVersion is the on-disk schema version of a Lock.
+const lockVersion = 1
+
+// Lock records the exact file set a run included, so a later run or a CI job
+// can check that the context it produces is built from the same sources. The
+// lock holds no timestamps: the same file set always yields the same bytes.
+type Lock struct {
+	// Version is the schema version (lockVersion).
+	Version int `json:"version"`
+
+	// ToolVersion is the harvx version that wrote the lock.
+	ToolVersion string `json:"tool_version"`
+
+	// Profile is the resolved profile name of the run.
+	Profile string `json:"profile"`
+
+	// Files lists every included file, sorted by path.
+	Files []LockEntry `json:"files"`
+}
+
+// LockEntry is one included file in a Lock.
+type LockEntry struct {
+	// Path is the file path relative to the repository root.
+	Path string `json:"path"`
+
+	// SHA256 is the hex-encoded SHA-256 of the file's content on disk.
+	SHA256 string `json:"sha256"`
+}
+
+// BuildLock builds the lock for the given included files. Each file is hashed
+// from its on-disk content at AbsPath, not from its processed Content, so the
+// hash does not depend on redaction or compression settings. A file without
+// an AbsPath is hashed from Content.
+func BuildLock(profileName string, files []pipeline.FileDescriptor) (*Lock, error) {
+	lock := &Lock{
+		Version:     lockVersion,
+		ToolVersion: buildinfo.Version,
+		Profile:     profileName,
+		Files:       make([]LockEntry, 0, len(files)),
+	}
+
+	for _, fd := range files {
+		sum := HashContentSHA256([]byte(fd.Content))
+		if fd.AbsPath != "" {
+			var err error
+			if sum, err = HashFileSHA256(fd.AbsPath); err != nil {
+				return nil, fmt.Errorf("building lock: %w", err)
+			}
+		}
+		lock.Files = append(lock.Files, LockEntry{Path: fd.Path, SHA256: sum})
+	}
+
+	sort.Slice(lock.Files, func(i, j int) bool {
+		return lock.Files[i].Path < lock.Files[j].Path
+	})
+
+	return lock, nil
+}
+
+// WriteLock writes the lock to path as pretty-printed JSON, atomically.
+func WriteLock(lock *Lock, path string) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling lock: %w", err)
+	}
+
+	// Append a trailing newline for POSIX compliance.
+	data = append(data, '\n')
+
+	err = WriteOutput(path, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("writing lock %s: %w", path, err)
+	}
+	return nil
+}
+
+// ReadLock loads the lock stored at path. A lock written with a different
+// schema version is an error.
+func ReadLock(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading lock %s: %w", path, err)
+	}
+
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("parsing lock %s: %w", path, err)
+	}
+	if lock.Version != lockVersion {
+		return nil, fmt.Errorf("lock %s has unsupported version %d (want %d)", path, lock.Version, lockVersion)
+	}
+
+	return &lock, nil
+}
+
+// HashFileSHA256 returns the hex-encoded SHA-256 of the file at path.
+func HashFileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// HashContentSHA256 returns the hex-encoded SHA-256 of content.
+func HashContentSHA256(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,112 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harvx/harvx/internal/buildinfo"
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+func TestBuildLock_HashesDiskContentSortedByPath(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	mainPath := filepath.Join(dir, "main.go")
+	require.NoError(t, os.WriteFile(mainPath, []byte("package main\n"), 0o644))
+
+	files := []pipeline.FileDescriptor{
+		// Processed content differs from disk; the disk bytes are hashed.
+		{Path: "main.go", AbsPath: mainPath, Content: "[REDACTED]"},
+		{Path: "README.md", Content: "# Readme\n"},
+	}
+
+	lock, err := BuildLock("default", files)
+	require.NoError(t, err)
+
+	assert.Equal(t, lockVersion, lock.Version)
+	assert.Equal(t, buildinfo.Version, lock.ToolVersion)
+	assert.Equal(t, "default", lock.Profile)
+	require.Len(t, lock.Files, 2)
+	assert.Equal(t, LockEntry{Path: "README.md", SHA256: HashContentSHA256([]byte("# Readme\n"))}, lock.Files[0])
+	assert.Equal(t, LockEntry{Path: "main.go", SHA256: HashContentSHA256([]byte("package main\n"))}, lock.Files[1])
+}
+
+func TestBuildLock_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	files := []pipeline.FileDescriptor{
+		{Path: "gone.go", AbsPath: filepath.Join(t.TempDir(), "gone.go")},
+	}
+	_, err := BuildLock("default", files)
+	require.Error(t, err)
+}
+
+func TestWriteLock_RoundTripAndDeterministic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	lock := &Lock{
+		Version:     lockVersion,
+		ToolVersion: "1.2.3",
+		Profile:     "ci",
+		Files:       []LockEntry{{Path: "a.go", SHA256: "aa"}, {Path: "b.go", SHA256: "bb"}},
+	}
+
+	first := filepath.Join(dir, "first.lock")
+	second := filepath.Join(dir, "second.lock")
+	require.NoError(t, WriteLock(lock, first))
+	require.NoError(t, WriteLock(lock, second))
+
+	a, err := os.ReadFile(first)
+	require.NoError(t, err)
+	b, err := os.ReadFile(second)
+	require.NoError(t, err)
+	assert.Equal(t, a, b)
+	assert.True(t, bytes.HasSuffix(a, []byte("}\n")))
+
+	got, err := ReadLock(first)
+	require.NoError(t, err)
+	assert.Equal(t, lock, got)
+}
+
+func TestReadLock_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), LockFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 99, "files": []}`), 0o644))
+
+	_, err := ReadLock(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported version 99")
+}
+
+func TestRenderOutput_WritesLockForStdout(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	cfg := stdoutPipelineConfig(NewOutputWriterWithStreams(&stdout, &stderr))
+	cfg.LockPath = filepath.Join(dir, LockFileName)
+
+	files := []pipeline.FileDescriptor{
+		{Path: "b.go", Content: "package b\n", TokenCount: 3},
+		{Path: "a.go", Content: "package a\n", TokenCount: 3},
+	}
+	_, err := RenderOutput(context.Background(), cfg, files)
+	require.NoError(t, err)
+	assert.NotEmpty(t, stdout.String())
+
+	lock, err := ReadLock(cfg.LockPath)
+	require.NoError(t, err)
+	assert.Equal(t, "default", lock.Profile)
+	require.Len(t, lock.Files, 2)
+	assert.Equal(t, "a.go", lock.Files[0].Path)
+	assert.Equal(t, "b.go", lock.Files[1].Path)
+}
@@ -0,0 +1,209 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+)
+
+// ManifestVersion is the schema version for the manifest sidecar JSON.
+// Consumers should check it before relying on field layout.
+const ManifestVersion = "1.0.0"
+
+// Manifest is the top-level structure written to the .manifest.json sidecar
+// file. It indexes every file included in a context document so tools can
+// locate a file's content without parsing the rendered output.
+type Manifest struct {
+	// Version is the manifest schema version (currently "1.0.0").
+	Version string `json:"version"`
+
+	// Output is the base name of the context document the offsets refer to.
+	Output string `json:"output"`
+
+	// Format is the output format: "markdown", "xml", "json", or "plain".
+	Format string `json:"format"`
+
+	// ContentHash is the hex-encoded XXH3 hash of the rendered output.
+	ContentHash string `json:"content_hash"`
+
+	// Files lists the included files in the order they appear in the output.
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile describes one included file and where its content lives in the
+// output document.
+type ManifestFile struct {
+	// Path is the file's relative path.
+	Path string `json:"path"`
+
+	// Tier is the relevance tier (0-5).
+	Tier int `json:"tier"`
+
+	// TierLabel is the human-readable tier name (e.g., "critical").
+	TierLabel string `json:"tier_label"`
+
+	// TokenCount is the token count after processing.
+	TokenCount int `json:"token_count"`
+
+	// Truncated reports whether the budget enforcer truncated the content.
+	Truncated bool `json:"truncated"`
+
+	// Offset is the byte range of the rendered content within the output.
+	// It is nil for files rendered with an error instead of content.
+	Offset *ByteRange `json:"offset,omitempty"`
+}
+
+// ByteRange is a half-open [Start, End) byte range within the output
+// document. Seeking to Start and reading End-Start bytes yields the file's
+// rendered content exactly as it appears in the document.
+type ByteRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// OffsetRecorder captures the byte range of each file's content while a
+// renderer writes the document. Templates call Begin and End around the
+// content; both methods are safe on a nil recorder and render nothing.
+type OffsetRecorder struct {
+	cw     *countingWriter
+	ranges map[string]ByteRange
+}
+
+// NewOffsetRecorder creates an empty OffsetRecorder.
+func NewOffsetRecorder() *OffsetRecorder {
+	return &OffsetRecorder{ranges: make(map[string]ByteRange)}
+}
+
+// attach wraps w so the recorder can observe the number of bytes written.
+// It returns w unchanged when r is nil.
+func (r *OffsetRecorder) attach(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+	r.cw = &countingWriter{w: w}
+	return r.cw
+}
+
+// Begin marks the start of path's content at the current write position.
+func (r *OffsetRecorder) Begin(path string) string {
+	if r == nil || r.cw == nil {
+		return ""
+	}
+	r.ranges[path] = ByteRange{Start: r.cw.written}
+	return ""
+}
+
+// End marks the end of path's content at the current write position.
+func (r *OffsetRecorder) End(path string) string {
+	if r == nil || r.cw == nil {
+		return ""
+	}
+	br := r.ranges[path]
+	br.End = r.cw.written
+	r.ranges[path] = br
+	return ""
+}
+
+// Range returns the recorded byte range for path.
+func (r *OffsetRecorder) Range(path string) (ByteRange, bool) {
+	if r == nil {
+		return ByteRange{}, false
+	}
+	br, ok := r.ranges[path]
+	return br, ok
+}
+
+// BuildManifest assembles a Manifest from the rendered data and the write
+// result. data.Offsets must have been attached during rendering for offsets
+// to be populated.
+func BuildManifest(data *RenderData, result *OutputResult, format string) *Manifest {
+	m := &Manifest{
+		Version:     ManifestVersion,
+		Output:      filepath.Base(result.Path),
+		Format:      format,
+		ContentHash: result.HashHex,
+		Files:       make([]ManifestFile, 0, len(data.Files)),
+	}
+
+	for _, f := range data.Files {
+		label := f.TierLabel
+		if label == "" {
+			label = tierLabel(f.Tier)
+		}
+		entry := ManifestFile{
+			Path:       f.Path,
+			Tier:       f.Tier,
+			TierLabel:  label,
+			TokenCount: f.TokenCount,
+			Truncated:  f.IsTruncated,
+		}
+		if br, ok := data.Offsets.Range(f.Path); ok {
+			entry.Offset = &br
+		}
+		m.Files = append(m.Files, entry)
+	}
+
+	return m
+}
+
+// WriteManifest marshals the manifest to pretty-printed JSON and writes it
+// atomically to the sidecar path (outputPath + ".manifest.json") using the
+// same temporary file and rename pattern as WriteMetadata.
+func WriteManifest(m *Manifest, outputPath string) (retErr error) {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling manifest: %w", err)
+	}
+
+	// Append a trailing newline for POSIX compliance.
+	data = append(data, '\n')
+
+	sidecarPath := ManifestSidecarPath(outputPath)
+	dir := filepath.Dir(sidecarPath)
+
+	tmpFile, err := os.CreateTemp(dir, ".harvx-manifest-*.tmp")
+	if err != nil {
+		return fmt.Errorf("writing manifest: creating temp file in %q: %w", dir, err)
+	}
+	tmpPath := tmpFile.Name()
+
+	// Clean up the temp file on any error.
+	defer func() {
+		if retErr != nil {
+			tmpFile.Close()
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		return fmt.Errorf("writing manifest: syncing temp file: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("writing manifest: closing temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, sidecarPath); err != nil {
+		return fmt.Errorf("writing manifest: renaming %q to %q: %w", tmpPath, sidecarPath, err)
+	}
+
+	slog.Debug("wrote manifest sidecar",
+		"path", sidecarPath,
+		"files", len(m.Files),
+	)
+
+	return nil
+}
+
+// ManifestSidecarPath returns the manifest file path for a given output path.
+// The manifest is always the output path with ".manifest.json" appended.
+func ManifestSidecarPath(outputPath string) string {
+	return outputPath + ".manifest.json"
+}
@@ -0,0 +1,189 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harvx/harvx/internal/pipeline"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readManifest loads and decodes the manifest sidecar for outputPath.
+func readManifest(t *testing.T, outputPath string) *Manifest {
+	t.Helper()
+
+	data, err := os.ReadFile(ManifestSidecarPath(outputPath))
+	require.NoError(t, err)
+
+	var m Manifest
+	require.NoError(t, json.Unmarshal(data, &m))
+	return &m
+}
+
+// seekRange opens path, seeks to br.Start, and reads the range, as a manifest
+// consumer would.
+func seekRange(t *testing.T, path string, br ByteRange) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	_, err = f.Seek(br.Start, io.SeekStart)
+	require.NoError(t, err)
+
+	buf := make([]byte, br.End-br.Start)
+	_, err = io.ReadFull(f, buf)
+	require.NoError(t, err)
+	return string(buf)
+}
+
+func TestManifestSidecarPath(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "out/harvx-output.md.manifest.json", ManifestSidecarPath("out/harvx-output.md"))
+}
+
+func TestRenderOutput_Manifest_MarkdownOffsetsAreByteAccurate(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := basePipelineConfig(dir)
+	cfg.WriteManifest = true
+	files := sampleFileDescriptors()
+	// Multi-byte characters and escaped fences shift offsets if counted wrong.
+	files[0].Content = "module example.com/ünïcode\n\n```go\n"
+
+	result, err := RenderOutput(context.Background(), cfg, files)
+	require.NoError(t, err)
+
+	m := readManifest(t, result.Path)
+	assert.Equal(t, ManifestVersion, m.Version)
+	assert.Equal(t, "output.md", m.Output)
+	assert.Equal(t, FormatMarkdown, m.Format)
+	assert.Equal(t, result.HashHex, m.ContentHash)
+	require.Len(t, m.Files, len(files))
+
+	for i, f := range m.Files {
+		require.NotNil(t, f.Offset, "file %s must have an offset", f.Path)
+		assert.Equal(t, files[i].Path, f.Path)
+		assert.Equal(t, files[i].Tier, f.Tier)
+		assert.Equal(t, tierLabel(files[i].Tier), f.TierLabel)
+		assert.Equal(t, files[i].TokenCount, f.TokenCount)
+		assert.Equal(t, files[i].Content, seekRange(t, result.Path, *f.Offset))
+	}
+}
+
+func TestRenderOutput_Manifest_LineNumbers(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := basePipelineConfig(dir)
+	cfg.WriteManifest = true
+	cfg.ShowLineNumbers = true
+	files := sampleFileDescriptors()
+
+	result, err := RenderOutput(context.Background(), cfg, files)
+	require.NoError(t, err)
+
+	m := readManifest(t, result.Path)
+	for i, f := range m.Files {
+		require.NotNil(t, f.Offset)
+		assert.Equal(t, addLineNumbers(files[i].Content), seekRange(t, result.Path, *f.Offset))
+	}
+}
+
+func TestRenderOutput_Manifest_XMLOffsets(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := basePipelineConfig(dir)
+	cfg.Format = FormatXML
+	cfg.OutputPath = filepath.Join(dir, "output.xml")
+	cfg.WriteManifest = true
+	files := sampleFileDescriptors()
+
+	result, err := RenderOutput(context.Background(), cfg, files)
+	require.NoError(t, err)
+
+	m := readManifest(t, result.Path)
+	for i, f := range m.Files {
+		require.NotNil(t, f.Offset)
+		assert.Equal(t, wrapCDATA(files[i].Content), seekRange(t, result.Path, *f.Offset))
+	}
+}
+
+func TestRenderOutput_Manifest_TruncatedAndErrorFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := basePipelineConfig(dir)
+	cfg.WriteManifest = true
+	files := []pipeline.FileDescriptor{
+		{Path: "big.go", Tier: 1, TokenCount: 10, Content: "package big\n", IsTruncated: true},
+		{Path: "broken.go", Tier: 2, Error: assert.AnError},
+	}
+
+	result, err := RenderOutput(context.Background(), cfg, files)
+	require.NoError(t, err)
+
+	m := readManifest(t, result.Path)
+	require.Len(t, m.Files, 2)
+	assert.True(t, m.Files[0].Truncated)
+	assert.NotNil(t, m.Files[0].Offset)
+	assert.False(t, m.Files[1].Truncated)
+	assert.Nil(t, m.Files[1].Offset, "error files have no content range")
+}
+
+func TestRenderOutput_Manifest_OptIn(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := basePipelineConfig(dir)
+
+	result, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	_, statErr := os.Stat(ManifestSidecarPath(result.Path))
+	assert.True(t, os.IsNotExist(statErr), "manifest must not be written unless requested")
+}
+
+func TestRenderOutput_Manifest_DoesNotChangeOutput(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	plain := basePipelineConfig(dir)
+	plain.OutputPath = filepath.Join(dir, "plain.md")
+	withManifest := basePipelineConfig(dir)
+	withManifest.OutputPath = filepath.Join(dir, "manifest.md")
+	withManifest.WriteManifest = true
+
+	r1, err := RenderOutput(context.Background(), plain, sampleFileDescriptors())
+	require.NoError(t, err)
+	r2, err := RenderOutput(context.Background(), withManifest, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	a, err := os.ReadFile(r1.Path)
+	require.NoError(t, err)
+	b, err := os.ReadFile(r2.Path)
+	require.NoError(t, err)
+	assert.True(t, bytes.Equal(a, b), "recording offsets must not alter the rendered document")
+}
+
+func TestOffsetRecorder_NilSafe(t *testing.T) {
+	t.Parallel()
+
+	var r *OffsetRecorder
+	assert.Empty(t, r.Begin("a.go"))
+	assert.Empty(t, r.End("a.go"))
+	_, ok := r.Range("a.go")
+	assert.False(t, ok)
+
+	var buf bytes.Buffer
+	assert.Same(t, io.Writer(&buf), r.attach(&buf))
+}
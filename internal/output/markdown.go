@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+
+	"github.com/harvx/harvx/internal/pipeline"
 )
 
 // Compile-time interface compliance check.
@@ -31,5 +33,23 @@ func (r *MarkdownRenderer) Render(ctx context.Context, w io.Writer, data *Render
 		return fmt.Errorf("render data is nil")
 	}
 
+	// Track byte offsets for the manifest; a no-op when Offsets is nil.
+	w = data.Offsets.attach(w)
+
 	return markdownTemplate.ExecuteTemplate(w, "markdown-root", data)
-}
\ No newline at end of file
+}
+
+// RenderMarkdown writes files as a Markdown context document (see
+// MarkdownRenderer) to w. Each file gets a heading with its path and tier and
+// a code block tagged with the language of its extension, or no language for
+// unknown extensions; the fence is lengthened when the content contains
+// backtick fences of its own. As with RenderJSON, files are ordered by tier
+// and then by path, the header is filled from opts and totals computed over
+// files, and files is not modified.
+func RenderMarkdown(files []*pipeline.FileDescriptor, w io.Writer, opts pipeline.RenderOptions) error {
+	data, err := renderDataFromDescriptors(files, opts)
+	if err != nil {
+		return err
+	}
+	return NewMarkdownRenderer().Render(context.Background(), w, data)
+}
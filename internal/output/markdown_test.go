@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/harvx/harvx/internal/pipeline"
 	"github.com/harvx/harvx/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -429,10 +430,10 @@ func TestMarkdownRenderer_EmptyFileList(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// TestMarkdownRenderer_TripleBacktickEscape
+// TestMarkdownRenderer_NestedFence
 // ---------------------------------------------------------------------------
 
-func TestMarkdownRenderer_TripleBacktickEscape(t *testing.T) {
+func TestMarkdownRenderer_NestedFence(t *testing.T) {
 	t.Parallel()
 
 	data := testRenderData()
@@ -451,19 +452,10 @@ func TestMarkdownRenderer_TripleBacktickEscape(t *testing.T) {
 
 	output := renderToString(t, context.Background(), data)
 
-	// The triple backticks inside the content should be escaped to "`` `".
-	assert.Contains(t, output, "`` `go",
-		"inner triple backticks should be escaped")
-	assert.Contains(t, output, "`` `\n",
-		"closing inner triple backticks should be escaped")
-
-	// The outer code fence (wrapping the file content) should still use "```".
-	// Count outer triple backticks: there should be exactly 2 for the code fence
-	// (opening and closing) per file, but the inner ones are escaped.
-	// The file section's opening and closing fences are real ```.
-	// Ensure the output does not have broken fences.
-	assert.True(t, strings.Contains(output, "```markdown"),
-		"outer code fence should have language identifier")
+	// The content is written verbatim inside a four-backtick fence, so its
+	// own triple-backtick fences cannot close the block.
+	assert.Contains(t, output, "````markdown\n# Hello\n\n```go\nfunc main() {}\n```\n\nEnd.\n````",
+		"content should be fenced with a longer fence and left unescaped")
 }
 
 // ---------------------------------------------------------------------------
@@ -1028,3 +1020,58 @@ func BenchmarkMarkdownRenderer_LargeFileList(b *testing.B) {
 		_ = r.Render(ctx, &buf, data)
 	}
 }
+
+// TestRenderMarkdown_LanguageFences verifies that RenderMarkdown tags each code
+// fence with the language of the file extension and heads each file with its
+// path and tier.
+func TestRenderMarkdown_LanguageFences(t *testing.T) {
+	t.Parallel()
+
+	files := []*pipeline.FileDescriptor{
+		{Path: "main.go", Tier: 1, Content: "package main"},
+		{Path: "web/app.ts", Tier: 1, Content: "export {}"},
+		{Path: "tools/gen.py", Tier: 2, Content: "print()"},
+		{Path: "Cargo.TOML", Tier: 0, Content: "[package]"},
+		{Path: "notes.unknownext", Tier: 4, Content: "plain"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderMarkdown(files, &buf, pipeline.RenderOptions{ProjectName: "demo"}))
+	output := buf.String()
+
+	for _, want := range []string{
+		"### `main.go`\n",
+		"```go\npackage main\n```",
+		"```typescript\nexport {}\n```",
+		"```python\nprint()\n```",
+		"```toml\n[package]\n```",
+		"### `notes.unknownext`\n",
+		"```\nplain\n```",
+		"**Tier:** docs",
+	} {
+		assert.Contains(t, output, want)
+	}
+
+	// Files are ordered by tier, then path.
+	assert.Less(t, strings.Index(output, "`Cargo.TOML`"), strings.Index(output, "`main.go`"))
+	assert.Less(t, strings.Index(output, "`web/app.ts`"), strings.Index(output, "`tools/gen.py`"))
+}
+
+func TestRenderMarkdown_TableOfContents(t *testing.T) {
+	t.Parallel()
+
+	files := []*pipeline.FileDescriptor{
+		{Path: "cmd/my app/main.go", Tier: 1, Content: "package main"},
+		{Path: "go.mod", Tier: 0, Content: "module x"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderMarkdown(files, &buf, pipeline.RenderOptions{IncludeTOC: true}))
+	output := buf.String()
+
+	assert.Contains(t, output, "## Table of Contents\n\n- [`go.mod`](#gomod)\n- [`cmd/my app/main.go`](#cmdmy-appmaingo)\n\n## Files")
+
+	buf.Reset()
+	require.NoError(t, RenderMarkdown(files, &buf, pipeline.RenderOptions{}))
+	assert.NotContains(t, buf.String(), "Table of Contents")
+}
@@ -30,7 +30,7 @@ type OutputMetadata struct {
 	// Tokenizer is the tokenizer encoding used (e.g., "cl100k_base").
 	Tokenizer string `json:"tokenizer"`
 
-	// Format is the output format: "markdown" or "xml".
+	// Format is the output format: "markdown", "xml", "json", or "plain".
 	Format string `json:"format"`
 
 	// Target is the target LLM (e.g., "claude").
@@ -114,7 +114,7 @@ type MetadataOpts struct {
 	// Result is the output result from writing the context document.
 	Result *OutputResult
 
-	// Format is the output format ("markdown" or "xml").
+	// Format is the output format ("markdown", "xml", "json", or "plain").
 	Format string
 
 	// Target is the target LLM identifier (e.g., "claude").
@@ -7,6 +7,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/harvx/harvx/internal/config"
 	"github.com/harvx/harvx/internal/pipeline"
 )
 
@@ -14,9 +15,13 @@ import (
 // pipeline. It is populated from CLI flags, profile configuration, and pipeline
 // defaults before being passed to RenderOutput.
 type OutputConfig struct {
-	// Format is the output format: "markdown" or "xml".
+	// Format is the output format: "markdown", "xml", "json", or "plain".
 	Format string
 
+	// XMLSchemaVersion selects the XML schema when Format is "xml".
+	// 0 (XMLSchemaLegacy) keeps the default layout; see XMLSchemaV1.
+	XMLSchemaVersion int
+
 	// Target is the LLM target: "claude", "chatgpt", or "generic".
 	Target string
 
@@ -26,7 +31,8 @@ type OutputConfig struct {
 	// ProfileOutput is the output path from the active TOML profile config.
 	ProfileOutput string
 
-	// UseStdout writes to stdout instead of a file when true.
+	// UseStdout writes to stdout instead of a file when true. An output path
+	// of StdoutPath ("-") has the same effect.
 	UseStdout bool
 
 	// SplitTokens is the maximum tokens per part. 0 means no splitting.
@@ -35,9 +41,17 @@ type OutputConfig struct {
 	// ShowLineNumbers enables line number prefixes in code blocks.
 	ShowLineNumbers bool
 
+	// IncludeTOC adds a table of contents listing every file (Markdown only).
+	IncludeTOC bool
+
 	// OutputMetadata enables .meta.json sidecar generation.
 	OutputMetadata bool
 
+	// WriteManifest enables .manifest.json sidecar generation listing every
+	// included file with the byte range of its content in the output.
+	// Ignored for stdout and split output.
+	WriteManifest bool
+
 	// TreeMaxDepth controls tree rendering depth. 0 means unlimited.
 	TreeMaxDepth int
 
@@ -70,6 +84,39 @@ type OutputConfig struct {
 	// Writer is an optional custom OutputWriter. When nil, a default
 	// OutputWriter writing to os.Stdout/os.Stderr is created.
 	Writer *OutputWriter
+
+	// CacheDir enables the run cache (see RunCache) stored in this directory.
+	// When the fingerprint and file hashes match a previous run, the existing
+	// output file is reused without rendering. Empty disables caching. The
+	// cache is not consulted for stdout or split output.
+	CacheDir string
+
+	// LockPath enables writing a lock file (see Lock) to this path, listing
+	// every included file with its SHA-256 content hash. Empty disables the
+	// lock. The lock is written for every output mode, including stdout.
+	LockPath string
+}
+
+// ConfigFromFlags returns the OutputConfig settings the global CLI flags
+// select: format and XML schema version, target, stdout, split size, line
+// numbers, the metadata sidecar, the run cache directory, and the header's
+// profile, tokenizer, and token budget. Output paths, project name, and
+// timestamp are left for the caller, which knows whether --output or the
+// profile's output applies.
+func ConfigFromFlags(fv *config.FlagValues) OutputConfig {
+	return OutputConfig{
+		Format:           fv.Format,
+		XMLSchemaVersion: fv.FormatVersion,
+		Target:           fv.Target,
+		UseStdout:        fv.Stdout,
+		SplitTokens:      fv.Split,
+		ShowLineNumbers:  fv.LineNumbers,
+		OutputMetadata:   fv.OutputMetadata,
+		ProfileName:      fv.Profile,
+		TokenizerName:    fv.Tokenizer,
+		MaxTokens:        fv.MaxTokens,
+		CacheDir:         fv.CacheDir,
+	}
 }
 
 // RenderOutput orchestrates the full output rendering flow. It converts
@@ -84,6 +131,10 @@ type OutputConfig struct {
 //  5. Write output via OutputWriter (file or stdout)
 //  6. Optionally split into multiple parts
 //  7. Optionally generate metadata sidecar
+//
+// When cfg.LockPath is set, the lock file is written after step 1. When
+// cfg.CacheDir is set, a run cache hit returns the previous result before
+// step 2 and nothing is rendered or written.
 func RenderOutput(ctx context.Context, cfg OutputConfig, files []pipeline.FileDescriptor) (*OutputResult, error) {
 	select {
 	case <-ctx.Done():
@@ -91,6 +142,12 @@ func RenderOutput(ctx context.Context, cfg OutputConfig, files []pipeline.FileDe
 	default:
 	}
 
+	// An output path of "-" streams to stdout; the run cache and sidecars
+	// only apply to file output.
+	if ResolveOutputPath(cfg.OutputPath, cfg.ProfileOutput, cfg.Format) == StdoutPath {
+		cfg.UseStdout = true
+	}
+
 	// Use default timestamp if not set.
 	ts := cfg.Timestamp
 	if ts.IsZero() {
@@ -108,6 +165,34 @@ func RenderOutput(ctx context.Context, cfg OutputConfig, files []pipeline.FileDe
 		"split_tokens", cfg.SplitTokens,
 	)
 
+	if cfg.LockPath != "" {
+		lock, err := BuildLock(cfg.ProfileName, files)
+		if err != nil {
+			return nil, err
+		}
+		if err := WriteLock(lock, cfg.LockPath); err != nil {
+			return nil, err
+		}
+	}
+
+	// A run cache hit reuses the previous output file as-is.
+	cache, fingerprint, fileHashes := openRunCache(cfg, renderEntries)
+	if cache != nil {
+		if entry, ok := cache.Lookup(fingerprint, fileHashes); ok {
+			slog.Info("output unchanged, reusing cached output",
+				"path", entry.OutputPath,
+				"files", len(renderEntries),
+			)
+			return &OutputResult{
+				Path:         entry.OutputPath,
+				Hash:         entry.OutputHash,
+				HashHex:      FormatHash(entry.OutputHash),
+				TotalTokens:  entry.TotalTokens,
+				BytesWritten: entry.BytesWritten,
+			}, nil
+		}
+	}
+
 	// Step 2: Build and render the directory tree.
 	tree := BuildTree(treeEntries)
 	treeString := RenderTree(tree, TreeRenderOpts{
@@ -141,6 +226,7 @@ func RenderOutput(ctx context.Context, cfg OutputConfig, files []pipeline.FileDe
 		Files:            renderEntries,
 		TreeString:       treeString,
 		ShowLineNumbers:  cfg.ShowLineNumbers,
+		IncludeTOC:       cfg.IncludeTOC,
 		TierCounts:       tierCounts,
 		TopFilesByTokens: topFiles,
 		RedactionSummary: map[string]int{},
@@ -159,7 +245,49 @@ func RenderOutput(ctx context.Context, cfg OutputConfig, files []pipeline.FileDe
 		return renderSplit(ctx, writer, data, cfg)
 	}
 
-	return renderSingle(ctx, writer, data, cfg)
+	result, err := renderSingle(ctx, writer, data, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if cache != nil && result.Path != "" {
+		cache.Store(fingerprint, RunCacheEntry{
+			FileHashes:   fileHashes,
+			OutputPath:   result.Path,
+			OutputHash:   result.Hash,
+			BytesWritten: result.BytesWritten,
+			TotalTokens:  result.TotalTokens,
+		})
+		if err := cache.Save(); err != nil {
+			slog.Warn("failed to save run cache", "dir", cfg.CacheDir, "error", err)
+		}
+	}
+
+	return result, nil
+}
+
+// openRunCache opens the run cache for cfg and computes the lookup key for
+// entries. It returns a nil cache when caching is disabled or does not apply
+// (stdout or split output). Cache failures are logged and disable caching
+// for the run rather than failing it.
+func openRunCache(cfg OutputConfig, entries []FileRenderEntry) (*RunCache, string, map[string]uint64) {
+	if cfg.CacheDir == "" || cfg.UseStdout || cfg.SplitTokens > 0 {
+		return nil, "", nil
+	}
+
+	fingerprint, err := RunFingerprint(cfg)
+	if err != nil {
+		slog.Warn("run cache disabled", "error", err)
+		return nil, "", nil
+	}
+
+	cache, err := OpenRunCache(cfg.CacheDir)
+	if err != nil {
+		slog.Warn("run cache disabled", "dir", cfg.CacheDir, "error", err)
+		return nil, "", nil
+	}
+
+	return cache, fingerprint, RunFileHashes(entries)
 }
 
 // renderSingle writes the output as a single file or to stdout.
@@ -168,8 +296,10 @@ func renderSingle(ctx context.Context, writer *OutputWriter, data *RenderData, c
 		OutputPath:       cfg.OutputPath,
 		ProfileOutput:    cfg.ProfileOutput,
 		Format:           cfg.Format,
+		XMLSchemaVersion: cfg.XMLSchemaVersion,
 		UseStdout:        cfg.UseStdout,
 		OutputMetadata:   cfg.OutputMetadata,
+		WriteManifest:    cfg.WriteManifest,
 		Target:           cfg.Target,
 		MaxTokens:        cfg.MaxTokens,
 		GenerationTimeMs: cfg.GenerationTimeMs,
@@ -199,6 +329,7 @@ func renderSplit(ctx context.Context, writer *OutputWriter, data *RenderData, cf
 			OutputPath:       cfg.OutputPath,
 			ProfileOutput:    cfg.ProfileOutput,
 			Format:           cfg.Format,
+			XMLSchemaVersion: cfg.XMLSchemaVersion,
 			UseStdout:        cfg.UseStdout,
 			OutputMetadata:   cfg.OutputMetadata,
 			Target:           cfg.Target,
@@ -254,6 +385,7 @@ func toFileRenderEntries(files []pipeline.FileDescriptor) []FileRenderEntry {
 			Language:     fd.Language,
 			Content:      fd.Content,
 			IsCompressed: fd.IsCompressed,
+			IsTruncated:  fd.IsTruncated,
 			Redactions:   fd.Redactions,
 		}
 		if fd.Error != nil {
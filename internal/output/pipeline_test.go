@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/harvx/harvx/internal/config"
 	"github.com/harvx/harvx/internal/pipeline"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -288,6 +289,26 @@ func TestRenderOutput_XMLBasic(t *testing.T) {
 	assert.Contains(t, string(content), "go.mod")
 }
 
+func TestRenderOutput_PlainBasic(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cfg := basePipelineConfig(dir)
+	cfg.Format = FormatPlain
+	cfg.OutputPath = filepath.Join(dir, "output.txt")
+	files := sampleFileDescriptors()
+
+	result, err := RenderOutput(context.Background(), cfg, files)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+
+	content, err := os.ReadFile(result.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "===== go.mod (tier ")
+	assert.Contains(t, string(content), "===== cmd/main.go (tier ")
+	assert.NotContains(t, string(content), "# Harvx Context")
+}
+
 func TestRenderOutput_SameContentHash_MarkdownAndXML(t *testing.T) {
 	t.Parallel()
 
@@ -687,3 +708,37 @@ func TestGolden_MetadataSidecar(t *testing.T) {
 	goldenPath := filepath.Join(goldenDir(), "pipeline-metadata-sidecar.golden")
 	compareGolden(t, metaContent, goldenPath)
 }
+
+// TestConfigFromFlags verifies that output flags, including the XML schema
+// version and the run cache directory, are carried into the OutputConfig.
+func TestConfigFromFlags(t *testing.T) {
+	t.Parallel()
+
+	cfg := ConfigFromFlags(&config.FlagValues{
+		Format:         "xml",
+		FormatVersion:  XMLSchemaV1,
+		Target:         "claude",
+		Stdout:         true,
+		Split:          50000,
+		LineNumbers:    true,
+		OutputMetadata: true,
+		Profile:        "ci",
+		Tokenizer:      "o200k_base",
+		MaxTokens:      100000,
+		CacheDir:       ".harvx/cache",
+	})
+
+	assert.Equal(t, OutputConfig{
+		Format:           "xml",
+		XMLSchemaVersion: XMLSchemaV1,
+		Target:           "claude",
+		UseStdout:        true,
+		SplitTokens:      50000,
+		ShowLineNumbers:  true,
+		OutputMetadata:   true,
+		ProfileName:      "ci",
+		TokenizerName:    "o200k_base",
+		MaxTokens:        100000,
+		CacheDir:         ".harvx/cache",
+	}, cfg)
+}
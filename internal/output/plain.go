@@ -0,0 +1,111 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+// Compile-time interface compliance check.
+var _ Renderer = (*PlainRenderer)(nil)
+
+// PlainRenderer produces the context document as plain text, for consumers
+// that want file contents without any markup:
+//
+//	===== src/main.go (tier 1) =====
+//	package main
+//	...
+//
+// Each file is a separator line naming its path and tier, the content
+// exactly as given, a newline if the content does not end with one, and a
+// blank line. There is no document header, so rendering no files writes
+// nothing. Content is never escaped: a line of "=====" inside a file is
+// written as is, and readers should locate files by the manifest offsets or
+// by the separator lines they expect. A file that failed to load is rendered
+// as "error: <message>" in place of its content. It implements the Renderer
+// interface.
+type PlainRenderer struct{}
+
+// NewPlainRenderer creates a new PlainRenderer.
+func NewPlainRenderer() *PlainRenderer {
+	return &PlainRenderer{}
+}
+
+// Render writes each file in data to w in the order given, streaming file by
+// file.
+func (r *PlainRenderer) Render(ctx context.Context, w io.Writer, data *RenderData) error {
+	if data == nil {
+		return fmt.Errorf("render data is nil")
+	}
+
+	// Track byte offsets for the manifest; a no-op when Offsets is nil.
+	w = data.Offsets.attach(w)
+
+	for _, f := range data.Files {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if _, err := fmt.Fprintf(w, "===== %s (tier %d) =====\n", f.Path, f.Tier); err != nil {
+			return err
+		}
+		content := f.Content
+		if f.Error != "" {
+			content = "error: " + f.Error
+		} else {
+			data.Offsets.Begin(f.Path)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			return err
+		}
+		if f.Error == "" {
+			data.Offsets.End(f.Path)
+		}
+
+		if _, err := io.WriteString(w, plainTrailer(content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// plainTrailer returns what follows a file's content: the blank line that
+// ends the section, preceded by a newline when content does not end with one.
+func plainTrailer(content string) string {
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		return "\n\n"
+	}
+	return "\n"
+}
+
+// plainTemplate renders the plain-text layout section by section for
+// RenderStream. It has no header or footer, and "plain-file" writes the same
+// bytes as PlainRenderer does for one file.
+var plainTemplate = template.Must(template.New("plain").Funcs(template.FuncMap{
+	"plainTrailer": plainTrailer,
+}).Parse(plainTmpl))
+
+// plainTmpl defines the "plain-head", "plain-file", and "plain-tail"
+// templates executed by RenderStream.
+const plainTmpl = `{{- define "plain-head" -}}{{- end -}}
+{{- define "plain-tail" -}}{{- end -}}
+{{- define "plain-file" -}}
+{{- $content := .File.Content -}}
+{{- if .File.Error}}{{$content = printf "error: %s" .File.Error}}{{end -}}
+===== {{.File.Path}} (tier {{.File.Tier}}) =====
+{{$content}}{{plainTrailer $content}}
+{{- end -}}`
+
+// RenderPlain writes files as a plain-text context document (see
+// PlainRenderer) to w. Files are ordered by tier and then by path regardless
+// of input order, and files is not modified.
+func RenderPlain(files []*pipeline.FileDescriptor, w io.Writer) error {
+	data := &RenderData{Files: toFileRenderEntries(sortedDescriptors(files))}
+	return NewPlainRenderer().Render(context.Background(), w, data)
+}
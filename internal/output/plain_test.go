@@ -0,0 +1,82 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+func TestNewRenderer_Plain(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRenderer(FormatPlain)
+	require.NoError(t, err)
+	assert.IsType(t, &PlainRenderer{}, r)
+}
+
+func TestRenderPlain_Separators(t *testing.T) {
+	t.Parallel()
+
+	files := []*pipeline.FileDescriptor{
+		{Path: "src/main.go", Tier: 1, Content: "package main\n"},
+		{Path: "go.mod", Tier: 0, Content: "module example.com/x"},
+		{Path: "docs/empty.md", Tier: 4},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderPlain(files, &buf))
+
+	want := "===== go.mod (tier 0) =====\n" +
+		"module example.com/x\n\n" +
+		"===== src/main.go (tier 1) =====\n" +
+		"package main\n\n" +
+		"===== docs/empty.md (tier 4) =====\n\n"
+	assert.Equal(t, want, buf.String())
+}
+
+func TestRenderPlain_ContentNotMangled(t *testing.T) {
+	t.Parallel()
+
+	content := "===== fake.go (tier 0) =====\n<tag> & ``` \\ \"quoted\"\n=====\n"
+	files := []*pipeline.FileDescriptor{{Path: "notes.txt", Tier: 2, Content: content}}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderPlain(files, &buf))
+	assert.Equal(t, "===== notes.txt (tier 2) =====\n"+content+"\n", buf.String())
+}
+
+func TestRenderPlain_Empty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderPlain(nil, &buf))
+	assert.Empty(t, buf.String())
+}
+
+func TestPlainRenderer_RecordsOffsets(t *testing.T) {
+	t.Parallel()
+
+	data := &RenderData{
+		Files: []FileRenderEntry{
+			{Path: "a.go", Tier: 1, Content: "package a"},
+			{Path: "b.go", Tier: 1, Error: "permission denied"},
+		},
+		Offsets: NewOffsetRecorder(),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, NewPlainRenderer().Render(context.Background(), &buf, data))
+	assert.Contains(t, buf.String(), "===== b.go (tier 1) =====\nerror: permission denied\n\n")
+
+	br, ok := data.Offsets.Range("a.go")
+	require.True(t, ok)
+	assert.Equal(t, "package a", buf.String()[br.Start:br.End])
+
+	_, ok = data.Offsets.Range("b.go")
+	assert.False(t, ok, "files rendered with an error have no offset")
+}
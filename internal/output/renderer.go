@@ -52,6 +52,10 @@ type RenderData struct {
 	// ShowLineNumbers enables line number prefixes inside code blocks.
 	ShowLineNumbers bool
 
+	// IncludeTOC adds a table of contents linking to every file after the
+	// directory tree. Only the Markdown renderer emits one.
+	IncludeTOC bool
+
 	// TierCounts maps tier number (0-5) to the count of files in that tier.
 	TierCounts map[int]int
 
@@ -67,6 +71,11 @@ type RenderData struct {
 	// DiffSummary holds change summary data when diff mode is active.
 	// Nil means no diff data is available.
 	DiffSummary *DiffSummaryData
+
+	// Offsets records the byte range of each file's content as the renderer
+	// writes it. It is set by OutputWriter when a manifest is requested and is
+	// nil otherwise; templates call its methods unconditionally.
+	Offsets *OffsetRecorder
 }
 
 // FileRenderEntry holds per-file data needed for rendering.
@@ -95,6 +104,9 @@ type FileRenderEntry struct {
 	// IsCompressed indicates whether compression was applied.
 	IsCompressed bool
 
+	// IsTruncated indicates whether the budget enforcer truncated the content.
+	IsTruncated bool
+
 	// Redactions is the number of secrets redacted from this file.
 	Redactions int
 
@@ -103,6 +115,24 @@ type FileRenderEntry struct {
 	Error string
 }
 
+// fileSection is the data the per-file templates ("file" and "xml-file") are
+// executed with: one file plus the document-wide settings it renders with.
+type fileSection struct {
+	// File is the file to render.
+	File FileRenderEntry
+
+	// ShowLineNumbers mirrors RenderData.ShowLineNumbers.
+	ShowLineNumbers bool
+
+	// Offsets mirrors RenderData.Offsets.
+	Offsets *OffsetRecorder
+}
+
+// newFileSection returns the fileSection for f within data.
+func newFileSection(data *RenderData, f FileRenderEntry) fileSection {
+	return fileSection{File: f, ShowLineNumbers: data.ShowLineNumbers, Offsets: data.Offsets}
+}
+
 // DiffSummaryData holds change summary information for diff mode rendering.
 type DiffSummaryData struct {
 	// AddedFiles is the list of newly added file paths.
@@ -0,0 +1,245 @@
+package output
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/zeebo/xxh3"
+)
+
+// RunCacheFile is the name of the run cache file inside the cache directory.
+const RunCacheFile = "run-cache.json"
+
+// runCacheVersion is the on-disk schema version of a RunCache. A cache file
+// with a different version is discarded rather than migrated.
+const runCacheVersion = 1
+
+// RunCache remembers the output of previous runs so that a run whose output
+// settings and included files are unchanged can reuse the existing output
+// file instead of rendering it again. Entries are keyed by the run
+// fingerprint (see RunFingerprint) and validated against the per-file hashes
+// of every included file (see RunFileHashes): any added, removed, or changed
+// file is a miss.
+//
+// RunCache is not safe for concurrent use.
+type RunCache struct {
+	// Version is the schema version (runCacheVersion).
+	Version int `json:"version"`
+
+	// Entries maps a run fingerprint to the output it produced.
+	Entries map[string]RunCacheEntry `json:"entries"`
+
+	path string
+}
+
+// RunCacheEntry records one rendered output and the inputs it was rendered
+// from.
+type RunCacheEntry struct {
+	// FileHashes maps each included file's relative path to its run hash.
+	FileHashes map[string]uint64 `json:"file_hashes"`
+
+	// OutputPath is the path of the rendered output file.
+	OutputPath string `json:"output_path"`
+
+	// OutputHash is the XXH3 hash of the rendered output.
+	OutputHash uint64 `json:"output_hash"`
+
+	// BytesWritten is the size of the rendered output in bytes.
+	BytesWritten int64 `json:"bytes_written"`
+
+	// TotalTokens is the total token count of the rendered output.
+	TotalTokens int `json:"total_tokens"`
+}
+
+// OpenRunCache loads the run cache stored in dir. A missing cache file, or
+// one written with a different schema version, yields an empty cache and no
+// error. A file that cannot be parsed is an error. The directory is created
+// on Save, not here.
+func OpenRunCache(dir string) (*RunCache, error) {
+	path := filepath.Join(dir, RunCacheFile)
+	cache := &RunCache{
+		Version: runCacheVersion,
+		Entries: make(map[string]RunCacheEntry),
+		path:    path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cache, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading run cache %s: %w", path, err)
+	}
+
+	var loaded RunCache
+	if err := json.Unmarshal(data, &loaded); err != nil {
+		return nil, fmt.Errorf("parsing run cache %s: %w", path, err)
+	}
+	if loaded.Version != runCacheVersion || loaded.Entries == nil {
+		return cache, nil
+	}
+
+	cache.Entries = loaded.Entries
+	return cache, nil
+}
+
+// Lookup returns the cached output for fingerprint when fileHashes matches
+// the recorded hashes exactly and the output file still exists with the
+// recorded size and hash, so an output edited in place is a miss.
+func (c *RunCache) Lookup(fingerprint string, fileHashes map[string]uint64) (RunCacheEntry, bool) {
+	entry, ok := c.Entries[fingerprint]
+	if !ok || !maps.Equal(entry.FileHashes, fileHashes) {
+		return RunCacheEntry{}, false
+	}
+
+	info, err := os.Stat(entry.OutputPath)
+	if err != nil || info.Size() != entry.BytesWritten {
+		return RunCacheEntry{}, false
+	}
+	if hash, err := hashOutputFile(entry.OutputPath); err != nil || hash != entry.OutputHash {
+		return RunCacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+// hashOutputFile returns the XXH3 hash of the file at path, computed the way
+// OutputWriter hashes the output it writes.
+func hashOutputFile(path string) (uint64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	hasher := NewIncrementalHasher()
+	if _, err := io.Copy(hasher, f); err != nil {
+		return 0, err
+	}
+	return hasher.Sum64(), nil
+}
+
+// Store records entry under fingerprint, replacing any previous output for
+// the same fingerprint.
+func (c *RunCache) Store(fingerprint string, entry RunCacheEntry) {
+	c.Entries[fingerprint] = entry
+}
+
+// Save writes the cache back to its directory atomically, creating the
+// directory if needed.
+func (c *RunCache) Save() error {
+	dir := filepath.Dir(c.path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating run cache directory %s: %w", dir, err)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshaling run cache: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, ".harvx-run-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file for run cache: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	success := false
+	defer func() {
+		if !success {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("writing run cache to temp file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("closing run cache temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, c.path); err != nil {
+		return fmt.Errorf("renaming run cache into place: %w", err)
+	}
+
+	success = true
+	return nil
+}
+
+// RunFingerprint returns a stable fingerprint of every OutputConfig setting
+// that affects the rendered document. Timestamp, GenerationTimeMs, Writer,
+// and CacheDir are excluded so that repeated runs of the same configuration
+// share a fingerprint.
+func RunFingerprint(cfg OutputConfig) (string, error) {
+	settings := struct {
+		Format           string
+		XMLSchemaVersion int
+		Target           string
+		OutputPath       string
+		ShowLineNumbers  bool
+		IncludeTOC       bool
+		OutputMetadata   bool
+		WriteManifest    bool
+		TreeMaxDepth     int
+		ShowTreeMetadata bool
+		ProjectName      string
+		ProfileName      string
+		TokenizerName    string
+		MaxTokens        int
+		DiffSummary      *DiffSummaryData
+	}{
+		Format:           cfg.Format,
+		XMLSchemaVersion: cfg.XMLSchemaVersion,
+		Target:           cfg.Target,
+		OutputPath:       ResolveOutputPath(cfg.OutputPath, cfg.ProfileOutput, cfg.Format),
+		ShowLineNumbers:  cfg.ShowLineNumbers,
+		IncludeTOC:       cfg.IncludeTOC,
+		OutputMetadata:   cfg.OutputMetadata,
+		WriteManifest:    cfg.WriteManifest,
+		TreeMaxDepth:     cfg.TreeMaxDepth,
+		ShowTreeMetadata: cfg.ShowTreeMetadata,
+		ProjectName:      cfg.ProjectName,
+		ProfileName:      cfg.ProfileName,
+		TokenizerName:    cfg.TokenizerName,
+		MaxTokens:        cfg.MaxTokens,
+		DiffSummary:      cfg.DiffSummary,
+	}
+
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", fmt.Errorf("marshaling run fingerprint: %w", err)
+	}
+	return FormatHash(xxh3.Hash(data)), nil
+}
+
+// RunFileHashes returns the run hash of every entry keyed by path. The hash
+// covers each field the renderers read, so a change to content, tier, token
+// count, or any other rendered attribute changes the hash.
+func RunFileHashes(entries []FileRenderEntry) map[string]uint64 {
+	hashes := make(map[string]uint64, len(entries))
+	for _, e := range entries {
+		h := xxh3.New()
+		for _, field := range []string{
+			strconv.FormatInt(e.Size, 10),
+			strconv.Itoa(e.TokenCount),
+			strconv.Itoa(e.Tier),
+			e.Language,
+			strconv.FormatBool(e.IsCompressed),
+			strconv.FormatBool(e.IsTruncated),
+			strconv.Itoa(e.Redactions),
+			e.Error,
+			e.Content,
+		} {
+			h.WriteString(field)
+			h.Write([]byte{0})
+		}
+		hashes[e.Path] = h.Sum64()
+	}
+	return hashes
+}
@@ -0,0 +1,243 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zeebo/xxh3"
+)
+
+// countingRenderer wraps a real Renderer and counts Render calls.
+type countingRenderer struct {
+	Renderer
+	calls *int
+}
+
+func (r countingRenderer) Render(ctx context.Context, w io.Writer, data *RenderData) error {
+	*r.calls++
+	return r.Renderer.Render(ctx, w, data)
+}
+
+// cachedPipelineConfig returns a file-output config with the run cache
+// enabled and an OutputWriter whose renders are counted in calls.
+func cachedPipelineConfig(dir string, calls *int) OutputConfig {
+	var stdout, stderr bytes.Buffer
+	writer := NewOutputWriterWithStreams(&stdout, &stderr)
+	writer.newRenderer = func(format string, xmlSchema int) (Renderer, error) {
+		r, err := NewRendererVersion(format, xmlSchema)
+		if err != nil {
+			return nil, err
+		}
+		return countingRenderer{Renderer: r, calls: calls}, nil
+	}
+
+	cfg := basePipelineConfig(dir)
+	cfg.Writer = writer
+	cfg.CacheDir = filepath.Join(dir, "cache")
+	return cfg
+}
+
+func TestRenderOutput_RunCacheHit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var calls int
+	cfg := cachedPipelineConfig(dir, &calls)
+
+	first, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+	require.Equal(t, 1, calls)
+	assert.FileExists(t, filepath.Join(cfg.CacheDir, RunCacheFile))
+
+	second, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, calls, "identical run must not re-render")
+	assert.Equal(t, first.Path, second.Path)
+	assert.Equal(t, first.Hash, second.Hash)
+	assert.Equal(t, first.HashHex, second.HashHex)
+	assert.Equal(t, first.BytesWritten, second.BytesWritten)
+	assert.Equal(t, first.TotalTokens, second.TotalTokens)
+}
+
+func TestRenderOutput_RunCacheMissOnContentChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var calls int
+	cfg := cachedPipelineConfig(dir, &calls)
+
+	first, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	changed := sampleFileDescriptors()
+	changed[0].Content = "module example.com/changed\n\ngo 1.24.0\n"
+
+	second, err := RenderOutput(context.Background(), cfg, changed)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls, "changed file must re-render")
+	assert.NotEqual(t, first.Hash, second.Hash)
+
+	content, err := os.ReadFile(second.Path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "example.com/changed")
+}
+
+func TestRenderOutput_RunCacheMissOnConfigChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var calls int
+	cfg := cachedPipelineConfig(dir, &calls)
+
+	_, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	cfg.ShowLineNumbers = true
+	_, err = RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls, "changed output settings must re-render")
+}
+
+func TestRenderOutput_RunCacheMissWhenOutputDeleted(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var calls int
+	cfg := cachedPipelineConfig(dir, &calls)
+
+	first, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(first.Path))
+
+	_, err = RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls)
+	assert.FileExists(t, first.Path)
+}
+
+func TestRenderOutput_RunCacheMissWhenOutputEdited(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var calls int
+	cfg := cachedPipelineConfig(dir, &calls)
+
+	first, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	// Same size, different bytes.
+	data, err := os.ReadFile(first.Path)
+	require.NoError(t, err)
+	data[0] ^= 0x01
+	require.NoError(t, os.WriteFile(first.Path, data, 0o644))
+
+	second, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, calls, "an edited output must be rendered again")
+	assert.Equal(t, first.Hash, second.Hash)
+}
+
+func TestRenderOutput_RunCacheDisabledForStdout(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	var calls int
+	cfg := cachedPipelineConfig(dir, &calls)
+	cfg.UseStdout = true
+
+	for range 2 {
+		_, err := RenderOutput(context.Background(), cfg, sampleFileDescriptors())
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2, calls)
+	assert.NoFileExists(t, filepath.Join(cfg.CacheDir, RunCacheFile))
+}
+
+func TestOpenRunCache_MissingAndCorrupt(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	cache, err := OpenRunCache(dir)
+	require.NoError(t, err)
+	assert.Empty(t, cache.Entries)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, RunCacheFile), []byte("{not json"), 0644))
+	_, err = OpenRunCache(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing run cache")
+}
+
+func TestRunCache_SaveRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "out.md")
+	require.NoError(t, os.WriteFile(outPath, []byte("hello"), 0644))
+
+	cache, err := OpenRunCache(filepath.Join(dir, "nested", "cache"))
+	require.NoError(t, err)
+
+	hashes := map[string]uint64{"a.go": 1, "b.go": 2}
+	outHash := xxh3.HashString("hello")
+	cache.Store("fp", RunCacheEntry{FileHashes: hashes, OutputPath: outPath, OutputHash: outHash, BytesWritten: 5})
+	require.NoError(t, cache.Save())
+
+	reloaded, err := OpenRunCache(filepath.Join(dir, "nested", "cache"))
+	require.NoError(t, err)
+
+	entry, ok := reloaded.Lookup("fp", map[string]uint64{"a.go": 1, "b.go": 2})
+	require.True(t, ok)
+	assert.Equal(t, outHash, entry.OutputHash)
+
+	_, ok = reloaded.Lookup("fp", map[string]uint64{"a.go": 1, "b.go": 3})
+	assert.False(t, ok, "changed file hash must miss")
+	_, ok = reloaded.Lookup("fp", map[string]uint64{"a.go": 1})
+	assert.False(t, ok, "removed file must miss")
+	_, ok = reloaded.Lookup("other", hashes)
+	assert.False(t, ok, "different fingerprint must miss")
+}
+
+func TestRunFileHashes(t *testing.T) {
+	t.Parallel()
+
+	base := toFileRenderEntries(sampleFileDescriptors())
+	hashes := RunFileHashes(base)
+	require.Len(t, hashes, len(base))
+
+	retiered := toFileRenderEntries(sampleFileDescriptors())
+	retiered[0].Tier = 5
+	assert.NotEqual(t, hashes[base[0].Path], RunFileHashes(retiered)[base[0].Path])
+	assert.Equal(t, hashes[base[1].Path], RunFileHashes(retiered)[base[1].Path])
+}
+
+func TestRunFingerprint_IgnoresTimestampAndWriter(t *testing.T) {
+	t.Parallel()
+
+	cfg := basePipelineConfig(t.TempDir())
+	fp1, err := RunFingerprint(cfg)
+	require.NoError(t, err)
+
+	cfg.Timestamp = cfg.Timestamp.AddDate(1, 0, 0)
+	cfg.GenerationTimeMs = 999
+	cfg.Writer = NewOutputWriter()
+	fp2, err := RunFingerprint(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, fp1, fp2)
+
+	cfg.Format = FormatXML
+	fp3, err := RunFingerprint(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, fp1, fp3)
+}
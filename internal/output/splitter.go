@@ -22,7 +22,7 @@ type SplitOpts struct {
 	// TokensPerPart is the maximum token budget per part. Must be > 0.
 	TokensPerPart int
 
-	// Format is the output format: "markdown" or "xml".
+	// Format is the output format: "markdown", "xml", "json", or "plain".
 	Format string
 
 	// OverheadPerFile is the estimated token overhead per file for headers
@@ -299,6 +299,7 @@ func (s *Splitter) buildPartRenderData(original *RenderData, files []FileRenderE
 		TotalFiles:      len(files),
 		Files:           files,
 		ShowLineNumbers: original.ShowLineNumbers,
+		IncludeTOC:      original.IncludeTOC,
 		TierCounts:      partTierCounts,
 		DiffSummary:     original.DiffSummary,
 	}
@@ -354,8 +355,9 @@ func (ow *OutputWriter) WriteSplit(ctx context.Context, data *RenderData, opts S
 		return nil, fmt.Errorf("writing split output: split tokens must be positive, got %d", opts.SplitTokens)
 	}
 
-	if opts.Format != FormatMarkdown && opts.Format != FormatXML {
-		return nil, fmt.Errorf("writing split output: unsupported format %q", opts.Format)
+	// Reject unknown formats before splitting; each part is rendered later.
+	if _, err := ow.renderer(opts.OutputOpts); err != nil {
+		return nil, fmt.Errorf("writing split output: creating renderer: %w", err)
 	}
 
 	splitter := NewSplitter(SplitOpts{
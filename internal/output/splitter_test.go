@@ -550,13 +550,13 @@ func TestWriteSplit_InvalidFormat(t *testing.T) {
 
 	data := splitterTestRenderData(nil)
 	results, err := ow.WriteSplit(context.Background(), data, SplitOutputOpts{
-		OutputOpts:  OutputOpts{Format: "json"},
+		OutputOpts:  OutputOpts{Format: "html"},
 		SplitTokens: 1000,
 	})
 
 	assert.Nil(t, results)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "unsupported format")
+	assert.Contains(t, err.Error(), "unknown output format")
 }
 
 func TestWriteSplit_SinglePartNoSuffix(t *testing.T) {
@@ -656,6 +656,39 @@ func TestWriteSplit_XMLFormat(t *testing.T) {
 	}
 }
 
+func TestWriteSplit_PlainFormat(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "output.txt")
+
+	var stdout, stderr bytes.Buffer
+	ow := NewOutputWriterWithStreams(&stdout, &stderr)
+
+	files := []FileRenderEntry{
+		makeFile("a.go", 5000, 0),
+		makeFile("b.go", 5000, 1),
+	}
+	data := splitterTestRenderData(files)
+
+	results, err := ow.WriteSplit(context.Background(), data, SplitOutputOpts{
+		OutputOpts:  OutputOpts{OutputPath: outPath, Format: FormatPlain},
+		SplitTokens: 6000,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, filepath.Join(dir, "output.part-001.txt"), results[0].Path)
+	assert.Equal(t, filepath.Join(dir, "output.part-002.txt"), results[1].Path)
+
+	for i, name := range []string{"a.go", "b.go"} {
+		content, err := os.ReadFile(results[i].Path)
+		require.NoError(t, err)
+		assert.Contains(t, string(content), "===== "+name+" (tier ")
+	}
+}
+
 func TestWriteSplit_StdoutSinglePart(t *testing.T) {
 	t.Parallel()
 
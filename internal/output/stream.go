@@ -0,0 +1,218 @@
+package output
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+// streamTemplates names the templates RenderStream executes for one format:
+// the sections before the files, one file section, and the sections after.
+type streamTemplates struct {
+	tmpl *template.Template
+	head string
+	file string
+	tail string
+}
+
+// streamTemplatesFor returns the stream templates for format. Markdown, the
+// legacy XML layout, and plain text are supported; any other format is an
+// error.
+func streamTemplatesFor(format string) (streamTemplates, error) {
+	switch strings.ToLower(format) {
+	case FormatMarkdown:
+		return streamTemplates{markdownTemplate, "markdown-head", "file", "markdown-tail"}, nil
+	case FormatXML:
+		return streamTemplates{xmlTemplate, "xml-head", "xml-file", "xml-tail"}, nil
+	case FormatPlain:
+		return streamTemplates{plainTemplate, "plain-head", "plain-file", "plain-tail"}, nil
+	default:
+		return streamTemplates{}, fmt.Errorf("streaming is not supported for output format %q (supported: markdown, xml, plain)", format)
+	}
+}
+
+// StreamResult describes the output produced by RenderStream.
+type StreamResult struct {
+	// FilesWritten is the number of file sections written completely.
+	FilesWritten int
+
+	// TotalFiles is the number of files that were to be rendered.
+	TotalFiles int
+
+	// BytesWritten is the total number of bytes written to the output.
+	BytesWritten int64
+
+	// Truncated reports whether files were left out because writing them
+	// would have exceeded RenderOptions.MaxOutputBytes.
+	Truncated bool
+}
+
+// RenderStream writes files as a Markdown, XML, or plain-text context
+// document to w, flushing after each file section so that the rendered
+// document is never held in memory: peak usage is roughly one file section
+// plus the write buffer. The output is byte-identical to rendering the same
+// data with MarkdownRenderer, XMLRenderer, or PlainRenderer.
+//
+// As with RenderJSON, files are ordered by tier and then by path, the header
+// is filled from opts and totals computed over files, and files is not
+// modified. A write error stops rendering immediately; the returned error
+// wraps it and reports how many files were written completely.
+//
+// When opts.MaxOutputBytes is positive, emission stops before the first file
+// section that would push the output past the limit. The remaining files are
+// skipped, a truncation note is written in their place, and the document is
+// closed normally. Room for the note and the footer is reserved within the
+// limit; only the header and footer are written unconditionally, so a limit
+// smaller than those is exceeded.
+func RenderStream(w io.Writer, files []*pipeline.FileDescriptor, opts pipeline.RenderOptions) (*StreamResult, error) {
+	st, err := streamTemplatesFor(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := renderDataFromDescriptors(files, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	total := len(data.Files)
+	var tail bytes.Buffer
+	if err := st.tmpl.ExecuteTemplate(&tail, st.tail, data); err != nil {
+		return nil, fmt.Errorf("rendering footer (0 of %d files written): %w", total, err)
+	}
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	result := &StreamResult{TotalFiles: total}
+	// written counts bytes handed to bw, including those still buffered.
+	written := func() int64 { return cw.written + int64(bw.Buffered()) }
+
+	if err := st.tmpl.ExecuteTemplate(bw, st.head, data); err != nil {
+		return nil, fmt.Errorf("rendering header (0 of %d files written): %w", total, err)
+	}
+
+	var section bytes.Buffer
+	for i, f := range data.Files {
+		section.Reset()
+		if err := st.tmpl.ExecuteTemplate(&section, st.file, newFileSection(data, f)); err != nil {
+			return nil, fmt.Errorf("rendering %s (%d of %d files written): %w", f.Path, i, total, err)
+		}
+
+		if opts.MaxOutputBytes > 0 {
+			reserve := int64(tail.Len())
+			if i < total-1 {
+				reserve += int64(len(truncationNote(opts.Format, opts.MaxOutputBytes, i+1, total)))
+			}
+			if written()+int64(section.Len())+reserve > opts.MaxOutputBytes {
+				result.Truncated = true
+				break
+			}
+		}
+
+		if _, err := bw.Write(section.Bytes()); err != nil {
+			return nil, fmt.Errorf("writing %s (%d of %d files written): %w", f.Path, i, total, err)
+		}
+		if err := bw.Flush(); err != nil {
+			return nil, fmt.Errorf("writing %s (%d of %d files written): %w", f.Path, i, total, err)
+		}
+		result.FilesWritten++
+	}
+
+	if result.Truncated {
+		note := truncationNote(opts.Format, opts.MaxOutputBytes, result.FilesWritten, total)
+		if _, err := bw.WriteString(note); err != nil {
+			return nil, fmt.Errorf("writing truncation note (%d of %d files written): %w", result.FilesWritten, total, err)
+		}
+	}
+	if _, err := bw.Write(tail.Bytes()); err != nil {
+		return nil, fmt.Errorf("writing footer (%d of %d files written): %w", result.FilesWritten, total, err)
+	}
+	if err := bw.Flush(); err != nil {
+		return nil, fmt.Errorf("writing footer (%d of %d files written): %w", result.FilesWritten, total, err)
+	}
+
+	result.BytesWritten = cw.written
+	return result, nil
+}
+
+// truncationNote returns the note RenderStream writes in place of the files
+// skipped because of the byte limit, formatted for the output format.
+func truncationNote(format string, maxBytes int64, written, total int) string {
+	msg := fmt.Sprintf("Output truncated: the %d-byte limit was reached after %d of %d files (%d omitted).",
+		maxBytes, written, total, total-written)
+	switch strings.ToLower(format) {
+	case FormatXML:
+		return "\n  <!-- " + msg + " -->"
+	case FormatPlain:
+		return "===== " + msg + " =====\n"
+	}
+	return "\n\n> **" + msg + "**\n"
+}
+
+// sortedDescriptors returns copies of the non-nil files ordered by tier and
+// then by path.
+func sortedDescriptors(files []*pipeline.FileDescriptor) []pipeline.FileDescriptor {
+	sorted := make([]pipeline.FileDescriptor, 0, len(files))
+	for _, fd := range files {
+		if fd != nil {
+			sorted = append(sorted, *fd)
+		}
+	}
+	slices.SortStableFunc(sorted, func(a, b pipeline.FileDescriptor) int {
+		return cmp.Or(cmp.Compare(a.Tier, b.Tier), cmp.Compare(a.Path, b.Path))
+	})
+	return sorted
+}
+
+// renderDataFromDescriptors assembles the RenderData for files the way
+// RenderOutput does, taking header fields from opts. File content is shared
+// with files rather than copied.
+func renderDataFromDescriptors(files []*pipeline.FileDescriptor, opts pipeline.RenderOptions) (*RenderData, error) {
+	sorted := sortedDescriptors(files)
+	entries := toFileRenderEntries(sorted)
+
+	hash, err := NewContentHasher().ComputeContentHash(toFileHashEntries(sorted))
+	if err != nil {
+		return nil, fmt.Errorf("computing content hash: %w", err)
+	}
+
+	ts := opts.Timestamp
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+
+	data := &RenderData{
+		ProjectName:      opts.ProjectName,
+		Timestamp:        ts,
+		ContentHash:      FormatHash(hash),
+		ProfileName:      opts.ProfileName,
+		TokenizerName:    opts.TokenizerName,
+		TotalTokens:      computeTotalTokens(entries),
+		TotalFiles:       len(entries),
+		Files:            entries,
+		TreeString:       RenderTree(BuildTree(toFileEntries(sorted)), TreeRenderOpts{}),
+		ShowLineNumbers:  opts.ShowLineNumbers,
+		IncludeTOC:       opts.IncludeTOC,
+		TierCounts:       computeTierCounts(entries),
+		TopFilesByTokens: computeTopFiles(entries, 5),
+		RedactionSummary: map[string]int{},
+		TotalRedactions:  computeTotalRedactions(entries),
+	}
+	if d := opts.DiffSummary; d != nil {
+		data.DiffSummary = &DiffSummaryData{
+			AddedFiles:    d.AddedFiles,
+			ModifiedFiles: d.ModifiedFiles,
+			DeletedFiles:  d.DeletedFiles,
+			Unchanged:     d.Unchanged,
+		}
+	}
+	return data, nil
+}
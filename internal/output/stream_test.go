@@ -0,0 +1,189 @@
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+// streamTestFiles returns a multi-file fixture covering every tier path,
+// an error entry, and content that needs escaping in both formats.
+func streamTestFiles() []*pipeline.FileDescriptor {
+	return []*pipeline.FileDescriptor{
+		{Path: "docs/guide.md", Tier: 4, TokenCount: 40, Size: 120, Content: "# Guide\n\n```go\nx := 1\n```\n"},
+		{Path: "src/z.go", Tier: 1, TokenCount: 20, Size: 60, Content: "package z\n\nvar s = \"]]>\"\n"},
+		{Path: "go.mod", Tier: 0, TokenCount: 10, Size: 30, Content: "module x\n"},
+		{Path: "src/a.go", Tier: 1, TokenCount: 30, Size: 90, Content: "package a\n", Redactions: 1},
+		{Path: "bin/tool", Tier: 2, Error: errors.New("read failed")},
+	}
+}
+
+// TestRenderStream_MatchesBufferedRenderer verifies that streamed output is
+// byte-identical to the in-memory renderer for every streamable format.
+func TestRenderStream_MatchesBufferedRenderer(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{FormatMarkdown, FormatXML, FormatPlain} {
+		for _, lineNumbers := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%s/line_numbers=%t", format, lineNumbers), func(t *testing.T) {
+				t.Parallel()
+
+				opts := pipeline.RenderOptions{
+					Format:          format,
+					ProjectName:     "demo",
+					ProfileName:     "default",
+					TokenizerName:   "cl100k_base",
+					ShowLineNumbers: lineNumbers,
+					Timestamp:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+					DiffSummary: &pipeline.DiffSummaryEntry{
+						AddedFiles:   []string{"src/z.go"},
+						DeletedFiles: []string{"old.go"},
+					},
+				}
+
+				data, err := renderDataFromDescriptors(streamTestFiles(), opts)
+				require.NoError(t, err)
+				renderer, err := NewRenderer(format)
+				require.NoError(t, err)
+
+				var buffered bytes.Buffer
+				require.NoError(t, renderer.Render(context.Background(), &buffered, data))
+
+				var streamed bytes.Buffer
+				result, err := RenderStream(&streamed, streamTestFiles(), opts)
+				require.NoError(t, err)
+				assert.Equal(t, 5, result.FilesWritten)
+				assert.False(t, result.Truncated)
+				assert.Equal(t, int64(streamed.Len()), result.BytesWritten)
+
+				assert.Equal(t, buffered.String(), streamed.String())
+			})
+		}
+	}
+}
+
+// TestRenderStream_UnsupportedFormat verifies that formats without a
+// streaming layout are rejected.
+func TestRenderStream_UnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	_, err := RenderStream(&buf, streamTestFiles(), pipeline.RenderOptions{Format: FormatJSON})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+	assert.Zero(t, buf.Len())
+}
+
+// failingWriter accepts limit bytes and then fails every write.
+type failingWriter struct {
+	limit   int
+	written int
+}
+
+var errWriteFailed = errors.New("disk full")
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.written+len(p) > w.limit {
+		return 0, errWriteFailed
+	}
+	w.written += len(p)
+	return len(p), nil
+}
+
+// TestRenderStream_WriteErrorReportsProgress verifies that a write error
+// stops rendering and reports how many files were written.
+func TestRenderStream_WriteErrorReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	opts := pipeline.RenderOptions{Format: FormatMarkdown, Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+
+	// Measure the output up to and including the first file section.
+	data, err := renderDataFromDescriptors(streamTestFiles(), opts)
+	require.NoError(t, err)
+	var head bytes.Buffer
+	require.NoError(t, markdownTemplate.ExecuteTemplate(&head, "markdown-head", data))
+	require.NoError(t, markdownTemplate.ExecuteTemplate(&head, "file", newFileSection(data, data.Files[0])))
+
+	w := &failingWriter{limit: head.Len()}
+	_, err = RenderStream(w, streamTestFiles(), opts)
+	require.ErrorIs(t, err, errWriteFailed)
+	assert.Contains(t, err.Error(), "1 of 5 files written")
+	assert.Equal(t, head.Len(), w.written)
+}
+
+// TestRenderStream_MaxOutputBytes verifies that emission stops at the byte
+// cap, that the output stays within it, and that the run is reported and
+// noted as truncated.
+func TestRenderStream_MaxOutputBytes(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{FormatMarkdown, FormatXML, FormatPlain} {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			opts := pipeline.RenderOptions{Format: format, Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+			var full bytes.Buffer
+			_, err := RenderStream(&full, streamTestFiles(), opts)
+			require.NoError(t, err)
+
+			// Leave room for everything except the last file section.
+			opts.MaxOutputBytes = int64(full.Len()) - 1
+			var buf bytes.Buffer
+			result, err := RenderStream(&buf, streamTestFiles(), opts)
+			require.NoError(t, err)
+
+			assert.True(t, result.Truncated)
+			assert.Less(t, result.FilesWritten, 5)
+			assert.Equal(t, 5, result.TotalFiles)
+			assert.Equal(t, int64(buf.Len()), result.BytesWritten)
+			assert.LessOrEqual(t, result.BytesWritten, opts.MaxOutputBytes)
+			assert.Contains(t, buf.String(), fmt.Sprintf("after %d of 5 files", result.FilesWritten))
+			assert.NotContains(t, buf.String(), "# Guide", "the last file must be omitted")
+		})
+	}
+}
+
+// TestRenderStream_MaxOutputBytesTooSmall verifies that a limit smaller than
+// the header still produces a closed document with no files.
+func TestRenderStream_MaxOutputBytesTooSmall(t *testing.T) {
+	t.Parallel()
+
+	opts := pipeline.RenderOptions{Format: FormatXML, MaxOutputBytes: 10}
+	var buf bytes.Buffer
+	result, err := RenderStream(&buf, streamTestFiles(), opts)
+	require.NoError(t, err)
+
+	assert.True(t, result.Truncated)
+	assert.Zero(t, result.FilesWritten)
+	assert.Contains(t, buf.String(), "after 0 of 5 files (5 omitted)")
+	assert.True(t, strings.HasSuffix(buf.String(), "</repository>"))
+}
+
+// TestRenderStream_MaxOutputBytesNotReached verifies that a limit larger than
+// the output changes nothing.
+func TestRenderStream_MaxOutputBytesNotReached(t *testing.T) {
+	t.Parallel()
+
+	opts := pipeline.RenderOptions{Format: FormatMarkdown, Timestamp: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)}
+	var want bytes.Buffer
+	_, err := RenderStream(&want, streamTestFiles(), opts)
+	require.NoError(t, err)
+
+	opts.MaxOutputBytes = int64(want.Len())
+	var got bytes.Buffer
+	result, err := RenderStream(&got, streamTestFiles(), opts)
+	require.NoError(t, err)
+
+	assert.False(t, result.Truncated)
+	assert.Equal(t, 5, result.FilesWritten)
+	assert.Equal(t, want.String(), got.String())
+}
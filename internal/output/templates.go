@@ -7,13 +7,13 @@ import (
 
 // markdownFuncMap provides helper functions available within the Markdown template.
 var markdownFuncMap = template.FuncMap{
-	"formatBytes":           formatBytes,
-	"formatNumber":          formatNumber,
-	"languageFromExt":       languageFromExt,
-	"addLineNumbers":        addLineNumbers,
-	"repeatString":          repeatString,
-	"tierLabel":             tierLabel,
-	"escapeTripleBackticks": escapeTripleBackticks,
+	"formatBytes":     formatBytes,
+	"formatNumber":    formatNumber,
+	"languageFromExt": languageFromExt,
+	"addLineNumbers":  addLineNumbers,
+	"repeatString":    repeatString,
+	"tierLabel":       tierLabel,
+	"codeFence":       codeFence,
 	"sortedKeys": func(m map[string]int) []string {
 		keys := make([]string, 0, len(m))
 		for k := range m {
@@ -28,6 +28,8 @@ var markdownFuncMap = template.FuncMap{
 	"tierCount": func(counts map[int]int, tier int) int {
 		return counts[tier]
 	},
+	"fileSection": newFileSection,
+	"fileAnchor":  fileAnchor,
 	"fileLang": func(f FileRenderEntry) string {
 		if f.Language != "" {
 			return f.Language
@@ -48,18 +50,31 @@ var markdownTemplate = template.Must(
 // Whitespace strategy: each sub-template produces exactly its own section
 // without leading or trailing blank lines. The root template controls spacing
 // between sections using explicit newlines.
-const markdownTmpl = headerTmpl + summaryTmpl + treeTmpl + filesTmpl + changeSummaryTmpl + rootTmpl
+const markdownTmpl = headerTmpl + summaryTmpl + treeTmpl + tocTmpl + filesTmpl + changeSummaryTmpl + rootTmpl
 
-// rootTmpl is the top-level composition template that invokes sub-templates
-// with controlled spacing between sections.
+// rootTmpl is the top-level composition template. It is split into the
+// sections before the files ("markdown-head"), one section per file ("file"),
+// and the sections after them ("markdown-tail") so that RenderStream can
+// execute them one at a time and produce the same bytes.
 const rootTmpl = `{{- define "markdown-root" -}}
+{{- template "markdown-head" . }}
+{{- range .Files}}{{template "file" (fileSection $ .)}}{{end}}
+{{- template "markdown-tail" . -}}
+{{- end -}}
+{{- define "markdown-head" -}}
 {{- template "header" . }}
 
 {{ template "summary" . }}
 
 {{ template "tree" . }}
+{{- if .IncludeTOC}}
 
-{{ template "files" . }}
+{{ template "toc" . }}
+{{- end}}
+
+## Files
+{{- end -}}
+{{- define "markdown-tail" -}}
 {{- template "changeSummary" . -}}
 {{- end -}}`
 
@@ -124,10 +139,20 @@ const treeTmpl = `{{- define "tree" -}}
 ` + "```" + `
 {{- end -}}`
 
-// filesTmpl renders each file with metadata and content in fenced code blocks.
-const filesTmpl = `{{- define "files" -}}
-## Files
-{{- range .Files}}
+// tocTmpl renders the table of contents, linking each file to its section
+// heading. It is only rendered when IncludeTOC is set.
+const tocTmpl = `{{- define "toc" -}}
+## Table of Contents
+{{range .Files}}
+- [` + "`" + `{{.Path}}` + "`" + `](#{{fileAnchor .Path}})
+{{- end}}
+{{- end -}}`
+
+// filesTmpl renders one file with metadata and content in a fenced code
+// block. The fence is longer than any backtick run in the content (see
+// codeFence), so content is written verbatim. It is executed with a
+// fileSection.
+const filesTmpl = `{{define "file"}}{{with .File}}
 
 ### ` + "`" + `{{.Path}}` + "`" + `
 
@@ -137,16 +162,15 @@ const filesTmpl = `{{- define "files" -}}
 **Error:** {{.Error}}
 {{- else}}
 
-` + "```" + `{{fileLang .}}
+{{$fence := codeFence .Content}}{{$fence}}{{fileLang .}}
 {{- if $.ShowLineNumbers}}
-{{addLineNumbers (escapeTripleBackticks .Content)}}
+{{$.Offsets.Begin .Path}}{{addLineNumbers .Content}}{{$.Offsets.End .Path}}
 {{- else}}
-{{escapeTripleBackticks .Content}}
+{{$.Offsets.Begin .Path}}{{.Content}}{{$.Offsets.End .Path}}
 {{- end}}
-` + "```" + `
+{{$fence}}
 {{- end}}
-{{- end}}
-{{- end -}}`
+{{- end}}{{end}}`
 
 // changeSummaryTmpl renders the diff mode change summary section, only when
 // DiffSummary is non-nil.
@@ -196,6 +220,7 @@ var xmlFuncMap = template.FuncMap{
 	"tierLabel":      tierLabel,
 	"wrapCDATA":      wrapCDATA,
 	"xmlEscapeAttr":  xmlEscapeAttr,
+	"fileSection":    newFileSection,
 	"tierNumbers": func() []int {
 		return []int{0, 1, 2, 3, 4, 5}
 	},
@@ -220,16 +245,27 @@ var xmlTemplate = template.Must(
 
 // xmlTmpl is the complete XML template string composed of named sub-templates
 // for metadata, summary, tree, files, statistics, and change summary sections.
-const xmlTmpl = xmlHeaderTmpl + xmlSummaryTmpl + xmlTreeTmpl + xmlFilesTmpl + xmlStatisticsTmpl + xmlChangeSummaryTmpl + xmlRootTmpl
+// It also carries the versioned schema templates (xml-v1-root).
+const xmlTmpl = xmlHeaderTmpl + xmlSummaryTmpl + xmlTreeTmpl + xmlFilesTmpl + xmlStatisticsTmpl + xmlChangeSummaryTmpl + xmlRootTmpl + xmlV1Tmpl
 
 // xmlRootTmpl is the top-level composition template that invokes sub-templates.
+// Like rootTmpl it is split into "xml-head", "xml-file", and "xml-tail" so
+// that RenderStream can execute the sections one at a time.
 const xmlRootTmpl = `{{- define "xml-root" -}}
+{{- template "xml-head" . }}
+{{- range .Files}}{{template "xml-file" (fileSection $ .)}}{{end}}
+{{- template "xml-tail" . }}
+{{- end -}}
+{{- define "xml-head" -}}
 <?xml version="1.0" encoding="UTF-8"?>
 <repository>
 {{- template "xml-metadata" . }}
 {{- template "xml-summary" . }}
 {{- template "xml-tree" . }}
-{{- template "xml-files" . }}
+  <files>
+{{- end -}}
+{{- define "xml-tail" }}
+  </files>
 {{- template "xml-statistics" . }}
 {{- template "xml-changeSummary" . }}
 </repository>
@@ -286,22 +322,19 @@ const xmlTreeTmpl = `{{- define "xml-tree" }}
   <directory_structure>{{wrapCDATA .TreeString}}</directory_structure>
 {{- end -}}`
 
-// xmlFilesTmpl renders each file with attributes and CDATA-wrapped content.
-const xmlFilesTmpl = `{{- define "xml-files" }}
-  <files>
-{{- range .Files}}
+// xmlFilesTmpl renders one file with attributes and CDATA-wrapped content.
+// It is executed with a fileSection.
+const xmlFilesTmpl = `{{define "xml-file"}}{{with .File}}
     <file path="{{xmlEscapeAttr .Path}}" tokens="{{.TokenCount}}" tier="{{if .TierLabel}}{{.TierLabel}}{{else}}{{tierLabel .Tier}}{{end}}" size="{{.Size}}" language="{{.Language}}" compressed="{{if .IsCompressed}}true{{else}}false{{end}}">
 {{- if .Error}}
       <error>{{xmlEscapeAttr .Error}}</error>
 {{- else if $.ShowLineNumbers}}
-      <content>{{wrapCDATA (addLineNumbers .Content)}}</content>
+      <content>{{$.Offsets.Begin .Path}}{{wrapCDATA (addLineNumbers .Content)}}{{$.Offsets.End .Path}}</content>
 {{- else}}
-      <content>{{wrapCDATA .Content}}</content>
+      <content>{{$.Offsets.Begin .Path}}{{wrapCDATA .Content}}{{$.Offsets.End .Path}}</content>
 {{- end}}
     </file>
-{{- end}}
-  </files>
-{{- end -}}`
+{{- end}}{{end}}`
 
 // xmlStatisticsTmpl renders the statistics summary section.
 const xmlStatisticsTmpl = `{{- define "xml-statistics" }}
@@ -334,3 +367,29 @@ const xmlChangeSummaryTmpl = `{{- define "xml-changeSummary" -}}
   </change_summary>
 {{- end}}
 {{- end -}}`
+
+// xmlV1Tmpl is the versioned XML schema 1 template (see XMLSchemaV1). Its
+// element and attribute set is frozen: changes require a new schema version.
+const xmlV1Tmpl = `{{- define "xml-v1-root" -}}
+<?xml version="1.0" encoding="UTF-8"?>
+<harvx version="1">
+  <metadata>
+    <project>{{xmlEscapeAttr .ProjectName}}</project>
+    <generated>{{.Timestamp.Format "2006-01-02T15:04:05Z07:00"}}</generated>
+    <content_hash>{{xmlEscapeAttr .ContentHash}}</content_hash>
+    <profile>{{xmlEscapeAttr .ProfileName}}</profile>
+    <tokenizer>{{xmlEscapeAttr .TokenizerName}}</tokenizer>
+    <total_tokens>{{.TotalTokens}}</total_tokens>
+    <total_files>{{.TotalFiles}}</total_files>
+  </metadata>
+{{- range .Files}}
+{{- if .Error}}
+  <file path="{{xmlEscapeAttr .Path}}" tier="{{.Tier}}" tokens="{{.TokenCount}}" error="{{xmlEscapeAttr .Error}}"/>
+{{- else if $.ShowLineNumbers}}
+  <file path="{{xmlEscapeAttr .Path}}" tier="{{.Tier}}" tokens="{{.TokenCount}}">{{$.Offsets.Begin .Path}}{{wrapCDATA (addLineNumbers .Content)}}{{$.Offsets.End .Path}}</file>
+{{- else}}
+  <file path="{{xmlEscapeAttr .Path}}" tier="{{.Tier}}" tokens="{{.TokenCount}}">{{$.Offsets.Begin .Path}}{{wrapCDATA .Content}}{{$.Offsets.End .Path}}</file>
+{{- end}}
+{{- end}}
+</harvx>
+{{- end -}}`
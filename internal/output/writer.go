@@ -19,15 +19,24 @@ type OutputOpts struct {
 	// Lower priority than OutputPath.
 	ProfileOutput string
 
-	// Format is the output format: "markdown" or "xml".
+	// Format is the output format: "markdown", "xml", "json", or "plain".
 	Format string
 
-	// UseStdout writes to stdout instead of a file when true.
+	// XMLSchemaVersion selects the XML schema when Format is "xml".
+	// 0 (XMLSchemaLegacy) keeps the default layout.
+	XMLSchemaVersion int
+
+	// UseStdout writes to stdout instead of a file when true. An output path
+	// of StdoutPath ("-") has the same effect.
 	UseStdout bool
 
 	// OutputMetadata enables .meta.json sidecar generation when true.
 	OutputMetadata bool
 
+	// WriteManifest enables .manifest.json sidecar generation when true.
+	// The manifest is only written for file output, not stdout.
+	WriteManifest bool
+
 	// Target is the LLM target (e.g., "claude"), used in metadata.
 	Target string
 
@@ -66,6 +75,10 @@ type OutputResult struct {
 type OutputWriter struct {
 	stdout io.Writer
 	stderr io.Writer
+
+	// newRenderer overrides NewRendererVersion when set, so tests can
+	// observe rendering.
+	newRenderer func(format string, xmlSchema int) (Renderer, error)
 }
 
 // NewOutputWriter creates a new OutputWriter that writes to os.Stdout and
@@ -89,6 +102,7 @@ func NewOutputWriterWithStreams(stdout, stderr io.Writer) *OutputWriter {
 // Write renders the context document and writes it to the configured destination.
 // In stdout mode, it streams directly to stdout while computing the content hash.
 // In file mode, it performs an atomic write using a temporary file and rename.
+// An output path of StdoutPath selects stdout mode.
 func (ow *OutputWriter) Write(ctx context.Context, data *RenderData, opts OutputOpts) (*OutputResult, error) {
 	select {
 	case <-ctx.Done():
@@ -96,19 +110,27 @@ func (ow *OutputWriter) Write(ctx context.Context, data *RenderData, opts Output
 	default:
 	}
 
-	if data == nil {
-		return nil, fmt.Errorf("writing output: render data is nil")
+	if ResolveOutputPath(opts.OutputPath, opts.ProfileOutput, opts.Format) == StdoutPath {
+		opts.UseStdout = true
 	}
 
-	if opts.Format != "markdown" && opts.Format != "xml" {
-		return nil, fmt.Errorf("writing output: unsupported format %q", opts.Format)
+	if data == nil {
+		return nil, fmt.Errorf("writing output: render data is nil")
 	}
 
-	renderer, err := NewRenderer(opts.Format)
+	renderer, err := ow.renderer(opts)
 	if err != nil {
 		return nil, fmt.Errorf("writing output: creating renderer: %w", err)
 	}
 
+	// Record per-file content offsets for the manifest. A shallow copy keeps
+	// the caller's RenderData free of the recorder.
+	if opts.WriteManifest && !opts.UseStdout {
+		withOffsets := *data
+		withOffsets.Offsets = NewOffsetRecorder()
+		data = &withOffsets
+	}
+
 	var result *OutputResult
 	if opts.UseStdout {
 		result, err = ow.writeStdout(ctx, data, renderer)
@@ -134,9 +156,27 @@ func (ow *OutputWriter) Write(ctx context.Context, data *RenderData, opts Output
 		}
 	}
 
+	// Write manifest sidecar if enabled and we have a file path.
+	if data.Offsets != nil && result.Path != "" {
+		manifest := BuildManifest(data, result, opts.Format)
+		if manErr := WriteManifest(manifest, result.Path); manErr != nil {
+			return nil, fmt.Errorf("writing manifest sidecar: %w", manErr)
+		}
+	}
+
 	return result, nil
 }
 
+// renderer returns the renderer for opts.Format. Unknown formats are rejected
+// here, so the renderer constructor is the only place a format is accepted.
+func (ow *OutputWriter) renderer(opts OutputOpts) (Renderer, error) {
+	newRenderer := ow.newRenderer
+	if newRenderer == nil {
+		newRenderer = NewRendererVersion
+	}
+	return newRenderer(opts.Format, opts.XMLSchemaVersion)
+}
+
 // writeStdout streams the rendered output to stdout while computing the content
 // hash incrementally.
 func (ow *OutputWriter) writeStdout(ctx context.Context, data *RenderData, renderer Renderer) (*OutputResult, error) {
@@ -81,12 +81,12 @@ func TestOutputWriter_Write_InvalidFormat(t *testing.T) {
 	ow := NewOutputWriterWithStreams(&stdout, &stderr)
 
 	result, err := ow.Write(context.Background(), minimalRenderData(), OutputOpts{
-		Format: "json",
+		Format: "html",
 	})
 
 	assert.Nil(t, result)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "unsupported format")
+	assert.Contains(t, err.Error(), "unknown output format")
 }
 
 func TestOutputWriter_Write_CancelledContext(t *testing.T) {
@@ -423,6 +423,42 @@ func TestOutputWriter_Write_StdoutDoesNotCreateFile(t *testing.T) {
 	assert.Empty(t, entries, "stdout mode should not create any files")
 }
 
+func TestOutputWriter_Write_DashOutputWritesStdout(t *testing.T) {
+	// Not parallel: os.Chdir affects the entire process.
+	dir := t.TempDir()
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() {
+		os.Chdir(origDir)
+	})
+
+	data := minimalRenderData()
+
+	var want bytes.Buffer
+	_, err = NewOutputWriterWithStreams(&want, &bytes.Buffer{}).Write(context.Background(), data, OutputOpts{
+		Format:    "markdown",
+		UseStdout: true,
+	})
+	require.NoError(t, err)
+
+	var stdout, stderr bytes.Buffer
+	ow := NewOutputWriterWithStreams(&stdout, &stderr)
+	result, err := ow.Write(context.Background(), data, OutputOpts{
+		ProfileOutput:  "-",
+		Format:         "markdown",
+		OutputMetadata: true,
+		WriteManifest:  true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Path)
+	assert.Equal(t, want.String(), stdout.String(), "output must reach the writer unchanged")
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "output \"-\" must not create any files")
+}
+
 func TestCountingWriter(t *testing.T) {
 	t.Parallel()
 
@@ -5,20 +5,68 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/harvx/harvx/internal/pipeline"
 )
 
 // Compile-time interface compliance check.
 var _ Renderer = (*XMLRenderer)(nil)
 
+// XML schema versions accepted by NewXMLRendererVersion.
+const (
+	// XMLSchemaLegacy is the unversioned <repository> layout produced by
+	// NewXMLRenderer. It is tuned for LLM prompts and may change between
+	// releases; downstream parsers should request XMLSchemaV1 instead.
+	XMLSchemaLegacy = 0
+
+	// XMLSchemaV1 is the stable, versioned schema for machine consumption:
+	//
+	//	<?xml version="1.0" encoding="UTF-8"?>
+	//	<harvx version="1">
+	//	  <metadata>
+	//	    <project>, <generated> (RFC 3339), <content_hash>, <profile>,
+	//	    <tokenizer>, <total_tokens>, <total_files>
+	//	  </metadata>
+	//	  <file path="src/main.go" tier="0" tokens="1200"><![CDATA[...]]></file>
+	//	  <file path="bad.bin" tier="2" tokens="0" error="read failed"/>
+	//	</harvx>
+	//
+	// Files appear in output order as direct children of <harvx>. tier is the
+	// numeric relevance tier and tokens the file's token count. Content is a
+	// single CDATA section, split at every "]]>" so the sequence round-trips
+	// intact. A file that failed to load is an empty element carrying an
+	// error attribute. Elements and attributes in version 1 will not be
+	// renamed or removed.
+	XMLSchemaV1 = 1
+)
+
 // XMLRenderer produces XML-formatted context documents optimized for Claude.
 // It uses semantic XML tags following Anthropic's best practices, with CDATA
 // sections for file content to avoid escaping issues. It implements the
 // Renderer interface.
-type XMLRenderer struct{}
+type XMLRenderer struct {
+	// root is the name of the top-level template for the selected schema.
+	root string
+}
 
-// NewXMLRenderer creates a new XMLRenderer.
+// NewXMLRenderer creates a new XMLRenderer using the legacy layout
+// (XMLSchemaLegacy).
 func NewXMLRenderer() *XMLRenderer {
-	return &XMLRenderer{}
+	return &XMLRenderer{root: "xml-root"}
+}
+
+// NewXMLRendererVersion creates an XMLRenderer for the given schema version.
+// It returns an error for versions it does not know, so callers pinned to a
+// version never silently receive a different layout.
+func NewXMLRendererVersion(version int) (*XMLRenderer, error) {
+	switch version {
+	case XMLSchemaLegacy:
+		return NewXMLRenderer(), nil
+	case XMLSchemaV1:
+		return &XMLRenderer{root: "xml-v1-root"}, nil
+	default:
+		return nil, fmt.Errorf("unsupported XML schema version %d (supported: %d, %d)", version, XMLSchemaLegacy, XMLSchemaV1)
+	}
 }
 
 // Render writes the complete XML context document to w. The template streams
@@ -34,7 +82,28 @@ func (r *XMLRenderer) Render(ctx context.Context, w io.Writer, data *RenderData)
 		return fmt.Errorf("render data is nil")
 	}
 
-	return xmlTemplate.ExecuteTemplate(w, "xml-root", data)
+	// Track byte offsets for the manifest; a no-op when Offsets is nil.
+	w = data.Offsets.attach(w)
+
+	root := r.root
+	if root == "" {
+		root = "xml-root"
+	}
+	return xmlTemplate.ExecuteTemplate(w, root, data)
+}
+
+// RenderXML writes files as a schema 1 XML document (see XMLSchemaV1) to w:
+// a <harvx> root with one <file> element per file carrying path and tier
+// attributes. Attribute values are entity-escaped and content is wrapped in
+// CDATA, so the output is well-formed whatever the files contain. As with
+// RenderJSON, files are ordered by tier and then by path, the metadata is
+// filled from opts and totals computed over files, and files is not modified.
+func RenderXML(files []*pipeline.FileDescriptor, w io.Writer, opts pipeline.RenderOptions) error {
+	data, err := renderDataFromDescriptors(files, opts)
+	if err != nil {
+		return err
+	}
+	return xmlTemplate.ExecuteTemplate(w, "xml-v1-root", data)
 }
 
 // wrapCDATA wraps content in a CDATA section, properly handling content that
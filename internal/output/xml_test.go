@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/harvx/harvx/internal/pipeline"
 	"github.com/harvx/harvx/internal/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1132,6 +1133,133 @@ func TestXMLRenderer_GoldenCDATAEdge(t *testing.T) {
 	testutil.Golden(t, "xml-cdata-edge", buf.Bytes())
 }
 
+// ---------------------------------------------------------------------------
+// Schema version 1
+// ---------------------------------------------------------------------------
+
+// xmlV1TestRenderData returns a small multi-file fixture covering plain
+// content, a "]]>" sequence that forces a CDATA split, and a file error.
+func xmlV1TestRenderData() *RenderData {
+	data := xmlTestRenderData()
+	data.Files = []FileRenderEntry{
+		data.Files[0],
+		{
+			Path:       "testdata/edge & <case>.txt",
+			Size:       40,
+			TokenCount: 12,
+			Tier:       3,
+			Content:    "a[b[c]]>d ]]> end",
+		},
+		{
+			Path:  "assets/broken.bin",
+			Tier:  5,
+			Error: "read failed: \"permission denied\"",
+		},
+	}
+	data.TotalFiles = len(data.Files)
+	return data
+}
+
+// xmlV1Doc mirrors the schema 1 layout for round-trip parsing in tests.
+type xmlV1Doc struct {
+	XMLName  xml.Name `xml:"harvx"`
+	Version  string   `xml:"version,attr"`
+	Metadata struct {
+		Project     string `xml:"project"`
+		Tokenizer   string `xml:"tokenizer"`
+		TotalTokens int    `xml:"total_tokens"`
+		TotalFiles  int    `xml:"total_files"`
+	} `xml:"metadata"`
+	Files []struct {
+		Path    string `xml:"path,attr"`
+		Tier    int    `xml:"tier,attr"`
+		Tokens  int    `xml:"tokens,attr"`
+		Error   string `xml:"error,attr"`
+		Content string `xml:",chardata"`
+	} `xml:"file"`
+}
+
+func TestNewXMLRendererVersion(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewXMLRendererVersion(XMLSchemaV1)
+	require.NoError(t, err)
+	require.NotNil(t, r)
+
+	legacy, err := NewXMLRendererVersion(XMLSchemaLegacy)
+	require.NoError(t, err)
+	assert.Equal(t, NewXMLRenderer(), legacy)
+
+	_, err = NewXMLRendererVersion(2)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported XML schema version 2")
+}
+
+func TestNewRendererVersion_IgnoredForMarkdown(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewRendererVersion(FormatMarkdown, 99)
+	require.NoError(t, err)
+	assert.IsType(t, &MarkdownRenderer{}, r)
+}
+
+func TestXMLRendererV1_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	data := xmlV1TestRenderData()
+	r, err := NewXMLRendererVersion(XMLSchemaV1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(context.Background(), &buf, data))
+
+	var doc xmlV1Doc
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+
+	assert.Equal(t, "1", doc.Version)
+	assert.Equal(t, "test-project", doc.Metadata.Project)
+	assert.Equal(t, "cl100k_base", doc.Metadata.Tokenizer)
+	assert.Equal(t, 3, doc.Metadata.TotalFiles)
+	require.Len(t, doc.Files, 3)
+
+	for i, f := range data.Files {
+		assert.Equal(t, f.Path, doc.Files[i].Path)
+		assert.Equal(t, f.Tier, doc.Files[i].Tier)
+		assert.Equal(t, f.TokenCount, doc.Files[i].Tokens)
+		assert.Equal(t, f.Error, doc.Files[i].Error)
+		assert.Equal(t, f.Content, doc.Files[i].Content,
+			"content must round-trip exactly, including ]]> sequences")
+	}
+}
+
+func TestXMLRendererV1_GoldenMultiFile(t *testing.T) {
+	t.Parallel()
+
+	r, err := NewXMLRendererVersion(XMLSchemaV1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.Render(context.Background(), &buf, xmlV1TestRenderData()))
+
+	testutil.Golden(t, "xml-v1-multi", buf.Bytes())
+}
+
+func TestOutputWriter_XMLSchemaVersion(t *testing.T) {
+	t.Parallel()
+
+	var stdout, stderr bytes.Buffer
+	writer := NewOutputWriterWithStreams(&stdout, &stderr)
+
+	_, err := writer.Write(context.Background(), xmlTestRenderData(), OutputOpts{
+		Format:           FormatXML,
+		XMLSchemaVersion: XMLSchemaV1,
+		UseStdout:        true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `<harvx version="1">`)
+	assert.NotContains(t, stdout.String(), "<repository>")
+}
+
 // ---------------------------------------------------------------------------
 // Benchmark tests
 // ---------------------------------------------------------------------------
@@ -1195,3 +1323,33 @@ func assertWellFormedXML(t *testing.T, output string) {
 		}
 	}
 }
+
+// TestRenderXML_EscapesMarkup verifies that RenderXML produces a parseable
+// schema 1 document when paths and content contain XML markup.
+func TestRenderXML_EscapesMarkup(t *testing.T) {
+	t.Parallel()
+
+	files := []*pipeline.FileDescriptor{
+		{Path: "web/<b>&co.html", Tier: 1, TokenCount: 7, Content: "<script>alert(1 && 2)</script>\n"},
+		{Path: "data/cdata.xml", Tier: 2, TokenCount: 3, Content: "<![CDATA[x]]> & more"},
+		{Path: "a\"quote'.txt", Tier: 0, TokenCount: 1, Content: "plain"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderXML(files, &buf, pipeline.RenderOptions{ProjectName: "a&b"}))
+	assertWellFormedXML(t, buf.String())
+
+	var doc xmlV1Doc
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "1", doc.Version)
+	assert.Equal(t, "a&b", doc.Metadata.Project)
+	require.Len(t, doc.Files, 3)
+
+	// Files are ordered by tier, then path.
+	assert.Equal(t, "a\"quote'.txt", doc.Files[0].Path)
+	assert.Equal(t, 0, doc.Files[0].Tier)
+	assert.Equal(t, "web/<b>&co.html", doc.Files[1].Path)
+	assert.Equal(t, 1, doc.Files[1].Tier)
+	assert.Equal(t, "<script>alert(1 && 2)</script>\n", doc.Files[1].Content)
+	assert.Equal(t, "<![CDATA[x]]> & more", doc.Files[2].Content)
+}
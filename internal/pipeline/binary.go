@@ -0,0 +1,52 @@
+package pipeline
+
+import (
+	"bytes"
+	"unicode/utf8"
+)
+
+// binarySniffBytes is how much of a file's content IsLikelyBinary inspects,
+// matching the 8KB window discovery uses for its null-byte check.
+const binarySniffBytes = 8192
+
+// IsLikelyBinary reports whether content looks like binary data rather than
+// text. Content is binary if its first 8KB contain a null byte or are not
+// valid UTF-8; a multi-byte character cut off by the 8KB boundary does not
+// count as invalid. Empty content is text.
+func IsLikelyBinary(content []byte) bool {
+	sample := content
+	truncated := len(sample) > binarySniffBytes
+	if truncated {
+		sample = sample[:binarySniffBytes]
+	}
+
+	if bytes.IndexByte(sample, 0) != -1 {
+		return true
+	}
+
+	for len(sample) > 0 {
+		r, size := utf8.DecodeRune(sample)
+		if r == utf8.RuneError && size == 1 {
+			return !truncated || utf8.FullRune(sample)
+		}
+		sample = sample[size:]
+	}
+	return false
+}
+
+// filterBinaryFiles removes files whose content IsLikelyBinary, or that
+// discovery already flagged with IsBinary, and returns the remaining files
+// along with the paths of the removed ones in input order. Files whose
+// content has not been loaded are kept.
+func filterBinaryFiles(files []*FileDescriptor) ([]*FileDescriptor, []string) {
+	var binary []string
+	kept := files[:0]
+	for _, fd := range files {
+		if fd.IsBinary || (fd.Content != "" && IsLikelyBinary([]byte(fd.Content))) {
+			binary = append(binary, fd.Path)
+			continue
+		}
+		kept = append(kept, fd)
+	}
+	return kept, binary
+}
@@ -0,0 +1,43 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLikelyBinary(t *testing.T) {
+	t.Parallel()
+
+	pngHeader := []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n', 0x00, 0x00, 0x00, 0x0d, 'I', 'H', 'D', 'R'}
+
+	tests := []struct {
+		name    string
+		content []byte
+		want    bool
+	}{
+		{name: "png header", content: pngHeader, want: true},
+		{name: "utf-8 text", content: []byte("package main\n\n// Grüße, 世界 🌍\nfunc main() {}\n"), want: false},
+		{name: "single embedded null byte", content: []byte("hello\x00world\n"), want: true},
+		{name: "invalid utf-8 without null", content: []byte("caf\xe9 latin-1\n"), want: true},
+		{name: "empty", content: nil, want: false},
+		{
+			name:    "multi-byte rune cut at sniff boundary",
+			content: []byte(strings.Repeat("a", binarySniffBytes-1) + "世"),
+			want:    false,
+		},
+		{
+			name:    "null byte past sniff window",
+			content: []byte(strings.Repeat("a", binarySniffBytes) + "\x00"),
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, IsLikelyBinary(tt.content))
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/zeebo/xxh3"
+)
+
+// tokenCacheVersion is the on-disk schema version of a TokenCache. A cache
+// file with a different version is discarded rather than migrated.
+const tokenCacheVersion = 1
+
+// TokenCache remembers per-file token counts between runs so that an
+// unchanged file is not re-tokenized. Entries are keyed by the file's
+// relative path and validated against the XXH3 hash of its processed content:
+// a hash mismatch is a miss and the file is counted again.
+//
+// Counts depend on the encoding, so the cache records the tokenizer name and
+// the pipeline discards every entry when it runs with a different tokenizer.
+// TokenCache is not safe for concurrent use.
+type TokenCache struct {
+	// Version is the schema version (tokenCacheVersion).
+	Version int `json:"version"`
+
+	// Tokenizer is the encoding name the counts were produced with.
+	Tokenizer string `json:"tokenizer"`
+
+	// Entries maps a relative file path to its cached count.
+	Entries map[string]TokenCacheEntry `json:"entries"`
+}
+
+// TokenCacheEntry is the cached token count for one file.
+type TokenCacheEntry struct {
+	// Hash is the XXH3 hash of the processed content that was counted.
+	Hash uint64 `json:"hash"`
+
+	// TokenCount is the number of tokens in that content.
+	TokenCount int `json:"token_count"`
+}
+
+// NewTokenCache returns an empty TokenCache.
+func NewTokenCache() *TokenCache {
+	return &TokenCache{
+		Version: tokenCacheVersion,
+		Entries: make(map[string]TokenCacheEntry),
+	}
+}
+
+// LoadCache reads a TokenCache from path. A missing file, or one written with
+// a different schema version, yields an empty cache and no error so that the
+// first run after an upgrade simply repopulates it. A file that cannot be
+// parsed is an error.
+func LoadCache(path string) (*TokenCache, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return NewTokenCache(), nil
+		}
+		return nil, fmt.Errorf("reading token cache %s: %w", path, err)
+	}
+
+	var c TokenCache
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("parsing token cache %s: %w", path, err)
+	}
+
+	if c.Version != tokenCacheVersion {
+		slog.Debug("discarding token cache with different version",
+			"path", path,
+			"version", c.Version,
+			"want", tokenCacheVersion,
+		)
+		return NewTokenCache(), nil
+	}
+	if c.Entries == nil {
+		c.Entries = make(map[string]TokenCacheEntry)
+	}
+
+	return &c, nil
+}
+
+// SaveCache writes the cache to path as JSON, creating the parent directory
+// if needed. The write is atomic: content goes to a temporary file in the
+// same directory that is then renamed over path.
+func (c *TokenCache) SaveCache(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating token cache directory %s: %w", dir, err)
+	}
+
+	data, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("marshaling token cache: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(dir, ".harvx-tokens-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file for token cache: %w", err)
+	}
+	tmpPath := tmpFile.Name()
+
+	success := false
+	defer func() {
+		if !success {
+			os.Remove(tmpPath)
+		}
+	}()
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("writing token cache to temp file: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("closing token cache temp file: %w", err)
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		return fmt.Errorf("setting token cache permissions: %w", err)
+	}
+
+	// On Windows, os.Rename may fail if the destination exists, so fall back
+	// to remove-then-rename.
+	if err := os.Rename(tmpPath, path); err != nil {
+		if runtime.GOOS != "windows" {
+			return fmt.Errorf("renaming token cache: %w", err)
+		}
+		os.Remove(path)
+		if err := os.Rename(tmpPath, path); err != nil {
+			return fmt.Errorf("renaming token cache (windows fallback): %w", err)
+		}
+	}
+
+	success = true
+	return nil
+}
+
+// Lookup returns the cached token count for fd when an entry exists for its
+// path and the entry's hash matches the hash of fd.Content.
+func (c *TokenCache) Lookup(fd *FileDescriptor) (int, bool) {
+	entry, ok := c.Entries[fd.Path]
+	if !ok || entry.Hash != xxh3.HashString(fd.Content) {
+		return 0, false
+	}
+	return entry.TokenCount, true
+}
+
+// Store records fd's current TokenCount against the hash of fd.Content,
+// replacing any previous entry for the same path.
+func (c *TokenCache) Store(fd *FileDescriptor) {
+	c.Entries[fd.Path] = TokenCacheEntry{
+		Hash:       xxh3.HashString(fd.Content),
+		TokenCount: fd.TokenCount,
+	}
+}
+
+// useTokenizer resets the cache when its counts were produced by a different
+// tokenizer than name, and records name for the next save.
+func (c *TokenCache) useTokenizer(name string) {
+	if c.Tokenizer == name {
+		return
+	}
+	if len(c.Entries) > 0 {
+		slog.Debug("discarding token cache for different tokenizer",
+			"cached", c.Tokenizer,
+			"current", name,
+		)
+	}
+	c.Tokenizer = name
+	c.Entries = make(map[string]TokenCacheEntry)
+}
@@ -0,0 +1,118 @@
+package pipeline
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runWithCache runs a discovery+tokenize pipeline over files using cache and
+// returns the per-path token counts and the number of tokenizer calls made.
+func runWithCache(t *testing.T, cache *TokenCache, tokName string, files []FileDescriptor) (map[string]int, int) {
+	t.Helper()
+
+	calls := 0
+	tok := &mockTokenizer{
+		name: tokName,
+		countFn: func(text string) int {
+			calls++
+			return len(text)
+		},
+	}
+
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: &DiscoveryResult{Files: files, TotalFound: len(files)}}),
+		WithTokenizer(tok),
+		WithTokenCache(cache),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{Dir: "/project"})
+	require.NoError(t, err)
+
+	counts := make(map[string]int, len(result.Files))
+	for _, f := range result.Files {
+		counts[f.Path] = f.TokenCount
+	}
+	return counts, calls
+}
+
+func TestTokenCache_ReusesCountsForUnchangedFiles(t *testing.T) {
+	t.Parallel()
+
+	cachePath := filepath.Join(t.TempDir(), ".harvx", "tokens.json")
+	files := []FileDescriptor{
+		{Path: "main.go", Content: "package main"},
+		{Path: "util.go", Content: "package util"},
+	}
+
+	// First run: cold cache, every file is tokenized.
+	cache, err := LoadCache(cachePath)
+	require.NoError(t, err)
+	counts, calls := runWithCache(t, cache, "cl100k_base", files)
+	assert.Equal(t, 2, calls)
+	require.NoError(t, cache.SaveCache(cachePath))
+
+	// Second run: warm cache, nothing is re-tokenized.
+	cache, err = LoadCache(cachePath)
+	require.NoError(t, err)
+	warm, calls := runWithCache(t, cache, "cl100k_base", files)
+	assert.Equal(t, 0, calls, "unchanged files must reuse cached counts")
+	assert.Equal(t, counts, warm)
+
+	// Third run: one file changed, only that file is recomputed.
+	files[1].Content = "package util // changed"
+	changed, calls := runWithCache(t, cache, "cl100k_base", files)
+	assert.Equal(t, 1, calls, "a hash mismatch must invalidate the entry")
+	assert.Equal(t, counts["main.go"], changed["main.go"])
+	assert.Equal(t, len(files[1].Content), changed["util.go"])
+}
+
+func TestTokenCache_TokenizerChangeInvalidates(t *testing.T) {
+	t.Parallel()
+
+	files := []FileDescriptor{{Path: "main.go", Content: "package main"}}
+	cache := NewTokenCache()
+
+	_, calls := runWithCache(t, cache, "cl100k_base", files)
+	assert.Equal(t, 1, calls)
+
+	_, calls = runWithCache(t, cache, "o200k_base", files)
+	assert.Equal(t, 1, calls, "counts from another tokenizer must not be reused")
+	assert.Equal(t, "o200k_base", cache.Tokenizer)
+}
+
+func TestLoadCache_Missing(t *testing.T) {
+	t.Parallel()
+
+	cache, err := LoadCache(filepath.Join(t.TempDir(), "nope.json"))
+	require.NoError(t, err)
+	assert.Empty(t, cache.Entries)
+}
+
+func TestLoadCache_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o644))
+
+	_, err := LoadCache(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing token cache")
+}
+
+func TestLoadCache_VersionMismatchDiscarded(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "tokens.json")
+	require.NoError(t, os.WriteFile(path,
+		[]byte(`{"version":99,"tokenizer":"cl100k_base","entries":{"a.go":{"hash":1,"token_count":5}}}`), 0o644))
+
+	cache, err := LoadCache(path)
+	require.NoError(t, err)
+	assert.Empty(t, cache.Entries)
+	assert.Equal(t, tokenCacheVersion, cache.Version)
+}
@@ -13,6 +13,7 @@ package pipeline
 import (
 	"context"
 	"io"
+	"time"
 )
 
 // DiscoveryService discovers files in a target directory, applying ignore
@@ -41,6 +42,9 @@ type DiscoveryOptions struct {
 
 	// Extensions are file extensions to filter by (no leading dot).
 	Extensions []string
+
+	// SkipEmpty drops files whose content is empty or whitespace-only.
+	SkipEmpty bool
 }
 
 // RelevanceService assigns relevance tiers to files and sorts them by
@@ -100,6 +104,14 @@ type CompressionService interface {
 	Compress(ctx context.Context, files []*FileDescriptor) error
 }
 
+// ChangeDetector lists the files changed in a repository since a git ref.
+// It backs RunOptions.Since; the default implementation shells out to git.
+type ChangeDetector interface {
+	// ChangedFiles returns the slash-separated paths, relative to dir, of the
+	// files changed since ref.
+	ChangedFiles(dir, ref string) ([]string, error)
+}
+
 // RenderService renders processed files into the final output document
 // (Markdown or XML format).
 type RenderService interface {
@@ -109,7 +121,7 @@ type RenderService interface {
 
 // RenderOptions holds rendering configuration for the output stage.
 type RenderOptions struct {
-	// Format is the output format ("markdown" or "xml").
+	// Format is the output format ("markdown", "xml", "json", or "plain").
 	Format string
 
 	// ProjectName is the project name for the output header.
@@ -124,6 +136,18 @@ type RenderOptions struct {
 	// ShowLineNumbers enables line number prefixes in code blocks.
 	ShowLineNumbers bool
 
+	// IncludeTOC adds a table of contents listing every file to formats
+	// that support one (Markdown).
+	IncludeTOC bool
+
+	// Timestamp is the generation timestamp for the output header. When
+	// zero, the current time is used.
+	Timestamp time.Time
+
+	// MaxOutputBytes caps the size of streamed output in bytes,
+	// independently of the token budget. Zero means no limit.
+	MaxOutputBytes int64
+
 	// DiffSummary holds change summary data for diff mode rendering.
 	// Nil when not in diff mode.
 	DiffSummary *DiffSummaryEntry
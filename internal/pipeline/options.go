@@ -45,6 +45,24 @@ func WithCompressor(c CompressionService) PipelineOption {
 	}
 }
 
+// WithTokenCache sets a TokenCache consulted during tokenization. Files whose
+// content hash matches a cached entry reuse its count instead of being
+// re-tokenized, and newly counted files are added to the cache. The caller
+// owns loading and saving the cache (see LoadCache and TokenCache.SaveCache).
+func WithTokenCache(c *TokenCache) PipelineOption {
+	return func(p *Pipeline) {
+		p.tokenCache = c
+	}
+}
+
+// WithChangeDetector sets the change detector used for RunOptions.Since. When
+// unset, the pipeline asks git for the changed files.
+func WithChangeDetector(c ChangeDetector) PipelineOption {
+	return func(p *Pipeline) {
+		p.changes = c
+	}
+}
+
 // WithRenderer sets the output rendering service.
 func WithRenderer(r RenderService) PipelineOption {
 	return func(p *Pipeline) {
@@ -1,15 +1,20 @@
 package pipeline
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"time"
 
+	"github.com/bmatcuk/doublestar/v4"
+
 	"github.com/harvx/harvx/internal/compression"
 	"github.com/harvx/harvx/internal/config"
+	"github.com/harvx/harvx/internal/git"
 	"github.com/harvx/harvx/internal/security"
 )
 
@@ -26,13 +31,15 @@ const DefaultRedactionReportPath = "harvx-redaction-report.json"
 // WithCompressor, and WithRenderer options. Stages without a configured service
 // are skipped during Run.
 type Pipeline struct {
-	discovery   DiscoveryService
-	relevance   RelevanceService
-	tokenizer   TokenizerService
-	budget      BudgetService
-	redactor    RedactionService
-	compressor  CompressionService
-	renderer    RenderService
+	discovery  DiscoveryService
+	relevance  RelevanceService
+	tokenizer  TokenizerService
+	budget     BudgetService
+	redactor   RedactionService
+	compressor CompressionService
+	renderer   RenderService
+	tokenCache *TokenCache
+	changes    ChangeDetector
 }
 
 // NewPipeline constructs a Pipeline with the provided functional options.
@@ -86,7 +93,8 @@ func (p *Pipeline) Run(ctx context.Context, opts RunOptions) (*RunResult, error)
 		}
 
 		discoveryOpts := DiscoveryOptions{
-			RootDir: opts.Dir,
+			RootDir:   opts.Dir,
+			SkipEmpty: opts.SkipEmpty,
 		}
 		discoveryResult, err := p.discovery.Discover(ctx, discoveryOpts)
 		if err != nil {
@@ -104,13 +112,74 @@ func (p *Pipeline) Run(ctx context.Context, opts RunOptions) (*RunResult, error)
 			"skipped", discoveryResult.TotalSkipped,
 			"duration", result.Timings.Discovery,
 		)
+
+		// A priority entry that matches no walked file is most likely a typo.
+		if len(opts.PriorityFiles) > 0 {
+			paths := make([]string, len(files))
+			for i := range files {
+				paths[i] = files[i].Path
+			}
+			result.Stats.MissingPriorityFiles = config.UnmatchedPriorityFiles(opts.PriorityFiles, opts.PriorityGlobs, opts.BaseDir, paths)
+			for _, missing := range result.Stats.MissingPriorityFiles {
+				slog.Warn("priority file not found", "path", missing)
+			}
+		}
 	}
 
 	// Convert to pointer slice for stages that mutate in place.
 	filePtrs = toPointerSlice(files)
 
+	// Incremental selection keeps only files changed since opts.Since, so
+	// unchanged files are never classified or budgeted.
+	if opts.Since != "" {
+		changes := p.changes
+		if changes == nil {
+			changes = gitChangeDetector{}
+		}
+		changed, err := changes.ChangedFiles(opts.Dir, opts.Since)
+		if errors.Is(err, git.ErrNotGitRepo) {
+			return nil, fmt.Errorf("--since %s needs a git repository, but %s is not inside one: %w",
+				opts.Since, opts.Dir, err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("selecting changed files: %w", err)
+		}
+		before := len(filePtrs)
+		filePtrs = selectPaths(filePtrs, changed)
+		result.Stats.SinceFiltered = before - len(filePtrs)
+
+		slog.Debug("incremental selection complete",
+			"since", opts.Since,
+			"changed", len(changed),
+			"files", len(filePtrs),
+			"filtered", result.Stats.SinceFiltered,
+		)
+	}
+
+	// Include-only selection replaces relevance classification entirely.
+	if opts.IncludeOnly {
+		filePtrs = selectIncludeOnly(filePtrs, opts.Includes, opts.BaseDir)
+		result.Stats.IncludeOnly = true
+
+		slog.Debug("include-only selection complete",
+			"files", len(filePtrs),
+			"includes", opts.Includes,
+		)
+	}
+
+	// Binary exclusion runs before classification so binary files never
+	// occupy a tier slot or count toward a tier file cap.
+	if opts.ExcludeBinary && len(filePtrs) > 0 {
+		filePtrs, result.Stats.BinaryFiles = filterBinaryFiles(filePtrs)
+
+		slog.Debug("binary files excluded",
+			"files", len(filePtrs),
+			"excluded", len(result.Stats.BinaryFiles),
+		)
+	}
+
 	// Stage 2: Relevance
-	if stages.Relevance && p.relevance != nil && len(filePtrs) > 0 {
+	if stages.Relevance && !opts.IncludeOnly && p.relevance != nil && len(filePtrs) > 0 {
 		start := time.Now()
 
 		select {
@@ -128,8 +197,58 @@ func (p *Pipeline) Run(ctx context.Context, opts RunOptions) (*RunResult, error)
 		)
 	}
 
+	// Path overrides replace the tier assigned by relevance for matching files.
+	if len(opts.Overrides) > 0 {
+		for _, fd := range filePtrs {
+			if ov, ok := config.MatchPathOverride(opts.Overrides, fd.Path); ok && ov.Tier != nil {
+				fd.Tier = *ov.Tier
+			}
+		}
+	}
+
+	// Focus patterns are a per-run layer over classification: they win over
+	// both relevance and path overrides.
+	if len(opts.Focus) > 0 {
+		focused := 0
+		for _, fd := range filePtrs {
+			if matchesFocus(opts.Focus, fd.Path) {
+				fd.Tier = 0
+				focused++
+			}
+		}
+
+		slog.Debug("focus applied",
+			"patterns", opts.Focus,
+			"files", focused,
+		)
+	}
+
+	// Tier file caps drop the lowest-ranked files of each capped tier before
+	// any content is processed.
+	if len(opts.TierFileCaps) > 0 && len(filePtrs) > 0 {
+		before := len(filePtrs)
+		filePtrs = capTierFiles(filePtrs, opts.TierFileCaps, priorityRanks(filePtrs, opts))
+		result.Stats.TierFileCapped = before - len(filePtrs)
+
+		slog.Debug("tier file caps applied",
+			"files", len(filePtrs),
+			"dropped", result.Stats.TierFileCapped,
+		)
+	}
+
+	// Vendored files are stubbed before redaction and compression, which
+	// would otherwise process content that is about to be discarded.
+	if opts.StubVendored && len(filePtrs) > 0 {
+		stubbed := stubVendoredFiles(filePtrs, opts.VendorDirs, p.tokenizer)
+		slog.Debug("vendored files stubbed",
+			"files", stubbed,
+		)
+	}
+
 	// Stage 3: Redaction
-	if stages.Redaction && p.redactor != nil && len(filePtrs) > 0 {
+	// An override with redact set decides per file, so the stage also runs
+	// when it is disabled but some override turns redaction on.
+	if (stages.Redaction || overridesRedact(opts.Overrides)) && p.redactor != nil && len(filePtrs) > 0 {
 		start := time.Now()
 
 		for _, fd := range filePtrs {
@@ -143,6 +262,14 @@ func (p *Pipeline) Run(ctx context.Context, opts RunOptions) (*RunResult, error)
 				continue
 			}
 
+			redact := stages.Redaction
+			if ov, ok := config.MatchPathOverride(opts.Overrides, fd.Path); ok && ov.Redact != nil {
+				redact = *ov.Redact
+			}
+			if !redact {
+				continue
+			}
+
 			redacted, count, err := p.redactor.Redact(ctx, fd.Content, fd.Path)
 			if err != nil {
 				fd.Error = fmt.Errorf("redacting %s: %w", fd.Path, err)
@@ -186,9 +313,25 @@ func (p *Pipeline) Run(ctx context.Context, opts RunOptions) (*RunResult, error)
 	if stages.Tokenize && p.tokenizer != nil && len(filePtrs) > 0 {
 		start := time.Now()
 
+		if p.tokenCache != nil {
+			p.tokenCache.useTokenizer(p.tokenizer.Name())
+		}
+
+		cacheHits := 0
 		for _, fd := range filePtrs {
-			if fd.Content != "" {
-				fd.TokenCount = p.tokenizer.Count(fd.Content)
+			if fd.Content == "" {
+				continue
+			}
+			if p.tokenCache != nil {
+				if n, ok := p.tokenCache.Lookup(fd); ok {
+					fd.TokenCount = n
+					cacheHits++
+					continue
+				}
+			}
+			fd.TokenCount = p.tokenizer.Count(fd.Content)
+			if p.tokenCache != nil {
+				p.tokenCache.Store(fd)
 			}
 		}
 
@@ -197,10 +340,16 @@ func (p *Pipeline) Run(ctx context.Context, opts RunOptions) (*RunResult, error)
 
 		slog.Debug("tokenization complete",
 			"tokenizer", p.tokenizer.Name(),
+			"cache_hits", cacheHits,
 			"duration", result.Timings.Tokenize,
 		)
 	}
 
+	// Order files within each tier so budget enforcement sees them in the
+	// configured order. Tiers keep their relative priority, adjusted by any
+	// tier weights.
+	filePtrs = sortWithinTiers(filePtrs, opts.SortOrder, opts.TierWeights, priorityRanks(filePtrs, opts))
+
 	// Stage 6: Budget enforcement
 	if stages.Budget && p.budget != nil && len(filePtrs) > 0 {
 		start := time.Now()
@@ -264,6 +413,224 @@ func (p *Pipeline) HasRedactor() bool {
 	return p.redactor != nil
 }
 
+// gitChangeDetector is the default ChangeDetector, backed by git diff.
+type gitChangeDetector struct{}
+
+// ChangedFiles implements ChangeDetector using git.ChangedFiles.
+func (gitChangeDetector) ChangedFiles(dir, ref string) ([]string, error) {
+	return git.ChangedFiles(dir, ref)
+}
+
+// selectPaths returns the files whose path is in paths, preserving order.
+func selectPaths(files []*FileDescriptor, paths []string) []*FileDescriptor {
+	keep := make(map[string]bool, len(paths))
+	for _, p := range paths {
+		keep[p] = true
+	}
+
+	selected := make([]*FileDescriptor, 0, len(paths))
+	for _, fd := range files {
+		if keep[fd.Path] {
+			selected = append(selected, fd)
+		}
+	}
+	return selected
+}
+
+// selectIncludeOnly returns the files whose path matches at least one include
+// pattern, sorted by path. Patterns are relative to baseDir (see
+// config.RebasePath), so files outside it are never selected. Invalid patterns
+// never match. The input slice is not mutated.
+func selectIncludeOnly(files []*FileDescriptor, includes []string, baseDir string) []*FileDescriptor {
+	selected := make([]*FileDescriptor, 0, len(files))
+	for _, fd := range files {
+		rel, ok := config.RebasePath(baseDir, fd.Path)
+		if !ok {
+			continue
+		}
+		for _, pattern := range includes {
+			if matched, err := doublestar.Match(pattern, rel); err == nil && matched {
+				selected = append(selected, fd)
+				break
+			}
+		}
+	}
+
+	slices.SortStableFunc(selected, func(a, b *FileDescriptor) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return selected
+}
+
+// matchesFocus reports whether path matches any of the focus patterns.
+func matchesFocus(patterns []string, path string) bool {
+	for _, pattern := range patterns {
+		if matched, err := doublestar.Match(pattern, path); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// capTierFiles keeps at most caps[tier] files of each capped tier and returns
+// the kept files in their input order. Within a tier, prioritized files come
+// first by ascending rank (see priorityRanks), followed by the remaining
+// files in input order, which is the classification order. Tiers with a cap
+// of zero or no entry are kept whole. Each dropped file is logged with the
+// reason "tier_file_cap".
+func capTierFiles(files []*FileDescriptor, caps map[int]int, priority map[string]int) []*FileDescriptor {
+	byTier := make(map[int][]*FileDescriptor)
+	for _, fd := range files {
+		if caps[fd.Tier] > 0 {
+			byTier[fd.Tier] = append(byTier[fd.Tier], fd)
+		}
+	}
+
+	dropped := make(map[*FileDescriptor]bool)
+	for tier, tierFiles := range byTier {
+		if len(tierFiles) <= caps[tier] {
+			continue
+		}
+		slices.SortStableFunc(tierFiles, func(a, b *FileDescriptor) int {
+			ra, pa := priority[a.Path]
+			rb, pb := priority[b.Path]
+			if pa != pb {
+				if pa {
+					return -1
+				}
+				return 1
+			}
+			return cmp.Compare(ra, rb)
+		})
+		for _, fd := range tierFiles[caps[tier]:] {
+			dropped[fd] = true
+			slog.Debug("file dropped", "path", fd.Path, "tier", tier, "reason", "tier_file_cap")
+		}
+	}
+
+	kept := make([]*FileDescriptor, 0, len(files)-len(dropped))
+	for _, fd := range files {
+		if !dropped[fd] {
+			kept = append(kept, fd)
+		}
+	}
+	return kept
+}
+
+// sortWithinTiers returns files ordered by descending tier weight (see
+// RunOptions.TierWeights), then ascending Tier and, within each tier, by order
+// (SortOrderPath when empty). Files whose path is in priority come before all
+// others, by ascending rank and then by the same rules. Ties fall back to Path
+// so the result is deterministic. The input slice is not mutated.
+func sortWithinTiers(files []*FileDescriptor, order SortOrder, weights map[int]int, priority map[string]int) []*FileDescriptor {
+	within := func(a, b *FileDescriptor) int { return 0 }
+	switch order {
+	case SortOrderTokensDesc, SortOrderTokens:
+		within = func(a, b *FileDescriptor) int { return cmp.Compare(b.TokenCount, a.TokenCount) }
+	case SortOrderTokensAsc:
+		within = func(a, b *FileDescriptor) int { return cmp.Compare(a.TokenCount, b.TokenCount) }
+	case SortOrderMTime:
+		within = func(a, b *FileDescriptor) int { return b.ModTime.Compare(a.ModTime) }
+	case SortOrderSize:
+		within = func(a, b *FileDescriptor) int { return cmp.Compare(b.Size, a.Size) }
+	}
+
+	sorted := slices.Clone(files)
+	slices.SortStableFunc(sorted, func(a, b *FileDescriptor) int {
+		ra, pa := priority[a.Path]
+		rb, pb := priority[b.Path]
+		if pa != pb {
+			if pa {
+				return -1
+			}
+			return 1
+		}
+		if n := cmp.Compare(ra, rb); n != 0 {
+			return n
+		}
+		if n := cmp.Compare(weights[b.Tier], weights[a.Tier]); n != 0 {
+			return n
+		}
+		if n := cmp.Compare(a.Tier, b.Tier); n != 0 {
+			return n
+		}
+		if n := within(a, b); n != 0 {
+			return n
+		}
+		return cmp.Compare(a.Path, b.Path)
+	})
+	return sorted
+}
+
+// SortForBudget returns files in budget order: by ascending Tier and, within
+// each tier, by order (SortOrderPath when empty), with ties broken by Path.
+// It is the ordering Run applies before budget enforcement, for callers that
+// enforce a budget without running the full pipeline. Priority files are not
+// considered. The input slice is not mutated.
+func SortForBudget(files []*FileDescriptor, order SortOrder) []*FileDescriptor {
+	return sortWithinTiers(files, order, nil, nil)
+}
+
+// priorityRanks returns the priority rank of every prioritized file path, or
+// nil when no file is prioritized. Files listed in opts.PriorityFiles (after
+// glob expansion) rank by their list position; files matching opts.Focus rank
+// after all of them, and files prioritized only by an override rank last.
+func priorityRanks(files []*FileDescriptor, opts RunOptions) map[string]int {
+	var ranks map[string]int
+	set := func(path string, rank int) {
+		if ranks == nil {
+			ranks = make(map[string]int)
+		}
+		if _, ok := ranks[path]; !ok {
+			ranks[path] = rank
+		}
+	}
+
+	if len(opts.PriorityFiles) > 0 {
+		paths := make([]string, len(files))
+		for i, fd := range files {
+			paths[i] = fd.Path
+		}
+		present := make(map[string]bool, len(paths))
+		for _, p := range paths {
+			present[p] = true
+		}
+		for i, p := range config.ExpandPriorityFiles(opts.PriorityFiles, opts.PriorityGlobs, opts.BaseDir, paths) {
+			if present[p] {
+				set(p, i)
+			}
+		}
+	}
+
+	focusRank := len(opts.PriorityFiles) + len(files)
+	if len(opts.Focus) > 0 {
+		for _, fd := range files {
+			if matchesFocus(opts.Focus, fd.Path) {
+				set(fd.Path, focusRank)
+			}
+		}
+	}
+
+	overrideRank := focusRank + 1
+	for _, fd := range files {
+		if ov, ok := config.MatchPathOverride(opts.Overrides, fd.Path); ok && ov.Priority {
+			set(fd.Path, overrideRank)
+		}
+	}
+	return ranks
+}
+
+// overridesRedact reports whether any override explicitly enables redaction.
+func overridesRedact(overrides []config.PathOverride) bool {
+	for _, ov := range overrides {
+		if ov.Redact != nil && *ov.Redact {
+			return true
+		}
+	}
+	return false
+}
+
 // toPointerSlice converts a value slice to a pointer slice.
 func toPointerSlice(files []FileDescriptor) []*FileDescriptor {
 	ptrs := make([]*FileDescriptor, len(files))
@@ -439,6 +806,51 @@ func buildCompressionConfig(cfg *config.FlagValues) compression.CompressionConfi
 	return cc
 }
 
+// StagesForProfile returns a StageSelection with every stage enabled except
+// that redaction and compression follow the resolved profile. Pass a profile
+// resolved with FlagValues.ProfileOverrides so --no-redaction and
+// --no-compression switch the passes off even when the profile enables them.
+func StagesForProfile(p *config.Profile) *StageSelection {
+	stages := NewStageSelection()
+	stages.Redaction = p.Redaction
+	stages.Compression = p.Compression
+	return stages
+}
+
+// RenderOptionsForProfile returns the RenderOptions selected by the resolved
+// profile: its output format and its [render] preferences. Header fields such
+// as ProjectName and Timestamp are left for the caller.
+func RenderOptionsForProfile(p *config.Profile) RenderOptions {
+	return RenderOptions{
+		Format:          p.Format,
+		ShowLineNumbers: p.Render.LineNumbers != nil && *p.Render.LineNumbers,
+		IncludeTOC:      p.Render.IncludeTOC != nil && *p.Render.IncludeTOC,
+	}
+}
+
+// RunOptionsForProfile returns the RunOptions selected by the resolved
+// profile: its include allowlist, base_dir, sort order, priority files, path
+// overrides, tier caps and weights, and empty, binary, and vendored file
+// handling.
+// Dir, MaxTokens, Stages, and other per-run fields are left for the caller.
+func RunOptionsForProfile(p *config.Profile) RunOptions {
+	return RunOptions{
+		Includes:      p.Include,
+		IncludeOnly:   p.IncludeOnly,
+		BaseDir:       p.BaseDir,
+		SortOrder:     SortOrder(p.SortOrder),
+		PriorityFiles: p.PriorityFiles,
+		PriorityGlobs: p.PriorityGlobs,
+		Overrides:     p.Overrides,
+		StubVendored:  p.StubVendored,
+		VendorDirs:    p.VendorDirs,
+		TierFileCaps:  p.TierFileCaps,
+		TierWeights:   p.TierWeights,
+		ExcludeBinary: p.ExcludeBinary,
+		SkipEmpty:     p.SkipEmpty,
+	}
+}
+
 // ErrNoDiscovery is returned when Pipeline.Run is called without a configured
 // discovery service and the stage selection requires discovery.
 var ErrNoDiscovery = errors.New("pipeline: no discovery service configured")
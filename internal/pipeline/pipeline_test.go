@@ -450,3 +450,151 @@ func TestPrintRedactionSummary_ZeroCountFormat(t *testing.T) {
 	assert.Equal(t, "Redactions:  0\n", stderr,
 		"zero redactions must produce 'Redactions:  0' on stderr")
 }
+
+// TestStagesForProfile_FlagsDisablePasses verifies that a profile resolved
+// with --no-redaction and --no-compression overrides yields a stage selection
+// that skips both passes, even though the profile enables them.
+func TestStagesForProfile_FlagsDisablePasses(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"), []byte(`
+[profile.default]
+redaction = true
+compression = true
+`), 0o644))
+
+	fv := &config.FlagValues{NoRedaction: true, NoCompression: true}
+	rc, err := config.Resolve(config.ResolveOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "nonexistent.toml"),
+		CLIFlags:         fv.ProfileOverrides(),
+	})
+	require.NoError(t, err)
+
+	var redacted, compressed bool
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: sampleDiscoveryResult()}),
+		WithRedactor(&mockRedactor{redactFn: func(_ context.Context, content, _ string) (string, int, error) {
+			redacted = true
+			return content, 0, nil
+		}}),
+		WithCompressor(&mockCompressor{compressFn: func(_ context.Context, _ []*FileDescriptor) error {
+			compressed = true
+			return nil
+		}}),
+	)
+
+	_, err = p.Run(context.Background(), RunOptions{Dir: dir, Stages: StagesForProfile(rc.Profile)})
+	require.NoError(t, err)
+	assert.False(t, redacted, "redaction pass must not run")
+	assert.False(t, compressed, "compression pass must not run")
+}
+
+// TestStagesForProfile_ProfileEnables verifies that without overrides the
+// profile's redaction and compression settings enable both passes.
+func TestStagesForProfile_ProfileEnables(t *testing.T) {
+	t.Parallel()
+
+	stages := StagesForProfile(&config.Profile{Redaction: true, Compression: true})
+	assert.Equal(t, NewStageSelection(), stages)
+
+	stages = StagesForProfile(&config.Profile{})
+	assert.False(t, stages.Redaction)
+	assert.False(t, stages.Compression)
+	assert.True(t, stages.Discovery)
+}
+
+func TestRenderOptionsForProfile(t *testing.T) {
+	t.Parallel()
+
+	on, off := true, false
+	opts := RenderOptionsForProfile(&config.Profile{
+		Format: "markdown",
+		Render: config.RenderConfig{IncludeTOC: &on, LineNumbers: &off},
+	})
+	assert.Equal(t, "markdown", opts.Format)
+	assert.True(t, opts.IncludeTOC)
+	assert.False(t, opts.ShowLineNumbers)
+
+	opts = RenderOptionsForProfile(&config.Profile{})
+	assert.False(t, opts.IncludeTOC, "unset options are off")
+	assert.False(t, opts.ShowLineNumbers)
+}
+
+func TestRunOptionsForProfile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "harvx.toml"), []byte(`
+[profile.default]
+include = ["src/**"]
+include_only = true
+base_dir = "pkg/api"
+sort_order = "size"
+priority_files = ["*.md"]
+priority_globs = true
+stub_vendored = true
+vendor_dirs = ["third_party"]
+exclude_binary = true
+
+[profile.default.tier_file_caps]
+3 = 5
+
+[profile.default.tier_weights]
+4 = 10
+
+[[profile.default.overrides]]
+match = "gen/**"
+tier = 5
+`), 0o644))
+
+	rc, err := config.Resolve(config.ResolveOptions{
+		TargetDir:        dir,
+		GlobalConfigPath: filepath.Join(dir, "nonexistent.toml"),
+	})
+	require.NoError(t, err)
+
+	opts := RunOptionsForProfile(rc.Profile)
+	assert.Equal(t, []string{"src/**"}, opts.Includes)
+	assert.True(t, opts.IncludeOnly)
+	assert.Equal(t, "pkg/api", opts.BaseDir)
+	assert.Equal(t, SortOrderSize, opts.SortOrder)
+	assert.Equal(t, []string{"*.md"}, opts.PriorityFiles)
+	assert.True(t, opts.PriorityGlobs)
+	assert.True(t, opts.StubVendored)
+	assert.Equal(t, []string{"third_party"}, opts.VendorDirs)
+	assert.True(t, opts.ExcludeBinary)
+	assert.True(t, opts.SkipEmpty, "skip_empty defaults to true")
+	assert.Equal(t, map[int]int{3: 5}, opts.TierFileCaps)
+	assert.Equal(t, map[int]int{4: 10}, opts.TierWeights)
+	require.Len(t, opts.Overrides, 1)
+	assert.Equal(t, "gen/**", opts.Overrides[0].Match)
+	assert.Empty(t, opts.Dir, "per-run fields are left for the caller")
+}
+
+func TestPipeline_TierWeightsOrderTiers(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "main.go", Content: "package main"},
+			{Path: "README.md", Content: "# Readme"},
+		},
+	}
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) {
+		fd.Tier = 1
+		if strings.HasSuffix(fd.Path, ".md") {
+			fd.Tier = 4
+		}
+	}}
+	p := NewPipeline(WithDiscovery(&mockDiscovery{result: disc}), WithRelevance(relevance))
+
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir:         "/project",
+		TierWeights: map[int]int{4: 10},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Files, 2)
+	assert.Equal(t, "README.md", result.Files[0].Path, "the heavier tier is ordered first")
+	assert.Equal(t, 4, result.Files[0].Tier, "weights do not change tier assignment")
+}
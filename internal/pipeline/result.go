@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"strconv"
 	"time"
+
+	"github.com/harvx/harvx/internal/config"
 )
 
 // RunOptions encapsulates all parameters for a single pipeline Run invocation.
@@ -28,6 +30,84 @@ type RunOptions struct {
 	// GitHeadRef is the head ref for PR-style diff (e.g., "feature-branch").
 	GitHeadRef string `json:"git_head_ref,omitempty"`
 
+	// Includes are the profile's include glob patterns. They only affect
+	// file selection when IncludeOnly is true.
+	Includes []string `json:"includes,omitempty"`
+
+	// IncludeOnly treats Includes as an exact allowlist: discovered files that
+	// match no include pattern are dropped, the relevance stage is skipped,
+	// and files are ordered by path. Ignore rules are applied by discovery
+	// beforehand, so they still take effect on top of the allowlist.
+	IncludeOnly bool `json:"include_only,omitempty"`
+
+	// Since is a git ref for incremental runs. When set, only discovered
+	// files that changed since Since, committed or not, or are untracked,
+	// continue past discovery (see git.ChangedFiles). Dir must be inside a git
+	// repository.
+	Since string `json:"since,omitempty"`
+
+	// SortOrder orders files within each relevance tier before budget
+	// enforcement, so it decides which files of a tier survive when the
+	// budget runs out. Tier priority always dominates. Empty means
+	// SortOrderPath.
+	SortOrder SortOrder `json:"sort_order,omitempty"`
+
+	// BaseDir is the profile's base_dir. Includes and PriorityFiles are
+	// relative to it, and files outside it match neither (see
+	// config.RebasePath). Empty means they are relative to Dir.
+	BaseDir string `json:"base_dir,omitempty"`
+
+	// PriorityFiles lists files that are ordered ahead of every other file
+	// during budget enforcement, in list order. Entries are exact paths
+	// unless PriorityGlobs is true, in which case glob entries are expanded
+	// against the discovered files (see config.ExpandPriorityFiles).
+	PriorityFiles []string `json:"priority_files,omitempty"`
+
+	// PriorityGlobs allows glob patterns in PriorityFiles.
+	PriorityGlobs bool `json:"priority_globs,omitempty"`
+
+	// Overrides are the profile's path-specific settings. The first override
+	// matching a file's path can force its tier after classification, turn
+	// redaction on or off for it, and order it ahead of all other files
+	// during budget enforcement.
+	Overrides []config.PathOverride `json:"overrides,omitempty"`
+
+	// Focus lists glob patterns for files to concentrate on in this run.
+	// Matching files are moved to tier 0 whatever relevance and path
+	// overrides assigned, and are ordered after PriorityFiles but ahead of
+	// every other file during budget enforcement.
+	Focus []string `json:"focus,omitempty"`
+
+	// StubVendored replaces the content of files under a vendor directory
+	// with a one-line VendoredStub before redaction, so they stay listed in
+	// the output and manifest but cost only the stub's tokens.
+	StubVendored bool `json:"stub_vendored,omitempty"`
+
+	// VendorDirs lists the directory names StubVendored treats as vendored
+	// (see config.IsVendoredPath). Empty uses config.DefaultVendorDirs.
+	VendorDirs []string `json:"vendor_dirs,omitempty"`
+
+	// TierFileCaps caps how many files of a tier survive classification,
+	// keyed by tier number. Prioritized files (PriorityFiles and priority
+	// overrides) are kept first, then the rest of the tier in classification
+	// order. A cap of zero or an absent tier means uncapped.
+	TierFileCaps map[int]int `json:"tier_file_caps,omitempty"`
+
+	// TierWeights reorders tiers for budget enforcement, keyed by tier
+	// number: heavier tiers are ordered first, tiers without a weight count
+	// as 0, and equal weights fall back to tier number. Prioritized files
+	// still come before all others. Empty means tier-number order.
+	TierWeights map[int]int `json:"tier_weights,omitempty"`
+
+	// ExcludeBinary drops files whose content IsLikelyBinary before
+	// relevance classification. The dropped paths are reported in
+	// RunStats.BinaryFiles.
+	ExcludeBinary bool `json:"exclude_binary,omitempty"`
+
+	// SkipEmpty asks discovery to drop files whose content is empty or
+	// whitespace-only (see DiscoveryOptions.SkipEmpty).
+	SkipEmpty bool `json:"skip_empty,omitempty"`
+
 	// Stages controls which pipeline stages to execute.
 	// Nil or empty means run all configured stages.
 	Stages *StageSelection `json:"stages,omitempty"`
@@ -101,7 +181,8 @@ type RunStats struct {
 	// TotalTokens is the sum of tokens across all included files.
 	TotalTokens int `json:"total_tokens"`
 
-	// TokenizerName is the tokenizer encoding used.
+	// TokenizerName is the tokenizer encoding used, as reported by the
+	// configured TokenizerService. Empty when tokenization did not run.
 	TokenizerName string `json:"tokenizer_name"`
 
 	// TierBreakdown maps tier number to file count.
@@ -118,6 +199,26 @@ type RunStats struct {
 
 	// DiscoverySkipped is the total files skipped during discovery.
 	DiscoverySkipped int `json:"discovery_skipped"`
+
+	// IncludeOnly reports that the run used include_only selection, meaning
+	// relevance tiers were inactive and files are ordered by path.
+	IncludeOnly bool `json:"include_only,omitempty"`
+
+	// SinceFiltered is the number of discovered files dropped because they
+	// did not change since the RunOptions.Since ref.
+	SinceFiltered int `json:"since_filtered,omitempty"`
+
+	// MissingPriorityFiles lists the RunOptions.PriorityFiles entries that
+	// match no discovered file, which usually means a typo'd path.
+	MissingPriorityFiles []string `json:"missing_priority_files,omitempty"`
+
+	// TierFileCapped is the number of files dropped because their tier
+	// reached its RunOptions.TierFileCaps limit.
+	TierFileCapped int `json:"tier_file_capped,omitempty"`
+
+	// BinaryFiles lists the paths RunOptions.ExcludeBinary dropped as
+	// binary, in discovery order.
+	BinaryFiles []string `json:"binary_files,omitempty"`
 }
 
 // StageTimings records wall-clock duration for each pipeline stage.
@@ -244,6 +345,24 @@ type PreviewResult struct {
 	// FilesOmitted is the number of files skipped during discovery (ignored,
 	// binary, oversized, etc.).
 	FilesOmitted int `json:"files_omitted"`
+
+	// IncludeOnly is true when the run used include_only selection. Tiers are
+	// inactive in that mode, so Tiers reflects unclassified files only.
+	IncludeOnly bool `json:"include_only,omitempty"`
+
+	// SinceFiltered is the number of files the --since filter removed because
+	// they did not change since the given ref.
+	SinceFiltered int `json:"since_filtered,omitempty"`
+
+	// MissingPriorityFiles lists priority_files entries that match no
+	// discovered file.
+	MissingPriorityFiles []string `json:"missing_priority_files,omitempty"`
+
+	// TierFileCapped is the number of files tier_file_caps dropped.
+	TierFileCapped int `json:"tier_file_capped,omitempty"`
+
+	// BinaryFiles lists the files exclude_binary dropped.
+	BinaryFiles []string `json:"binary_files,omitempty"`
 }
 
 // BuildPreviewResult converts a RunResult into a PreviewResult for JSON output.
@@ -282,6 +401,11 @@ func BuildPreviewResult(result *RunResult, profile string, maxTokens int) *Previ
 		BudgetUtilizationPercent: budgetPct,
 		FilesTruncated:           0, // Populated from BudgetResult when budget stage runs.
 		FilesOmitted:             result.Stats.DiscoverySkipped,
+		IncludeOnly:              result.Stats.IncludeOnly,
+		SinceFiltered:            result.Stats.SinceFiltered,
+		MissingPriorityFiles:     result.Stats.MissingPriorityFiles,
+		TierFileCapped:           result.Stats.TierFileCapped,
+		BinaryFiles:              result.Stats.BinaryFiles,
 	}
 }
 
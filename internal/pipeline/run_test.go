@@ -5,10 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/harvx/harvx/internal/config"
+	"github.com/harvx/harvx/internal/git"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -916,6 +921,149 @@ func TestPipeline_DiscoveryAndRelevanceStages(t *testing.T) {
 	assert.Equal(t, time.Duration(0), result.Timings.Redaction)
 }
 
+func TestPipeline_IncludeOnlySelection(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "internal/z.go", Content: "package internal"},
+			{Path: "cmd/main.go", Content: "package main"},
+			{Path: "internal/a.go", Content: "package internal"},
+			{Path: "internal/notes.md", Content: "# Notes"},
+		},
+	}
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) { fd.Tier = 5 }}
+
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithRelevance(relevance),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir:         "/project",
+		Includes:    []string{"internal/**/*.go"},
+		IncludeOnly: true,
+		Stages:      DiscoveryAndRelevance(),
+	})
+	require.NoError(t, err)
+
+	paths := make([]string, len(result.Files))
+	for i, f := range result.Files {
+		paths[i] = f.Path
+		assert.NotEqual(t, 5, f.Tier, "relevance must not run under include_only")
+	}
+	assert.Equal(t, []string{"internal/a.go", "internal/z.go"}, paths,
+		"only allowlisted files are kept, ordered by path")
+	assert.True(t, result.Stats.IncludeOnly)
+	assert.True(t, BuildPreviewResult(result, "default", 0).IncludeOnly)
+}
+
+func TestPipeline_SinceSelectsChangedFiles(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	gitCmd := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, "git %v failed: %s", args, string(out))
+	}
+	gitCmd("init", "-q")
+	gitCmd("config", "user.email", "test@test.com")
+	gitCmd("config", "user.name", "Test")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.go"), []byte("package b\n"), 0o644))
+	gitCmd("add", ".")
+	gitCmd("commit", "-q", "-m", "initial")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.go"), []byte("package b\n\nvar B = 1\n"), 0o644))
+	gitCmd("commit", "-q", "-am", "change b")
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "a.go", Content: "package a\n"},
+			{Path: "b.go", Content: "package b\n\nvar B = 1\n"},
+		},
+	}
+	classified := 0
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) { classified++ }}
+
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithRelevance(relevance),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir:    dir,
+		Since:  "HEAD~1",
+		Stages: DiscoveryAndRelevance(),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "b.go", result.Files[0].Path)
+	assert.Equal(t, 1, classified, "only changed files are classified")
+	assert.Equal(t, 1, result.Stats.SinceFiltered)
+
+	_, err = p.Run(context.Background(), RunOptions{
+		Dir:   t.TempDir(),
+		Since: "HEAD~1",
+	})
+	require.Error(t, err, "--since outside a git repository must fail")
+	assert.ErrorIs(t, err, git.ErrNotGitRepo)
+	assert.Contains(t, err.Error(), "needs a git repository")
+}
+
+// fakeChangeDetector is a ChangeDetector returning a fixed file list.
+type fakeChangeDetector struct {
+	files  []string
+	err    error
+	gotDir string
+	gotRef string
+}
+
+func (f *fakeChangeDetector) ChangedFiles(dir, ref string) ([]string, error) {
+	f.gotDir, f.gotRef = dir, ref
+	return f.files, f.err
+}
+
+func TestPipeline_SinceUsesChangeDetector(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "a.go", Content: "package a\n"},
+			{Path: "b.go", Content: "package b\n"},
+			{Path: "c.go", Content: "package c\n"},
+		},
+	}
+	detector := &fakeChangeDetector{files: []string{"c.go", "deleted.go"}}
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithChangeDetector(detector),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir:    "/repo",
+		Since:  "main",
+		Stages: DiscoveryOnly(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "/repo", detector.gotDir)
+	assert.Equal(t, "main", detector.gotRef)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "c.go", result.Files[0].Path)
+	assert.Equal(t, 2, result.Stats.SinceFiltered)
+	assert.Equal(t, 2, BuildPreviewResult(result, "default", 0).SinceFiltered)
+
+	detector.err = fmt.Errorf("fatal: not a git repository: %w", git.ErrNotGitRepo)
+	_, err = p.Run(context.Background(), RunOptions{Dir: "/repo", Since: "main", Stages: DiscoveryOnly()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--since main needs a git repository, but /repo is not inside one")
+}
+
 func TestPipeline_FileDescriptorErrorSetsExitPartial(t *testing.T) {
 	t.Parallel()
 
@@ -1035,6 +1183,32 @@ func (d *dirCapturingDiscovery) Discover(ctx context.Context, opts DiscoveryOpti
 	return d.result, nil
 }
 
+func TestPipeline_SkipEmptyPassedToDiscovery(t *testing.T) {
+	t.Parallel()
+
+	for _, skip := range []bool{true, false} {
+		var received DiscoveryOptions
+		p := NewPipeline(WithDiscovery(&optsCapturingDiscovery{
+			result: &DiscoveryResult{},
+			opts:   &received,
+		}))
+		_, err := p.Run(context.Background(), RunOptions{Dir: "/project", SkipEmpty: skip})
+		require.NoError(t, err)
+		assert.Equal(t, skip, received.SkipEmpty)
+	}
+}
+
+// optsCapturingDiscovery captures the DiscoveryOptions passed to Discover.
+type optsCapturingDiscovery struct {
+	result *DiscoveryResult
+	opts   *DiscoveryOptions
+}
+
+func (d *optsCapturingDiscovery) Discover(ctx context.Context, opts DiscoveryOptions) (*DiscoveryResult, error) {
+	*d.opts = opts
+	return d.result, nil
+}
+
 func TestPipeline_TierBreakdownMapInitialized(t *testing.T) {
 	t.Parallel()
 
@@ -1048,3 +1222,433 @@ func TestPipeline_TierBreakdownMapInitialized(t *testing.T) {
 	assert.Empty(t, result.Stats.TierBreakdown,
 		"TierBreakdown should be empty when no files are processed")
 }
+
+func TestPipeline_SortOrderWithinTiers(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	newDisc := func() *DiscoveryResult {
+		return &DiscoveryResult{
+			Files: []FileDescriptor{
+				{Path: "a.go", Content: strings.Repeat("a", 30), Size: 100, ModTime: now.Add(-3 * time.Hour)},
+				{Path: "b.go", Content: strings.Repeat("b", 10), Size: 300, ModTime: now.Add(-1 * time.Hour)},
+				{Path: "c.go", Content: strings.Repeat("c", 20), Size: 200, ModTime: now.Add(-2 * time.Hour)},
+				{Path: "z.md", Content: "z", Size: 900, ModTime: now},
+			},
+		}
+	}
+	// Source files share tier 1; the doc is tier 2 and must stay last
+	// regardless of the sort order.
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) {
+		fd.Tier = 1
+		if strings.HasSuffix(fd.Path, ".md") {
+			fd.Tier = 2
+		}
+	}}
+
+	tests := []struct {
+		order SortOrder
+		want  []string
+	}{
+		{order: "", want: []string{"a.go", "b.go", "c.go", "z.md"}},
+		{order: SortOrderPath, want: []string{"a.go", "b.go", "c.go", "z.md"}},
+		{order: SortOrderTokensDesc, want: []string{"a.go", "c.go", "b.go", "z.md"}},
+		{order: SortOrderTokens, want: []string{"a.go", "c.go", "b.go", "z.md"}},
+		{order: SortOrderTokensAsc, want: []string{"b.go", "c.go", "a.go", "z.md"}},
+		{order: SortOrderMTime, want: []string{"b.go", "c.go", "a.go", "z.md"}},
+		{order: SortOrderSize, want: []string{"b.go", "c.go", "a.go", "z.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			t.Parallel()
+
+			var budgetOrder []string
+			budget := &mockBudget{enforceFn: func(files []*FileDescriptor, _ int) (*BudgetResult, error) {
+				for _, fd := range files {
+					budgetOrder = append(budgetOrder, fd.Path)
+				}
+				return &BudgetResult{Included: files}, nil
+			}}
+
+			p := NewPipeline(
+				WithDiscovery(&mockDiscovery{result: newDisc()}),
+				WithRelevance(relevance),
+				WithTokenizer(&mockTokenizer{}),
+				WithBudget(budget),
+			)
+
+			result, err := p.Run(context.Background(), RunOptions{Dir: "/project", SortOrder: tt.order})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, budgetOrder, "budget enforcement must see files in sort order")
+
+			paths := make([]string, len(result.Files))
+			for i, f := range result.Files {
+				paths[i] = f.Path
+			}
+			assert.Equal(t, tt.want, paths)
+		})
+	}
+}
+
+func TestPipeline_PathOverrides(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "examples/demo.go", Content: "key := SECRET"},
+			{Path: "internal/app.go", Content: "key := SECRET"},
+			{Path: "migrations/001.sql", Content: "CREATE TABLE t;"},
+		},
+	}
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) { fd.Tier = 3 }}
+
+	tier0, noRedact := 0, false
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithRelevance(relevance),
+		WithRedactor(&mockRedactor{}),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir: "/project",
+		Overrides: []config.PathOverride{
+			{Match: "examples/**", Tier: &tier0, Redact: &noRedact},
+			{Match: "migrations/**", Priority: true},
+		},
+	})
+	require.NoError(t, err)
+
+	byPath := make(map[string]FileDescriptor, len(result.Files))
+	paths := make([]string, len(result.Files))
+	for i, f := range result.Files {
+		byPath[f.Path] = f
+		paths[i] = f.Path
+	}
+
+	assert.Equal(t, 0, byPath["examples/demo.go"].Tier, "override must force tier 0")
+	assert.Equal(t, "key := SECRET", byPath["examples/demo.go"].Content, "override must disable redaction")
+	assert.Zero(t, byPath["examples/demo.go"].Redactions)
+
+	assert.Equal(t, 3, byPath["internal/app.go"].Tier)
+	assert.Equal(t, "key := [REDACTED]", byPath["internal/app.go"].Content)
+
+	assert.Equal(t, []string{"migrations/001.sql", "examples/demo.go", "internal/app.go"}, paths,
+		"priority files come first, then tier order")
+}
+
+func TestPipeline_Focus(t *testing.T) {
+	t.Parallel()
+
+	newDisc := func() *DiscoveryResult {
+		return &DiscoveryResult{
+			Files: []FileDescriptor{
+				{Path: "go.mod", Content: "module x"},
+				{Path: "cmd/main.go", Content: "package main"},
+				{Path: "internal/auth/token.go", Content: "package auth"},
+				{Path: "internal/auth/token_test.go", Content: "package auth"},
+				{Path: "docs/auth/README.md", Content: "# Auth"},
+			},
+		}
+	}
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) {
+		switch {
+		case fd.Path == "go.mod":
+			fd.Tier = 0
+		case strings.HasSuffix(fd.Path, "_test.go"):
+			fd.Tier = 3
+		case strings.HasSuffix(fd.Path, ".md"):
+			fd.Tier = 4
+		default:
+			fd.Tier = 1
+		}
+	}}
+
+	run := func(opts RunOptions) []FileDescriptor {
+		p := NewPipeline(
+			WithDiscovery(&mockDiscovery{result: newDisc()}),
+			WithRelevance(relevance),
+		)
+		opts.Dir = "/project"
+		result, err := p.Run(context.Background(), opts)
+		require.NoError(t, err)
+		return result.Files
+	}
+	pathsOf := func(files []FileDescriptor) []string {
+		paths := make([]string, len(files))
+		for i, f := range files {
+			paths[i] = f.Path
+		}
+		return paths
+	}
+
+	tier2 := 2
+	files := run(RunOptions{
+		Focus:     []string{"**/auth/**"},
+		Overrides: []config.PathOverride{{Match: "docs/**", Tier: &tier2}},
+	})
+	assert.Equal(t, []string{
+		"docs/auth/README.md",
+		"internal/auth/token.go",
+		"internal/auth/token_test.go",
+		"go.mod",
+		"cmd/main.go",
+	}, pathsOf(files), "focused files come first regardless of their tier")
+	for _, f := range files[:3] {
+		assert.Equal(t, 0, f.Tier, "%s must be promoted to tier 0", f.Path)
+	}
+	assert.Equal(t, 1, files[4].Tier, "unfocused files keep their tier")
+
+	assert.Equal(t, []string{
+		"go.mod",
+		"cmd/main.go",
+		"internal/auth/token.go",
+		"internal/auth/token_test.go",
+		"docs/auth/README.md",
+	}, pathsOf(run(RunOptions{})), "without focus files follow tier order")
+
+	assert.Equal(t, "go.mod", pathsOf(run(RunOptions{
+		Focus:         []string{"**/auth/**"},
+		PriorityFiles: []string{"go.mod"},
+	}))[0], "priority files still come before focused files")
+}
+
+func TestPipeline_PriorityFilesGlobExpansion(t *testing.T) {
+	t.Parallel()
+
+	newDisc := func() *DiscoveryResult {
+		return &DiscoveryResult{
+			Files: []FileDescriptor{
+				{Path: "cmd/main.go", Content: "package main"},
+				{Path: "docs/b.md", Content: "# B"},
+				{Path: "docs/a.md", Content: "# A"},
+				{Path: "go.mod", Content: "module x"},
+			},
+		}
+	}
+	relevance := &mockRelevance{tierFn: func(fd *FileDescriptor) {
+		fd.Tier = 1
+		if strings.HasSuffix(fd.Path, ".md") {
+			fd.Tier = 4
+		}
+	}}
+
+	run := func(globs bool) []string {
+		p := NewPipeline(
+			WithDiscovery(&mockDiscovery{result: newDisc()}),
+			WithRelevance(relevance),
+		)
+		result, err := p.Run(context.Background(), RunOptions{
+			Dir:           "/project",
+			PriorityFiles: []string{"go.mod", "docs/*.md"},
+			PriorityGlobs: globs,
+		})
+		require.NoError(t, err)
+
+		paths := make([]string, len(result.Files))
+		for i, f := range result.Files {
+			paths[i] = f.Path
+		}
+		return paths
+	}
+
+	assert.Equal(t, []string{"go.mod", "docs/a.md", "docs/b.md", "cmd/main.go"}, run(true),
+		"expanded priority files come first, in list order")
+	assert.Equal(t, []string{"go.mod", "cmd/main.go", "docs/a.md", "docs/b.md"}, run(false),
+		"without priority_globs the pattern is a literal path and matches nothing")
+}
+
+func TestPipeline_PriorityFilesRelativeToBaseDir(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "pkg/api/handler.go", Content: "package api"},
+			{Path: "pkg/api/main.go", Content: "package main"},
+		},
+	}
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithRelevance(&mockRelevance{tierFn: func(fd *FileDescriptor) { fd.Tier = 1 }}),
+	)
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir:           "/project",
+		BaseDir:       "pkg/api",
+		PriorityFiles: []string{"main.go"},
+	})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Stats.MissingPriorityFiles)
+	require.Len(t, result.Files, 2)
+	assert.Equal(t, "pkg/api/main.go", result.Files[0].Path)
+}
+
+func TestPipeline_MissingPriorityFiles(t *testing.T) {
+	t.Parallel()
+
+	run := func(priority ...string) *RunResult {
+		disc := &DiscoveryResult{
+			Files: []FileDescriptor{
+				{Path: "README.md", Content: "# Readme"},
+				{Path: "main.go", Content: "package main"},
+			},
+		}
+		p := NewPipeline(WithDiscovery(&mockDiscovery{result: disc}))
+		result, err := p.Run(context.Background(), RunOptions{
+			Dir:           "/project",
+			PriorityFiles: priority,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	missing := run("CLAUDE.md", "README.md")
+	assert.Equal(t, []string{"CLAUDE.md"}, missing.Stats.MissingPriorityFiles)
+	assert.Equal(t, []string{"CLAUDE.md"}, BuildPreviewResult(missing, "default", 0).MissingPriorityFiles)
+	assert.Len(t, missing.Files, 2, "a missing priority file does not fail the run")
+
+	disc := &DiscoveryResult{Files: []FileDescriptor{{Path: "CLAUDE.md", Content: "# Claude"}}}
+	p := NewPipeline(WithDiscovery(&mockDiscovery{result: disc}))
+	result, err := p.Run(context.Background(), RunOptions{Dir: "/project", PriorityFiles: []string{"CLAUDE.md"}})
+	require.NoError(t, err)
+	assert.Empty(t, result.Stats.MissingPriorityFiles, "an existing pinned CLAUDE.md is not reported")
+}
+
+func TestPipeline_StubVendored(t *testing.T) {
+	t.Parallel()
+
+	vendored := "package y\n\nfunc Y() {}\n"
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "internal/app.go", Content: "package app\n"},
+			{Path: "vendor/github.com/x/y/y.go", Content: vendored},
+			{Path: "web/third_party/lib.js", Content: ""},
+		},
+	}
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithTokenizer(&mockTokenizer{}),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{Dir: "/project", StubVendored: true})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 3, "stubbed files stay in the output")
+
+	byPath := make(map[string]FileDescriptor, len(result.Files))
+	for _, f := range result.Files {
+		byPath[f.Path] = f
+	}
+
+	stub := VendoredStub("vendor/github.com/x/y/y.go", 3, len(vendored))
+	assert.Equal(t, "// vendored: vendor/github.com/x/y/y.go (3 lines, 23 tokens) omitted", stub)
+	assert.Equal(t, stub, byPath["vendor/github.com/x/y/y.go"].Content)
+	assert.Equal(t, len(stub), byPath["vendor/github.com/x/y/y.go"].TokenCount,
+		"vendored file must be counted at the stub size")
+
+	assert.Equal(t, "package app\n", byPath["internal/app.go"].Content, "source files are untouched")
+	assert.Equal(t, len("package app\n"), byPath["internal/app.go"].TokenCount)
+	assert.Empty(t, byPath["web/third_party/lib.js"].Content, "empty files are not stubbed")
+	assert.Equal(t, len(stub)+len("package app\n"), result.Stats.TotalTokens)
+}
+
+func TestPipeline_StubVendoredDisabled(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{{Path: "vendor/x.go", Content: "package x\n"}},
+	}
+	p := NewPipeline(WithDiscovery(&mockDiscovery{result: disc}))
+
+	result, err := p.Run(context.Background(), RunOptions{Dir: "/project"})
+	require.NoError(t, err)
+	require.Len(t, result.Files, 1)
+	assert.Equal(t, "package x\n", result.Files[0].Content)
+}
+
+func TestPipeline_TierFileCaps(t *testing.T) {
+	t.Parallel()
+
+	disc := &DiscoveryResult{
+		Files: []FileDescriptor{
+			{Path: "a_test.go", Content: "package a"},
+			{Path: "b_test.go", Content: "package b"},
+			{Path: "c_test.go", Content: "package c"},
+			{Path: "d_test.go", Content: "package d"},
+			{Path: "main.go", Content: "package main"},
+			{Path: "util.go", Content: "package main"},
+			{Path: "lib.go", Content: "package main"},
+		},
+	}
+	tierByName := func(fd *FileDescriptor) {
+		fd.Tier = 1
+		if strings.HasSuffix(fd.Path, "_test.go") {
+			fd.Tier = 3
+		}
+	}
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: disc}),
+		WithRelevance(&mockRelevance{tierFn: tierByName}),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{
+		Dir:           "/project",
+		TierFileCaps:  map[int]int{3: 2, 1: 0},
+		PriorityFiles: []string{"d_test.go"},
+	})
+	require.NoError(t, err)
+
+	var tier1, tier3 []string
+	for _, fd := range result.Files {
+		switch fd.Tier {
+		case 1:
+			tier1 = append(tier1, fd.Path)
+		case 3:
+			tier3 = append(tier3, fd.Path)
+		}
+	}
+	assert.ElementsMatch(t, []string{"d_test.go", "a_test.go"}, tier3,
+		"a cap of 2 keeps the prioritized test file and the first classified one")
+	assert.ElementsMatch(t, []string{"main.go", "util.go", "lib.go"}, tier1, "uncapped tiers are unaffected")
+	assert.Equal(t, 2, result.Stats.TierFileCapped)
+	assert.Equal(t, 2, BuildPreviewResult(result, "default", 0).TierFileCapped)
+}
+
+func TestPipeline_ExcludeBinary(t *testing.T) {
+	t.Parallel()
+
+	newDisc := func() *DiscoveryResult {
+		return &DiscoveryResult{
+			Files: []FileDescriptor{
+				{Path: "main.go", Content: "package main\n"},
+				{Path: "logo.png", Content: "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"},
+				{Path: "flagged.bin", IsBinary: true},
+				{Path: "notes.txt", Content: "naïve café\n"},
+			},
+		}
+	}
+	var classified []string
+	p := NewPipeline(
+		WithDiscovery(&mockDiscovery{result: newDisc()}),
+		WithRelevance(&mockRelevance{tierFn: func(fd *FileDescriptor) {
+			classified = append(classified, fd.Path)
+		}}),
+	)
+
+	result, err := p.Run(context.Background(), RunOptions{Dir: "/project", ExcludeBinary: true})
+	require.NoError(t, err)
+
+	var paths []string
+	for _, fd := range result.Files {
+		paths = append(paths, fd.Path)
+	}
+	assert.ElementsMatch(t, []string{"main.go", "notes.txt"}, paths)
+	assert.ElementsMatch(t, []string{"main.go", "notes.txt"}, classified, "binary files are dropped before tiering")
+	assert.Equal(t, []string{"logo.png", "flagged.bin"}, result.Stats.BinaryFiles)
+	assert.Equal(t, result.Stats.BinaryFiles, BuildPreviewResult(result, "default", 0).BinaryFiles)
+
+	p = NewPipeline(WithDiscovery(&mockDiscovery{result: newDisc()}))
+	result, err = p.Run(context.Background(), RunOptions{Dir: "/project"})
+	require.NoError(t, err)
+	assert.Len(t, result.Files, 4, "binary files are kept when ExcludeBinary is off")
+	assert.Empty(t, result.Stats.BinaryFiles)
+}
@@ -7,6 +7,11 @@
 // It contains only data types and lightweight validation helpers; no business logic.
 package pipeline
 
+import (
+	"io"
+	"time"
+)
+
 // ExitCode represents the process exit code returned by the harvx CLI.
 type ExitCode int
 
@@ -53,6 +58,36 @@ const (
 	TargetGeneric LLMTarget = "generic"
 )
 
+// SortOrder controls how files are ordered within a relevance tier. Ordering
+// is intra-tier only: tier priority always dominates, so a tier 0 file comes
+// before every tier 1 file regardless of SortOrder.
+type SortOrder string
+
+const (
+	// SortOrderPath orders files alphabetically by Path. It is the default
+	// when no sort order is configured.
+	SortOrderPath SortOrder = "path"
+
+	// SortOrderTokensDesc orders the largest files (by TokenCount) first.
+	SortOrderTokensDesc SortOrder = "tokens-desc"
+
+	// SortOrderTokens is shorthand for SortOrderTokensDesc.
+	SortOrderTokens SortOrder = "tokens"
+
+	// SortOrderTokensAsc orders the smallest files (by TokenCount) first,
+	// which lets more files fit under a token budget.
+	SortOrderTokensAsc SortOrder = "tokens-asc"
+
+	// SortOrderMTime orders the most recently modified files (by ModTime)
+	// first.
+	SortOrderMTime SortOrder = "mtime"
+
+	// SortOrderSize orders the largest files on disk (by Size) first. Unlike
+	// the token orders it does not depend on the tokenizer or on redaction
+	// and compression having run.
+	SortOrderSize SortOrder = "size"
+)
+
 // DefaultTier is the relevance tier assigned to files that do not match any
 // explicit tier pattern. Per the PRD (Section 5.3), unmatched files default to
 // tier 2 (source code) to avoid excluding unexpected but important files.
@@ -62,7 +97,7 @@ const DefaultTier = 2
 // stage enriches or mutates the descriptor as the file flows through the
 // pipeline:
 //
-//   - Discovery: sets Path, AbsPath, Size, IsSymlink, IsBinary
+//   - Discovery: sets Path, AbsPath, Size, ModTime, IsSymlink, IsBinary
 //   - Relevance: sets Tier
 //   - Content loading: sets Content, ContentHash, Language
 //   - Security: updates Content (redacted), sets Redactions count
@@ -83,6 +118,10 @@ type FileDescriptor struct {
 	// Size is the file size in bytes as reported by the filesystem.
 	Size int64 `json:"size"`
 
+	// ModTime is the file's modification time as reported by the filesystem.
+	// Used by SortOrderMTime.
+	ModTime time.Time `json:"mod_time"`
+
 	// Tier is the relevance tier (0-5). Lower tiers are higher priority and
 	// included first when enforcing token budgets. Defaults to DefaultTier (2)
 	// for unmatched files.
@@ -101,10 +140,21 @@ type FileDescriptor struct {
 	// tree-sitter compression. The original content is never stored.
 	Content string `json:"content"`
 
+	// ContentReader optionally streams the processed content on demand
+	// instead of holding it in Content. When Content is empty and
+	// ContentReader is set, consumers such as the budget enforcer count tokens
+	// by streaming and only materialize Content for files that are actually
+	// emitted. Each call must return a fresh reader positioned at the start.
+	ContentReader func() (io.ReadCloser, error) `json:"-"`
+
 	// IsCompressed indicates whether tree-sitter compression was applied to
 	// this file's content.
 	IsCompressed bool `json:"is_compressed"`
 
+	// IsTruncated indicates whether the budget enforcer shortened Content to
+	// fit the remaining token budget.
+	IsTruncated bool `json:"is_truncated,omitempty"`
+
 	// Redactions is the number of secrets that were redacted from this file's
 	// content during the security scanning stage.
 	Redactions int `json:"redactions"`
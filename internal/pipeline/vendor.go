@@ -0,0 +1,44 @@
+package pipeline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harvx/harvx/internal/config"
+)
+
+// VendoredStub returns the one-line stub that replaces the content of a
+// vendored file when RunOptions.StubVendored is set.
+func VendoredStub(path string, lines, tokens int) string {
+	return fmt.Sprintf("// vendored: %s (%d lines, %d tokens) omitted", path, lines, tokens)
+}
+
+// stubVendoredFiles replaces the content of every file under one of dirs (see
+// config.IsVendoredPath) with its VendoredStub and returns the number of files
+// stubbed. Token counts in the stub come from tok, or from the len/4
+// character estimate when tok is nil. Empty files are left alone.
+func stubVendoredFiles(files []*FileDescriptor, dirs []string, tok TokenizerService) int {
+	stubbed := 0
+	for _, fd := range files {
+		if fd.Content == "" || !config.IsVendoredPath(dirs, fd.Path) {
+			continue
+		}
+		tokens := len(fd.Content) / 4
+		if tok != nil {
+			tokens = tok.Count(fd.Content)
+		}
+		fd.Content = VendoredStub(fd.Path, countLines(fd.Content), tokens)
+		stubbed++
+	}
+	return stubbed
+}
+
+// countLines returns the number of lines in content, counting a final line
+// without a trailing newline.
+func countLines(content string) int {
+	n := strings.Count(content, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		n++
+	}
+	return n
+}
@@ -3,6 +3,7 @@
 package relevance
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
@@ -16,12 +17,54 @@ import (
 // would claim the file, helping users debug profile configuration conflicts.
 type PatternMatch struct {
 	// Tier is the tier number that contains the matching pattern.
-	Tier int
+	Tier int `json:"tier"`
 
 	// Pattern is the glob pattern that matched the file.
-	Pattern string
+	Pattern string `json:"pattern"`
 }
 
+// sortPatternMatches sorts matches by ascending tier, then lexicographically
+// by pattern, for deterministic output.
+func sortPatternMatches(matches []PatternMatch) {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Tier != matches[j].Tier {
+			return matches[i].Tier < matches[j].Tier
+		}
+		return matches[i].Pattern < matches[j].Pattern
+	})
+}
+
+// ExplainEntry is the compact explanation of one file produced by
+// ExplainRepoJSON.
+type ExplainEntry struct {
+	// Path is the file path as supplied by the caller.
+	Path string `json:"path"`
+
+	// Tier is the assigned tier number.
+	Tier int `json:"tier"`
+
+	// Pattern is the pattern that assigned Tier. Empty when Default is true.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Default is true when no pattern matched and the file was assigned
+	// DefaultUnmatchedTier.
+	Default bool `json:"default,omitempty"`
+
+	// Matches lists every matching pattern, ordered like
+	// ExplainResult.AllMatches. It is an empty array when nothing matched.
+	Matches []PatternMatch `json:"matches"`
+}
+
+// Exclusion reasons recorded in ExplainResult.ExclusionReason.
+const (
+	// ExclusionReasonIgnored marks a file removed during discovery by an
+	// ignore rule or filter; IgnoredBy names the rule.
+	ExclusionReasonIgnored = "ignored"
+
+	// ExclusionReasonOverBudget marks a file dropped by budget enforcement.
+	ExclusionReasonOverBudget = "over_budget"
+)
+
 // ExplainResult holds the detailed tier-matching explanation for a single file.
 // The caller is responsible for enriching WouldBeIncluded and ExclusionReason
 // after budget enforcement (option 2 from the T-032 spec).
@@ -46,6 +89,11 @@ type ExplainResult struct {
 	// DefaultUnmatchedTier (tier 2).
 	IsDefault bool
 
+	// TierLabel is the Label of the TierDefinition for AssignedTier. Empty
+	// when that definition has no label, in which case FormatExplain uses the
+	// built-in name.
+	TierLabel string
+
 	// AllMatches contains every pattern across every tier that matched this
 	// file, sorted by ascending tier number then lexicographically by pattern.
 	// Useful for diagnosing overlapping rules.
@@ -57,10 +105,17 @@ type ExplainResult struct {
 	WouldBeIncluded bool
 
 	// ExclusionReason is a short machine-readable string describing why the
-	// file was excluded (e.g. "budget_exceeded", "filtered_by_ignore"). Empty
-	// when the file is included or when the budget context has not been applied.
+	// file was excluded, such as ExclusionReasonIgnored or
+	// ExclusionReasonOverBudget. Empty when the file is included or when the
+	// budget context has not been applied.
 	ExclusionReason string
 
+	// Ignored is set by the caller when the file never reached classification
+	// because discovery removed it. IgnoredBy then names the rule, e.g.
+	// ".gitignore" or "default ignore patterns".
+	Ignored   bool
+	IgnoredBy string
+
 	// TokenCount is the number of tokens counted for this file. Populated by
 	// the caller alongside WouldBeIncluded when budget context is available.
 	TokenCount int
@@ -130,19 +185,54 @@ func Explain(filePath string, tiers []TierDefinition) *ExplainResult {
 		result.AssignedTier = int(DefaultUnmatchedTier)
 		result.MatchedPattern = ""
 	}
+	result.TierLabel = definedLabel(result.AssignedTier, sorted)
 
-	// Sort AllMatches: ascending tier, then lexicographic pattern for determinism.
-	sort.Slice(allMatches, func(i, j int) bool {
-		if allMatches[i].Tier != allMatches[j].Tier {
-			return allMatches[i].Tier < allMatches[j].Tier
-		}
-		return allMatches[i].Pattern < allMatches[j].Pattern
-	})
+	sortPatternMatches(allMatches)
 
 	result.AllMatches = allMatches
 	return result
 }
 
+// ExplainRepoJSON explains the classification of every file in files against
+// defs and returns the explanations as a JSON array of ExplainEntry, sorted by
+// tier and then by path. It is the batch form of Explain for tools such as IDE
+// panels: a single TierMatcher is built for all files instead of re-sorting
+// and re-validating defs per file. Tier, Pattern, and Matches agree with the
+// AssignedTier, MatchedPattern, and AllMatches that Explain reports.
+func ExplainRepoJSON(files []string, defs []TierDefinition) ([]byte, error) {
+	matcher := NewTierMatcher(defs)
+
+	entries := make([]ExplainEntry, 0, len(files))
+	for _, f := range files {
+		matches := matcher.matchesInOrder(f)
+		entry := ExplainEntry{Path: f, Tier: int(DefaultUnmatchedTier), Default: true}
+		if len(matches) > 0 {
+			entry.Tier = matches[0].Tier
+			entry.Pattern = matches[0].Pattern
+			entry.Default = false
+		}
+		sortPatternMatches(matches)
+		entry.Matches = matches
+		if entry.Matches == nil {
+			entry.Matches = []PatternMatch{}
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Tier != entries[j].Tier {
+			return entries[i].Tier < entries[j].Tier
+		}
+		return entries[i].Path < entries[j].Path
+	})
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling explain entries: %w", err)
+	}
+	return data, nil
+}
+
 // TierLabel returns a short human-readable label for a tier number.
 //
 // Default mappings:
@@ -174,10 +264,34 @@ func TierLabel(tier int) string {
 	}
 }
 
+// definedLabel returns the Label of the first definition in defs for tier,
+// or "" when no such definition has a label.
+func definedLabel(tier int, defs []TierDefinition) string {
+	for _, def := range defs {
+		if int(def.Tier) == tier && def.Label != "" {
+			return def.Label
+		}
+	}
+	return ""
+}
+
+// summaryTierLabel returns the label for tier in inclusion summaries: the
+// definition's label from defs when set, otherwise TierLabel.
+func summaryTierLabel(tier int, defs []TierDefinition) string {
+	if label := definedLabel(tier, defs); label != "" {
+		return label
+	}
+	return TierLabel(tier)
+}
+
 // tierDisplayLabel returns the label used in FormatExplain's "Tier:" line.
-// Tier 1 uses the slightly longer "Source Code" form to match the spec example;
-// all other tiers use TierLabel.
-func tierDisplayLabel(tier int) string {
+// A label from the tier definition wins. Otherwise tier 1 uses the slightly
+// longer "Source Code" form to match the spec example and all other tiers use
+// TierLabel.
+func tierDisplayLabel(tier int, label string) string {
+	if label != "" {
+		return label
+	}
 	if tier == 1 {
 		return "Source Code"
 	}
@@ -205,7 +319,7 @@ func FormatExplain(result *ExplainResult) string {
 	var b strings.Builder
 
 	fmt.Fprintf(&b, "File: %s\n", result.FilePath)
-	fmt.Fprintf(&b, "Tier: %d (%s)\n", result.AssignedTier, tierDisplayLabel(result.AssignedTier))
+	fmt.Fprintf(&b, "Tier: %d (%s)\n", result.AssignedTier, tierDisplayLabel(result.AssignedTier, result.TierLabel))
 
 	if result.IsDefault {
 		fmt.Fprintf(&b, "Matched Pattern: (default, unmatched)\n")
@@ -243,9 +357,12 @@ func FormatExplain(result *ExplainResult) string {
 // Total line omits the budget fraction. Otherwise the Total line shows tokens
 // used, budget capacity, and percentage consumed.
 //
+// The Tokenizer line is printed only when result.TokenizerName is set.
+//
 // Example output:
 //
 //	Files: 342 included, 48 excluded
+//	Tokenizer: o200k_base
 //
 //	By Tier:
 //	  Tier 0 (Config):      5 files,   2,100 tokens
@@ -257,16 +374,158 @@ func FormatExplain(result *ExplainResult) string {
 //
 //	Total: 89,420 tokens / 200,000 budget (45%)
 func GenerateInclusionSummary(result *tokenizer.BudgetResult) string {
-	totalIncluded := len(result.IncludedFiles)
-	totalExcluded := len(result.ExcludedFiles)
+	return GenerateInclusionSummaryForModel(result, "")
+}
+
+// GenerateInclusionSummaryForModel renders the same summary as
+// GenerateInclusionSummary and, when model has a known price, appends an
+// estimated input cost line after the Total line:
+//
+//	Estimated cost: ~$0.27 input (claude-sonnet-4)
+//
+// Unknown or empty model names produce output identical to
+// GenerateInclusionSummary.
+func GenerateInclusionSummaryForModel(result *tokenizer.BudgetResult, model string) string {
+	return GenerateInclusionSummaryForTiers(result, model, nil)
+}
+
+// GenerateInclusionSummaryForTiers renders the same summary as
+// GenerateInclusionSummaryForModel but names each tier after the Label of its
+// definition in defs, so profiles that redefine tiers get meaningful names.
+// Tiers without a labelled definition keep their TierLabel names, and a nil
+// defs produces output identical to GenerateInclusionSummaryForModel.
+func GenerateInclusionSummaryForTiers(result *tokenizer.BudgetResult, model string, defs []TierDefinition) string {
+	s := BuildInclusionSummaryForTiers(result, defs)
 
 	var b strings.Builder
 
 	fmt.Fprintf(&b, "Files: %s included, %s excluded\n",
-		formatInt(totalIncluded), formatInt(totalExcluded))
+		formatInt(s.FilesIncluded), formatInt(s.FilesExcluded))
+	if s.Tokenizer != "" {
+		fmt.Fprintf(&b, "Tokenizer: %s\n", s.Tokenizer)
+	}
 
 	b.WriteString("\nBy Tier:\n")
 
+	// Compute max label width for alignment.
+	maxLabelWidth := 0
+	for _, ts := range s.Tiers {
+		label := fmt.Sprintf("Tier %d (%s)", ts.Tier, ts.Label)
+		if len(label) > maxLabelWidth {
+			maxLabelWidth = len(label)
+		}
+	}
+
+	for _, ts := range s.Tiers {
+		label := fmt.Sprintf("Tier %d (%s)", ts.Tier, ts.Label)
+		padding := strings.Repeat(" ", maxLabelWidth-len(label))
+
+		if ts.FilesExcluded > 0 {
+			fmt.Fprintf(&b, "  %s:%s  %s files,  %s tokens (%s excluded by budget)\n",
+				label, padding,
+				formatInt(ts.FilesIncluded),
+				formatInt(ts.TokensUsed),
+				formatInt(ts.FilesExcluded),
+			)
+		} else {
+			fmt.Fprintf(&b, "  %s:%s  %s files,  %s tokens\n",
+				label, padding,
+				formatInt(ts.FilesIncluded),
+				formatInt(ts.TokensUsed),
+			)
+		}
+	}
+
+	b.WriteString("\n")
+	if s.HasBudget {
+		fmt.Fprintf(&b, "Total: %s tokens / %s budget (%d%%)\n",
+			formatInt(s.TotalTokens),
+			formatInt(s.Budget),
+			s.BudgetPercent,
+		)
+	} else {
+		fmt.Fprintf(&b, "Total: %s tokens (no budget)\n",
+			formatInt(s.TotalTokens),
+		)
+	}
+
+	if cost, ok := tokenizer.EstimateCostUSD(s.TotalTokens, model); ok {
+		fmt.Fprintf(&b, "Estimated cost: ~$%.2f input (%s)\n", cost, model)
+	}
+
+	return b.String()
+}
+
+// InclusionSummary is the structured form of the inclusion summary that
+// GenerateInclusionSummary renders as text, for dashboards and other tools
+// that consume the numbers directly. Build it with BuildInclusionSummary.
+type InclusionSummary struct {
+	// FilesIncluded is the number of files included, fully or truncated.
+	FilesIncluded int `json:"files_included"`
+
+	// FilesExcluded is the number of files dropped by the budget.
+	FilesExcluded int `json:"files_excluded"`
+
+	// Tokenizer is the tokenizer used for counting. Empty when unknown.
+	Tokenizer string `json:"tokenizer,omitempty"`
+
+	// Tiers lists per-tier statistics in ascending tier order. Tiers whose
+	// files were all excluded are listed too.
+	Tiers []InclusionTier `json:"tiers"`
+
+	// TotalTokens is the sum of tokens across included files.
+	TotalTokens int `json:"total_tokens"`
+
+	// HasBudget reports that a token budget was enforced. Budget and
+	// BudgetPercent are zero when it is false.
+	HasBudget bool `json:"has_budget"`
+
+	// Budget is the token budget capacity (BudgetUsed + BudgetRemaining).
+	Budget int `json:"budget"`
+
+	// BudgetPercent is TotalTokens as a whole percentage of Budget, rounded
+	// down.
+	BudgetPercent int `json:"budget_percent"`
+}
+
+// InclusionTier holds the inclusion statistics for one tier of an
+// InclusionSummary.
+type InclusionTier struct {
+	// Tier is the tier number.
+	Tier int `json:"tier"`
+
+	// Label is the tier's display name, e.g. "Config" or a profile label.
+	Label string `json:"label"`
+
+	// FilesIncluded is the number of files included from this tier.
+	FilesIncluded int `json:"files_included"`
+
+	// FilesExcluded is the number of files from this tier dropped by the
+	// budget.
+	FilesExcluded int `json:"files_excluded"`
+
+	// TokensUsed is the sum of tokens across the tier's included files.
+	TokensUsed int `json:"tokens_used"`
+}
+
+// BuildInclusionSummary returns the structured inclusion summary of result,
+// with the numbers GenerateInclusionSummary renders. Tiers carry their
+// TierLabel names.
+func BuildInclusionSummary(result *tokenizer.BudgetResult) InclusionSummary {
+	return BuildInclusionSummaryForTiers(result, nil)
+}
+
+// BuildInclusionSummaryForTiers is like BuildInclusionSummary but labels each
+// tier after its definition in defs, as GenerateInclusionSummaryForTiers does.
+func BuildInclusionSummaryForTiers(result *tokenizer.BudgetResult, defs []TierDefinition) InclusionSummary {
+	s := InclusionSummary{
+		FilesIncluded: len(result.IncludedFiles),
+		FilesExcluded: len(result.ExcludedFiles),
+		Tokenizer:     result.TokenizerName,
+		Tiers:         []InclusionTier{},
+		TotalTokens:   result.TotalTokens,
+	}
+
 	// Collect all unique tier keys from both included (TierStats) and excluded
 	// files so that tiers with only excluded files also appear in the table.
 	tierKeys := result.Summary.SortedTierKeys()
@@ -288,61 +547,85 @@ func GenerateInclusionSummary(result *tokenizer.BudgetResult) string {
 	}
 	sort.Ints(tierKeys)
 
-	// Compute max label width for alignment.
-	maxLabelWidth := 0
 	for _, tier := range tierKeys {
-		label := fmt.Sprintf("Tier %d (%s)", tier, TierLabel(tier))
-		if len(label) > maxLabelWidth {
-			maxLabelWidth = len(label)
+		stat := result.Summary.TierStats[tier]
+		s.Tiers = append(s.Tiers, InclusionTier{
+			Tier:          tier,
+			Label:         summaryTierLabel(tier, defs),
+			FilesIncluded: stat.FilesIncluded,
+			FilesExcluded: stat.FilesExcluded,
+			TokensUsed:    stat.TokensUsed,
+		})
+	}
+
+	// Budget enforcement was active when there was an active budget,
+	// indicated by BudgetUsed > 0 or excluded files.
+	s.HasBudget = result.BudgetUsed > 0 || s.FilesExcluded > 0
+	if s.HasBudget {
+		s.Budget = result.BudgetUsed + result.BudgetRemaining
+		if s.Budget > 0 {
+			s.BudgetPercent = (result.TotalTokens * 100) / s.Budget
 		}
 	}
 
-	for _, tier := range tierKeys {
-		stat := result.Summary.TierStats[tier]
-		label := fmt.Sprintf("Tier %d (%s)", tier, TierLabel(tier))
-		padding := strings.Repeat(" ", maxLabelWidth-len(label))
+	return s
+}
 
-		filesCount := stat.FilesIncluded
-		tokensUsed := stat.TokensUsed
-		excluded := stat.FilesExcluded
+// InclusionSummaryJSON returns BuildInclusionSummaryForTiers(result, defs)
+// encoded as indented JSON.
+func InclusionSummaryJSON(result *tokenizer.BudgetResult, defs []TierDefinition) ([]byte, error) {
+	data, err := json.MarshalIndent(BuildInclusionSummaryForTiers(result, defs), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling inclusion summary: %w", err)
+	}
+	return data, nil
+}
 
-		if excluded > 0 {
-			fmt.Fprintf(&b, "  %s:%s  %s files,  %s tokens (%s excluded by budget)\n",
-				label, padding,
-				formatInt(filesCount),
-				formatInt(tokensUsed),
-				formatInt(excluded),
-			)
-		} else {
-			fmt.Fprintf(&b, "  %s:%s  %s files,  %s tokens\n",
-				label, padding,
-				formatInt(filesCount),
-				formatInt(tokensUsed),
-			)
-		}
+// FormatTierDistribution renders a TierDistribution as a human-readable
+// report listing each tier in ascending order with its file count and share
+// of all files, followed by a note on where unmatched files go.
+//
+// Example output:
+//
+//	Files by Tier (352 files):
+//	  Tier 0 (Config):   12 files    3.4%
+//	  Tier 1 (Source):  340 files   96.6%
+//
+//	Unmatched files are assigned to Tier 2 (Secondary).
+func FormatTierDistribution(dist map[Tier]int) string {
+	tiers := make([]int, 0, len(dist))
+	total := 0
+	for tier, n := range dist {
+		tiers = append(tiers, int(tier))
+		total += n
 	}
+	sort.Ints(tiers)
 
-	// Budget line: only shown when budget enforcement was active (i.e. there
-	// was an active budget, indicated by BudgetUsed > 0 or excluded files).
-	hasBudget := result.BudgetUsed > 0 || totalExcluded > 0
-	b.WriteString("\n")
-	if hasBudget {
-		budgetTotal := result.BudgetUsed + result.BudgetRemaining
-		pct := 0
-		if budgetTotal > 0 {
-			pct = (result.TotalTokens * 100) / budgetTotal
-		}
-		fmt.Fprintf(&b, "Total: %s tokens / %s budget (%d%%)\n",
-			formatInt(result.TotalTokens),
-			formatInt(budgetTotal),
-			pct,
-		)
-	} else {
-		fmt.Fprintf(&b, "Total: %s tokens (no budget)\n",
-			formatInt(result.TotalTokens),
+	var b strings.Builder
+	fmt.Fprintf(&b, "Files by Tier (%s files):\n", formatInt(total))
+	if total == 0 {
+		b.WriteString("  (no files)\n")
+	}
+
+	// Compute column widths for alignment.
+	maxLabelWidth, maxCountWidth := 0, 0
+	for _, tier := range tiers {
+		maxLabelWidth = max(maxLabelWidth, len(fmt.Sprintf("Tier %d (%s)", tier, TierLabel(tier))))
+		maxCountWidth = max(maxCountWidth, len(formatInt(dist[Tier(tier)])))
+	}
+
+	for _, tier := range tiers {
+		n := dist[Tier(tier)]
+		label := fmt.Sprintf("Tier %d (%s)", tier, TierLabel(tier))
+		fmt.Fprintf(&b, "  %s:%s  %*s files  %5.1f%%\n",
+			label, strings.Repeat(" ", maxLabelWidth-len(label)),
+			maxCountWidth, formatInt(n),
+			float64(n)*100/float64(total),
 		)
 	}
 
+	fmt.Fprintf(&b, "\nUnmatched files are assigned to Tier %d (%s).\n",
+		int(DefaultUnmatchedTier), TierLabel(int(DefaultUnmatchedTier)))
 	return b.String()
 }
 
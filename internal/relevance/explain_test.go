@@ -2,6 +2,8 @@
 package relevance
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -468,6 +470,93 @@ func TestGenerateInclusionSummaryWithBudget(t *testing.T) {
 	assert.Contains(t, output, "44%", "~89420/200000 = 44%%")
 }
 
+// sampleBudgetResult returns a BudgetResult with an active budget, included
+// files in tiers 0 and 1, and tier 3 files that were all excluded.
+func sampleBudgetResult() *tokenizer.BudgetResult {
+	return &tokenizer.BudgetResult{
+		IncludedFiles: []*pipeline.FileDescriptor{
+			newFD("go.mod", 0, 50),
+			newFD("main.go", 1, 400),
+			newFD("util.go", 1, 300),
+		},
+		ExcludedFiles: []*pipeline.FileDescriptor{
+			newFD("big.go", 1, 5000),
+			newFD("a_test.go", 3, 900),
+			newFD("b_test.go", 3, 800),
+		},
+		TotalTokens:     750,
+		BudgetUsed:      800,
+		BudgetRemaining: 200,
+		TokenizerName:   "o200k_base",
+		Summary: tokenizer.BudgetSummary{
+			TierStats: map[int]tokenizer.TierStat{
+				0: {FilesIncluded: 1, TokensUsed: 50},
+				1: {FilesIncluded: 2, FilesExcluded: 1, TokensUsed: 700},
+				3: {FilesExcluded: 2},
+			},
+		},
+	}
+}
+
+// TestBuildInclusionSummary verifies the structured summary's header counts,
+// per-tier statistics, and budget numbers.
+func TestBuildInclusionSummary(t *testing.T) {
+	t.Parallel()
+
+	s := BuildInclusionSummary(sampleBudgetResult())
+
+	assert.Equal(t, 3, s.FilesIncluded)
+	assert.Equal(t, 3, s.FilesExcluded)
+	assert.Equal(t, "o200k_base", s.Tokenizer)
+	assert.Equal(t, []InclusionTier{
+		{Tier: 0, Label: "Config", FilesIncluded: 1, TokensUsed: 50},
+		{Tier: 1, Label: "Source", FilesIncluded: 2, FilesExcluded: 1, TokensUsed: 700},
+		{Tier: 3, Label: "Tests", FilesExcluded: 2},
+	}, s.Tiers)
+	assert.Equal(t, 750, s.TotalTokens)
+	assert.True(t, s.HasBudget)
+	assert.Equal(t, 1000, s.Budget)
+	assert.Equal(t, 75, s.BudgetPercent)
+}
+
+// TestBuildInclusionSummaryNoBudget verifies that an unbudgeted result reports
+// no budget numbers and that tier labels come from the definitions.
+func TestBuildInclusionSummaryNoBudget(t *testing.T) {
+	t.Parallel()
+
+	br := &tokenizer.BudgetResult{
+		IncludedFiles: []*pipeline.FileDescriptor{newFD("go.mod", 0, 50)},
+		TotalTokens:   50,
+		Summary: tokenizer.BudgetSummary{
+			TierStats: map[int]tokenizer.TierStat{0: {FilesIncluded: 1, TokensUsed: 50}},
+		},
+	}
+	defs := []TierDefinition{{Tier: Tier0Critical, Patterns: []string{"go.mod"}, Label: "Manifests"}}
+
+	s := BuildInclusionSummaryForTiers(br, defs)
+
+	assert.False(t, s.HasBudget)
+	assert.Zero(t, s.Budget)
+	assert.Zero(t, s.BudgetPercent)
+	require.Len(t, s.Tiers, 1)
+	assert.Equal(t, "Manifests", s.Tiers[0].Label)
+}
+
+// TestInclusionSummaryJSONRoundTrip verifies that the JSON emitter's output
+// decodes back into the same InclusionSummary.
+func TestInclusionSummaryJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	br := sampleBudgetResult()
+	data, err := InclusionSummaryJSON(br, nil)
+	require.NoError(t, err)
+
+	var decoded InclusionSummary
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, BuildInclusionSummary(br), decoded)
+	assert.Contains(t, string(data), `"files_excluded": 2`)
+}
+
 // TestGenerateInclusionSummaryNoExclusions verifies the summary when budget
 // enforcement was active but no files were excluded (all fit within budget).
 func TestGenerateInclusionSummaryNoExclusions(t *testing.T) {
@@ -576,6 +665,68 @@ func TestGenerateInclusionSummaryByTierSection(t *testing.T) {
 	assert.Contains(t, output, "By Tier:")
 }
 
+// TestGenerateInclusionSummaryTokenizerLine verifies that the effective
+// tokenizer appears in the header, including the estimator fallback note.
+func TestGenerateInclusionSummaryTokenizerLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		tok  tokenizer.Tokenizer
+		want string
+	}{
+		{name: "resolved tokenizer", tok: &fixedTokenizer{name: tokenizer.NameO200K}, want: "Tokenizer: o200k_base\n"},
+		{name: "nil falls back to estimator", tok: nil, want: "Tokenizer: char-estimator\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			br := tokenizer.NewBudgetEnforcer(0, tokenizer.SkipStrategy, tt.tok).Enforce(nil, 0)
+			assert.Contains(t, GenerateInclusionSummary(br), tt.want)
+		})
+	}
+}
+
+// TestGenerateInclusionSummaryForModelCost verifies that a priced model adds
+// the estimated input cost line and an unknown model leaves it out.
+func TestGenerateInclusionSummaryForModelCost(t *testing.T) {
+	t.Parallel()
+
+	br := &tokenizer.BudgetResult{
+		TotalTokens: 89420,
+		Summary:     tokenizer.BudgetSummary{TierStats: map[int]tokenizer.TierStat{}},
+	}
+
+	priced := GenerateInclusionSummaryForModel(br, "claude-sonnet-4")
+	assert.Contains(t, priced, "Estimated cost: ~$0.27 input (claude-sonnet-4)\n")
+
+	unknown := GenerateInclusionSummaryForModel(br, "made-up-model")
+	assert.NotContains(t, unknown, "Estimated cost")
+	assert.Equal(t, GenerateInclusionSummary(br), unknown)
+}
+
+// TestGenerateInclusionSummaryNoTokenizerLine verifies that the Tokenizer line
+// is omitted when the result carries no tokenizer name.
+func TestGenerateInclusionSummaryNoTokenizerLine(t *testing.T) {
+	t.Parallel()
+
+	br := &tokenizer.BudgetResult{
+		Summary: tokenizer.BudgetSummary{TierStats: map[int]tokenizer.TierStat{}},
+	}
+
+	assert.NotContains(t, GenerateInclusionSummary(br), "Tokenizer:")
+}
+
+// fixedTokenizer is a Tokenizer stub with a configurable name that counts one
+// token per byte, avoiding tiktoken initialisation in tests.
+type fixedTokenizer struct {
+	name string
+}
+
+func (f *fixedTokenizer) Count(text string) int { return len(text) }
+func (f *fixedTokenizer) Name() string          { return f.name }
+
 // ----------------------------------------------------------------------------
 // TestFormatInt (internal helper via exported behaviour)
 // ----------------------------------------------------------------------------
@@ -646,3 +797,171 @@ func TestFormatExplainTierDisplayLabel(t *testing.T) {
 		})
 	}
 }
+
+// TestExplainCustomTierLabels verifies that a labelled tier definition names
+// the tier in Explain and FormatExplain, including the default tier, while
+// unlabelled tiers keep their built-in names.
+func TestExplainCustomTierLabels(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"schema/**"}, Label: "Schemas"},
+		{Tier: Tier1Primary, Patterns: []string{"lib/**"}},
+		{Tier: Tier2Secondary, Patterns: []string{"vendor/**"}, Label: "Everything else"},
+	}
+
+	labelled := Explain("schema/user.sql", defs)
+	assert.Equal(t, "Schemas", labelled.TierLabel)
+	assert.Contains(t, FormatExplain(labelled), "Tier: 0 (Schemas)\n")
+
+	unlabelled := Explain("lib/user.go", defs)
+	assert.Empty(t, unlabelled.TierLabel)
+	assert.Contains(t, FormatExplain(unlabelled), "Tier: 1 (Source Code)\n")
+
+	unmatched := Explain("main.go", defs)
+	require.True(t, unmatched.IsDefault)
+	assert.Equal(t, "Everything else", unmatched.TierLabel)
+	assert.Contains(t, FormatExplain(unmatched), "Tier: 2 (Everything else)\n")
+}
+
+// TestGenerateInclusionSummaryForTiersLabels verifies that tier definition
+// labels replace the built-in names and that nil definitions match
+// GenerateInclusionSummaryForModel.
+func TestGenerateInclusionSummaryForTiersLabels(t *testing.T) {
+	t.Parallel()
+
+	br := &tokenizer.BudgetResult{
+		Summary: tokenizer.BudgetSummary{
+			TierStats: map[int]tokenizer.TierStat{
+				0: {FilesIncluded: 2, TokensUsed: 20},
+				1: {FilesIncluded: 1, TokensUsed: 10},
+			},
+		},
+		TotalTokens: 30,
+	}
+	defs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"schema/**"}, Label: "Schemas"},
+		{Tier: Tier1Primary, Patterns: []string{"lib/**"}},
+	}
+
+	output := GenerateInclusionSummaryForTiers(br, "", defs)
+	assert.Contains(t, output, "Tier 0 (Schemas):  2 files")
+	assert.Contains(t, output, "Tier 1 (Source):   1 files")
+	assert.NotContains(t, output, "Config")
+
+	assert.Equal(t, GenerateInclusionSummaryForModel(br, ""), GenerateInclusionSummaryForTiers(br, "", nil))
+}
+
+// ----------------------------------------------------------------------------
+// ExplainRepoJSON
+// ----------------------------------------------------------------------------
+
+// TestExplainRepoJSON verifies ordering by tier then path, default entries,
+// and that a file claimed by several patterns lists every match consistently
+// with Explain.
+func TestExplainRepoJSON(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go"}},
+		{Tier: Tier1Primary, Patterns: []string{"src/**", "**/*.go"}},
+		{Tier: Tier0Critical, Patterns: []string{"go.mod"}},
+	}
+	files := []string{"src/z.go", "Makefile", "src/a_test.go", "go.mod", "src/a.go"}
+
+	data, err := ExplainRepoJSON(files, defs)
+	require.NoError(t, err)
+
+	var entries []ExplainEntry
+	require.NoError(t, json.Unmarshal(data, &entries))
+	require.Len(t, entries, len(files))
+
+	var paths []string
+	for _, e := range entries {
+		paths = append(paths, e.Path)
+	}
+	assert.Equal(t, []string{"go.mod", "src/a.go", "src/a_test.go", "src/z.go", "Makefile"}, paths)
+
+	multi := entries[2]
+	assert.Equal(t, "src/a_test.go", multi.Path)
+	assert.Equal(t, 1, multi.Tier)
+	assert.Equal(t, "src/**", multi.Pattern)
+	assert.Equal(t, []PatternMatch{
+		{Tier: 1, Pattern: "**/*.go"},
+		{Tier: 1, Pattern: "src/**"},
+		{Tier: 3, Pattern: "**/*_test.go"},
+	}, multi.Matches)
+
+	for _, e := range entries {
+		want := Explain(e.Path, defs)
+		assert.Equal(t, want.AssignedTier, e.Tier, e.Path)
+		assert.Equal(t, want.MatchedPattern, e.Pattern, e.Path)
+		assert.Equal(t, want.IsDefault, e.Default, e.Path)
+		assert.ElementsMatch(t, want.AllMatches, e.Matches, e.Path)
+	}
+
+	assert.Contains(t, string(data), `{"path":"Makefile","tier":2,"default":true,"matches":[]}`)
+}
+
+// ----------------------------------------------------------------------------
+// TierDistribution / FormatTierDistribution
+// ----------------------------------------------------------------------------
+
+// TestTierDistributionCounts verifies per-tier counts for a known file set,
+// including unmatched files counted under DefaultUnmatchedTier.
+func TestTierDistributionCounts(t *testing.T) {
+	t.Parallel()
+
+	files := []string{
+		"go.mod", "Makefile",
+		"src/a.go", "src/b.go", "src/c.go",
+		"src/a_test.go",
+		"docs/guide.md",
+		"notes.txt", "scripts/x.sh",
+	}
+	defs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod", "Makefile"}},
+		{Tier: Tier1Primary, Patterns: []string{"src/*.go"}},
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go"}},
+		{Tier: Tier4Docs, Patterns: []string{"docs/**"}},
+	}
+
+	dist := TierDistribution(files, defs)
+	// src/a_test.go matches tier 1 first, so it counts there.
+	assert.Equal(t, map[Tier]int{
+		Tier0Critical:  2,
+		Tier1Primary:   4,
+		Tier2Secondary: 2,
+		Tier4Docs:      1,
+	}, dist)
+}
+
+// TestFormatTierDistribution verifies ordering, labels, the unmatched note,
+// and that the printed percentages sum to ~100%.
+func TestFormatTierDistribution(t *testing.T) {
+	t.Parallel()
+
+	dist := map[Tier]int{Tier4Docs: 1, Tier0Critical: 2, Tier1Primary: 4, Tier2Secondary: 2}
+	out := FormatTierDistribution(dist)
+
+	assert.Contains(t, out, "Files by Tier (9 files):")
+	assert.Less(t, strings.Index(out, "Tier 0 (Config)"), strings.Index(out, "Tier 1 (Source)"))
+	assert.Less(t, strings.Index(out, "Tier 1 (Source)"), strings.Index(out, "Tier 2 (Secondary)"))
+	assert.Less(t, strings.Index(out, "Tier 2 (Secondary)"), strings.Index(out, "Tier 4 (Docs)"))
+	assert.Contains(t, out, "Unmatched files are assigned to Tier 2 (Secondary).")
+
+	sum := 0.0
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || !strings.HasSuffix(line, "%") {
+			continue
+		}
+		var pct float64
+		_, err := fmt.Sscanf(strings.TrimSuffix(fields[len(fields)-1], "%"), "%f", &pct)
+		require.NoError(t, err)
+		sum += pct
+	}
+	assert.InDelta(t, 100.0, sum, 0.5)
+
+	assert.Contains(t, FormatTierDistribution(nil), "(no files)")
+}
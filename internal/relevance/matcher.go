@@ -3,9 +3,12 @@
 package relevance
 
 import (
+	"runtime"
 	"strings"
+	"sync"
 
 	"github.com/bmatcuk/doublestar/v4"
+	"github.com/harvx/harvx/internal/config"
 )
 
 // TierMatcher assigns each file path to exactly one relevance tier using
@@ -20,12 +23,44 @@ type TierMatcher struct {
 	// tier number first). Within each tier, patterns are stored in their
 	// original order so first-match semantics are preserved.
 	tiers []tierEntry
+
+	// baseDir, when non-empty, is stripped from each candidate path before
+	// pattern evaluation. Paths outside baseDir match no pattern.
+	baseDir string
+
+	// caseInsensitive folds patterns and candidate paths to lowercase before
+	// comparing them. Paths are folded only for matching, never rewritten.
+	caseInsensitive bool
+}
+
+// TierMatcherOptions configures NewTierMatcherWithOptions.
+type TierMatcherOptions struct {
+	// BaseDir evaluates patterns relative to this directory, as in
+	// NewTierMatcherWithBaseDir. Empty means the target directory.
+	BaseDir string
+
+	// CaseInsensitive matches patterns regardless of letter case, mirroring
+	// config.Profile.CaseInsensitive.
+	CaseInsensitive bool
 }
 
 // tierEntry pairs a Tier with its pre-validated patterns.
 type tierEntry struct {
 	tier     Tier
 	patterns []string // only syntactically valid patterns are kept
+
+	// literals holds the patterns from patterns that contain no glob
+	// metacharacters. doublestar.Match reduces to string equality for such
+	// patterns, so they are checked with a single map lookup instead.
+	// Case-insensitive matchers store them folded to lowercase.
+	literals map[string]bool
+	// globs holds the remaining patterns in their original order; these are
+	// evaluated with doublestar.Match. Case-insensitive matchers store them
+	// folded to lowercase.
+	globs []string
+	// globPatterns holds the unfolded form of each entry in globs, at the
+	// same index, for reporting by MatchDetail.
+	globPatterns []string
 }
 
 // NewTierMatcher constructs a TierMatcher from the supplied tier definitions.
@@ -39,6 +74,16 @@ type tierEntry struct {
 // Pass nil or an empty slice to get a matcher that assigns every file to
 // DefaultUnmatchedTier.
 func NewTierMatcher(defs []TierDefinition) *TierMatcher {
+	return NewTierMatcherWithOptions(defs, TierMatcherOptions{})
+}
+
+// NewTierMatcherWithOptions is like NewTierMatcher but applies opts. With
+// opts.CaseInsensitive, patterns are folded to lowercase here and candidate
+// paths are folded in Match, so "README.md" matches "readme.md". Character
+// classes fold too: "[A-Z]" becomes "[a-z]" and matches either case.
+func NewTierMatcherWithOptions(defs []TierDefinition, opts TierMatcherOptions) *TierMatcher {
+	m := &TierMatcher{baseDir: opts.BaseDir, caseInsensitive: opts.CaseInsensitive}
+
 	// Sort a copy of defs by tier number so the caller's slice is never mutated.
 	sorted := make([]TierDefinition, len(defs))
 	copy(sorted, defs)
@@ -46,16 +91,51 @@ func NewTierMatcher(defs []TierDefinition) *TierMatcher {
 
 	entries := make([]tierEntry, 0, len(sorted))
 	for _, d := range sorted {
-		valid := make([]string, 0, len(d.Patterns))
+		entry := tierEntry{tier: d.Tier, patterns: make([]string, 0, len(d.Patterns))}
 		for _, p := range d.Patterns {
-			if doublestar.ValidatePattern(p) {
-				valid = append(valid, p)
+			if !doublestar.ValidatePattern(p) {
+				continue
+			}
+			entry.patterns = append(entry.patterns, p)
+			if isLiteralPattern(p) {
+				if entry.literals == nil {
+					entry.literals = make(map[string]bool)
+				}
+				entry.literals[m.fold(p)] = true
+			} else {
+				entry.globs = append(entry.globs, m.fold(p))
+				entry.globPatterns = append(entry.globPatterns, p)
 			}
 		}
-		entries = append(entries, tierEntry{tier: d.Tier, patterns: valid})
+		entries = append(entries, entry)
 	}
 
-	return &TierMatcher{tiers: entries}
+	m.tiers = entries
+	return m
+}
+
+// NewTierMatcherWithBaseDir is like NewTierMatcher but evaluates patterns
+// relative to baseDir, mirroring config.Profile.BaseDir. Candidate paths are
+// still given relative to the target directory; the baseDir prefix is
+// stripped before matching, and paths outside baseDir are assigned
+// DefaultUnmatchedTier. An empty baseDir behaves exactly like NewTierMatcher.
+func NewTierMatcherWithBaseDir(defs []TierDefinition, baseDir string) *TierMatcher {
+	return NewTierMatcherWithOptions(defs, TierMatcherOptions{BaseDir: baseDir})
+}
+
+// fold returns s lowercased for a case-insensitive matcher and unchanged
+// otherwise.
+func (m *TierMatcher) fold(s string) string {
+	if m.caseInsensitive {
+		return strings.ToLower(s)
+	}
+	return s
+}
+
+// isLiteralPattern reports whether pattern contains no doublestar
+// metacharacters, meaning it can only ever match a path equal to itself.
+func isLiteralPattern(pattern string) bool {
+	return !strings.ContainsAny(pattern, `*?[]{}\`)
 }
 
 // sortTierDefinitions sorts defs in place by ascending Tier value using
@@ -82,11 +162,43 @@ func sortTierDefinitions(defs []TierDefinition) {
 // priority) to highest number. Within each tier patterns are checked in
 // definition order. The Tier of the first matching pattern is returned.
 // If no pattern matches, DefaultUnmatchedTier is returned.
+//
+// Literal patterns (e.g. "go.mod") are checked by exact lookup before the
+// tier's glob patterns. Every pattern in a tier yields the same Tier, so
+// checking literals first never changes the result.
+//
+// For a matcher built with a base directory, the base prefix is stripped from
+// filePath first; a path outside the base directory returns
+// DefaultUnmatchedTier without consulting any pattern.
 func (m *TierMatcher) Match(filePath string) Tier {
+	tier, _ := m.MatchDetail(filePath)
+	return tier
+}
+
+// MatchDetail is like Match but also returns the pattern that assigned the
+// tier, exactly as it was written in the tier definition. The pattern is the
+// first one in definition order within the winning tier, so for a tier
+// defined as ["*.go", "main.go"] the file "main.go" reports "*.go". The
+// pattern is empty when the file falls through to DefaultUnmatchedTier,
+// including paths outside the base directory.
+func (m *TierMatcher) MatchDetail(filePath string) (Tier, string) {
 	normalised := normalisePath(filePath)
+	if m.baseDir != "" {
+		rel, ok := config.RebasePath(m.baseDir, normalised)
+		if !ok {
+			return DefaultUnmatchedTier, ""
+		}
+		normalised = rel
+	}
+	normalised = m.fold(normalised)
 
 	for _, entry := range m.tiers {
-		for _, pattern := range entry.patterns {
+		if entry.literals[normalised] {
+			// A glob defined before the literal may match too; rescan the
+			// tier in definition order so the first pattern is reported.
+			return entry.tier, m.firstMatchingPattern(entry, normalised)
+		}
+		for i, pattern := range entry.globs {
 			matched, err := doublestar.Match(pattern, normalised)
 			if err != nil {
 				// ValidatePattern already filtered bad patterns at construction
@@ -94,12 +206,79 @@ func (m *TierMatcher) Match(filePath string) Tier {
 				continue
 			}
 			if matched {
-				return entry.tier
+				return entry.tier, entry.globPatterns[i]
 			}
 		}
 	}
 
-	return DefaultUnmatchedTier
+	return DefaultUnmatchedTier, ""
+}
+
+// firstMatchingPattern returns the first pattern of entry, in definition
+// order, that matches the already normalised and folded path. It is only
+// called once a pattern of entry is known to match.
+func (m *TierMatcher) firstMatchingPattern(entry tierEntry, normalised string) string {
+	for _, pattern := range entry.patterns {
+		folded := m.fold(pattern)
+		if entry.literals[folded] {
+			if folded == normalised {
+				return pattern
+			}
+			continue
+		}
+		if matched, _ := doublestar.Match(folded, normalised); matched {
+			return pattern
+		}
+	}
+	return ""
+}
+
+// MatchAll returns every tier-pattern combination that matches filePath,
+// sorted by ascending tier then lexicographically by pattern. It is the set
+// Explain reports in ExplainResult.AllMatches, without building the rest of
+// the explanation, and is intended for detecting overlapping rules.
+//
+// filePath is normalised as in Match, including base directory stripping; a
+// path outside the base directory matches nothing. The result is nil when no
+// pattern matches.
+func (m *TierMatcher) MatchAll(filePath string) []PatternMatch {
+	matches := m.matchesInOrder(filePath)
+	sortPatternMatches(matches)
+	return matches
+}
+
+// matchesInOrder returns the matches MatchAll reports in evaluation order:
+// ascending tier, then pattern definition order. The first element is
+// therefore the pattern that assigns the file its tier.
+func (m *TierMatcher) matchesInOrder(filePath string) []PatternMatch {
+	normalised := normalisePath(filePath)
+	if m.baseDir != "" {
+		rel, ok := config.RebasePath(m.baseDir, normalised)
+		if !ok {
+			return nil
+		}
+		normalised = rel
+	}
+	normalised = m.fold(normalised)
+
+	var matches []PatternMatch
+	for _, entry := range m.tiers {
+		for _, pattern := range entry.patterns {
+			folded := m.fold(pattern)
+			var matched bool
+			if entry.literals[folded] {
+				matched = folded == normalised
+			} else {
+				// Patterns were validated at construction time, so the error
+				// is unreachable in practice.
+				matched, _ = doublestar.Match(folded, normalised)
+			}
+			if matched {
+				matches = append(matches, PatternMatch{Tier: int(entry.tier), Pattern: pattern})
+			}
+		}
+	}
+	return matches
 }
 
 // ClassifyFiles bulk-classifies a slice of file paths against the provided tier
@@ -121,6 +300,93 @@ func ClassifyFiles(files []string, tiers []TierDefinition) map[string]Tier {
 	return result
 }
 
+// ClassifyFilesConcurrent is a parallel ClassifyFiles for large file sets. It
+// builds a single TierMatcher, shards files into contiguous ranges across
+// workers goroutines that share the matcher read-only, and merges the results
+// into one map. A workers value <= 0 defaults to runtime.GOMAXPROCS(0), and
+// no more goroutines are started than there are files.
+//
+// Tier assignment is per file, so the result is identical to ClassifyFiles
+// for the same input, including original (non-normalised) keys and duplicate
+// paths collapsing to a single entry.
+func ClassifyFilesConcurrent(files []string, defs []TierDefinition, workers int) map[string]Tier {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+	if workers <= 1 {
+		return ClassifyFiles(files, defs)
+	}
+
+	matcher := NewTierMatcher(defs)
+	tiers := make([]Tier, len(files))
+	chunk := (len(files) + workers - 1) / workers
+
+	var wg sync.WaitGroup
+	for start := 0; start < len(files); start += chunk {
+		end := min(start+chunk, len(files))
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for i := start; i < end; i++ {
+				tiers[i] = matcher.Match(files[i])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+
+	result := make(map[string]Tier, len(files))
+	for i, f := range files {
+		result[f] = tiers[i]
+	}
+	return result
+}
+
+// TierChange records a file whose tier assignment differs between two sets of
+// tier definitions.
+type TierChange struct {
+	Path    string
+	OldTier Tier
+	NewTier Tier
+}
+
+// TierDiff classifies files against oldDefs and newDefs and returns a
+// TierChange for every file whose tier differs. Files that keep their tier are
+// omitted. Results follow the order of files, and duplicate paths are reported
+// once. It is intended for previewing the effect of editing tier patterns.
+func TierDiff(files []string, oldDefs, newDefs []TierDefinition) []TierChange {
+	oldTiers := ClassifyFiles(files, oldDefs)
+	newTiers := ClassifyFiles(files, newDefs)
+
+	var changes []TierChange
+	seen := make(map[string]bool, len(files))
+	for _, f := range files {
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		if oldTiers[f] != newTiers[f] {
+			changes = append(changes, TierChange{Path: f, OldTier: oldTiers[f], NewTier: newTiers[f]})
+		}
+	}
+	return changes
+}
+
+// TierDistribution classifies files against defs and returns how many files
+// fall into each tier. Only tiers with at least one file are present, and
+// files that match no pattern are counted under DefaultUnmatchedTier. Paths
+// are counted once per occurrence in files.
+func TierDistribution(files []string, defs []TierDefinition) map[Tier]int {
+	matcher := NewTierMatcher(defs)
+	dist := make(map[Tier]int)
+	for _, f := range files {
+		dist[matcher.Match(f)]++
+	}
+	return dist
+}
+
 // normalisePath strips a leading "./" from path and converts any OS-specific
 // separators to forward slashes, ensuring compatibility with doublestar.Match
 // which splits on "/".
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -268,6 +269,59 @@ func TestMatchWithinTierPatternOrder(t *testing.T) {
 		"*.go in Tier0Critical should match before Tier1Primary")
 }
 
+// TestMatchDetail verifies that MatchDetail reports the first pattern in
+// definition order within the winning tier, unfolded, and an empty pattern
+// for unmatched paths.
+func TestMatchDetail(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"special.go", "*.go"}},
+		{Tier: Tier1Primary, Patterns: []string{"src/**", "src/main.ts"}},
+		{Tier: Tier2Secondary, Patterns: []string{"README.md"}},
+	}
+
+	tests := []struct {
+		name        string
+		path        string
+		wantTier    Tier
+		wantPattern string
+	}{
+		{name: "literal before glob", path: "special.go", wantTier: Tier0Critical, wantPattern: "special.go"},
+		{name: "glob", path: "other.go", wantTier: Tier0Critical, wantPattern: "*.go"},
+		{name: "glob before literal", path: "src/main.ts", wantTier: Tier1Primary, wantPattern: "src/**"},
+		{name: "literal only", path: "./README.md", wantTier: Tier2Secondary, wantPattern: "README.md"},
+		{name: "unmatched", path: "docs/guide.txt", wantTier: DefaultUnmatchedTier, wantPattern: ""},
+	}
+
+	m := NewTierMatcher(defs)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			tier, pattern := m.MatchDetail(tt.path)
+			assert.Equal(t, tt.wantTier, tier)
+			assert.Equal(t, tt.wantPattern, pattern)
+			assert.Equal(t, tt.wantTier, m.Match(tt.path))
+		})
+	}
+
+	t.Run("case insensitive reports original pattern", func(t *testing.T) {
+		t.Parallel()
+		ci := NewTierMatcherWithOptions(defs, TierMatcherOptions{CaseInsensitive: true})
+		tier, pattern := ci.MatchDetail("readme.MD")
+		assert.Equal(t, Tier2Secondary, tier)
+		assert.Equal(t, "README.md", pattern)
+	})
+
+	t.Run("outside base dir", func(t *testing.T) {
+		t.Parallel()
+		based := NewTierMatcherWithBaseDir(defs, "pkg")
+		tier, pattern := based.MatchDetail("other.go")
+		assert.Equal(t, DefaultUnmatchedTier, tier)
+		assert.Empty(t, pattern)
+	})
+}
+
 // ----------------------------------------------------------------------------
 // TestMatch -- unmatched / empty
 // ----------------------------------------------------------------------------
@@ -515,6 +569,112 @@ func TestNoFileInMultipleTiers(t *testing.T) {
 	}
 }
 
+// ----------------------------------------------------------------------------
+// TestMatch -- literal fast path
+// ----------------------------------------------------------------------------
+
+// doublestarOnlyMatch is the reference implementation of Match that evaluates
+// every pattern with doublestar.Match, bypassing the literal lookup. It is
+// used to prove the fast path never changes classification.
+func doublestarOnlyMatch(m *TierMatcher, filePath string) Tier {
+	normalised := normalisePath(filePath)
+	for _, entry := range m.tiers {
+		for _, pattern := range entry.patterns {
+			if matched, _ := doublestar.Match(pattern, normalised); matched {
+				return entry.tier
+			}
+		}
+	}
+	return DefaultUnmatchedTier
+}
+
+// TestNewTierMatcherSplitsLiteralPatterns verifies that patterns without glob
+// metacharacters are indexed as literals while globs keep their order.
+func TestNewTierMatcherSplitsLiteralPatterns(t *testing.T) {
+	t.Parallel()
+
+	m := NewTierMatcher([]TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod", "*.config.ts", "Dockerfile", "cmd/**", "a\\*b", "[bad"}},
+	})
+
+	require.Len(t, m.tiers, 1)
+	assert.Equal(t, map[string]bool{"go.mod": true, "Dockerfile": true}, m.tiers[0].literals)
+	assert.Equal(t, []string{"*.config.ts", "cmd/**", "a\\*b"}, m.tiers[0].globs)
+	assert.Equal(t, []string{"go.mod", "*.config.ts", "Dockerfile", "cmd/**", "a\\*b"}, m.tiers[0].patterns)
+}
+
+// literalOnlyTierDefinitions returns tiers made up entirely of exact file
+// names, the shape of configs dominated by Tier 0 style literals.
+func literalOnlyTierDefinitions() []TierDefinition {
+	return []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod", "package.json", "Dockerfile", "Makefile"}},
+		{Tier: Tier3Tests, Patterns: []string{"main_test.go"}},
+		{Tier: Tier4Docs, Patterns: []string{"README.md"}},
+		{Tier: Tier5Low, Patterns: []string{"package-lock.json"}},
+	}
+}
+
+// TestNewTierMatcherAllLiteralTierHasNoGlobs verifies that a tier made only
+// of exact names is served entirely by the literal map, so Match never calls
+// doublestar for it.
+func TestNewTierMatcherAllLiteralTierHasNoGlobs(t *testing.T) {
+	t.Parallel()
+
+	m := NewTierMatcher(literalOnlyTierDefinitions())
+
+	for _, entry := range m.tiers {
+		assert.Empty(t, entry.globs, "tier %d", entry.tier)
+		assert.Len(t, entry.literals, len(entry.patterns), "tier %d", entry.tier)
+	}
+}
+
+// TestMatchLiteralOnlyMatchesWholePath verifies that a literal pattern keeps
+// doublestar semantics: "go.mod" matches the root file only, not a nested one.
+func TestMatchLiteralOnlyMatchesWholePath(t *testing.T) {
+	t.Parallel()
+
+	m := defaultMatcher(t)
+
+	assert.Equal(t, Tier0Critical, m.Match("go.mod"))
+	assert.Equal(t, Tier0Critical, m.Match("./go.mod"))
+	assert.Equal(t, DefaultUnmatchedTier, m.Match("tools/go.mod"))
+}
+
+// TestMatchLiteralFastPathEquivalence verifies that Match assigns the same
+// tier as a pure doublestar evaluation across the test corpus, for both the
+// default tiers and definitions that mix literals and globs within a tier.
+func TestMatchLiteralFastPathEquivalence(t *testing.T) {
+	t.Parallel()
+
+	files := append(benchmarkFiles(2000),
+		"go.mod", "./go.mod", "tools/go.mod", "Dockerfile", "deploy/Dockerfile",
+		"package.json", "web/package.json", `src\\main.go`, "main_test.go",
+		"src/(group)/page.tsx", "my file.go", ".eslintrc", "",
+		"README.md", "docs/README.md", "Makefile", "a/b/c/d/e/f/g/h/i/j/k/l.go",
+	)
+
+	defSets := map[string][]TierDefinition{
+		"default": DefaultTierDefinitions(),
+		"mixed": {
+			{Tier: Tier3Tests, Patterns: []string{"main_test.go", "**/*_test.go"}},
+			{Tier: Tier0Critical, Patterns: []string{"**/Dockerfile", "go.mod", "docs/README.md"}},
+			{Tier: Tier1Primary, Patterns: []string{"Dockerfile", "src/**", "package.json"}},
+			{Tier: Tier4Docs, Patterns: []string{"**/*.md", "README.md"}},
+		},
+		"literal-only": literalOnlyTierDefinitions(),
+	}
+
+	for name, defs := range defSets {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			m := NewTierMatcher(defs)
+			for _, f := range files {
+				assert.Equal(t, doublestarOnlyMatch(m, f), m.Match(f), "file %q", f)
+			}
+		})
+	}
+}
+
 // ----------------------------------------------------------------------------
 // TestClassifyFiles
 // ----------------------------------------------------------------------------
@@ -602,6 +762,45 @@ func TestClassifyFilesBulkResult(t *testing.T) {
 	}
 }
 
+// TestClassifyFilesConcurrentMatchesSequential verifies that the concurrent
+// classifier returns exactly the sequential result for the bulk fixture and a
+// larger generated corpus, across worker counts including the default.
+func TestClassifyFilesConcurrentMatchesSequential(t *testing.T) {
+	t.Parallel()
+
+	bulk := []string{
+		"go.mod",
+		"src/main.go",
+		"components/Button.tsx",
+		"main_test.go",
+		"README.md",
+		".github/workflows/ci.yml",
+		"package-lock.json",
+		"mystery.xyz",
+		"./go.mod",
+		"go.mod",
+	}
+	corpus := append(benchmarkFiles(5000), bulk...)
+
+	for _, files := range [][]string{bulk, corpus} {
+		want := ClassifyFiles(files, DefaultTierDefinitions())
+		for _, workers := range []int{-1, 0, 1, 3, 8, len(files) + 5} {
+			got := ClassifyFilesConcurrent(files, DefaultTierDefinitions(), workers)
+			assert.Equal(t, want, got, "files=%d workers=%d", len(files), workers)
+		}
+	}
+}
+
+// TestClassifyFilesConcurrentEmpty verifies that an empty input yields an
+// empty, non-nil map like ClassifyFiles.
+func TestClassifyFilesConcurrentEmpty(t *testing.T) {
+	t.Parallel()
+
+	result := ClassifyFilesConcurrent(nil, DefaultTierDefinitions(), 4)
+	assert.NotNil(t, result)
+	assert.Empty(t, result)
+}
+
 // TestClassifyFilesDuplicatePaths verifies that duplicate paths in the input
 // are all present in the result with the correct tier.
 func TestClassifyFilesDuplicatePaths(t *testing.T) {
@@ -695,10 +894,9 @@ func TestNormalisePath(t *testing.T) {
 // Benchmark
 // ----------------------------------------------------------------------------
 
-// BenchmarkClassifyFiles10K measures throughput for 10 000 files against the
-// default tier definitions (~20 patterns).
-func BenchmarkClassifyFiles10K(b *testing.B) {
-	// Build a representative set of 10 000 file paths.
+// benchmarkFiles builds a representative set of n file paths mixing literal
+// and glob-matched names.
+func benchmarkFiles(n int) []string {
 	patterns := []string{
 		"go.mod", "package.json", "Dockerfile", "Makefile",
 		"src/%d/main.go", "internal/%d/handler.go", "cmd/%d/main.go",
@@ -709,10 +907,10 @@ func BenchmarkClassifyFiles10K(b *testing.B) {
 		"random/%d/unknown.xyz",
 	}
 
-	files := make([]string, 0, 10000)
-	for i := 0; len(files) < 10000; i++ {
+	files := make([]string, 0, n)
+	for i := 0; len(files) < n; i++ {
 		for _, p := range patterns {
-			if len(files) >= 10000 {
+			if len(files) >= n {
 				break
 			}
 			if strings.Contains(p, "%d") {
@@ -722,7 +920,13 @@ func BenchmarkClassifyFiles10K(b *testing.B) {
 			}
 		}
 	}
+	return files
+}
 
+// BenchmarkClassifyFiles10K measures throughput for 10 000 files against the
+// default tier definitions (~20 patterns).
+func BenchmarkClassifyFiles10K(b *testing.B) {
+	files := benchmarkFiles(10000)
 	defs := DefaultTierDefinitions()
 
 	b.ResetTimer()
@@ -733,6 +937,64 @@ func BenchmarkClassifyFiles10K(b *testing.B) {
 	}
 }
 
+// BenchmarkClassifyFilesConcurrent10K compares the sequential and concurrent
+// classifiers over the same 10 000 files.
+func BenchmarkClassifyFilesConcurrent10K(b *testing.B) {
+	files := benchmarkFiles(10000)
+	defs := DefaultTierDefinitions()
+
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			_ = ClassifyFiles(files, defs)
+		}
+	})
+
+	b.Run("concurrent", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			_ = ClassifyFilesConcurrent(files, defs, 0)
+		}
+	})
+}
+
+// BenchmarkMatchLiteralFastPath compares Match against the doublestar-only
+// reference over the same 10 000 files to show the literal lookup speedup,
+// for the default tiers and for tiers made entirely of exact names.
+func BenchmarkMatchLiteralFastPath(b *testing.B) {
+	files := benchmarkFiles(10000)
+
+	defSets := []struct {
+		name string
+		defs []TierDefinition
+	}{
+		{"default", DefaultTierDefinitions()},
+		{"literal-only", literalOnlyTierDefinitions()},
+	}
+
+	for _, ds := range defSets {
+		m := NewTierMatcher(ds.defs)
+
+		b.Run(ds.name+"/fast-path", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				for _, f := range files {
+					_ = m.Match(f)
+				}
+			}
+		})
+
+		b.Run(ds.name+"/doublestar-only", func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				for _, f := range files {
+					_ = doublestarOnlyMatch(m, f)
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkMatchSingle measures the per-file Match cost with the default tiers.
 func BenchmarkMatchSingle(b *testing.B) {
 	m := NewTierMatcher(DefaultTierDefinitions())
@@ -745,3 +1007,190 @@ func BenchmarkMatchSingle(b *testing.B) {
 		_ = m.Match(path)
 	}
 }
+
+// ----------------------------------------------------------------------------
+// TierDiff
+// ----------------------------------------------------------------------------
+
+// TestTierDiffTightenedPatternMovesFiles verifies that narrowing a tier
+// pattern reports exactly the files that moved, and omits unchanged files.
+func TestTierDiffTightenedPatternMovesFiles(t *testing.T) {
+	t.Parallel()
+
+	oldDefs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod"}},
+		{Tier: Tier1Primary, Patterns: []string{"src/**"}},
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go"}},
+	}
+	newDefs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod"}},
+		{Tier: Tier1Primary, Patterns: []string{"src/core/**"}},
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go"}},
+	}
+	files := []string{
+		"go.mod",
+		"src/core/engine.go",
+		"src/util/strings.go",
+		"src/util/strings_test.go",
+		"README.md",
+	}
+
+	changes := TierDiff(files, oldDefs, newDefs)
+
+	assert.Equal(t, []TierChange{
+		{Path: "src/util/strings.go", OldTier: Tier1Primary, NewTier: DefaultUnmatchedTier},
+		{Path: "src/util/strings_test.go", OldTier: Tier1Primary, NewTier: Tier3Tests},
+	}, changes)
+}
+
+// TestTierDiffIdenticalDefinitions verifies that identical definitions
+// produce no changes.
+func TestTierDiffIdenticalDefinitions(t *testing.T) {
+	t.Parallel()
+
+	files := []string{"go.mod", "src/main.go", "docs/guide.md"}
+	changes := TierDiff(files, DefaultTierDefinitions(), DefaultTierDefinitions())
+	assert.Empty(t, changes)
+}
+
+// TestTierDiffDuplicatePathsReportedOnce verifies that a path listed twice
+// yields a single change.
+func TestTierDiffDuplicatePathsReportedOnce(t *testing.T) {
+	t.Parallel()
+
+	oldDefs := []TierDefinition{{Tier: Tier0Critical, Patterns: []string{"Makefile"}}}
+	files := []string{"Makefile", "Makefile"}
+
+	changes := TierDiff(files, oldDefs, nil)
+	require.Len(t, changes, 1)
+	assert.Equal(t, TierChange{Path: "Makefile", OldTier: Tier0Critical, NewTier: DefaultUnmatchedTier}, changes[0])
+}
+
+// ----------------------------------------------------------------------------
+// NewTierMatcherWithOptions
+// ----------------------------------------------------------------------------
+
+// TestTierMatcherCaseInsensitive verifies that a case-insensitive matcher
+// matches literal and glob patterns regardless of case, while the default
+// matcher stays case-sensitive.
+func TestTierMatcherCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"README.md"}},
+		{Tier: Tier4Docs, Patterns: []string{"docs/**/*.MD"}},
+	}
+	folded := NewTierMatcherWithOptions(defs, TierMatcherOptions{CaseInsensitive: true})
+	exact := NewTierMatcher(defs)
+
+	assert.Equal(t, Tier0Critical, folded.Match("readme.md"))
+	assert.Equal(t, Tier0Critical, folded.Match("./Readme.MD"))
+	assert.Equal(t, Tier4Docs, folded.Match("Docs/guide/intro.md"))
+	assert.Equal(t, []PatternMatch{{Tier: int(Tier0Critical), Pattern: "README.md"}},
+		folded.MatchAll("readme.md"), "MatchAll reports the pattern as written")
+
+	assert.Equal(t, DefaultUnmatchedTier, exact.Match("readme.md"))
+	assert.Equal(t, DefaultUnmatchedTier, exact.Match("docs/guide/intro.md"))
+}
+
+// ----------------------------------------------------------------------------
+// NewTierMatcherWithBaseDir
+// ----------------------------------------------------------------------------
+
+// TestTierMatcherBaseDirStripsPrefix verifies that patterns are evaluated
+// relative to the base directory and paths outside it stay unmatched.
+func TestTierMatcherBaseDirStripsPrefix(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod"}},
+		{Tier: Tier1Primary, Patterns: []string{"src/**"}},
+	}
+	m := NewTierMatcherWithBaseDir(defs, "packages/api")
+
+	assert.Equal(t, Tier0Critical, m.Match("packages/api/go.mod"))
+	assert.Equal(t, Tier1Primary, m.Match("./packages/api/src/handler.go"))
+	assert.Equal(t, DefaultUnmatchedTier, m.Match("packages/web/src/app.ts"))
+	assert.Equal(t, DefaultUnmatchedTier, m.Match("go.mod"),
+		"a root-level file is outside base_dir even when it matches a literal")
+}
+
+// TestTierMatcherBaseDirMatchesSubdirInvocation documents how base_dir
+// composes with running harvx from a package subdirectory: classifying from
+// the repo root with base_dir = "packages/api" yields the same tiers as
+// classifying from packages/api itself with no base_dir.
+func TestTierMatcherBaseDirMatchesSubdirInvocation(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier1Primary, Patterns: []string{"src/**"}},
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go"}},
+	}
+	fromRoot := NewTierMatcherWithBaseDir(defs, "packages/api")
+	fromSubdir := NewTierMatcher(defs)
+
+	for _, rel := range []string{"src/main.go", "internal/x_test.go", "README.md"} {
+		assert.Equal(t, fromSubdir.Match(rel), fromRoot.Match("packages/api/"+rel),
+			"tier for %q must not depend on where harvx was invoked", rel)
+	}
+}
+
+// TestNewTierMatcherWithBaseDirEmpty verifies that an empty base directory is
+// equivalent to NewTierMatcher.
+func TestNewTierMatcherWithBaseDirEmpty(t *testing.T) {
+	t.Parallel()
+
+	m := NewTierMatcherWithBaseDir(DefaultTierDefinitions(), "")
+	ref := defaultMatcher(t)
+	for _, f := range []string{"go.mod", "src/main.go", "docs/guide.md"} {
+		assert.Equal(t, ref.Match(f), m.Match(f))
+	}
+}
+
+// TestMatchAllEqualsExplainAllMatches verifies that MatchAll returns the same
+// set of matches that Explain reports in AllMatches for paths claimed by
+// several overlapping patterns.
+func TestMatchAllEqualsExplainAllMatches(t *testing.T) {
+	t.Parallel()
+
+	defs := []TierDefinition{
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go", "internal/**/*_test.go"}},
+		{Tier: Tier0Critical, Patterns: []string{"go.mod", "*.go"}},
+		{Tier: Tier1Primary, Patterns: []string{"internal/**", "**/*.go", "go.mod"}},
+		{Tier: Tier4Docs, Patterns: []string{"**/*.md", "README.md"}},
+	}
+	m := NewTierMatcher(defs)
+
+	for _, path := range []string{
+		"go.mod",
+		"./main.go",
+		"internal/cli/root_test.go",
+		"internal/cli/root.go",
+		"README.md",
+		"docs/guide.md",
+		"Makefile",
+	} {
+		assert.Equal(t, Explain(path, defs).AllMatches, m.MatchAll(path), "path %q", path)
+	}
+
+	assert.Equal(t, []PatternMatch{
+		{Tier: 1, Pattern: "**/*.go"},
+		{Tier: 1, Pattern: "internal/**"},
+		{Tier: 3, Pattern: "**/*_test.go"},
+		{Tier: 3, Pattern: "internal/**/*_test.go"},
+	}, m.MatchAll("internal/cli/root_test.go"))
+	assert.Nil(t, m.MatchAll("Makefile"))
+}
+
+// TestMatchAllBaseDir verifies that MatchAll strips the base directory and
+// reports nothing for paths outside it.
+func TestMatchAllBaseDir(t *testing.T) {
+	t.Parallel()
+
+	m := NewTierMatcherWithBaseDir([]TierDefinition{
+		{Tier: Tier1Primary, Patterns: []string{"src/**"}},
+	}, "packages/api")
+
+	assert.Equal(t, []PatternMatch{{Tier: 1, Pattern: "src/**"}}, m.MatchAll("packages/api/src/main.go"))
+	assert.Nil(t, m.MatchAll("src/main.go"))
+}
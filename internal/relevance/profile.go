@@ -0,0 +1,133 @@
+// Package relevance — this file converts config.Profile relevance settings into
+// TierDefinition values.
+package relevance
+
+import (
+	"fmt"
+
+	"github.com/harvx/harvx/internal/config"
+)
+
+// TierDefinitionsFromProfile converts the tier_0..tier_5 pattern lists of a
+// profile into TierDefinition values, one per tier in ascending order.
+//
+// A tier whose slice is nil (not set in the profile) takes its patterns from
+// DefaultTierDefinitions. A tier set to an explicit empty list is kept and
+// simply never matches. When p is nil or every tier is empty the built-in
+// defaults are returned with their patterns unchanged.
+//
+// Each definition's Label comes from the profile's relevance labels table,
+// keyed "tier_0".."tier_5"; tiers without a label keep an empty Label.
+func TierDefinitionsFromProfile(p *config.Profile) []TierDefinition {
+	if p == nil {
+		return DefaultTierDefinitions()
+	}
+
+	rel := p.Relevance
+	tiers := [...]struct {
+		tier     Tier
+		patterns []string
+	}{
+		{Tier0Critical, rel.Tier0},
+		{Tier1Primary, rel.Tier1},
+		{Tier2Secondary, rel.Tier2},
+		{Tier3Tests, rel.Tier3},
+		{Tier4Docs, rel.Tier4},
+		{Tier5Low, rel.Tier5},
+	}
+	label := func(t Tier) string {
+		return rel.Labels[fmt.Sprintf("tier_%d", int(t))]
+	}
+
+	allEmpty := true
+	for _, t := range tiers {
+		if len(t.patterns) > 0 {
+			allEmpty = false
+			break
+		}
+	}
+	if allEmpty {
+		defs := DefaultTierDefinitions()
+		for i := range defs {
+			defs[i].Label = label(defs[i].Tier)
+		}
+		return defs
+	}
+
+	defaults := make(map[Tier][]string)
+	for _, d := range DefaultTierDefinitions() {
+		defaults[d.Tier] = d.Patterns
+	}
+
+	defs := make([]TierDefinition, 0, len(tiers))
+	for _, t := range tiers {
+		patterns := t.patterns
+		if patterns == nil {
+			patterns = defaults[t.tier]
+		}
+		defs = append(defs, TierDefinition{
+			Tier:     t.tier,
+			Patterns: append([]string(nil), patterns...),
+			Label:    label(t.tier),
+		})
+	}
+	return defs
+}
+
+// NewTierMatcherForProfile builds a TierMatcher from the profile's tiers (see
+// TierDefinitionsFromProfile) that honours the profile's base_dir and
+// case_insensitive settings. A nil profile yields a case-sensitive matcher over
+// the default tiers with no base directory.
+func NewTierMatcherForProfile(p *config.Profile) *TierMatcher {
+	if p == nil {
+		return NewTierMatcher(DefaultTierDefinitions())
+	}
+	return NewTierMatcherWithOptions(TierDefinitionsFromProfile(p), TierMatcherOptions{
+		BaseDir:         p.BaseDir,
+		CaseInsensitive: p.CaseInsensitive,
+	})
+}
+
+// CascadeMatcher assigns tiers using the per-file effective profile of a
+// config.Cascade, so a directory-local harvx.toml re-tiers only the files
+// below it. One TierMatcher is built per governing directory and reused for
+// every file under it.
+type CascadeMatcher struct {
+	base        *config.Profile
+	profileName string
+	cascade     *config.Cascade
+	matchers    map[string]*TierMatcher
+}
+
+// NewCascadeMatcher returns a CascadeMatcher over base, the resolved
+// repository profile, and the directory configs in cascade. profileName
+// selects the profile read from each directory config. A nil base stands for
+// config.DefaultProfile(), matching Cascade.ProfileFor, and a nil cascade
+// classifies every file with base alone.
+func NewCascadeMatcher(base *config.Profile, profileName string, cascade *config.Cascade) *CascadeMatcher {
+	if base == nil {
+		base = config.DefaultProfile()
+	}
+	return &CascadeMatcher{
+		base:        base,
+		profileName: profileName,
+		cascade:     cascade,
+		matchers:    make(map[string]*TierMatcher),
+	}
+}
+
+// Match returns the tier for filePath under the effective profile of the
+// directory that governs it. CascadeMatcher is not safe for concurrent use.
+func (m *CascadeMatcher) Match(filePath string) Tier {
+	dir := m.cascade.GoverningDir(filePath)
+	matcher, ok := m.matchers[dir]
+	if !ok {
+		if dir == "" {
+			matcher = NewTierMatcherForProfile(m.base)
+		} else {
+			matcher = NewTierMatcherForProfile(m.cascade.ProfileFor(m.base, m.profileName, filePath))
+		}
+		m.matchers[dir] = matcher
+	}
+	return matcher.Match(filePath)
+}
@@ -0,0 +1,194 @@
+package relevance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// defaultPatterns returns the default patterns for tier.
+func defaultPatterns(t *testing.T, tier Tier) []string {
+	t.Helper()
+	for _, d := range DefaultTierDefinitions() {
+		if d.Tier == tier {
+			return d.Patterns
+		}
+	}
+	t.Fatalf("no default definition for tier %d", tier)
+	return nil
+}
+
+// TestTierDefinitionsFromProfileFullyCustom verifies that every tier set in
+// the profile maps onto the matching Tier constant with its own patterns.
+func TestTierDefinitionsFromProfileFullyCustom(t *testing.T) {
+	t.Parallel()
+
+	p := &config.Profile{Relevance: config.RelevanceConfig{
+		Tier0: []string{"go.mod"},
+		Tier1: []string{"cmd/**"},
+		Tier2: []string{"pkg/**"},
+		Tier3: []string{"**/*_test.go"},
+		Tier4: []string{"**/*.md"},
+		Tier5: []string{"go.sum"},
+	}}
+
+	defs := TierDefinitionsFromProfile(p)
+
+	assert.Equal(t, []TierDefinition{
+		{Tier: Tier0Critical, Patterns: []string{"go.mod"}},
+		{Tier: Tier1Primary, Patterns: []string{"cmd/**"}},
+		{Tier: Tier2Secondary, Patterns: []string{"pkg/**"}},
+		{Tier: Tier3Tests, Patterns: []string{"**/*_test.go"}},
+		{Tier: Tier4Docs, Patterns: []string{"**/*.md"}},
+		{Tier: Tier5Low, Patterns: []string{"go.sum"}},
+	}, defs)
+}
+
+// TestTierDefinitionsFromProfileLabels verifies that relevance labels are
+// copied onto the matching definitions, including when every tier falls back
+// to the defaults.
+func TestTierDefinitionsFromProfileLabels(t *testing.T) {
+	t.Parallel()
+
+	labels := map[string]string{"tier_0": "Schemas", "tier_3": "Fixtures"}
+
+	for name, rel := range map[string]config.RelevanceConfig{
+		"custom tiers":  {Tier0: []string{"schema/**"}, Labels: labels},
+		"default tiers": {Labels: labels},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			defs := TierDefinitionsFromProfile(&config.Profile{Relevance: rel})
+			require.Len(t, defs, 6)
+			got := make(map[Tier]string, len(defs))
+			for _, d := range defs {
+				got[d.Tier] = d.Label
+			}
+			assert.Equal(t, map[Tier]string{
+				Tier0Critical:  "Schemas",
+				Tier1Primary:   "",
+				Tier2Secondary: "",
+				Tier3Tests:     "Fixtures",
+				Tier4Docs:      "",
+				Tier5Low:       "",
+			}, got)
+		})
+	}
+}
+
+// TestTierDefinitionsFromProfilePartial verifies that tiers left unset in the
+// profile fall back to the default patterns for that tier.
+func TestTierDefinitionsFromProfilePartial(t *testing.T) {
+	t.Parallel()
+
+	p := &config.Profile{Relevance: config.RelevanceConfig{
+		Tier0: []string{"schema.sql"},
+		Tier3: []string{},
+	}}
+
+	defs := TierDefinitionsFromProfile(p)
+	require.Len(t, defs, 6)
+
+	assert.Equal(t, []string{"schema.sql"}, defs[0].Patterns)
+	assert.Equal(t, defaultPatterns(t, Tier1Primary), defs[1].Patterns)
+	assert.Equal(t, defaultPatterns(t, Tier2Secondary), defs[2].Patterns)
+	assert.Empty(t, defs[3].Patterns, "explicit empty tier must not fall back to defaults")
+	assert.Equal(t, defaultPatterns(t, Tier5Low), defs[5].Patterns)
+
+	m := NewTierMatcher(defs)
+	assert.Equal(t, Tier0Critical, m.Match("schema.sql"))
+	assert.Equal(t, Tier1Primary, m.Match("cmd/harvx/main.go"))
+}
+
+// TestTierDefinitionsFromProfileEmpty verifies that a nil profile and a
+// profile without any tier patterns both yield DefaultTierDefinitions.
+func TestTierDefinitionsFromProfileEmpty(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultTierDefinitions(), TierDefinitionsFromProfile(nil))
+	assert.Equal(t, DefaultTierDefinitions(), TierDefinitionsFromProfile(&config.Profile{}))
+	assert.Equal(t, DefaultTierDefinitions(), TierDefinitionsFromProfile(&config.Profile{
+		Relevance: config.RelevanceConfig{Tier0: []string{}},
+	}))
+}
+
+// TestTierDefinitionsFromProfileDoesNotAliasProfile verifies that mutating
+// the returned patterns leaves the profile untouched.
+func TestTierDefinitionsFromProfileDoesNotAliasProfile(t *testing.T) {
+	t.Parallel()
+
+	p := &config.Profile{Relevance: config.RelevanceConfig{Tier0: []string{"go.mod"}}}
+	defs := TierDefinitionsFromProfile(p)
+	defs[0].Patterns[0] = "changed"
+
+	assert.Equal(t, []string{"go.mod"}, p.Relevance.Tier0)
+}
+
+// TestNewTierMatcherForProfileHonoursBaseDir verifies that the profile's
+// base_dir is applied to the matcher built from its tiers.
+func TestNewTierMatcherForProfileHonoursBaseDir(t *testing.T) {
+	t.Parallel()
+
+	p := &config.Profile{
+		BaseDir:   "services/billing",
+		Relevance: config.RelevanceConfig{Tier0: []string{"cmd/**"}},
+	}
+	m := NewTierMatcherForProfile(p)
+
+	assert.Equal(t, Tier0Critical, m.Match("services/billing/cmd/main.go"))
+	assert.Equal(t, DefaultUnmatchedTier, m.Match("cmd/main.go"))
+
+	require.NotNil(t, NewTierMatcherForProfile(nil))
+}
+
+// TestNewTierMatcherForProfileCaseInsensitive verifies that the profile's
+// case_insensitive setting reaches the matcher alongside base_dir.
+func TestNewTierMatcherForProfileCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	p := &config.Profile{
+		BaseDir:         "packages/api",
+		CaseInsensitive: true,
+		Relevance:       config.RelevanceConfig{Tier0: []string{"Makefile"}},
+	}
+	m := NewTierMatcherForProfile(p)
+
+	assert.Equal(t, Tier0Critical, m.Match("packages/api/makefile"))
+	assert.Equal(t, DefaultUnmatchedTier, m.Match("makefile"), "base_dir still applies")
+
+	p.CaseInsensitive = false
+	assert.Equal(t, DefaultUnmatchedTier, NewTierMatcherForProfile(p).Match("packages/api/makefile"))
+}
+
+// TestCascadeMatcherRaisesSubtreeOnly verifies that a subdir/harvx.toml
+// raises the tier of files in its subtree without affecting siblings.
+func TestCascadeMatcherRaisesSubtreeOnly(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "subdir"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "subdir", "harvx.toml"), []byte(`
+[profile.default.relevance]
+tier_0 = ["*.txt"]
+`), 0o644))
+
+	cascade, err := config.DiscoverCascade(root)
+	require.NoError(t, err)
+
+	base := config.DefaultProfile()
+	m := NewCascadeMatcher(base, "default", cascade)
+	plain := NewTierMatcherForProfile(base)
+
+	assert.Equal(t, Tier0Critical, m.Match("subdir/notes.txt"))
+	require.NotEqual(t, Tier0Critical, plain.Match("sibling/notes.txt"))
+	assert.Equal(t, plain.Match("sibling/notes.txt"), m.Match("sibling/notes.txt"))
+	assert.Equal(t, plain.Match("notes.txt"), m.Match("notes.txt"))
+
+	// Tiers the directory config leaves unset still come from the base.
+	assert.Equal(t, plain.Match("subdir/main_test.go"), m.Match("subdir/main_test.go"))
+}
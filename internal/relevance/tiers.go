@@ -63,9 +63,13 @@ func (t Tier) String() string {
 // TierDefinition maps a Tier to the glob patterns that place a file into it.
 // Patterns use doublestar (bmatcuk/doublestar/v4) glob syntax; validation is
 // performed by the classifier in T-027.
+//
+// Label optionally names the tier in explain and summary output. When it is
+// empty, TierLabel's built-in name for the tier number is used.
 type TierDefinition struct {
 	Tier     Tier     `toml:"tier"`
 	Patterns []string `toml:"patterns"`
+	Label    string   `toml:"label"`
 }
 
 // DefaultTierDefinitions returns the built-in tier definitions as specified in
@@ -0,0 +1,190 @@
+package security
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/harvx/harvx/internal/config"
+)
+
+// Entropy cutoffs in bits per character applied by EntropyScanner for each
+// confidence threshold. Shannon entropy is bounded by log2 of the token
+// length, so a higher cutoff also implies a longer minimum token: 4.8 needs
+// roughly 28 distinct characters, 3.8 roughly 14.
+const (
+	entropyCutoffHigh   = 4.8
+	entropyCutoffMedium = 4.3
+	entropyCutoffLow    = 3.8
+)
+
+// maxEntropyTokenLength is the longest token EntropyScanner considers.
+// Longer runs of base64 are encoded data (images, certificates, embedded
+// blobs) rather than credentials.
+const maxEntropyTokenLength = 128
+
+// digestPrefixes mark tokens that are content digests rather than secrets,
+// such as the integrity hashes in package-lock.json and yarn.lock and the
+// h1: hashes in go.sum. A prefix may be part of the token or immediately
+// precede it (the tokenizer splits on ':').
+var digestPrefixes = []string{"sha1-", "sha256-", "sha384-", "sha512-", "h1:"}
+
+// digestHexLengths are the lengths of hex-encoded MD5, SHA-1, SHA-256 and
+// SHA-512 digests, as found in Cargo.lock checksums and commit hashes.
+var digestHexLengths = map[int]bool{32: true, 40: true, 64: true, 128: true}
+
+// entropyCalculator computes entropy for DetectHighEntropy. It is read-only
+// after initialisation and therefore safe for concurrent use.
+var entropyCalculator = NewEntropyAnalyzer()
+
+// DetectHighEntropy reports whether the Shannon entropy of token, in bits per
+// character, is at least threshold. Tokens shorter than 16 characters never
+// qualify because their entropy is not a meaningful signal.
+func DetectHighEntropy(token string, threshold float64) bool {
+	entropy := entropyCalculator.Calculate(token)
+	return entropy > 0.0 && entropy >= threshold
+}
+
+// EntropyCutoff maps a confidence threshold to the entropy cutoff used by
+// EntropyScanner, so the existing confidence_threshold setting drives
+// sensitivity: "high" flags only the most random tokens and "low" is the most
+// aggressive. ConfidenceOff and unrecognised values behave like
+// ConfidenceLow.
+func EntropyCutoff(threshold Confidence) float64 {
+	switch threshold {
+	case ConfidenceHigh:
+		return entropyCutoffHigh
+	case ConfidenceMedium:
+		return entropyCutoffMedium
+	default:
+		return entropyCutoffLow
+	}
+}
+
+// EntropyScanner replaces high-entropy tokens that no named pattern matched,
+// such as random API keys, with [REDACTED:entropy] markers. Unlike the
+// entropy pass of StreamRedactor it runs regardless of file sensitivity and
+// needs no suspicious variable name; its sensitivity comes entirely from the
+// confidence threshold.
+//
+// To keep false positives down, a token is only flagged when it contains both
+// letters and digits, and content digests (lockfile integrity hashes, go.sum
+// hashes, hex checksums) and base64 data (data URIs, blobs longer than 128
+// characters) are skipped.
+//
+// EntropyScanner holds no mutable state and is safe for concurrent use.
+type EntropyScanner struct {
+	enabled      bool
+	cutoff       float64
+	excludePaths []string
+	baseDir      string
+}
+
+// NewEntropyScanner returns an EntropyScanner configured from cfg: Enabled
+// turns scanning on, ConfidenceThreshold selects the cutoff via
+// EntropyCutoff, and ExcludePaths (relative to BaseDir) lists files that are
+// never scanned.
+func NewEntropyScanner(cfg RedactionConfig) *EntropyScanner {
+	return &EntropyScanner{
+		enabled:      cfg.Enabled,
+		cutoff:       EntropyCutoff(cfg.ConfidenceThreshold),
+		excludePaths: cfg.ExcludePaths,
+		baseDir:      cfg.BaseDir,
+	}
+}
+
+// Scan redacts high-entropy tokens in content and returns the redacted
+// content with the number of replacements. Content is returned unchanged
+// when the scanner is disabled or filePath matches an exclude pattern.
+func (s *EntropyScanner) Scan(content, filePath string) (string, int) {
+	if !s.enabled || isExcludedPath(s.excludePaths, s.baseDir, filePath) {
+		return content, 0
+	}
+
+	lines := strings.Split(content, "\n")
+	total := 0
+	for i, line := range lines {
+		redacted, n := s.ScanLine(line)
+		lines[i] = redacted
+		total += n
+	}
+	if total == 0 {
+		return content, 0
+	}
+	return strings.Join(lines, "\n"), total
+}
+
+// ScanLine redacts high-entropy tokens in a single line and returns the
+// redacted line with the number of replacements. Tokens inside existing
+// [REDACTED:...] markers or partial masks are left alone, so ScanLine can run
+// after pattern-based redaction.
+func (s *EntropyScanner) ScanLine(line string) (string, int) {
+	tokens := tokenizeLine(line)
+	if len(tokens) == 0 {
+		return line, 0
+	}
+	redactedSpans := findRedactedSpans(line)
+
+	var toRedact []lineToken
+	for _, tok := range tokens {
+		if overlapsRedacted(tok.start, tok.end, redactedSpans) || isPartiallyMasked(tok.value) {
+			continue
+		}
+		if isEntropyFalsePositive(line, tok) {
+			continue
+		}
+		if DetectHighEntropy(tok.value, s.cutoff) {
+			toRedact = append(toRedact, tok)
+		}
+	}
+	if len(toRedact) == 0 {
+		return line, 0
+	}
+
+	// Apply replacements right-to-left to preserve byte offsets.
+	result := line
+	for i := len(toRedact) - 1; i >= 0; i-- {
+		tok := toRedact[i]
+		result = result[:tok.start] + FormatReplacement("entropy") + result[tok.end:]
+	}
+	return result, len(toRedact)
+}
+
+// isEntropyFalsePositive reports whether tok is high-entropy text that is
+// known not to be a secret, or text that does not look like a credential.
+func isEntropyFalsePositive(line string, tok lineToken) bool {
+	value := tok.value
+	if len(value) > maxEntropyTokenLength || strings.Contains(value, "base64,") {
+		return true
+	}
+	for _, prefix := range digestPrefixes {
+		if strings.HasPrefix(value, prefix) || strings.HasSuffix(line[:tok.start], prefix) {
+			return true
+		}
+	}
+	if DetectCharset(value) == CharsetHex && digestHexLengths[len(value)] {
+		return true
+	}
+	return !strings.ContainsAny(value, "0123456789") ||
+		!strings.ContainsAny(strings.ToLower(value), "abcdefghijklmnopqrstuvwxyz")
+}
+
+// isExcludedPath reports whether filePath matches any of the doublestar glob
+// patterns, which are relative to baseDir (see config.RebasePath). A file
+// outside baseDir is never excluded.
+func isExcludedPath(patterns []string, baseDir, filePath string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+	normalized, ok := config.RebasePath(baseDir, filepath.ToSlash(filePath))
+	if !ok {
+		return false
+	}
+	for _, pattern := range patterns {
+		if ok, _ := doublestar.Match(pattern, normalized); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,129 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Realistic fixtures for tuning the entropy cutoffs.
+const (
+	// randomKey40 is a 40-character random base62 token, the shape of many
+	// vendor API keys that have no recognisable prefix.
+	randomKey40 = "Zq8Xw2Lr5Tn9Vb4Km7Hc1Jd6Pf3Gs0Ya8Ue5Ri2"
+
+	// randomKey24 is a shorter random token that only the lower cutoffs catch.
+	randomKey24 = "k7Qm2xR9vL4tW8nB3pZ6cJ1d"
+)
+
+func TestDetectHighEntropy(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		threshold float64
+		want      bool
+	}{
+		{"random key above cutoff", randomKey40, entropyCutoffHigh, true},
+		{"short random key below high cutoff", randomKey24, entropyCutoffHigh, false},
+		{"short random key above medium cutoff", randomKey24, entropyCutoffMedium, true},
+		{"repeated characters", strings.Repeat("ab", 20), entropyCutoffLow, false},
+		{"below minimum length", "k7Qm2xR9vL4", 1.0, false},
+		{"empty", "", 0.5, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectHighEntropy(tt.token, tt.threshold))
+		})
+	}
+}
+
+func TestEntropyCutoff(t *testing.T) {
+	assert.Equal(t, entropyCutoffHigh, EntropyCutoff(ConfidenceHigh))
+	assert.Equal(t, entropyCutoffMedium, EntropyCutoff(ConfidenceMedium))
+	assert.Equal(t, entropyCutoffLow, EntropyCutoff(ConfidenceLow))
+	assert.Equal(t, entropyCutoffLow, EntropyCutoff(ConfidenceOff))
+	assert.Equal(t, entropyCutoffLow, EntropyCutoff(""))
+	assert.Greater(t, EntropyCutoff(ConfidenceHigh), EntropyCutoff(ConfidenceMedium))
+	assert.Greater(t, EntropyCutoff(ConfidenceMedium), EntropyCutoff(ConfidenceLow))
+}
+
+func TestEntropyScanner_RedactsRandomKeys(t *testing.T) {
+	s := NewEntropyScanner(RedactionConfig{Enabled: true, ConfidenceThreshold: ConfidenceHigh})
+
+	got, n := s.ScanLine(`client := vendor.New("` + randomKey40 + `")`)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, `client := vendor.New("[REDACTED:entropy]")`, got)
+
+	got, n = s.ScanLine("PAYMENTS_KEY=" + randomKey40 + " OTHER=" + randomKey40)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "PAYMENTS_KEY=[REDACTED:entropy] OTHER=[REDACTED:entropy]", got)
+}
+
+func TestEntropyScanner_ThresholdDrivesSensitivity(t *testing.T) {
+	line := "token: " + randomKey24
+
+	for _, tc := range []struct {
+		threshold Confidence
+		want      int
+	}{
+		{ConfidenceHigh, 0},
+		{ConfidenceMedium, 1},
+		{ConfidenceLow, 1},
+	} {
+		s := NewEntropyScanner(RedactionConfig{Enabled: true, ConfidenceThreshold: tc.threshold})
+		_, n := s.ScanLine(line)
+		assert.Equal(t, tc.want, n, "threshold %s", tc.threshold)
+	}
+}
+
+// TestEntropyScanner_FalsePositives verifies that common high-entropy text
+// that is not a secret survives even the most aggressive cutoff.
+func TestEntropyScanner_FalsePositives(t *testing.T) {
+	s := NewEntropyScanner(RedactionConfig{Enabled: true, ConfidenceThreshold: ConfidenceLow})
+
+	lines := map[string]string{
+		"package-lock integrity": `      "integrity": "sha512-v2kDEe57lecTulaDIuNTPy3Ry4gLGJ6Z1O3vE1krgXZNrsQ+LFTGHVxVjcXPs17LhbZVGedAJv8XZ1tvj5FvSg==",`,
+		"yarn.lock integrity":    `  integrity sha1-ivHkwSISRMxiRZ+vOJQNTmRKVyM=`,
+		"go.sum hash":            `github.com/stretchr/testify v1.9.0 h1:HtqpIVDClZ4nwg75+f6Lvsy/wHu+3BoSGCbBAcpTsTg=`,
+		"Cargo.lock checksum":    `checksum = "0453232ace82dee0dd0b4c383a8d36af3a1d4f2a9f5a8b4aa6f0e0c4f5a9e1b2"`,
+		"git commit hash":        `replace example.com/mod => example.com/fork 9fceb02d0ae598e95dc970b74767f19372d61af8`,
+		"data URI":               `background: url("data:image/png;base64,iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg==");`,
+		"base64 blob":            `const font = "` + strings.Repeat("d09GMgABAAAAAAUQAA0AAAAACsQAAAS5AAEAAAAAAAAAAAAAAAAAAAAA", 3) + `"`,
+		"camelCase identifier":   `func handleUserAccountSettingsUpdateRequest(w http.ResponseWriter) {`,
+		"file path":              `import "github.com/harvx/harvx/internal/security/redactor"`,
+		"existing marker":        `api_key = "[REDACTED:generic_api_key]"`,
+	}
+	for name, line := range lines {
+		t.Run(name, func(t *testing.T) {
+			got, n := s.ScanLine(line)
+			assert.Zero(t, n)
+			assert.Equal(t, line, got)
+		})
+	}
+}
+
+func TestEntropyScanner_Scan(t *testing.T) {
+	content := "package main\n\nconst key = \"" + randomKey40 + "\"\n"
+	cfg := RedactionConfig{
+		Enabled:             true,
+		ConfidenceThreshold: ConfidenceHigh,
+		ExcludePaths:        []string{"**/testdata/**", "**/*_test.go"},
+	}
+	s := NewEntropyScanner(cfg)
+
+	got, n := s.Scan(content, "cmd/main.go")
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "package main\n\nconst key = \"[REDACTED:entropy]\"\n", got)
+
+	for _, path := range []string{"internal/testdata/fixture.go", "cmd/main_test.go"} {
+		got, n = s.Scan(content, path)
+		assert.Zero(t, n, path)
+		assert.Equal(t, content, got, path)
+	}
+
+	cfg.Enabled = false
+	got, n = NewEntropyScanner(cfg).Scan(content, "cmd/main.go")
+	assert.Zero(t, n)
+	assert.Equal(t, content, got)
+}
@@ -2,11 +2,8 @@ package security
 
 import (
 	"context"
-	"path/filepath"
 	"strings"
 	"sync"
-
-	"github.com/bmatcuk/doublestar/v4"
 )
 
 // Redactor scans file content for secrets and returns a sanitised copy with
@@ -107,7 +104,8 @@ func NewStreamRedactor(registry *PatternRegistry, analyzer *EntropyAnalyzer, cfg
 //   - err: non-nil only if ctx is cancelled mid-scan
 //
 // When cfg.Enabled is false, Redact returns content unchanged with nil matches.
-// When filePath matches any cfg.ExcludePaths pattern, Redact returns unchanged.
+// When filePath matches any cfg.ExcludePaths pattern (relative to
+// cfg.BaseDir), Redact returns unchanged.
 func (r *StreamRedactor) Redact(ctx context.Context, content string, filePath string) (string, []RedactionMatch, error) {
 	// Fast path: redaction disabled.
 	if !r.config.Enabled {
@@ -115,11 +113,8 @@ func (r *StreamRedactor) Redact(ctx context.Context, content string, filePath st
 	}
 
 	// Check path exclusions.
-	normalized := filepath.ToSlash(filePath)
-	for _, pattern := range r.config.ExcludePaths {
-		if ok, _ := doublestar.Match(pattern, normalized); ok {
-			return content, nil, nil
-		}
+	if isExcludedPath(r.config.ExcludePaths, r.config.BaseDir, filePath) {
+		return content, nil, nil
 	}
 
 	// Determine if heightened scanning mode applies.
@@ -307,7 +302,7 @@ func (r *StreamRedactor) processLine(line string, lineNum int, filePath string,
 				}
 			}
 
-			repl := FormatReplacement(rule.SecretType)
+			repl := r.replacement(rule.SecretType, secretValue)
 			replacements = append(replacements, lineReplacement{
 				start:       secretStart,
 				end:         secretEnd,
@@ -387,8 +382,9 @@ func (r *StreamRedactor) processEntropy(line string, lineNum int, filePath strin
 	redactedSpans := findRedactedSpans(line)
 
 	for _, tok := range tokens {
-		// Skip tokens whose byte range overlaps an existing [REDACTED:...] span.
-		if overlapsRedacted(tok.start, tok.end, redactedSpans) {
+		// Skip tokens whose byte range overlaps an existing [REDACTED:...] span,
+		// and tokens already masked by a partial redaction.
+		if overlapsRedacted(tok.start, tok.end, redactedSpans) || isPartiallyMasked(tok.value) {
 			continue
 		}
 		// Minimum 16 characters for entropy analysis.
@@ -421,7 +417,7 @@ func (r *StreamRedactor) processEntropy(line string, lineNum int, filePath strin
 		if tm.start < 0 || tm.end > len(result) || tm.start > tm.end {
 			continue
 		}
-		repl := "[REDACTED:high_entropy_secret]"
+		repl := r.replacement("high_entropy_secret", result[tm.start:tm.end])
 		result = result[:tm.start] + repl + result[tm.end:]
 		matches = append(matches, RedactionMatch{
 			RuleID:      "entropy-analyzer",
@@ -443,6 +439,24 @@ func (r *StreamRedactor) processEntropy(line string, lineNum int, filePath strin
 	return result, matches
 }
 
+// replacement returns the string that replaces secret under the configured
+// RedactionMode: a partial mask when the mode is partial and secret is long
+// enough to mask safely, and the [REDACTED:type] marker otherwise.
+func (r *StreamRedactor) replacement(secretType, secret string) string {
+	if r.config.Mode == RedactionModePartial {
+		if masked, ok := MaskPartial(secret); ok {
+			return masked
+		}
+	}
+	return FormatReplacement(secretType)
+}
+
+// isPartiallyMasked reports whether token contains the asterisk run that
+// MaskPartial writes, so the entropy pass does not re-redact masked secrets.
+func isPartiallyMasked(token string) bool {
+	return strings.Contains(token, strings.Repeat("*", minPartialMasked))
+}
+
 // lineToken represents a token extracted from a line with its byte positions.
 type lineToken struct {
 	value string
@@ -520,6 +534,8 @@ func confidenceLevel(c Confidence) int {
 		return 2
 	case ConfidenceMedium:
 		return 1
+	case ConfidenceOff:
+		return -1
 	default: // ConfidenceLow or empty
 		return 0
 	}
@@ -530,6 +546,7 @@ func confidenceLevel(c Confidence) int {
 //	high   -> medium
 //	medium -> low
 //	low    -> low (floor)
+//	off    -> off
 //	empty  -> low
 func lowerConfidence(c Confidence) Confidence {
 	switch c {
@@ -537,6 +554,8 @@ func lowerConfidence(c Confidence) Confidence {
 		return ConfidenceMedium
 	case ConfidenceMedium:
 		return ConfidenceLow
+	case ConfidenceOff:
+		return ConfidenceOff
 	default:
 		return ConfidenceLow
 	}
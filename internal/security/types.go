@@ -31,6 +31,27 @@ const (
 	// without supporting keywords. Use with caution; false-positive rate is
 	// elevated.
 	ConfidenceLow Confidence = "low"
+
+	// ConfidenceOff disables confidence filtering when used as a
+	// RedactionConfig.ConfidenceThreshold. Every candidate match is kept
+	// regardless of its confidence, and the entropy pass runs on every file.
+	// It is never assigned to a RedactionMatch.
+	ConfidenceOff Confidence = "off"
+)
+
+// RedactionMode selects how a detected secret is replaced in the output.
+type RedactionMode string
+
+const (
+	// RedactionModeFull replaces the secret with a [REDACTED:type] marker.
+	// It is the default when RedactionConfig.Mode is empty.
+	RedactionModeFull RedactionMode = "full"
+
+	// RedactionModePartial keeps the first and last few characters of the
+	// secret and masks the middle with asterisks (see MaskPartial), preserving
+	// its length and prefix. Secrets too short to keep enough of them masked
+	// fall back to the full marker.
+	RedactionModePartial RedactionMode = "partial"
 )
 
 // RedactionMatch records a single detected secret within a file. All fields
@@ -99,12 +120,21 @@ type RedactionConfig struct {
 	// example secrets.
 	ExcludePaths []string `json:"exclude_paths"`
 
+	// BaseDir evaluates ExcludePaths relative to this directory, mirroring
+	// config.Profile.BaseDir. Files outside it match no exclude pattern.
+	// Empty means patterns are relative to the target directory.
+	BaseDir string `json:"base_dir,omitempty"`
+
 	// ConfidenceThreshold is the minimum confidence level for a match to
 	// trigger redaction. Matches below this level are reported but not
-	// replaced. Valid values are ConfidenceLow, ConfidenceMedium, and
-	// ConfidenceHigh.
+	// replaced. Valid values are ConfidenceLow, ConfidenceMedium,
+	// ConfidenceHigh, and ConfidenceOff (keep every match).
 	ConfidenceThreshold Confidence `json:"confidence_threshold"`
 
+	// Mode selects how detected secrets are replaced. An empty value behaves
+	// like RedactionModeFull. Private key blocks are always fully redacted.
+	Mode RedactionMode `json:"mode"`
+
 	// CustomPatterns holds additional redaction rules supplied at runtime
 	// (e.g. from a harvx.toml custom_patterns block). They are appended to
 	// the built-in rules in the PatternRegistry.
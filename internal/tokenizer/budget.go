@@ -4,10 +4,14 @@
 package tokenizer
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/harvx/harvx/internal/pipeline"
 )
@@ -48,6 +52,11 @@ type BudgetSummary struct {
 	// TierStats maps tier number (0-5) to the corresponding TierStat.
 	// Only tiers that had at least one file processed are present.
 	TierStats map[int]TierStat
+
+	// ExclusionCounts maps each exclusion reason (see BudgetResult
+	// .ExclusionReasons) to the number of files excluded for it. Only
+	// reasons that excluded at least one file are present.
+	ExclusionCounts map[string]int
 }
 
 // BudgetResult is the output of a single BudgetEnforcer.Enforce call. It
@@ -76,8 +85,69 @@ type BudgetResult struct {
 	// alone exceeds maxTokens.
 	BudgetRemaining int
 
+	// TruncatedTokens is the number of tokens TruncateStrategy cut from
+	// TruncatedFiles: their original TokenCount minus their truncated one.
+	TruncatedTokens int
+
 	// Summary provides per-tier statistics for the enforcement run.
 	Summary BudgetSummary
+
+	// TokenizerName is the tokenizer used for truncation counts, or
+	// NameEstimatorFallback when the enforcer fell back to the estimator.
+	TokenizerName string
+
+	// TimedOut reports that BudgetEnforcer.Timeout expired before every file
+	// was decided. The result then covers only the files decided in time:
+	// undecided files appear in none of the file lists.
+	TimedOut bool
+
+	// ExclusionReasons maps the path of every file in ExcludedFiles to the
+	// reason it was dropped: ExclusionReasonBudgetExceeded,
+	// ExclusionReasonOversized, ExclusionReasonFileTooLarge, or
+	// ExclusionReasonTierCap. The first three correspond to
+	// relevance.ExclusionReasonOverBudget in explain output.
+	ExclusionReasons map[string]string
+}
+
+// Exclusion reasons recorded in BudgetResult.ExclusionReasons.
+const (
+	// ExclusionReasonBudgetExceeded marks a file that would fit an empty
+	// budget but not the budget left when it was reached.
+	ExclusionReasonBudgetExceeded = "budget_exceeded"
+
+	// ExclusionReasonOversized marks a file whose token count alone exceeds
+	// the whole budget after overhead, so no ordering could include it.
+	ExclusionReasonOversized = "oversized"
+
+	// ExclusionReasonTierCap marks a file dropped because its tier already
+	// reached its BudgetEnforcer.TierFileCaps limit.
+	ExclusionReasonTierCap = "tier_cap"
+
+	// ExclusionReasonFileTooLarge marks a file whose token count exceeds
+	// BudgetEnforcer.MaxFileTokens.
+	ExclusionReasonFileTooLarge = "file_too_large"
+)
+
+// FillPercent returns BudgetUsed as a percentage of the token budget
+// (BudgetUsed + BudgetRemaining), or 0 when no budget was enforced. It may
+// exceed 100 when overhead alone exceeds the budget.
+func (r *BudgetResult) FillPercent() float64 {
+	budget := r.BudgetUsed + r.BudgetRemaining
+	if budget <= 0 {
+		return 0
+	}
+	return float64(r.BudgetUsed) * 100 / float64(budget)
+}
+
+// TruncationRatio returns the fraction, between 0 and 1, of the included
+// files' original tokens that truncation dropped: TruncatedTokens over
+// TotalTokens + TruncatedTokens. It returns 0 when nothing was included.
+func (r *BudgetResult) TruncationRatio() float64 {
+	attempted := r.TotalTokens + r.TruncatedTokens
+	if attempted <= 0 {
+		return 0
+	}
+	return float64(r.TruncatedTokens) / float64(attempted)
 }
 
 // BudgetEnforcer enforces a maximum token budget over an ordered slice of
@@ -85,9 +155,50 @@ type BudgetResult struct {
 // exceeds the remaining budget. It is safe for sequential use only; do not
 // call Enforce from multiple goroutines simultaneously.
 type BudgetEnforcer struct {
+	// TruncationMarker builds the marker appended to truncated file content
+	// from the tokens shown and the file's original token count. Nil selects
+	// DefaultTruncationMarker. Set it to match the output format, since the
+	// default is a Markdown/HTML comment.
+	TruncationMarker func(shown, total int) string
+
+	// Timeout bounds the wall-clock time of a single Enforce or EnforceStream
+	// call. When it expires, enforcement stops before the next file and
+	// returns what was decided so far. Zero means no limit.
+	Timeout time.Duration
+
+	// TierFileCaps limits how many files of each tier, keyed by tier number,
+	// are considered: files of a capped tier beyond the first N in input
+	// order are excluded with ExclusionReasonTierCap before budgeting. A cap
+	// of zero or an absent tier means uncapped. The pipeline applies profile
+	// caps itself (see pipeline.RunOptions.TierFileCaps); set this when using
+	// the enforcer directly.
+	TierFileCaps map[int]int
+
+	// TierWeights changes the order in which tiers compete for the budget,
+	// keyed by tier number: files of heavier tiers are decided first, tiers
+	// without a weight count as 0, and equal weights keep tier-number order.
+	// Within a tier, input order is preserved. Weights do not change a file's
+	// Tier, only which files win when the budget runs out. Empty means files
+	// are decided in input order. See config.Profile.TierWeights.
+	TierWeights map[int]int
+
+	// MaxFileTokens excludes every file whose TokenCount exceeds it with
+	// ExclusionReasonFileTooLarge, before budgeting and whatever budget
+	// remains: such a file is never truncated to fit, and it does not count
+	// toward its tier's TierFileCaps limit. It applies even when maxTokens
+	// <= 0. Zero means no per-file cap.
+	MaxFileTokens int
+
 	maxTokens int
 	strategy  TruncationStrategy
 	tok       Tokenizer
+	tokName   string
+}
+
+// DefaultTruncationMarker returns the Markdown comment appended to truncated
+// content when BudgetEnforcer.TruncationMarker is nil.
+func DefaultTruncationMarker(shown, total int) string {
+	return fmt.Sprintf("<!-- Content truncated: %d of %d tokens shown -->", shown, total)
 }
 
 // NewBudgetEnforcer constructs a BudgetEnforcer.
@@ -101,8 +212,10 @@ type BudgetEnforcer struct {
 //
 // tok is used to count tokens of candidate line subsets during the binary
 // search in TruncateStrategy. Pass nil to fall back to the character estimator
-// (len/4), which is fast but less accurate.
+// (len/4), which is fast but less accurate; pass NewCharEstimator to use a
+// different chars-per-token ratio.
 func NewBudgetEnforcer(maxTokens int, strategy TruncationStrategy, tok Tokenizer) *BudgetEnforcer {
+	tokName := DisplayName(tok)
 	if tok == nil {
 		tok = newEstimatorTokenizer()
 	}
@@ -110,41 +223,194 @@ func NewBudgetEnforcer(maxTokens int, strategy TruncationStrategy, tok Tokenizer
 		maxTokens: maxTokens,
 		strategy:  strategy,
 		tok:       tok,
+		tokName:   tokName,
+	}
+}
+
+// Decision is the outcome BudgetEnforcer reaches for a single file.
+type Decision int
+
+const (
+	// DecisionInclude includes the file at its full token count.
+	DecisionInclude Decision = iota
+
+	// DecisionTruncate includes a truncated copy of the file that fills the
+	// remaining budget.
+	DecisionTruncate
+
+	// DecisionExclude drops the file because the budget is exhausted.
+	DecisionExclude
+)
+
+// String returns the lowercase name of the decision.
+func (d Decision) String() string {
+	switch d {
+	case DecisionInclude:
+		return "include"
+	case DecisionTruncate:
+		return "truncate"
+	case DecisionExclude:
+		return "exclude"
+	default:
+		return fmt.Sprintf("Decision(%d)", int(d))
 	}
 }
 
 // Enforce applies the token budget to files and returns a BudgetResult.
 //
 // files must already be sorted by tier then path (as produced by T-028); they
-// are processed in the provided order without re-sorting.
+// are processed in the provided order without re-sorting, except that
+// TierWeights, when set, reorders tiers by weight.
 //
 // overhead is the estimated token cost of output document structure (headers,
 // file tree, section markers). It is subtracted from maxTokens before
-// evaluating individual files.
+// evaluating individual files. EstimateOverhead derives it from the output
+// format, target, and file count.
 //
 // When maxTokens <= 0 all files are included, overhead is ignored, and the
 // result reports zero budget fields.
+//
+// Enforcement runs in two passes. The size pass counts tokens for files that
+// carry a ContentReader but no Content and no TokenCount, streaming the reader
+// without retaining it. Content is then loaded only for each file as it is
+// included, so excluded files never read their content. Files
+// whose reader fails have their Error field set and are kept with no content.
+//
+// Enforce collects the decisions made by EnforceStream into slices; use
+// EnforceStream directly to avoid holding them for very large runs.
 func (e *BudgetEnforcer) Enforce(files []*pipeline.FileDescriptor, overhead int) *BudgetResult {
 	result := &BudgetResult{
-		IncludedFiles: make([]*pipeline.FileDescriptor, 0, len(files)),
-		ExcludedFiles: make([]*pipeline.FileDescriptor, 0),
-		TruncatedFiles: make([]*pipeline.FileDescriptor, 0),
-		Summary: BudgetSummary{
-			TierStats: make(map[int]TierStat),
-		},
+		IncludedFiles:    make([]*pipeline.FileDescriptor, 0, len(files)),
+		ExcludedFiles:    make([]*pipeline.FileDescriptor, 0),
+		TruncatedFiles:   make([]*pipeline.FileDescriptor, 0),
+		TokenizerName:    e.tokName,
+		ExclusionReasons: make(map[string]string),
 	}
 
-	// When no budget is configured, include everything.
-	if e.maxTokens <= 0 {
-		result.IncludedFiles = append(result.IncludedFiles, files...)
-		for _, fd := range files {
+	result.Summary, result.TruncatedTokens, result.TimedOut = e.enforceStream(files, overhead, func(fd *pipeline.FileDescriptor, decision Decision, reason string) {
+		switch decision {
+		case DecisionInclude:
+			result.IncludedFiles = append(result.IncludedFiles, fd)
+			result.TotalTokens += fd.TokenCount
+		case DecisionTruncate:
+			result.IncludedFiles = append(result.IncludedFiles, fd)
+			result.TruncatedFiles = append(result.TruncatedFiles, fd)
 			result.TotalTokens += fd.TokenCount
-			stat := result.Summary.TierStats[fd.Tier]
+		case DecisionExclude:
+			result.ExcludedFiles = append(result.ExcludedFiles, fd)
+			result.ExclusionReasons[fd.Path] = reason
+		}
+	})
+
+	if e.maxTokens > 0 {
+		result.BudgetUsed = overhead + result.TotalTokens
+		result.BudgetRemaining = e.maxTokens - result.BudgetUsed
+	}
+
+	return result
+}
+
+// EnforceStream applies the token budget like Enforce but reports each file's
+// outcome through onDecision instead of collecting result slices, and returns
+// only the per-tier summary. onDecision is called exactly once per file, in
+// input order, or in tier-weight order when TierWeights is set. For
+// DecisionTruncate it receives the truncated copy rather than the original
+// descriptor. Included and truncated files have their Content loaded from
+// ContentReader before the callback runs; excluded files are never read.
+// onDecision may be nil.
+//
+// When e.Timeout expires, no further files are decided and onDecision is not
+// called for them; the summary covers only the files reported.
+func (e *BudgetEnforcer) EnforceStream(
+	files []*pipeline.FileDescriptor,
+	overhead int,
+	onDecision func(fd *pipeline.FileDescriptor, decision Decision),
+) BudgetSummary {
+	var report func(*pipeline.FileDescriptor, Decision, string)
+	if onDecision != nil {
+		report = func(fd *pipeline.FileDescriptor, decision Decision, _ string) {
+			onDecision(fd, decision)
+		}
+	}
+	summary, _, _ := e.enforceStream(files, overhead, report)
+	return summary
+}
+
+// enforceStream implements EnforceStream, additionally passing onDecision
+// the exclusion reason of each excluded file (empty for included ones), and
+// also reports the number of tokens truncation dropped and whether e.Timeout
+// expired before every file was decided.
+func (e *BudgetEnforcer) enforceStream(
+	files []*pipeline.FileDescriptor,
+	overhead int,
+	onDecision func(fd *pipeline.FileDescriptor, decision Decision, reason string),
+) (BudgetSummary, int, bool) {
+	summary := BudgetSummary{
+		TierStats:       make(map[int]TierStat),
+		ExclusionCounts: make(map[string]int),
+	}
+
+	var deadline time.Time
+	if e.Timeout > 0 {
+		deadline = time.Now().Add(e.Timeout)
+	}
+	timedOut := false
+	expired := func() bool {
+		if !timedOut && !deadline.IsZero() && time.Now().After(deadline) {
+			timedOut = true
+			slog.Warn("budget enforcement timed out", "timeout", e.Timeout)
+		}
+		return timedOut
+	}
+
+	var included, excluded, truncated, totalTokens int
+	emit := func(fd *pipeline.FileDescriptor, decision Decision, reason string) bool {
+		if expired() {
+			return false
+		}
+		stat := summary.TierStats[fd.Tier]
+		switch decision {
+		case DecisionInclude, DecisionTruncate:
+			if needsRealize(fd) {
+				e.realize(fd)
+			}
 			stat.FilesIncluded++
 			stat.TokensUsed += fd.TokenCount
-			result.Summary.TierStats[fd.Tier] = stat
+			included++
+			totalTokens += fd.TokenCount
+			if decision == DecisionTruncate {
+				truncated++
+			}
+		case DecisionExclude:
+			stat.FilesExcluded++
+			excluded++
+			summary.ExclusionCounts[reason]++
+		}
+		summary.TierStats[fd.Tier] = stat
+
+		if onDecision != nil {
+			onDecision(fd, decision, reason)
 		}
-		return result
+		return true
+	}
+
+	files = e.weightedOrder(files)
+	e.sizeFiles(files, expired)
+	preExcluded := e.preExclusions(files)
+
+	// When no budget is configured, include everything within the per-file
+	// and tier caps.
+	if e.maxTokens <= 0 {
+		for _, fd := range files {
+			decision, reason := DecisionInclude, ""
+			if r, ok := preExcluded[fd]; ok {
+				decision, reason = DecisionExclude, r
+			}
+			if !emit(fd, decision, reason) {
+				break
+			}
+		}
+		return summary, 0, timedOut
 	}
 
 	remaining := e.maxTokens - overhead
@@ -156,27 +422,27 @@ func (e *BudgetEnforcer) Enforce(files []*pipeline.FileDescriptor, overhead int)
 		"fileCount", len(files),
 	)
 
+	dropped := 0
 	switch e.strategy {
 	case TruncateStrategy:
-		e.enforceWithTruncate(files, remaining, result)
+		dropped = e.enforceWithTruncate(files, remaining, preExcluded, emit)
 	default:
 		// SkipStrategy is the default for any unrecognised value.
-		e.enforceWithSkip(files, remaining, result)
+		e.enforceWithSkip(files, remaining, preExcluded, emit)
 	}
 
-	result.BudgetUsed = overhead + result.TotalTokens
-	result.BudgetRemaining = e.maxTokens - result.BudgetUsed
-
 	slog.Debug("budget enforcement complete",
-		"included", len(result.IncludedFiles),
-		"excluded", len(result.ExcludedFiles),
-		"truncated", len(result.TruncatedFiles),
-		"totalTokens", result.TotalTokens,
-		"budgetUsed", result.BudgetUsed,
-		"budgetRemaining", result.BudgetRemaining,
+		"included", included,
+		"excluded", excluded,
+		"truncated", truncated,
+		"truncatedTokens", dropped,
+		"totalTokens", totalTokens,
+		"budgetUsed", overhead+totalTokens,
+		"budgetRemaining", e.maxTokens-overhead-totalTokens,
+		"timedOut", timedOut,
 	)
 
-	return result
+	return summary, dropped, timedOut
 }
 
 // enforceWithSkip runs the skip strategy: files that exceed remaining budget
@@ -185,19 +451,24 @@ func (e *BudgetEnforcer) Enforce(files []*pipeline.FileDescriptor, overhead int)
 func (e *BudgetEnforcer) enforceWithSkip(
 	files []*pipeline.FileDescriptor,
 	remaining int,
-	result *BudgetResult,
+	preExcluded map[*pipeline.FileDescriptor]string,
+	emit func(*pipeline.FileDescriptor, Decision, string) bool,
 ) {
+	available := remaining
 	for _, fd := range files {
+		if reason, ok := preExcluded[fd]; ok {
+			if !emit(fd, DecisionExclude, reason) {
+				return
+			}
+			continue
+		}
+
 		if fd.TokenCount <= remaining {
-			result.IncludedFiles = append(result.IncludedFiles, fd)
-			result.TotalTokens += fd.TokenCount
+			if !emit(fd, DecisionInclude, "") {
+				return
+			}
 			remaining -= fd.TokenCount
 
-			stat := result.Summary.TierStats[fd.Tier]
-			stat.FilesIncluded++
-			stat.TokensUsed += fd.TokenCount
-			result.Summary.TierStats[fd.Tier] = stat
-
 			slog.Debug("file included",
 				"path", fd.Path,
 				"tier", fd.Tier,
@@ -205,11 +476,9 @@ func (e *BudgetEnforcer) enforceWithSkip(
 				"remaining", remaining,
 			)
 		} else {
-			result.ExcludedFiles = append(result.ExcludedFiles, fd)
-
-			stat := result.Summary.TierStats[fd.Tier]
-			stat.FilesExcluded++
-			result.Summary.TierStats[fd.Tier] = stat
+			if !emit(fd, DecisionExclude, budgetExclusionReason(fd, available)) {
+				return
+			}
 
 			slog.Debug("file skipped (exceeds budget)",
 				"path", fd.Path,
@@ -224,35 +493,40 @@ func (e *BudgetEnforcer) enforceWithSkip(
 // enforceWithTruncate runs the truncate strategy: the first file that exceeds
 // the remaining budget is truncated at a line boundary to consume exactly
 // `remaining` tokens. All subsequent files are excluded because the budget is
-// now fully consumed after the truncation.
+// now fully consumed after the truncation. It returns the number of tokens
+// the truncation dropped.
 func (e *BudgetEnforcer) enforceWithTruncate(
 	files []*pipeline.FileDescriptor,
 	remaining int,
-	result *BudgetResult,
-) {
+	preExcluded map[*pipeline.FileDescriptor]string,
+	emit func(*pipeline.FileDescriptor, Decision, string) bool,
+) int {
+	available := remaining
 	budgetExhausted := false
+	dropped := 0
 
 	for _, fd := range files {
-		if budgetExhausted {
-			result.ExcludedFiles = append(result.ExcludedFiles, fd)
+		if reason, ok := preExcluded[fd]; ok {
+			if !emit(fd, DecisionExclude, reason) {
+				return dropped
+			}
+			continue
+		}
 
-			stat := result.Summary.TierStats[fd.Tier]
-			stat.FilesExcluded++
-			result.Summary.TierStats[fd.Tier] = stat
+		if budgetExhausted {
+			if !emit(fd, DecisionExclude, budgetExclusionReason(fd, available)) {
+				return dropped
+			}
 			continue
 		}
 
 		if fd.TokenCount <= remaining {
 			// File fits fully within the remaining budget.
-			result.IncludedFiles = append(result.IncludedFiles, fd)
-			result.TotalTokens += fd.TokenCount
+			if !emit(fd, DecisionInclude, "") {
+				return dropped
+			}
 			remaining -= fd.TokenCount
 
-			stat := result.Summary.TierStats[fd.Tier]
-			stat.FilesIncluded++
-			stat.TokensUsed += fd.TokenCount
-			result.Summary.TierStats[fd.Tier] = stat
-
 			slog.Debug("file included",
 				"path", fd.Path,
 				"tier", fd.Tier,
@@ -260,17 +534,19 @@ func (e *BudgetEnforcer) enforceWithTruncate(
 				"remaining", remaining,
 			)
 		} else if remaining > 0 {
-			// File exceeds budget; truncate it to fit.
-			truncated := e.truncateToFit(fd, remaining)
-
-			result.IncludedFiles = append(result.IncludedFiles, truncated)
-			result.TruncatedFiles = append(result.TruncatedFiles, truncated)
-			result.TotalTokens += truncated.TokenCount
-
-			stat := result.Summary.TierStats[fd.Tier]
-			stat.FilesIncluded++
-			stat.TokensUsed += truncated.TokenCount
-			result.Summary.TierStats[fd.Tier] = stat
+			// File exceeds budget; truncate it to fit. Streamed files are
+			// loaded into a copy so the original descriptor stays untouched.
+			source := fd
+			if needsRealize(fd) {
+				loaded := *fd
+				e.realize(&loaded)
+				source = &loaded
+			}
+			truncated := e.truncateToFit(source, remaining)
+			if !emit(truncated, DecisionTruncate, "") {
+				return dropped
+			}
+			dropped += source.TokenCount - truncated.TokenCount
 
 			slog.Debug("file truncated",
 				"path", fd.Path,
@@ -284,31 +560,91 @@ func (e *BudgetEnforcer) enforceWithTruncate(
 			budgetExhausted = true
 		} else {
 			// remaining == 0: budget is already fully consumed.
-			result.ExcludedFiles = append(result.ExcludedFiles, fd)
+			if !emit(fd, DecisionExclude, budgetExclusionReason(fd, available)) {
+				return dropped
+			}
+			budgetExhausted = true
+		}
+	}
+	return dropped
+}
 
-			stat := result.Summary.TierStats[fd.Tier]
-			stat.FilesExcluded++
-			result.Summary.TierStats[fd.Tier] = stat
+// budgetExclusionReason returns the reason a file that did not fit was
+// excluded: ExclusionReasonOversized when it exceeds available, the whole
+// budget after overhead, and ExclusionReasonBudgetExceeded otherwise.
+func budgetExclusionReason(fd *pipeline.FileDescriptor, available int) string {
+	if fd.TokenCount > available {
+		return ExclusionReasonOversized
+	}
+	return ExclusionReasonBudgetExceeded
+}
 
-			budgetExhausted = true
+// weightedOrder returns files reordered for budget filling by e.TierWeights:
+// by descending tier weight, then ascending tier, then input order. It
+// returns files itself when no weights are set; otherwise the input slice is
+// not mutated.
+func (e *BudgetEnforcer) weightedOrder(files []*pipeline.FileDescriptor) []*pipeline.FileDescriptor {
+	if len(e.TierWeights) == 0 {
+		return files
+	}
+	ordered := append([]*pipeline.FileDescriptor(nil), files...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		wi, wj := e.TierWeights[ordered[i].Tier], e.TierWeights[ordered[j].Tier]
+		if wi != wj {
+			return wi > wj
+		}
+		return ordered[i].Tier < ordered[j].Tier
+	})
+	return ordered
+}
+
+// preExclusions returns the files excluded before budgeting, mapped to
+// their exclusion reason: ExclusionReasonFileTooLarge for files over
+// e.MaxFileTokens, then ExclusionReasonTierCap for the remaining files that
+// fall beyond their tier's cap in e.TierFileCaps, counting files of each tier
+// in input order. It returns nil when neither cap is set.
+func (e *BudgetEnforcer) preExclusions(files []*pipeline.FileDescriptor) map[*pipeline.FileDescriptor]string {
+	if e.MaxFileTokens <= 0 && len(e.TierFileCaps) == 0 {
+		return nil
+	}
+	excluded := make(map[*pipeline.FileDescriptor]string)
+	seen := make(map[int]int)
+	for _, fd := range files {
+		if e.MaxFileTokens > 0 && fd.TokenCount > e.MaxFileTokens {
+			excluded[fd] = ExclusionReasonFileTooLarge
+			continue
+		}
+		limit := e.TierFileCaps[fd.Tier]
+		if limit <= 0 {
+			continue
+		}
+		seen[fd.Tier]++
+		if seen[fd.Tier] > limit {
+			excluded[fd] = ExclusionReasonTierCap
 		}
 	}
+	return excluded
 }
 
 // truncateToFit creates a shallow copy of fd with Content and TokenCount
 // adjusted so that the content fits within remaining tokens. It finds the
 // maximum number of lines whose joined token count is <= remaining via binary
-// search, then appends a truncation marker.
+// search, then appends the truncation marker built by e.TruncationMarker.
 //
 // The original fd is never mutated; the returned descriptor is a new value.
 func (e *BudgetEnforcer) truncateToFit(fd *pipeline.FileDescriptor, remaining int) *pipeline.FileDescriptor {
 	lines := strings.Split(fd.Content, "\n")
 	n := len(lines)
 
-	// Reserve tokens for the truncation marker itself.
-	// "<!-- Content truncated: X of Y tokens shown -->" is at most ~60 chars.
-	// We use a small fixed reservation so the marker always fits.
-	const markerReservation = 20
+	// Reserve tokens for the truncation marker itself. The marker is sized
+	// with shown == total, its widest form, and never reserves less than the
+	// small fixed minimum so short custom markers keep some headroom.
+	const minMarkerReservation = 20
+	markerFor := e.TruncationMarker
+	if markerFor == nil {
+		markerFor = DefaultTruncationMarker
+	}
+	markerReservation := max(minMarkerReservation, e.tok.Count(markerFor(fd.TokenCount, fd.TokenCount)))
 	budgetForContent := remaining - markerReservation
 	if budgetForContent <= 0 {
 		budgetForContent = 0
@@ -335,7 +671,7 @@ func (e *BudgetEnforcer) truncateToFit(fd *pipeline.FileDescriptor, remaining in
 
 	// Build the truncation marker.
 	shownTokens := e.tok.Count(keptContent)
-	marker := fmt.Sprintf("<!-- Content truncated: %d of %d tokens shown -->", shownTokens, fd.TokenCount)
+	marker := markerFor(shownTokens, fd.TokenCount)
 
 	var truncatedContent string
 	if keptContent == "" {
@@ -348,10 +684,11 @@ func (e *BudgetEnforcer) truncateToFit(fd *pipeline.FileDescriptor, remaining in
 	// accurately (includes the marker).
 	actualTokens := e.tok.Count(truncatedContent)
 
-	// Shallow-copy the descriptor; only Content and TokenCount differ.
+	// Shallow-copy the descriptor; only Content, TokenCount, and IsTruncated differ.
 	truncated := *fd
 	truncated.Content = truncatedContent
 	truncated.TokenCount = actualTokens
+	truncated.IsTruncated = true
 
 	slog.Debug("truncation result",
 		"path", fd.Path,
@@ -365,6 +702,84 @@ func (e *BudgetEnforcer) truncateToFit(fd *pipeline.FileDescriptor, remaining in
 	return &truncated
 }
 
+// streamChunkSize is the approximate number of bytes buffered before a chunk
+// of streamed content is passed to the tokenizer during the size pass.
+const streamChunkSize = 64 * 1024
+
+// needsRealize reports whether fd's content must be read from its
+// ContentReader before it can be emitted.
+func needsRealize(fd *pipeline.FileDescriptor) bool {
+	return fd.Content == "" && fd.ContentReader != nil && fd.Error == nil
+}
+
+// sizeFiles is the size pass of Enforce. It fills TokenCount for streamed
+// files whose count is not yet known, leaving Content empty. It stops early
+// once expired reports that the enforcement deadline has passed.
+func (e *BudgetEnforcer) sizeFiles(files []*pipeline.FileDescriptor, expired func() bool) {
+	for _, fd := range files {
+		if expired() {
+			return
+		}
+		if !needsRealize(fd) || fd.TokenCount != 0 {
+			continue
+		}
+		count, err := e.countStream(fd.ContentReader)
+		if err != nil {
+			fd.Error = fmt.Errorf("count tokens for %s: %w", fd.Path, err)
+			slog.Warn("streaming token count failed", "path", fd.Path, "error", err)
+			continue
+		}
+		fd.TokenCount = count
+	}
+}
+
+// countStream counts tokens in the content produced by open without holding
+// the whole content in memory. Content is tokenized in chunks split at line
+// boundaries of roughly streamChunkSize bytes.
+func (e *BudgetEnforcer) countStream(open func() (io.ReadCloser, error)) (int, error) {
+	rc, err := open()
+	if err != nil {
+		return 0, fmt.Errorf("open content: %w", err)
+	}
+	defer rc.Close()
+
+	br := bufio.NewReaderSize(rc, streamChunkSize)
+	var chunk strings.Builder
+	total := 0
+	for {
+		line, readErr := br.ReadString('\n')
+		chunk.WriteString(line)
+		if chunk.Len() >= streamChunkSize || (readErr != nil && chunk.Len() > 0) {
+			total += e.tok.Count(chunk.String())
+			chunk.Reset()
+		}
+		if errors.Is(readErr, io.EOF) {
+			return total, nil
+		}
+		if readErr != nil {
+			return 0, fmt.Errorf("read content: %w", readErr)
+		}
+	}
+}
+
+// realize reads fd.ContentReader into fd.Content. Failures are recorded on
+// fd.Error so the file can still be rendered with an error annotation.
+func (e *BudgetEnforcer) realize(fd *pipeline.FileDescriptor) {
+	rc, err := fd.ContentReader()
+	if err != nil {
+		fd.Error = fmt.Errorf("open content for %s: %w", fd.Path, err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		fd.Error = fmt.Errorf("read content for %s: %w", fd.Path, err)
+		return
+	}
+	fd.Content = string(data)
+}
+
 // SortedTierKeys returns the tier numbers present in the BudgetSummary,
 // sorted in ascending order. This is a convenience helper for deterministic
 // reporting and testing.
@@ -0,0 +1,167 @@
+package tokenizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/harvx/harvx/internal/pipeline"
+)
+
+// BudgetDiff describes how the file selection changed between two budget
+// enforcement runs, for example the same files enforced with different
+// budgets or truncation strategies. Files are matched by path, and every
+// list is sorted by path.
+type BudgetDiff struct {
+	// NewlyIncluded lists files included in the second run but not the first.
+	NewlyIncluded []BudgetDiffEntry
+
+	// NewlyExcluded lists files included in the first run but not the second.
+	NewlyExcluded []BudgetDiffEntry
+
+	// TruncationChanged lists files whose truncation status differs between
+	// the runs. A file missing from a run's IncludedFiles counts as not
+	// truncated there, so a file may appear here and in NewlyIncluded or
+	// NewlyExcluded.
+	TruncationChanged []TruncationChange
+
+	// TokensBefore and TokensAfter are the TotalTokens of the two runs.
+	TokensBefore int
+	TokensAfter  int
+}
+
+// BudgetDiffEntry is a file that entered or left the selection.
+type BudgetDiffEntry struct {
+	// Path is the file's relative path.
+	Path string
+
+	// Tokens is the file's token count in the run that included it.
+	Tokens int
+}
+
+// TruncationChange is a file whose truncation status differs between runs.
+type TruncationChange struct {
+	// Path is the file's relative path.
+	Path string
+
+	// WasTruncated reports whether the first run truncated the file.
+	WasTruncated bool
+
+	// IsTruncated reports whether the second run truncated the file.
+	IsTruncated bool
+}
+
+// DiffBudgetResults compares the selections of two BudgetResults, treating a
+// as the earlier run and b as the later one. A nil result is treated as an
+// empty selection.
+func DiffBudgetResults(a, b *BudgetResult) BudgetDiff {
+	if a == nil {
+		a = &BudgetResult{}
+	}
+	if b == nil {
+		b = &BudgetResult{}
+	}
+
+	includedA := tokensByPath(a.IncludedFiles)
+	includedB := tokensByPath(b.IncludedFiles)
+	truncatedA := tokensByPath(a.TruncatedFiles)
+	truncatedB := tokensByPath(b.TruncatedFiles)
+
+	diff := BudgetDiff{TokensBefore: a.TotalTokens, TokensAfter: b.TotalTokens}
+	for path, tokens := range includedB {
+		if _, ok := includedA[path]; !ok {
+			diff.NewlyIncluded = append(diff.NewlyIncluded, BudgetDiffEntry{Path: path, Tokens: tokens})
+		}
+	}
+	for path, tokens := range includedA {
+		if _, ok := includedB[path]; !ok {
+			diff.NewlyExcluded = append(diff.NewlyExcluded, BudgetDiffEntry{Path: path, Tokens: tokens})
+		}
+	}
+
+	for _, path := range unionPaths(truncatedA, truncatedB) {
+		_, was := truncatedA[path]
+		_, is := truncatedB[path]
+		if was != is {
+			diff.TruncationChanged = append(diff.TruncationChanged, TruncationChange{Path: path, WasTruncated: was, IsTruncated: is})
+		}
+	}
+
+	sort.Slice(diff.NewlyIncluded, func(i, j int) bool { return diff.NewlyIncluded[i].Path < diff.NewlyIncluded[j].Path })
+	sort.Slice(diff.NewlyExcluded, func(i, j int) bool { return diff.NewlyExcluded[i].Path < diff.NewlyExcluded[j].Path })
+	return diff
+}
+
+// tokensByPath maps each non-nil file's path to its token count.
+func tokensByPath(files []*pipeline.FileDescriptor) map[string]int {
+	m := make(map[string]int, len(files))
+	for _, fd := range files {
+		if fd != nil {
+			m[fd.Path] = fd.TokenCount
+		}
+	}
+	return m
+}
+
+// unionPaths returns the keys of a and b, deduplicated and sorted.
+func unionPaths(a, b map[string]int) []string {
+	paths := make([]string, 0, len(a)+len(b))
+	for path := range a {
+		paths = append(paths, path)
+	}
+	for path := range b {
+		if _, ok := a[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// IsEmpty reports whether the two runs selected and truncated the same files.
+func (d BudgetDiff) IsEmpty() bool {
+	return len(d.NewlyIncluded) == 0 && len(d.NewlyExcluded) == 0 && len(d.TruncationChanged) == 0
+}
+
+// Format renders the diff as a plain-text report.
+func (d BudgetDiff) Format() string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("Budget Diff: %s -> %s tokens", FormatInt(d.TokensBefore), FormatInt(d.TokensAfter))
+	sb.WriteString(title + "\n")
+	sb.WriteString(strings.Repeat("─", len(title)+2) + "\n")
+
+	if d.IsEmpty() {
+		sb.WriteString("  (no changes)\n")
+		return sb.String()
+	}
+
+	if len(d.NewlyIncluded) > 0 {
+		fmt.Fprintf(&sb, "Newly included (%d):\n", len(d.NewlyIncluded))
+		for _, e := range d.NewlyIncluded {
+			fmt.Fprintf(&sb, "  + %-50s  %s tokens\n", e.Path, FormatInt(e.Tokens))
+		}
+	}
+	if len(d.NewlyExcluded) > 0 {
+		fmt.Fprintf(&sb, "Newly excluded (%d):\n", len(d.NewlyExcluded))
+		for _, e := range d.NewlyExcluded {
+			fmt.Fprintf(&sb, "  - %-50s  %s tokens\n", e.Path, FormatInt(e.Tokens))
+		}
+	}
+	if len(d.TruncationChanged) > 0 {
+		fmt.Fprintf(&sb, "Truncation changed (%d):\n", len(d.TruncationChanged))
+		for _, c := range d.TruncationChanged {
+			fmt.Fprintf(&sb, "  ~ %-50s  %s -> %s\n", c.Path, truncationLabel(c.WasTruncated), truncationLabel(c.IsTruncated))
+		}
+	}
+
+	return sb.String()
+}
+
+// truncationLabel describes a truncation status for BudgetDiff.Format.
+func truncationLabel(truncated bool) string {
+	if truncated {
+		return "truncated"
+	}
+	return "not truncated"
+}
@@ -0,0 +1,77 @@
+package tokenizer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/harvx/harvx/internal/pipeline"
+	"github.com/harvx/harvx/internal/tokenizer"
+)
+
+// budgetDiffFiles returns files where, at a budget of 40, the skip strategy
+// drops big.go and keeps small.go, while the truncate strategy truncates
+// big.go and excludes everything after it.
+func budgetDiffFiles() []*pipeline.FileDescriptor {
+	big := strings.Join([]string{"1234567890", "abcdefghij", "ABCDEFGHIJ", "0987654321", "zyxwvutsrq"}, "\n")
+	return []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, "package a"),
+		makeFile("big.go", 1, big),
+		makeFile("small.go", 2, "package s"),
+	}
+}
+
+func TestDiffBudgetResults_SkipVersusTruncate(t *testing.T) {
+	t.Parallel()
+
+	skip := newEnforcer(40, tokenizer.SkipStrategy).Enforce(budgetDiffFiles(), 0)
+	truncate := newEnforcer(40, tokenizer.TruncateStrategy).Enforce(budgetDiffFiles(), 0)
+
+	diff := tokenizer.DiffBudgetResults(skip, truncate)
+
+	assert.Equal(t, []tokenizer.TruncationChange{
+		{Path: "big.go", WasTruncated: false, IsTruncated: true},
+	}, diff.TruncationChanged)
+	if assert.Len(t, diff.NewlyIncluded, 1) {
+		assert.Equal(t, "big.go", diff.NewlyIncluded[0].Path)
+	}
+	assert.Equal(t, []tokenizer.BudgetDiffEntry{{Path: "small.go", Tokens: 9}}, diff.NewlyExcluded)
+	assert.Equal(t, skip.TotalTokens, diff.TokensBefore)
+	assert.Equal(t, truncate.TotalTokens, diff.TokensAfter)
+	assert.False(t, diff.IsEmpty())
+
+	reverse := tokenizer.DiffBudgetResults(truncate, skip)
+	assert.Equal(t, []tokenizer.TruncationChange{
+		{Path: "big.go", WasTruncated: true, IsTruncated: false},
+	}, reverse.TruncationChanged)
+}
+
+func TestDiffBudgetResults_IdenticalAndNil(t *testing.T) {
+	t.Parallel()
+
+	result := newEnforcer(1000, tokenizer.SkipStrategy).Enforce(budgetDiffFiles(), 0)
+	assert.True(t, tokenizer.DiffBudgetResults(result, result).IsEmpty())
+
+	diff := tokenizer.DiffBudgetResults(nil, result)
+	assert.Len(t, diff.NewlyIncluded, 3)
+	assert.Empty(t, diff.NewlyExcluded)
+}
+
+func TestBudgetDiff_Format(t *testing.T) {
+	t.Parallel()
+
+	skip := newEnforcer(40, tokenizer.SkipStrategy).Enforce(budgetDiffFiles(), 0)
+	truncate := newEnforcer(40, tokenizer.TruncateStrategy).Enforce(budgetDiffFiles(), 0)
+
+	out := tokenizer.DiffBudgetResults(skip, truncate).Format()
+	assert.Contains(t, out, "Budget Diff:")
+	assert.Contains(t, out, "Newly included (1):")
+	assert.Contains(t, out, "  + big.go")
+	assert.Contains(t, out, "Newly excluded (1):")
+	assert.Contains(t, out, "  - small.go")
+	assert.Contains(t, out, "Truncation changed (1):")
+	assert.Contains(t, out, "not truncated -> truncated")
+
+	assert.Contains(t, tokenizer.DiffBudgetResults(skip, skip).Format(), "(no changes)")
+}
@@ -1,9 +1,12 @@
 package tokenizer_test
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -56,6 +59,23 @@ func TestNewBudgetEnforcer_NilTokenizerFallsBackToEstimator(t *testing.T) {
 	require.NotNil(t, e)
 }
 
+func TestEnforce_ReportsTokenizerName(t *testing.T) {
+	t.Parallel()
+
+	tok, err := tokenizer.NewTokenizer(tokenizer.NameNone)
+	require.NoError(t, err)
+
+	result := tokenizer.NewBudgetEnforcer(1000, tokenizer.SkipStrategy, tok).Enforce(nil, 0)
+	assert.Equal(t, tokenizer.NameNone, result.TokenizerName)
+}
+
+func TestEnforce_NilTokenizerReportsFallback(t *testing.T) {
+	t.Parallel()
+
+	result := tokenizer.NewBudgetEnforcer(1000, tokenizer.SkipStrategy, nil).Enforce(nil, 0)
+	assert.Equal(t, "char-estimator", result.TokenizerName)
+}
+
 // ---------------------------------------------------------------------------
 // Enforce -- no budget (maxTokens <= 0)
 // ---------------------------------------------------------------------------
@@ -413,6 +433,128 @@ func TestEnforce_BudgetAccounting(t *testing.T) {
 	}
 }
 
+func TestEnforce_Truncate_RecordsTruncatedTokens(t *testing.T) {
+	t.Parallel()
+	lines := []string{"1234567890", "abcdefghij", "ABCDEFGHIJ", "0987654321", "zyxwvutsrq"}
+	content := strings.Join(lines, "\n")
+	files := []*pipeline.FileDescriptor{
+		{Path: "big.go", Tier: 0, Content: content, TokenCount: len(content)},
+	}
+
+	e := newEnforcer(35, tokenizer.TruncateStrategy)
+	result := e.Enforce(files, 0)
+
+	require.Len(t, result.TruncatedFiles, 1)
+	assert.Equal(t, len(content)-result.TruncatedFiles[0].TokenCount, result.TruncatedTokens)
+	assert.Positive(t, result.TruncatedTokens)
+	assert.InDelta(t,
+		float64(result.TruncatedTokens)/float64(len(content)),
+		result.TruncationRatio(), 1e-9)
+}
+
+func TestEnforce_Skip_NoTruncatedTokens(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, "hello"),
+		makeFile("big.go", 0, strings.Repeat("x", 100)),
+	}
+	result := newEnforcer(50, tokenizer.SkipStrategy).Enforce(files, 0)
+
+	assert.Zero(t, result.TruncatedTokens)
+	assert.Zero(t, result.TruncationRatio())
+}
+
+// ---------------------------------------------------------------------------
+// BudgetResult -- fill and truncation helpers
+// ---------------------------------------------------------------------------
+
+func TestBudgetResult_FillPercent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		result tokenizer.BudgetResult
+		want   float64
+	}{
+		{
+			name:   "no budget",
+			result: tokenizer.BudgetResult{TotalTokens: 350},
+			want:   0,
+		},
+		{
+			name:   "partially filled",
+			result: tokenizer.BudgetResult{TotalTokens: 89420, BudgetUsed: 90000, BudgetRemaining: 110000},
+			want:   45,
+		},
+		{
+			name:   "all excluded with overhead only",
+			result: tokenizer.BudgetResult{BudgetUsed: 5, BudgetRemaining: 0},
+			want:   100,
+		},
+		{
+			name:   "overhead exceeds budget",
+			result: tokenizer.BudgetResult{BudgetUsed: 10, BudgetRemaining: -5},
+			want:   200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, tt.result.FillPercent(), 1e-9)
+		})
+	}
+}
+
+func TestBudgetResult_TruncationRatio(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		result tokenizer.BudgetResult
+		want   float64
+	}{
+		{
+			name:   "no budget all included",
+			result: tokenizer.BudgetResult{TotalTokens: 350},
+			want:   0,
+		},
+		{
+			name:   "all excluded",
+			result: tokenizer.BudgetResult{BudgetUsed: 5},
+			want:   0,
+		},
+		{
+			name:   "quarter truncated",
+			result: tokenizer.BudgetResult{TotalTokens: 75, TruncatedTokens: 25},
+			want:   0.25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tt.want, tt.result.TruncationRatio(), 1e-9)
+		})
+	}
+}
+
+func TestBudgetResult_Helpers_FromEnforce(t *testing.T) {
+	t.Parallel()
+
+	// All excluded: overhead consumes the whole budget.
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, "hello"),
+		makeFile("b.go", 1, "world"),
+	}
+	allExcluded := newEnforcer(5, tokenizer.SkipStrategy).Enforce(files, 5)
+	assert.InDelta(t, 100, allExcluded.FillPercent(), 1e-9)
+	assert.Zero(t, allExcluded.TruncationRatio())
+
+	// No budget: everything is included and neither helper divides by zero.
+	noBudget := newEnforcer(0, tokenizer.TruncateStrategy).Enforce(files, 5)
+	assert.Zero(t, noBudget.FillPercent())
+	assert.Zero(t, noBudget.TruncationRatio())
+}
+
 // ---------------------------------------------------------------------------
 // BudgetSummary -- per-tier statistics
 // ---------------------------------------------------------------------------
@@ -531,6 +673,45 @@ func TestEnforce_Truncate_MarkerContent(t *testing.T) {
 	assert.Equal(t, len(truncated.Content), truncated.TokenCount)
 }
 
+func TestEnforce_Truncate_CustomMarker(t *testing.T) {
+	t.Parallel()
+	var linesSlice []string
+	for i := 0; i < 20; i++ {
+		linesSlice = append(linesSlice, fmt.Sprintf("line %02d: content here", i))
+	}
+	content := strings.Join(linesSlice, "\n")
+
+	files := []*pipeline.FileDescriptor{
+		{Path: "many.go", Tier: 0, Content: content, TokenCount: len(content)},
+	}
+
+	e := newEnforcer(100, tokenizer.TruncateStrategy)
+	e.TruncationMarker = func(shown, total int) string {
+		return fmt.Sprintf("[... truncated for plain output: %d/%d tokens shown ...]", shown, total)
+	}
+	result := e.Enforce(files, 0)
+
+	require.Len(t, result.TruncatedFiles, 1)
+	truncated := result.TruncatedFiles[0]
+	assert.NotContains(t, truncated.Content, "<!--")
+
+	// The stub counts one token per byte, so shown is the kept content length.
+	kept := truncated.Content[:strings.LastIndex(truncated.Content, "\n")]
+	marker := e.TruncationMarker(len(kept), len(content))
+	assert.True(t, strings.HasSuffix(truncated.Content, "\n"+marker),
+		"content must end with the custom marker verbatim, got %q", truncated.Content)
+
+	// The marker is longer than the fixed minimum reservation; its full cost
+	// must still fit within the budget.
+	assert.LessOrEqual(t, truncated.TokenCount, 100)
+}
+
+func TestDefaultTruncationMarker(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "<!-- Content truncated: 10 of 42 tokens shown -->",
+		tokenizer.DefaultTruncationMarker(10, 42))
+}
+
 // ---------------------------------------------------------------------------
 // Invariants
 // ---------------------------------------------------------------------------
@@ -601,6 +782,116 @@ func TestEnforce_Invariant_TotalTokensSumCheck(t *testing.T) {
 		"TotalTokens must equal sum of included file token counts")
 }
 
+// ---------------------------------------------------------------------------
+// Enforce -- streaming ContentReader
+// ---------------------------------------------------------------------------
+
+// streamedFile creates a descriptor whose content is only available through
+// an in-memory ContentReader. opens counts how many times the reader was
+// opened so tests can assert which files were read.
+func streamedFile(path string, tier int, content string, opens *int) *pipeline.FileDescriptor {
+	return &pipeline.FileDescriptor{
+		Path: path,
+		Tier: tier,
+		ContentReader: func() (io.ReadCloser, error) {
+			*opens++
+			return io.NopCloser(strings.NewReader(content)), nil
+		},
+	}
+}
+
+func TestEnforce_Stream_SizesAndRealizesIncludedFiles(t *testing.T) {
+	t.Parallel()
+	var aOpens, bOpens int
+	a := streamedFile("a.go", 0, "package a\nfunc A() {}\n", &aOpens)
+	b := streamedFile("b.go", 1, strings.Repeat("b", 40), &bOpens)
+
+	e := newEnforcer(1000, tokenizer.SkipStrategy)
+	result := e.Enforce([]*pipeline.FileDescriptor{a, b}, 0)
+
+	require.Len(t, result.IncludedFiles, 2)
+	assert.Equal(t, "package a\nfunc A() {}\n", a.Content)
+	assert.Equal(t, strings.Repeat("b", 40), b.Content)
+	assert.Equal(t, len(a.Content), a.TokenCount)
+	assert.Equal(t, 40, b.TokenCount)
+	assert.Equal(t, len(a.Content)+40, result.TotalTokens)
+	// One open for the size pass, one for the realize pass.
+	assert.Equal(t, 2, aOpens)
+	assert.Equal(t, 2, bOpens)
+}
+
+func TestEnforce_Stream_ExcludedFilesNeverRead(t *testing.T) {
+	t.Parallel()
+	var keptOpens, droppedOpens int
+	kept := streamedFile("kept.go", 0, strings.Repeat("k", 30), &keptOpens)
+	kept.TokenCount = 30
+	dropped := streamedFile("dropped.go", 1, strings.Repeat("d", 500), &droppedOpens)
+	dropped.TokenCount = 500
+
+	e := newEnforcer(100, tokenizer.SkipStrategy)
+	result := e.Enforce([]*pipeline.FileDescriptor{kept, dropped}, 0)
+
+	require.Len(t, result.IncludedFiles, 1)
+	require.Len(t, result.ExcludedFiles, 1)
+	assert.Equal(t, strings.Repeat("k", 30), kept.Content)
+	assert.Equal(t, 1, keptOpens, "included file is read once, in the realize pass")
+	assert.Equal(t, 0, droppedOpens, "excluded file must never read its reader")
+	assert.Empty(t, dropped.Content)
+}
+
+func TestEnforce_Stream_TruncatedFileRealizedFromReader(t *testing.T) {
+	t.Parallel()
+	var opens int
+	content := strings.Repeat("line content\n", 20)
+	fd := streamedFile("big.go", 0, content, &opens)
+
+	e := newEnforcer(100, tokenizer.TruncateStrategy)
+	result := e.Enforce([]*pipeline.FileDescriptor{fd}, 0)
+
+	require.Len(t, result.TruncatedFiles, 1)
+	truncated := result.TruncatedFiles[0]
+	assert.Contains(t, truncated.Content, "line content")
+	assert.Contains(t, truncated.Content, "Content truncated")
+	assert.True(t, truncated.IsTruncated)
+	assert.False(t, fd.IsTruncated)
+	assert.Less(t, truncated.TokenCount, len(content))
+	assert.Empty(t, fd.Content, "original descriptor must not be mutated by truncation")
+	assert.Equal(t, len(content), fd.TokenCount)
+}
+
+func TestEnforce_Stream_ReaderErrorRecorded(t *testing.T) {
+	t.Parallel()
+	fd := &pipeline.FileDescriptor{
+		Path: "broken.go",
+		ContentReader: func() (io.ReadCloser, error) {
+			return nil, errors.New("disk gone")
+		},
+	}
+
+	e := newEnforcer(100, tokenizer.SkipStrategy)
+	result := e.Enforce([]*pipeline.FileDescriptor{fd}, 0)
+
+	require.Len(t, result.IncludedFiles, 1)
+	require.Error(t, fd.Error)
+	assert.Contains(t, fd.Error.Error(), "disk gone")
+	assert.Empty(t, fd.Content)
+	assert.Zero(t, fd.TokenCount)
+}
+
+func TestEnforce_Stream_ContentTakesPrecedence(t *testing.T) {
+	t.Parallel()
+	var opens int
+	fd := streamedFile("a.go", 0, "from reader", &opens)
+	fd.Content = "inline"
+	fd.TokenCount = len("inline")
+
+	e := newEnforcer(0, tokenizer.SkipStrategy)
+	_ = e.Enforce([]*pipeline.FileDescriptor{fd}, 0)
+
+	assert.Equal(t, "inline", fd.Content)
+	assert.Zero(t, opens, "reader must not be used when Content is set")
+}
+
 // ---------------------------------------------------------------------------
 // Benchmark
 // ---------------------------------------------------------------------------
@@ -642,3 +933,359 @@ func BenchmarkBudgetEnforcer_Truncate_1K(b *testing.B) {
 		_ = e.Enforce(files, 500)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// EnforceStream
+// ---------------------------------------------------------------------------
+
+// streamDecision records one EnforceStream callback invocation.
+type streamDecision struct {
+	path     string
+	decision tokenizer.Decision
+}
+
+func TestEnforceStream_CallbackPerFile_Skip(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, strings.Repeat("a", 40)),
+		makeFile("b.go", 1, strings.Repeat("b", 80)),
+		makeFile("c.go", 1, strings.Repeat("c", 30)),
+	}
+
+	var got []streamDecision
+	e := newEnforcer(100, tokenizer.SkipStrategy)
+	summary := e.EnforceStream(files, 10, func(fd *pipeline.FileDescriptor, d tokenizer.Decision) {
+		got = append(got, streamDecision{fd.Path, d})
+	})
+
+	assert.Equal(t, []streamDecision{
+		{"a.go", tokenizer.DecisionInclude},
+		{"b.go", tokenizer.DecisionExclude},
+		{"c.go", tokenizer.DecisionInclude},
+	}, got)
+	assert.Equal(t, tokenizer.TierStat{FilesIncluded: 1, TokensUsed: 40}, summary.TierStats[0])
+	assert.Equal(t, tokenizer.TierStat{FilesIncluded: 1, FilesExcluded: 1, TokensUsed: 30}, summary.TierStats[1])
+}
+
+func TestEnforceStream_CallbackPerFile_Truncate(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, strings.Repeat("a", 40)),
+		makeFile("big.go", 1, strings.Repeat("line content\n", 20)),
+		makeFile("c.go", 2, strings.Repeat("c", 5)),
+	}
+
+	var got []streamDecision
+	var truncated *pipeline.FileDescriptor
+	e := newEnforcer(100, tokenizer.TruncateStrategy)
+	summary := e.EnforceStream(files, 0, func(fd *pipeline.FileDescriptor, d tokenizer.Decision) {
+		got = append(got, streamDecision{fd.Path, d})
+		if d == tokenizer.DecisionTruncate {
+			truncated = fd
+		}
+	})
+
+	assert.Equal(t, []streamDecision{
+		{"a.go", tokenizer.DecisionInclude},
+		{"big.go", tokenizer.DecisionTruncate},
+		{"c.go", tokenizer.DecisionExclude},
+	}, got)
+	require.NotNil(t, truncated)
+	assert.True(t, truncated.IsTruncated, "callback receives the truncated copy")
+	assert.False(t, files[1].IsTruncated)
+	assert.Equal(t, 1, summary.TierStats[2].FilesExcluded)
+}
+
+func TestEnforceStream_NoBudgetIncludesAll(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, "aaa"),
+		makeFile("b.go", 3, "bbbb"),
+	}
+
+	calls := 0
+	e := newEnforcer(0, tokenizer.SkipStrategy)
+	e.EnforceStream(files, 500, func(_ *pipeline.FileDescriptor, d tokenizer.Decision) {
+		calls++
+		assert.Equal(t, tokenizer.DecisionInclude, d)
+	})
+	assert.Equal(t, len(files), calls)
+}
+
+func TestEnforceStream_RealizesBeforeCallback(t *testing.T) {
+	t.Parallel()
+	var opens int
+	fd := streamedFile("a.go", 0, "package a\n", &opens)
+
+	e := newEnforcer(1000, tokenizer.SkipStrategy)
+	e.EnforceStream([]*pipeline.FileDescriptor{fd}, 0, func(got *pipeline.FileDescriptor, _ tokenizer.Decision) {
+		assert.Equal(t, "package a\n", got.Content)
+	})
+}
+
+func TestEnforceStream_NilCallback(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{makeFile("a.go", 0, "aaa")}
+
+	e := newEnforcer(100, tokenizer.SkipStrategy)
+	summary := e.EnforceStream(files, 0, nil)
+	assert.Equal(t, 1, summary.TierStats[0].FilesIncluded)
+}
+
+func TestDecision_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "include", tokenizer.DecisionInclude.String())
+	assert.Equal(t, "truncate", tokenizer.DecisionTruncate.String())
+	assert.Equal(t, "exclude", tokenizer.DecisionExclude.String())
+	assert.Equal(t, "Decision(9)", tokenizer.Decision(9).String())
+}
+
+// ---------------------------------------------------------------------------
+// Timeout
+// ---------------------------------------------------------------------------
+
+func TestEnforce_Timeout_ReturnsConsistentPartialResult(t *testing.T) {
+	t.Parallel()
+	// Every included file is realized from a slow reader, so a short timeout
+	// expires part way through the list.
+	files := make([]*pipeline.FileDescriptor, 500)
+	for i := range files {
+		files[i] = &pipeline.FileDescriptor{
+			Path:       fmt.Sprintf("f%03d.go", i),
+			Tier:       i % 3,
+			TokenCount: 10,
+			ContentReader: func() (io.ReadCloser, error) {
+				time.Sleep(time.Millisecond)
+				return io.NopCloser(strings.NewReader(strings.Repeat("x", 10))), nil
+			},
+		}
+	}
+
+	e := newEnforcer(2000, tokenizer.SkipStrategy)
+	e.Timeout = 20 * time.Millisecond
+	result := e.Enforce(files, 7)
+
+	require.True(t, result.TimedOut)
+	decided := len(result.IncludedFiles) + len(result.ExcludedFiles)
+	assert.Less(t, decided, len(files), "a timed-out run must not decide every file")
+
+	sum := 0
+	for _, f := range result.IncludedFiles {
+		sum += f.TokenCount
+		assert.Equal(t, strings.Repeat("x", 10), f.Content, "included file %s must be realized", f.Path)
+	}
+	assert.Equal(t, sum, result.TotalTokens)
+	assert.Equal(t, 7+sum, result.BudgetUsed)
+
+	included, excluded, tokens := 0, 0, 0
+	for _, stat := range result.Summary.TierStats {
+		included += stat.FilesIncluded
+		excluded += stat.FilesExcluded
+		tokens += stat.TokensUsed
+	}
+	assert.Equal(t, len(result.IncludedFiles), included)
+	assert.Equal(t, len(result.ExcludedFiles), excluded)
+	assert.Equal(t, sum, tokens)
+}
+
+func TestEnforce_Timeout_NotReachedDecidesAll(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, "aaaa"),
+		makeFile("b.go", 1, "bbbb"),
+	}
+
+	e := newEnforcer(100, tokenizer.TruncateStrategy)
+	e.Timeout = time.Minute
+	result := e.Enforce(files, 0)
+
+	assert.False(t, result.TimedOut)
+	assert.Len(t, result.IncludedFiles, 2)
+}
+
+// ---------------------------------------------------------------------------
+// ExclusionReasons
+// ---------------------------------------------------------------------------
+
+func TestEnforce_ExclusionReasons_Skip(t *testing.T) {
+	t.Parallel()
+	// Budget 20. a (15) fits; b (10) would fit an empty budget but not the
+	// 5 left; c (30) exceeds the whole budget.
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, strings.Repeat("a", 15)),
+		makeFile("b.go", 1, strings.Repeat("b", 10)),
+		makeFile("c.go", 1, strings.Repeat("c", 30)),
+	}
+	result := newEnforcer(20, tokenizer.SkipStrategy).Enforce(files, 0)
+
+	assert.Equal(t, map[string]string{
+		"b.go": tokenizer.ExclusionReasonBudgetExceeded,
+		"c.go": tokenizer.ExclusionReasonOversized,
+	}, result.ExclusionReasons)
+	assert.Len(t, result.ExclusionReasons, len(result.ExcludedFiles))
+}
+
+func TestEnforce_ExclusionReasons_Truncate(t *testing.T) {
+	t.Parallel()
+	// Budget 60. a (40) fits, b (50) is truncated into the last 20 tokens,
+	// and c (5) is dropped because the budget is exhausted.
+	files := []*pipeline.FileDescriptor{
+		makeFile("a.go", 0, strings.Repeat("a", 40)),
+		makeFile("b.go", 0, strings.Repeat("line\n", 10)),
+		makeFile("c.go", 1, "hello"),
+	}
+	result := newEnforcer(60, tokenizer.TruncateStrategy).Enforce(files, 0)
+
+	assert.Equal(t, map[string]string{
+		"c.go": tokenizer.ExclusionReasonBudgetExceeded,
+	}, result.ExclusionReasons)
+}
+
+func TestEnforce_ExclusionReasons_TierCap(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("main.go", 1, "main"),
+		makeFile("a_test.go", 3, "a"),
+		makeFile("b_test.go", 3, "b"),
+		makeFile("c_test.go", 3, "c"),
+		makeFile("big_test.go", 3, strings.Repeat("x", 100)),
+	}
+
+	e := newEnforcer(50, tokenizer.SkipStrategy)
+	e.TierFileCaps = map[int]int{3: 2}
+	result := e.Enforce(files, 0)
+
+	paths := make([]string, 0, len(result.IncludedFiles))
+	for _, fd := range result.IncludedFiles {
+		paths = append(paths, fd.Path)
+	}
+	assert.Equal(t, []string{"main.go", "a_test.go", "b_test.go"}, paths)
+	assert.Equal(t, map[string]string{
+		"c_test.go":   tokenizer.ExclusionReasonTierCap,
+		"big_test.go": tokenizer.ExclusionReasonTierCap,
+	}, result.ExclusionReasons)
+	assert.Equal(t, 2, result.Summary.TierStats[3].FilesExcluded)
+
+	// Caps also apply without a budget.
+	e = newEnforcer(0, tokenizer.SkipStrategy)
+	e.TierFileCaps = map[int]int{3: 2}
+	result = e.Enforce(files, 0)
+	assert.Len(t, result.IncludedFiles, 3)
+	assert.Equal(t, tokenizer.ExclusionReasonTierCap, result.ExclusionReasons["c_test.go"])
+}
+
+func TestEnforce_MaxFileTokens(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("under.go", 1, strings.Repeat("u", 99)),
+		makeFile("at.go", 1, strings.Repeat("a", 100)),
+		makeFile("over.go", 1, strings.Repeat("o", 101)),
+	}
+
+	e := newEnforcer(1000, tokenizer.SkipStrategy)
+	e.MaxFileTokens = 100
+	result := e.Enforce(files, 0)
+
+	paths := make([]string, 0, len(result.IncludedFiles))
+	for _, fd := range result.IncludedFiles {
+		paths = append(paths, fd.Path)
+	}
+	assert.Equal(t, []string{"under.go", "at.go"}, paths)
+	assert.Equal(t, map[string]string{
+		"over.go": tokenizer.ExclusionReasonFileTooLarge,
+	}, result.ExclusionReasons)
+	assert.Equal(t, map[string]int{
+		tokenizer.ExclusionReasonFileTooLarge: 1,
+	}, result.Summary.ExclusionCounts)
+
+	// The cap also applies without a budget.
+	e = newEnforcer(0, tokenizer.SkipStrategy)
+	e.MaxFileTokens = 100
+	result = e.Enforce(files, 0)
+	assert.Len(t, result.IncludedFiles, 2)
+	assert.Equal(t, tokenizer.ExclusionReasonFileTooLarge, result.ExclusionReasons["over.go"])
+}
+
+func TestEnforce_MaxFileTokens_BeforeTruncation(t *testing.T) {
+	t.Parallel()
+	// Budget 60. big.go (80) would otherwise be truncated into the budget,
+	// but it is over the 50-token cap, so it is excluded and small.go is
+	// included whole.
+	files := []*pipeline.FileDescriptor{
+		makeFile("big.go", 0, strings.Repeat("line\n", 16)),
+		makeFile("small.go", 1, strings.Repeat("s", 30)),
+	}
+
+	e := newEnforcer(60, tokenizer.TruncateStrategy)
+	e.MaxFileTokens = 50
+	result := e.Enforce(files, 0)
+
+	require.Len(t, result.IncludedFiles, 1)
+	assert.Equal(t, "small.go", result.IncludedFiles[0].Path)
+	assert.Empty(t, result.TruncatedFiles)
+	assert.Equal(t, map[string]string{
+		"big.go": tokenizer.ExclusionReasonFileTooLarge,
+	}, result.ExclusionReasons)
+}
+
+func TestEnforce_MaxFileTokens_NotCountedTowardTierCap(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{
+		makeFile("big_test.go", 3, strings.Repeat("x", 100)),
+		makeFile("a_test.go", 3, "a"),
+		makeFile("b_test.go", 3, "b"),
+	}
+
+	e := newEnforcer(0, tokenizer.SkipStrategy)
+	e.MaxFileTokens = 50
+	e.TierFileCaps = map[int]int{3: 2}
+	result := e.Enforce(files, 0)
+
+	assert.Len(t, result.IncludedFiles, 2)
+	assert.Equal(t, map[string]string{
+		"big_test.go": tokenizer.ExclusionReasonFileTooLarge,
+	}, result.ExclusionReasons)
+}
+
+func TestEnforce_ExclusionReasons_EmptyWhenAllIncluded(t *testing.T) {
+	t.Parallel()
+	files := []*pipeline.FileDescriptor{makeFile("a.go", 0, "hello")}
+	result := newEnforcer(100, tokenizer.SkipStrategy).Enforce(files, 0)
+	assert.Empty(t, result.ExclusionReasons)
+}
+
+func TestEnforce_TierWeights(t *testing.T) {
+	t.Parallel()
+	newFiles := func() []*pipeline.FileDescriptor {
+		return []*pipeline.FileDescriptor{
+			makeFile("main.go", 1, strings.Repeat("m", 20)),
+			makeFile("util.go", 2, strings.Repeat("u", 50)),
+			makeFile("guide.md", 4, strings.Repeat("g", 50)),
+		}
+	}
+	includedPaths := func(r *tokenizer.BudgetResult) []string {
+		paths := make([]string, 0, len(r.IncludedFiles))
+		for _, fd := range r.IncludedFiles {
+			paths = append(paths, fd.Path)
+		}
+		return paths
+	}
+
+	// Without weights, tier 2 fills the budget before the docs are reached.
+	result := newEnforcer(80, tokenizer.SkipStrategy).Enforce(newFiles(), 0)
+	assert.Equal(t, []string{"main.go", "util.go"}, includedPaths(result))
+	assert.Equal(t, tokenizer.ExclusionReasonBudgetExceeded, result.ExclusionReasons["guide.md"])
+
+	// Weighting tier 4 lets the docs win the budget instead; tier 1 has no
+	// weight and now competes after it.
+	e := newEnforcer(80, tokenizer.SkipStrategy)
+	e.TierWeights = map[int]int{4: 10}
+	files := newFiles()
+	result = e.Enforce(files, 0)
+	assert.Equal(t, []string{"guide.md", "main.go"}, includedPaths(result))
+	assert.Equal(t, tokenizer.ExclusionReasonBudgetExceeded, result.ExclusionReasons["util.go"])
+
+	// Tier assignment is unchanged and the caller's slice keeps its order.
+	assert.Equal(t, 4, result.IncludedFiles[0].Tier)
+	assert.Equal(t, "main.go", files[0].Path)
+}
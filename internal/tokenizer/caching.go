@@ -0,0 +1,90 @@
+package tokenizer
+
+import (
+	"container/list"
+	"sync"
+
+	"github.com/zeebo/xxh3"
+)
+
+// DefaultCacheEntries is the number of token counts a tokenizer returned by
+// NewCachingTokenizer remembers before evicting the least recently used one.
+const DefaultCacheEntries = 4096
+
+// cachingTokenizer wraps a Tokenizer and memoizes Count results keyed by the
+// XXH3 hash of the text, so identical content (vendored copies, generated
+// stubs) is tokenized once. The cache is a bounded LRU.
+//
+// cachingTokenizer is goroutine-safe: the cache is guarded by a mutex, and the
+// inner tokenizer is only required to be safe for concurrent use, as every
+// Tokenizer is.
+type cachingTokenizer struct {
+	inner    Tokenizer
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List // front is most recently used; values are cacheEntry
+	entries map[uint64]*list.Element
+}
+
+// cacheEntry is one memoized count in cachingTokenizer.order.
+type cacheEntry struct {
+	key   uint64
+	count int
+}
+
+// NewCachingTokenizer returns a Tokenizer that counts with inner and caches up
+// to DefaultCacheEntries results by content hash. Name reports inner's name,
+// so cached and uncached runs produce identical metadata.
+func NewCachingTokenizer(inner Tokenizer) Tokenizer {
+	return NewCachingTokenizerSize(inner, DefaultCacheEntries)
+}
+
+// NewCachingTokenizerSize is like NewCachingTokenizer but keeps at most
+// entries results. A value below 1 is treated as 1.
+func NewCachingTokenizerSize(inner Tokenizer, entries int) Tokenizer {
+	return &cachingTokenizer{
+		inner:    inner,
+		capacity: max(entries, 1),
+		order:    list.New(),
+		entries:  make(map[uint64]*list.Element),
+	}
+}
+
+// Count returns the cached count for text, or counts it with the inner
+// tokenizer and caches the result. Two texts with the same 64-bit hash share
+// an entry; XXH3 makes such a collision vanishingly unlikely.
+func (c *cachingTokenizer) Count(text string) int {
+	key := xxh3.HashString(text)
+
+	c.mu.Lock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		n := el.Value.(cacheEntry).count
+		c.mu.Unlock()
+		return n
+	}
+	c.mu.Unlock()
+
+	// Count outside the lock so slow BPE encodes do not serialize callers.
+	n := c.inner.Count(text)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return n
+	}
+	c.entries[key] = c.order.PushFront(cacheEntry{key: key, count: n})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).key)
+	}
+	return n
+}
+
+// Name returns the inner tokenizer's name.
+func (c *cachingTokenizer) Name() string {
+	return c.inner.Name()
+}
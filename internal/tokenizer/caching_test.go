@@ -0,0 +1,92 @@
+package tokenizer_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/harvx/harvx/internal/tokenizer"
+)
+
+// countingTokenizer counts one token per byte and records how many times
+// Count was called.
+type countingTokenizer struct {
+	calls atomic.Int64
+}
+
+func (c *countingTokenizer) Count(text string) int {
+	c.calls.Add(1)
+	return len(text)
+}
+
+func (c *countingTokenizer) Name() string { return "counting" }
+
+func TestCachingTokenizer_RepeatedContentHitsCache(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingTokenizer{}
+	tok := tokenizer.NewCachingTokenizer(inner)
+
+	assert.Equal(t, 11, tok.Count("hello world"))
+	assert.Equal(t, 11, tok.Count("hello world"))
+	assert.Equal(t, 11, tok.Count("hello world"))
+	assert.Equal(t, int64(1), inner.calls.Load(), "repeated content must be counted once")
+	assert.Equal(t, "counting", tok.Name())
+}
+
+func TestCachingTokenizer_DistinctContentCountedIndependently(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingTokenizer{}
+	tok := tokenizer.NewCachingTokenizer(inner)
+
+	assert.Equal(t, 3, tok.Count("abc"))
+	assert.Equal(t, 5, tok.Count("abcde"))
+	assert.Equal(t, 0, tok.Count(""))
+	assert.Equal(t, int64(3), inner.calls.Load())
+
+	assert.Equal(t, 3, tok.Count("abc"))
+	assert.Equal(t, 5, tok.Count("abcde"))
+	assert.Equal(t, int64(3), inner.calls.Load())
+}
+
+func TestCachingTokenizer_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	inner := &countingTokenizer{}
+	tok := tokenizer.NewCachingTokenizerSize(inner, 2)
+
+	tok.Count("a")
+	tok.Count("bb")
+	tok.Count("a")   // "a" is now most recently used
+	tok.Count("ccc") // evicts "bb"
+	assert.Equal(t, int64(3), inner.calls.Load())
+
+	tok.Count("a")
+	assert.Equal(t, int64(3), inner.calls.Load(), "recently used entry must survive eviction")
+
+	tok.Count("bb")
+	assert.Equal(t, int64(4), inner.calls.Load(), "evicted entry must be recounted")
+}
+
+func TestCachingTokenizer_ConcurrentUse(t *testing.T) {
+	t.Parallel()
+
+	tok := tokenizer.NewCachingTokenizerSize(&countingTokenizer{}, 8)
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := range 100 {
+				text := fmt.Sprintf("file-%d", (i+j)%20)
+				assert.Equal(t, len(text), tok.Count(text))
+			}
+		}(i)
+	}
+	wg.Wait()
+}
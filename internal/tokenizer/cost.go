@@ -0,0 +1,36 @@
+// Package tokenizer provides token counting implementations for LLM context
+// documents. This file implements rough per-model input cost estimates so that
+// token counts can be presented alongside an approximate dollar amount.
+package tokenizer
+
+import "strings"
+
+// inputPricePerMillion maps a model name to its published input token price in
+// USD per one million tokens. Prices are approximate list prices and are only
+// used for display; they are not a billing reference.
+var inputPricePerMillion = map[string]float64{
+	"claude-opus-4":    15.00,
+	"claude-sonnet-4":  3.00,
+	"claude-3-5-haiku": 0.80,
+	"gpt-4.1":          2.00,
+	"gpt-4o":           2.50,
+	"gpt-4o-mini":      0.15,
+	"o1":               15.00,
+	"o3-mini":          1.10,
+}
+
+// EstimateCostUSD returns the approximate input cost in USD of sending tokens
+// tokens to model, and whether the model has a known price. Model names are
+// matched case-insensitively after trimming whitespace. Unknown models and
+// non-positive token counts return 0; the boolean still reports whether the
+// model is priced.
+func EstimateCostUSD(tokens int, model string) (float64, bool) {
+	price, ok := inputPricePerMillion[strings.ToLower(strings.TrimSpace(model))]
+	if !ok {
+		return 0, false
+	}
+	if tokens <= 0 {
+		return 0, true
+	}
+	return float64(tokens) * price / 1_000_000, true
+}
@@ -0,0 +1,36 @@
+package tokenizer_test
+
+import (
+	"testing"
+
+	"github.com/harvx/harvx/internal/tokenizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateCostUSD_KnownModel(t *testing.T) {
+	t.Parallel()
+
+	cost, ok := tokenizer.EstimateCostUSD(1_000_000, "claude-sonnet-4")
+	assert.True(t, ok)
+	assert.InDelta(t, 3.00, cost, 1e-9)
+
+	cost, ok = tokenizer.EstimateCostUSD(89_420, " Claude-Sonnet-4 ")
+	assert.True(t, ok, "lookup must be case-insensitive and trim whitespace")
+	assert.InDelta(t, 0.26826, cost, 1e-9)
+}
+
+func TestEstimateCostUSD_UnknownModel(t *testing.T) {
+	t.Parallel()
+
+	cost, ok := tokenizer.EstimateCostUSD(1_000_000, "made-up-model")
+	assert.False(t, ok)
+	assert.Zero(t, cost)
+}
+
+func TestEstimateCostUSD_NonPositiveTokens(t *testing.T) {
+	t.Parallel()
+
+	cost, ok := tokenizer.EstimateCostUSD(0, "gpt-4o")
+	assert.True(t, ok)
+	assert.Zero(t, cost)
+}
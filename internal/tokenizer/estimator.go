@@ -1,5 +1,7 @@
 package tokenizer
 
+import "math"
+
 // estimatorTokenizer is the "none" Tokenizer implementation.
 // It estimates token count as len(text) / 4, which is the widely accepted
 // industry heuristic of approximately 4 characters per token for English text.
@@ -27,3 +29,43 @@ func (e *estimatorTokenizer) Count(text string) int {
 func (e *estimatorTokenizer) Name() string {
 	return NameNone
 }
+
+// DefaultCharsPerToken is the chars-per-token ratio NewCharEstimator uses
+// when given a ratio that is not positive. It matches the "none" estimator.
+const DefaultCharsPerToken = 4.0
+
+// charEstimator is a character-count estimator with a configurable
+// chars-per-token ratio, constructed by NewCharEstimator.
+//
+// charEstimator is goroutine-safe: it holds no mutable state.
+type charEstimator struct {
+	charsPerToken float64
+}
+
+// NewCharEstimator returns a Tokenizer that estimates token counts as the
+// byte length of the text divided by charsPerToken, rounded up, so any
+// non-empty text counts as at least one token. Typical ratios are 4.0 for
+// English prose and about 3.0 for source code. A ratio that is not positive
+// is replaced by DefaultCharsPerToken.
+//
+// The returned Tokenizer reports its name as "none".
+func NewCharEstimator(charsPerToken float64) Tokenizer {
+	if charsPerToken <= 0 {
+		charsPerToken = DefaultCharsPerToken
+	}
+	return &charEstimator{charsPerToken: charsPerToken}
+}
+
+// Count returns ceil(len(text) / charsPerToken). Returns 0 for empty text.
+// Safe for concurrent use.
+func (e *charEstimator) Count(text string) int {
+	if text == "" {
+		return 0
+	}
+	return int(math.Ceil(float64(len(text)) / e.charsPerToken))
+}
+
+// Name returns "none", indicating this is a character-count estimator.
+func (e *charEstimator) Name() string {
+	return NameNone
+}
@@ -178,3 +178,39 @@ func BenchmarkEstimator_100KB(b *testing.B) {
 		tok.Count(text)
 	}
 }
+
+// TestCharEstimator_RatioFour verifies ratio 4.0 on a known string.
+// "hello world!" has 12 bytes -> 12/4 = 3.
+func TestCharEstimator_RatioFour(t *testing.T) {
+	t.Parallel()
+	tok := tokenizer.NewCharEstimator(4.0)
+	assert.Equal(t, 3, tok.Count("hello world!"))
+	assert.Equal(t, 0, tok.Count(""))
+	assert.Equal(t, "none", tok.Name())
+}
+
+// TestCharEstimator_RoundsUp verifies that non-divisible lengths round up.
+func TestCharEstimator_RoundsUp(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		ratio float64
+		text  string
+		want  int
+	}{
+		{"1 char ratio 4", 4.0, "a", 1},                    // 1/4 = 0.25 -> 1
+		{"5 chars ratio 4", 4.0, "abcde", 2},               // 5/4 = 1.25 -> 2
+		{"11 chars ratio 4", 4.0, "hello world", 3},        // 11/4 = 2.75 -> 3
+		{"9 chars ratio 3", 3.0, "abcdefghi", 3},           // 9/3 = 3
+		{"10 chars ratio 3", 3.0, "abcdefghij", 4},         // 10/3 = 3.33 -> 4
+		{"7 chars ratio 3.5", 3.5, "abcdefg", 2},           // 7/3.5 = 2
+		{"zero ratio uses default", 0, "abcde", 2},         // 5/4 = 1.25 -> 2
+		{"negative ratio uses default", -1, "abcdefgh", 2}, // 8/4 = 2
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, tokenizer.NewCharEstimator(tt.ratio).Count(tt.text))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package tokenizer provides token counting implementations for LLM context
+// documents. This file estimates the token cost of output document structure
+// so callers can pass a realistic overhead to BudgetEnforcer.Enforce.
+package tokenizer
+
+import "strings"
+
+// formatOverhead is the estimated structural token cost of one output format:
+// a fixed part for the document header, tree, and summary, plus a per-file
+// part for the heading, metadata line, and code fence or tags around each
+// file's content.
+type formatOverhead struct {
+	fixed   int
+	perFile int
+}
+
+// formatOverheads holds the estimates for each output format. The numbers
+// were measured on rendered output for typical repositories and rounded up,
+// so budgets err on the side of leaving headroom.
+var formatOverheads = map[string]formatOverhead{
+	// "### `path`", the size/tokens/tier blockquote, and the fence pair.
+	"markdown": {fixed: 250, perFile: 40},
+	// <file> open/close tags with path/tier/tokens attributes and CDATA.
+	"xml": {fixed: 300, perFile: 45},
+	// A one-line path separator per file and a short header.
+	"plain": {fixed: 50, perFile: 10},
+}
+
+// EstimateOverhead returns the estimated number of tokens the output document
+// adds around the content of fileCount files when rendered in format for
+// target. The result is a fixed part plus a per-file part and is monotonic in
+// fileCount; a non-positive fileCount yields the fixed part only.
+//
+// An empty format is taken from target the way the target presets choose it
+// ("claude" renders XML, everything else Markdown). Unknown formats use the
+// Markdown estimate. Names are matched case-insensitively.
+func EstimateOverhead(format, target string, fileCount int) int {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" {
+		format = "markdown"
+		if strings.EqualFold(strings.TrimSpace(target), "claude") {
+			format = "xml"
+		}
+	}
+
+	o, ok := formatOverheads[format]
+	if !ok {
+		o = formatOverheads["markdown"]
+	}
+
+	if fileCount < 0 {
+		fileCount = 0
+	}
+	return o.fixed + o.perFile*fileCount
+}
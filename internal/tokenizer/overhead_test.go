@@ -0,0 +1,58 @@
+package tokenizer_test
+
+import (
+	"testing"
+
+	"github.com/harvx/harvx/internal/tokenizer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimateOverhead_XMLExceedsPlain(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []int{0, 1, 10, 500} {
+		xml := tokenizer.EstimateOverhead("xml", "", n)
+		plain := tokenizer.EstimateOverhead("plain", "", n)
+		assert.Greater(t, xml, plain, "fileCount=%d", n)
+	}
+}
+
+func TestEstimateOverhead_MonotonicInFileCount(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{"markdown", "xml", "plain", "unknown"} {
+		prev := tokenizer.EstimateOverhead(format, "", 0)
+		for n := 1; n <= 200; n++ {
+			got := tokenizer.EstimateOverhead(format, "", n)
+			assert.Greater(t, got, prev, "format=%s fileCount=%d", format, n)
+			prev = got
+		}
+	}
+}
+
+func TestEstimateOverhead_FormatFromTarget(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		tokenizer.EstimateOverhead("xml", "", 5),
+		tokenizer.EstimateOverhead("", "claude", 5))
+	assert.Equal(t,
+		tokenizer.EstimateOverhead("markdown", "", 5),
+		tokenizer.EstimateOverhead("", "chatgpt", 5))
+
+	// An explicit format wins over the target's preset.
+	assert.Equal(t,
+		tokenizer.EstimateOverhead("markdown", "", 5),
+		tokenizer.EstimateOverhead("markdown", "claude", 5))
+}
+
+func TestEstimateOverhead_NonPositiveFileCount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		tokenizer.EstimateOverhead("xml", "", 0),
+		tokenizer.EstimateOverhead("xml", "", -3))
+	assert.Equal(t,
+		tokenizer.EstimateOverhead("markdown", "", 3),
+		tokenizer.EstimateOverhead("MARKDOWN", "", 3))
+}
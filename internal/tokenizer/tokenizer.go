@@ -39,8 +39,22 @@ const (
 	// NameNone selects the character-count estimator: len(text) / 4.
 	// Use this for maximum speed when exact token counts are not required.
 	NameNone = "none"
+
+	// NameEstimatorFallback is the display name reported when no tokenizer was
+	// supplied and token counts came from the character estimator instead.
+	NameEstimatorFallback = "char-estimator"
 )
 
+// DisplayName returns the name to report for tok in run output. A nil tok
+// yields NameEstimatorFallback, since callers such as NewBudgetEnforcer
+// substitute the character estimator in that case.
+func DisplayName(tok Tokenizer) string {
+	if tok == nil {
+		return NameEstimatorFallback
+	}
+	return tok.Name()
+}
+
 // ErrUnknownTokenizer is returned by NewTokenizer when an unrecognised
 // encoding name is provided. Callers can check for this with errors.Is.
 var ErrUnknownTokenizer = fmt.Errorf("unknown tokenizer")
@@ -170,6 +170,18 @@ func TestTokenizer_NameConstants(t *testing.T) {
 	}
 }
 
+// TestDisplayName verifies that DisplayName reports the tokenizer's own name
+// and falls back to the estimator label for a nil tokenizer.
+func TestDisplayName(t *testing.T) {
+	t.Parallel()
+
+	tok, err := tokenizer.NewTokenizer(tokenizer.NameNone)
+	require.NoError(t, err)
+
+	assert.Equal(t, tokenizer.NameNone, tokenizer.DisplayName(tok))
+	assert.Equal(t, tokenizer.NameEstimatorFallback, tokenizer.DisplayName(nil))
+}
+
 // TestNewTokenizer_EmptyStringIsDefaultCL100K explicitly verifies that the
 // empty string input selects cl100k_base as the default, per spec requirement.
 func TestNewTokenizer_EmptyStringIsDefaultCL100K(t *testing.T) {
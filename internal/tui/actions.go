@@ -36,10 +36,10 @@ func (m Model) handleGenerate() (Model, tea.Cmd) {
 	p := m.pipeline
 	selectedFiles := m.fileTree.SelectedFiles()
 
+	runOpts := m.runOptions()
+
 	genCmd := func() tea.Msg {
-		result, err := p.Run(context.Background(), pipeline.RunOptions{
-			Dir: m.rootDir(),
-		})
+		result, err := p.Run(context.Background(), runOpts)
 		_ = selectedFiles // Selected files used for filtering if needed.
 		return generateCompleteMsg{result: result, err: err}
 	}
@@ -148,3 +148,15 @@ func (m Model) handleClipboardComplete(msg clipboardCompleteMsg) (Model, tea.Cmd
 func (m Model) rootDir() string {
 	return "."
 }
+
+// runOptions returns the pipeline options for a generation run, taking the
+// file selection and ordering settings from the resolved profile (see
+// pipeline.RunOptionsForProfile).
+func (m Model) runOptions() pipeline.RunOptions {
+	var opts pipeline.RunOptions
+	if m.cfg != nil && m.cfg.Profile != nil {
+		opts = pipeline.RunOptionsForProfile(m.cfg.Profile)
+	}
+	opts.Dir = m.rootDir()
+	return opts
+}
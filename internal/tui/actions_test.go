@@ -20,6 +20,21 @@ func TestHandleGenerate_ActivatesOverlay(t *testing.T) {
 	require.NotNil(t, cmd)
 }
 
+func TestRunOptions_IncludeOnlyFromProfile(t *testing.T) {
+	t.Parallel()
+
+	m := mustNewModel(t)
+	m.cfg.Profile.IncludeOnly = true
+	m.cfg.Profile.Include = []string{"cmd/**"}
+	m.cfg.Profile.SortOrder = "tokens"
+
+	opts := m.runOptions()
+	assert.Equal(t, ".", opts.Dir)
+	assert.True(t, opts.IncludeOnly)
+	assert.Equal(t, []string{"cmd/**"}, opts.Includes)
+	assert.Equal(t, pipeline.SortOrderTokens, opts.SortOrder)
+}
+
 func TestHandleGenerateComplete_Success(t *testing.T) {
 	t.Parallel()
 
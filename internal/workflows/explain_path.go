@@ -0,0 +1,268 @@
+// Package workflows — this file implements ExplainPath, the end-to-end answer
+// to "what happened to file X".
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/harvx/harvx/internal/discovery"
+	"github.com/harvx/harvx/internal/pipeline"
+	"github.com/harvx/harvx/internal/relevance"
+	"github.com/harvx/harvx/internal/tokenizer"
+)
+
+// ExplainPathOptions configures ExplainPath.
+type ExplainPathOptions struct {
+	// RootDir is the repository root directory.
+	RootDir string
+
+	// ProfileName selects the profile to resolve. Empty follows the usual
+	// resolution (HARVX_PROFILE, then "default").
+	ProfileName string
+
+	// CLIFlags holds flag overrides applied on top of the resolved profile,
+	// keyed like config.ResolveOptions.CLIFlags.
+	CLIFlags map[string]any
+
+	// Strategy is the budget truncation strategy. Empty uses
+	// tokenizer.SkipStrategy, the CLI default.
+	Strategy tokenizer.TruncationStrategy
+}
+
+// ExplainPath runs configuration resolution, discovery, relevance
+// classification, tokenization, and budget enforcement over the repository at
+// opts.RootDir, then reports what happened to filePath, a path relative to
+// the root.
+//
+// The returned result carries the tier and matched pattern as
+// relevance.Explain reports them, with AssignedTier replaced by a path
+// override's tier when one applies. Budget fields are always filled in:
+//   - a file removed during discovery has Ignored set, IgnoredBy naming the
+//     rule, and ExclusionReason relevance.ExclusionReasonIgnored;
+//   - a file dropped by the budget has ExclusionReason
+//     relevance.ExclusionReasonOverBudget;
+//   - an included file has WouldBeIncluded set.
+//
+// TokenCount is set for every file that reached tokenization. Files are
+// budgeted in tier order and then by path, so the result is deterministic
+// for a given repository state. A filePath that does not name a regular file
+// under the root is an error.
+func ExplainPath(filePath string, opts ExplainPathOptions) (*relevance.ExplainResult, error) {
+	if opts.RootDir == "" {
+		return nil, fmt.Errorf("explain: root directory required")
+	}
+	rel := path.Clean(filepath.ToSlash(filePath))
+	info, err := os.Stat(filepath.Join(opts.RootDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("explain: %s is a directory", rel)
+	}
+
+	resolved, err := config.Resolve(config.ResolveOptions{
+		TargetDir:   opts.RootDir,
+		ProfileName: opts.ProfileName,
+		CLIFlags:    opts.CLIFlags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+	profile := resolved.Profile
+
+	result := explainTier(rel, profile)
+
+	ignorers, err := explainIgnorers(opts.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+	filter := explainPatternFilter(profile)
+	files, err := discoverForProfile(opts.RootDir, profile, ignorers, filter)
+	if err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+
+	var target *pipeline.FileDescriptor
+	for _, fd := range files {
+		if fd.Path == rel {
+			target = fd
+			break
+		}
+	}
+	if target == nil {
+		result.Ignored = true
+		result.IgnoredBy = explainIgnoredBy(rel, ignorers, filter)
+		result.ExclusionReason = relevance.ExclusionReasonIgnored
+		return result, nil
+	}
+
+	tok, err := rankForBudget(files, profile)
+	if err != nil {
+		return nil, fmt.Errorf("explain: %w", err)
+	}
+	result.AssignedTier = target.Tier
+	result.TokenCount = target.TokenCount
+
+	budget := newBudgetEnforcer(profile, opts.Strategy, tok).Enforce(files, 0)
+	for _, fd := range budget.IncludedFiles {
+		if fd.Path == rel {
+			result.WouldBeIncluded = true
+			result.TokenCount = fd.TokenCount
+			return result, nil
+		}
+	}
+	result.ExclusionReason = relevance.ExclusionReasonOverBudget
+	return result, nil
+}
+
+// explainTier explains rel's tier against the profile's tier definitions,
+// rebasing it onto the profile's base_dir first. Files outside base_dir match
+// no profile pattern and get the default tier.
+func explainTier(rel string, p *config.Profile) *relevance.ExplainResult {
+	defs := relevance.TierDefinitionsFromProfile(p)
+	rebased, ok := config.RebasePath(p.BaseDir, rel)
+	if !ok {
+		defs = nil
+		rebased = rel
+	}
+	result := relevance.Explain(rebased, defs)
+	result.FilePath = rel
+	return result
+}
+
+// discoverForProfile walks root with the given ignore matchers (as returned by
+// explainIgnorers) and pattern filter, honoring the profile's skip_empty
+// setting, and returns the discovered files.
+func discoverForProfile(root string, profile *config.Profile, ignorers []namedIgnorer, filter *discovery.PatternFilter) ([]*pipeline.FileDescriptor, error) {
+	walkCfg := discovery.WalkerConfig{
+		Root:               root,
+		DefaultIgnorer:     ignorers[0].ignorer,
+		GitignoreMatcher:   ignorers[1].ignorer,
+		HarvxignoreMatcher: ignorers[2].ignorer,
+		PatternFilter:      filter,
+		SkipEmpty:          profile.SkipEmpty,
+	}
+	discovered, err := discovery.NewWalker().Walk(context.Background(), walkCfg)
+	if err != nil {
+		return nil, fmt.Errorf("discovery: %w", err)
+	}
+
+	files := make([]*pipeline.FileDescriptor, 0, len(discovered.Files))
+	for i := range discovered.Files {
+		files = append(files, &discovered.Files[i])
+	}
+	return files, nil
+}
+
+// rankForBudget assigns each file its tier, with path override tiers taking
+// precedence, and its token count under the profile's tokenizer, then sorts
+// files in place into budget order: by tier, then by the profile's
+// sort_order. It returns the tokenizer for the budget enforcer.
+func rankForBudget(files []*pipeline.FileDescriptor, profile *config.Profile) (tokenizer.Tokenizer, error) {
+	matcher := relevance.NewTierMatcherForProfile(profile)
+	for _, fd := range files {
+		fd.Tier = int(matcher.Match(fd.Path))
+		if ov, ok := config.MatchPathOverride(profile.Overrides, fd.Path); ok && ov.Tier != nil {
+			fd.Tier = *ov.Tier
+		}
+	}
+
+	tok, err := tokenizer.NewTokenizer(profile.Tokenizer)
+	if err != nil {
+		return nil, err
+	}
+	for _, fd := range files {
+		fd.TokenCount = tok.Count(fd.Content)
+	}
+
+	copy(files, pipeline.SortForBudget(files, pipeline.SortOrder(profile.SortOrder)))
+	return tok, nil
+}
+
+// newBudgetEnforcer returns the enforcer a run of profile uses: its token
+// budget, per-file token cap, and tier weights, with strategy defaulted by
+// budgetStrategy.
+func newBudgetEnforcer(profile *config.Profile, strategy tokenizer.TruncationStrategy, tok tokenizer.Tokenizer) *tokenizer.BudgetEnforcer {
+	enforcer := tokenizer.NewBudgetEnforcer(profile.MaxTokens, budgetStrategy(strategy), tok)
+	enforcer.TierWeights = profile.TierWeights
+	enforcer.MaxFileTokens = profile.MaxFileTokens
+	return enforcer
+}
+
+// budgetStrategy returns strategy, or tokenizer.SkipStrategy, the CLI
+// default, when strategy is empty.
+func budgetStrategy(strategy tokenizer.TruncationStrategy) tokenizer.TruncationStrategy {
+	if strategy == "" {
+		return tokenizer.SkipStrategy
+	}
+	return strategy
+}
+
+// namedIgnorer pairs an ignore source with the name ExplainPath reports.
+type namedIgnorer struct {
+	name    string
+	ignorer discovery.Ignorer
+}
+
+// explainIgnorers returns the default, .gitignore, and .harvxignore matchers
+// for root, in the order ExplainPath checks them when naming the rule that
+// removed a file.
+func explainIgnorers(root string) ([]namedIgnorer, error) {
+	gitignore, err := discovery.NewGitignoreMatcher(root)
+	if err != nil {
+		return nil, err
+	}
+	harvxignore, err := discovery.NewHarvxignoreMatcher(root)
+	if err != nil {
+		return nil, err
+	}
+	return []namedIgnorer{
+		{"default ignore patterns", discovery.NewDefaultIgnoreMatcher()},
+		{".gitignore", gitignore},
+		{".harvxignore", harvxignore},
+	}, nil
+}
+
+// explainPatternFilter builds the discovery filter for the profile's ignore
+// and include lists, evaluated relative to its base_dir, or nil when the
+// profile sets neither.
+func explainPatternFilter(p *config.Profile) *discovery.PatternFilter {
+	if len(p.Ignore) == 0 && len(p.Include) == 0 {
+		return nil
+	}
+	return discovery.NewPatternFilter(discovery.PatternFilterOptions{
+		Includes:        p.Include,
+		Excludes:        p.Ignore,
+		CaseInsensitive: p.CaseInsensitive,
+		BaseDir:         p.BaseDir,
+	})
+}
+
+// explainIgnoredBy names the first rule that removes rel during discovery.
+// Directory rules are checked against each ancestor of rel, as the walker
+// skips ignored directories without visiting their files. Files removed by
+// a check with no pattern behind it, such as binary detection or the size
+// limit, are reported as "discovery filters".
+func explainIgnoredBy(rel string, ignorers []namedIgnorer, filter *discovery.PatternFilter) string {
+	parts := strings.Split(rel, "/")
+	for _, ig := range ignorers {
+		for i := 1; i < len(parts); i++ {
+			if ig.ignorer.IsIgnored(strings.Join(parts[:i], "/"), true) {
+				return ig.name
+			}
+		}
+		if ig.ignorer.IsIgnored(rel, false) {
+			return ig.name
+		}
+	}
+	if filter != nil && !filter.Matches(rel) {
+		return "profile ignore/include patterns"
+	}
+	return "discovery filters"
+}
@@ -0,0 +1,109 @@
+package workflows
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/harvx/harvx/internal/pipeline"
+	"github.com/harvx/harvx/internal/relevance"
+)
+
+// setupExplainRepo creates a repository whose harvx.toml uses the estimator
+// tokenizer and a budget that fits src/main.go but not docs/big.md.
+func setupExplainRepo(t *testing.T) string {
+	t.Helper()
+	for _, name := range []string{"HARVX_PROFILE", "HARVX_MAX_TOKENS", "HARVX_TOKENIZER", "HARVX_IGNORE"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	dir := t.TempDir()
+	writeFile(t, dir, "harvx.toml", `
+[profile.default]
+tokenizer = "none"
+max_tokens = 200
+
+[profile.default.relevance]
+tier_0 = ["src/**"]
+`)
+	writeFile(t, dir, ".gitignore", "generated/\n")
+	writeFile(t, dir, "src/main.go", "package main\n\nfunc main() {}\n")
+	writeFile(t, dir, "docs/big.md", strings.Repeat("lorem ipsum dolor sit amet\n", 200))
+	writeFile(t, dir, "generated/out.go", "package generated\n")
+	return dir
+}
+
+func TestExplainPath_Included(t *testing.T) {
+	dir := setupExplainRepo(t)
+
+	result, err := ExplainPath("src/main.go", ExplainPathOptions{RootDir: dir})
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, result.AssignedTier)
+	assert.Equal(t, "src/**", result.MatchedPattern)
+	assert.True(t, result.WouldBeIncluded)
+	assert.False(t, result.Ignored)
+	assert.Empty(t, result.ExclusionReason)
+	assert.Positive(t, result.TokenCount)
+}
+
+func TestExplainPath_OverBudget(t *testing.T) {
+	dir := setupExplainRepo(t)
+
+	result, err := ExplainPath("docs/big.md", ExplainPathOptions{RootDir: dir})
+	require.NoError(t, err)
+
+	assert.False(t, result.WouldBeIncluded)
+	assert.False(t, result.Ignored)
+	assert.Equal(t, relevance.ExclusionReasonOverBudget, result.ExclusionReason)
+	assert.Greater(t, result.TokenCount, 200)
+}
+
+func TestExplainPath_Ignored(t *testing.T) {
+	dir := setupExplainRepo(t)
+
+	result, err := ExplainPath("generated/out.go", ExplainPathOptions{RootDir: dir})
+	require.NoError(t, err)
+
+	assert.True(t, result.Ignored)
+	assert.Equal(t, ".gitignore", result.IgnoredBy)
+	assert.False(t, result.WouldBeIncluded)
+	assert.Equal(t, relevance.ExclusionReasonIgnored, result.ExclusionReason)
+	assert.Zero(t, result.TokenCount)
+}
+
+func TestExplainPath_MissingFile(t *testing.T) {
+	dir := setupExplainRepo(t)
+
+	_, err := ExplainPath("src/missing.go", ExplainPathOptions{RootDir: dir})
+	require.Error(t, err)
+}
+
+func TestRankForBudget_SortOrderTokens(t *testing.T) {
+	files := []*pipeline.FileDescriptor{
+		{Path: "a.go", Content: strings.Repeat("a", 40)},
+		{Path: "b.go", Content: strings.Repeat("b", 400)},
+		{Path: "c.go", Content: strings.Repeat("c", 4000)},
+		{Path: "README.md", Content: strings.Repeat("r", 8000)},
+	}
+	profile := &config.Profile{
+		Tokenizer: "none",
+		SortOrder: "tokens",
+		Relevance: config.RelevanceConfig{Tier1: []string{"*.go"}, Tier2: []string{"*.md"}},
+	}
+
+	_, err := rankForBudget(files, profile)
+	require.NoError(t, err)
+
+	paths := make([]string, len(files))
+	for i, fd := range files {
+		paths[i] = fd.Path
+	}
+	assert.Equal(t, []string{"c.go", "b.go", "a.go", "README.md"}, paths,
+		"tokens sorts descending by TokenCount within a tier; tiers still dominate")
+}
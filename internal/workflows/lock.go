@@ -0,0 +1,142 @@
+// Package workflows — this file implements VerifyLock, which compares the
+// file set recorded in a .harvx.lock with the one a run would include now.
+package workflows
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/harvx/harvx/internal/config"
+	"github.com/harvx/harvx/internal/output"
+	"github.com/harvx/harvx/internal/tokenizer"
+)
+
+// DriftKind classifies how a file differs from its lock entry.
+type DriftKind string
+
+const (
+	// DriftChanged indicates the file is still included but its content
+	// hash differs from the locked one.
+	DriftChanged DriftKind = "changed"
+
+	// DriftAdded indicates the file is included now but is not in the lock.
+	DriftAdded DriftKind = "added"
+
+	// DriftRemoved indicates the file is in the lock but is no longer
+	// included, either because it was deleted or because it is now filtered
+	// out or over budget.
+	DriftRemoved DriftKind = "removed"
+)
+
+// Drift describes one difference between a lock and the current tree.
+type Drift struct {
+	// Path is the file path relative to the repository root.
+	Path string `json:"path"`
+
+	// Kind is the type of difference.
+	Kind DriftKind `json:"kind"`
+
+	// LockedSHA256 is the content hash recorded in the lock. Empty for
+	// DriftAdded.
+	LockedSHA256 string `json:"locked_sha256,omitempty"`
+
+	// CurrentSHA256 is the current content hash. Empty for DriftRemoved.
+	CurrentSHA256 string `json:"current_sha256,omitempty"`
+}
+
+// VerifyLockOptions configures VerifyLock.
+type VerifyLockOptions struct {
+	// RootDir is the repository root directory. Empty uses the directory
+	// containing the lock file.
+	RootDir string
+
+	// ProfileName selects the profile to resolve. Empty uses the profile
+	// recorded in the lock.
+	ProfileName string
+
+	// CLIFlags holds flag overrides applied on top of the resolved profile,
+	// keyed like config.ResolveOptions.CLIFlags.
+	CLIFlags map[string]any
+
+	// Strategy is the budget truncation strategy. Empty uses
+	// tokenizer.SkipStrategy, the CLI default.
+	Strategy tokenizer.TruncationStrategy
+}
+
+// VerifyLock re-runs discovery, relevance classification, tokenization, and
+// budget enforcement over the repository, as ExplainPath does, and compares
+// the resulting file set with the lock at lockPath. It returns one Drift per
+// file that changed, was added, or disappeared, sorted by path. An empty
+// result means the tree still produces exactly the locked file set.
+//
+// Content hashes are taken from the files on disk, like output.BuildLock, so
+// redaction and compression settings do not cause drift.
+func VerifyLock(lockPath string, opts VerifyLockOptions) ([]Drift, error) {
+	lock, err := output.ReadLock(lockPath)
+	if err != nil {
+		return nil, fmt.Errorf("verify lock: %w", err)
+	}
+
+	root := opts.RootDir
+	if root == "" {
+		root = filepath.Dir(lockPath)
+	}
+	profileName := opts.ProfileName
+	if profileName == "" {
+		profileName = lock.Profile
+	}
+
+	resolved, err := config.Resolve(config.ResolveOptions{
+		TargetDir:   root,
+		ProfileName: profileName,
+		CLIFlags:    opts.CLIFlags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("verify lock: %w", err)
+	}
+	profile := resolved.Profile
+
+	ignorers, err := explainIgnorers(root)
+	if err != nil {
+		return nil, fmt.Errorf("verify lock: %w", err)
+	}
+	files, err := discoverForProfile(root, profile, ignorers, explainPatternFilter(profile))
+	if err != nil {
+		return nil, fmt.Errorf("verify lock: %w", err)
+	}
+	tok, err := rankForBudget(files, profile)
+	if err != nil {
+		return nil, fmt.Errorf("verify lock: %w", err)
+	}
+	budget := newBudgetEnforcer(profile, opts.Strategy, tok).Enforce(files, 0)
+
+	current := make(map[string]string, len(budget.IncludedFiles))
+	for _, fd := range budget.IncludedFiles {
+		sum, err := output.HashFileSHA256(fd.AbsPath)
+		if err != nil {
+			return nil, fmt.Errorf("verify lock: %w", err)
+		}
+		current[fd.Path] = sum
+	}
+
+	var drifts []Drift
+	for _, entry := range lock.Files {
+		sum, ok := current[entry.Path]
+		switch {
+		case !ok:
+			drifts = append(drifts, Drift{Path: entry.Path, Kind: DriftRemoved, LockedSHA256: entry.SHA256})
+		case sum != entry.SHA256:
+			drifts = append(drifts, Drift{Path: entry.Path, Kind: DriftChanged, LockedSHA256: entry.SHA256, CurrentSHA256: sum})
+		}
+		delete(current, entry.Path)
+	}
+	for path, sum := range current {
+		drifts = append(drifts, Drift{Path: path, Kind: DriftAdded, CurrentSHA256: sum})
+	}
+
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].Path < drifts[j].Path
+	})
+	return drifts, nil
+}
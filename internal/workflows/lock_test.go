@@ -0,0 +1,75 @@
+package workflows
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/harvx/harvx/internal/output"
+)
+
+// writeRepoLock locks the files a harvest of setupExplainRepo includes now.
+func writeRepoLock(t *testing.T, dir string) string {
+	t.Helper()
+	lockPath := filepath.Join(dir, output.LockFileName)
+	require.NoError(t, output.WriteLock(&output.Lock{Version: 1, Profile: "default"}, lockPath))
+
+	drifts, err := VerifyLock(lockPath, VerifyLockOptions{})
+	require.NoError(t, err)
+
+	lock := &output.Lock{Version: 1, Profile: "default"}
+	for _, d := range drifts {
+		require.Equal(t, DriftAdded, d.Kind)
+		lock.Files = append(lock.Files, output.LockEntry{Path: d.Path, SHA256: d.CurrentSHA256})
+	}
+	require.NoError(t, output.WriteLock(lock, lockPath))
+	return lockPath
+}
+
+func TestVerifyLock_NoDrift(t *testing.T) {
+	dir := setupExplainRepo(t)
+	lockPath := writeRepoLock(t, dir)
+
+	drifts, err := VerifyLock(lockPath, VerifyLockOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, drifts)
+}
+
+func TestVerifyLock_ReportsDrift(t *testing.T) {
+	dir := setupExplainRepo(t)
+	lockPath := writeRepoLock(t, dir)
+
+	lock, err := output.ReadLock(lockPath)
+	require.NoError(t, err)
+	lock.Files = append(lock.Files, output.LockEntry{Path: "src/gone.go", SHA256: "abc"})
+	require.NoError(t, output.WriteLock(lock, lockPath))
+
+	writeFile(t, dir, "src/main.go", "package main\n\nfunc main() { println() }\n")
+	writeFile(t, dir, "src/util.go", "package main\n")
+
+	drifts, err := VerifyLock(lockPath, VerifyLockOptions{})
+	require.NoError(t, err)
+	require.Len(t, drifts, 3)
+
+	assert.Equal(t, "src/gone.go", drifts[0].Path)
+	assert.Equal(t, DriftRemoved, drifts[0].Kind)
+	assert.Equal(t, "abc", drifts[0].LockedSHA256)
+	assert.Empty(t, drifts[0].CurrentSHA256)
+
+	assert.Equal(t, "src/main.go", drifts[1].Path)
+	assert.Equal(t, DriftChanged, drifts[1].Kind)
+	assert.NotEqual(t, drifts[1].LockedSHA256, drifts[1].CurrentSHA256)
+
+	assert.Equal(t, "src/util.go", drifts[2].Path)
+	assert.Equal(t, DriftAdded, drifts[2].Kind)
+	assert.Empty(t, drifts[2].LockedSHA256)
+}
+
+func TestVerifyLock_MissingLock(t *testing.T) {
+	dir := setupExplainRepo(t)
+
+	_, err := VerifyLock(filepath.Join(dir, output.LockFileName), VerifyLockOptions{})
+	require.Error(t, err)
+}